@@ -10,6 +10,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
@@ -23,540 +25,11238 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// Error defines model for Error.
-type Error struct {
-	// Error Error message
-	Error *string `json:"error,omitempty"`
-}
-
-// Health Health response
-type Health struct {
-	// Status Health response
-	Status *string `json:"status,omitempty"`
-}
-
-// User defines model for User.
-type User struct {
-	// CreatedAt User creation timestamp
-	CreatedAt time.Time `json:"created_at"`
-
-	// Email User's email address
-	Email openapi_types.Email `json:"email"`
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
 
-	// FirstName User's first name
-	FirstName string `json:"first_name"`
+// Defines values for BatchOperationMethod.
+const (
+	BatchOperationMethodCreate BatchOperationMethod = "create"
+	BatchOperationMethodDelete BatchOperationMethod = "delete"
+	BatchOperationMethodUpdate BatchOperationMethod = "update"
+)
 
-	// Id Unique user identifier
-	Id uint `json:"id"`
+// Defines values for InvitationEventEvent.
+const (
+	InvitationEventEventAccepted InvitationEventEvent = "accepted"
+	InvitationEventEventCreated  InvitationEventEvent = "created"
+	InvitationEventEventResent   InvitationEventEvent = "resent"
+	InvitationEventEventRevoked  InvitationEventEvent = "revoked"
+)
 
-	// LastName User's last name
-	LastName string `json:"last_name"`
+// Defines values for InvitationStatus.
+const (
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusExpired  InvitationStatus = "expired"
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
 
-	// UpdatedAt User last update timestamp
-	UpdatedAt time.Time `json:"updated_at"`
-}
+// Defines values for JobStatus.
+const (
+	JobStatusCancelled JobStatus = "cancelled"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+)
 
-// UserRequest defines model for UserRequest.
-type UserRequest struct {
-	// Email User's email address
-	Email openapi_types.Email `json:"email"`
+// Defines values for JobRequestType.
+const (
+	JobRequestTypeUsersExport  JobRequestType = "users.export"
+	JobRequestTypeUsersImport  JobRequestType = "users.import"
+	JobRequestTypeUsersReindex JobRequestType = "users.reindex"
+)
 
-	// FirstName User's first name
-	FirstName string `json:"first_name"`
+// Defines values for LoginResponseTokenType.
+const (
+	LoginResponseTokenTypeBearer LoginResponseTokenType = "Bearer"
+)
 
-	// LastName User's last name
-	LastName string `json:"last_name"`
-}
+// Defines values for TokenResponseTokenType.
+const (
+	TokenResponseTokenTypeBearer TokenResponseTokenType = "Bearer"
+)
 
-// PostUserJSONRequestBody defines body for PostUser for application/json ContentType.
-type PostUserJSONRequestBody = UserRequest
+// Defines values for UserStatus.
+const (
+	UserStatusActive      UserStatus = "active"
+	UserStatusDeactivated UserStatus = "deactivated"
+	UserStatusPending     UserStatus = "pending"
+	UserStatusSuspended   UserStatus = "suspended"
+)
 
-// PutUserJSONRequestBody defines body for PutUser for application/json ContentType.
-type PutUserJSONRequestBody = UserRequest
+// Defines values for GetAvatarParamsSize.
+const (
+	GetAvatarParamsSizeN128 GetAvatarParamsSize = 128
+	GetAvatarParamsSizeN512 GetAvatarParamsSize = 512
+	GetAvatarParamsSizeN64  GetAvatarParamsSize = 64
+)
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Service Health
-	// (GET /health)
-	Health(w http.ResponseWriter, r *http.Request)
-	// Create new user
-	// (POST /users)
-	PostUser(w http.ResponseWriter, r *http.Request)
-	// Get user by ID
-	// (GET /users/{id})
-	GetUser(w http.ResponseWriter, r *http.Request, id uint)
-	// Update user
-	// (PUT /users/{id})
-	PutUser(w http.ResponseWriter, r *http.Request, id uint)
-}
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+	// LastUsedAt Last use of the key, updated at most once a minute
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
 
-type Unimplemented struct{}
+	// Prefix Visible start of the key for telling keys apart
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
 
-// Service Health
-// (GET /health)
-func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// UserId Owner of a personal access token; absent for service keys
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
 }
 
-// Create new user
-// (POST /users)
-func (_ Unimplemented) PostUser(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// APIKeyList defines model for APIKeyList.
+type APIKeyList struct {
+	ApiKeys []APIKey `json:"api_keys"`
 }
 
-// Get user by ID
-// (GET /users/{id})
-func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, id uint) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// APIKeyRequest defines model for APIKeyRequest.
+type APIKeyRequest struct {
+	// ExpiresAt When the key stops working; keys without expiry stay valid until revoked
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
-// Update user
-// (PUT /users/{id})
-func (_ Unimplemented) PutUser(w http.ResponseWriter, r *http.Request, id uint) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// Name Name describing what the key is used for
+	Name string `json:"name"`
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	// Scopes Permissions the key may use, e.g. users:read
+	Scopes []string `json:"scopes"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// APIKeySecret defines model for APIKeySecret.
+type APIKeySecret struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
 
-// Health operation middleware
-func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+	// LastUsedAt Last use of the key, updated at most once a minute
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Health(w, r)
-	}))
+	// Prefix Visible start of the key for telling keys apart
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Secret The complete key, sent in the X-API-Key header. It cannot be retrieved again.
+	Secret string `json:"secret"`
 
-	handler.ServeHTTP(w, r)
+	// UserId Owner of a personal access token; absent for service keys
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
 }
 
-// PostUser operation middleware
-func (siw *ServerInterfaceWrapper) PostUser(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUser(w, r)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// AttributeSchema defines model for AttributeSchema.
+type AttributeSchema struct {
+	Schema    map[string]interface{} `json:"schema"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
 
-	handler.ServeHTTP(w, r)
+// AttributeSchemaRequest defines model for AttributeSchemaRequest.
+type AttributeSchemaRequest struct {
+	// Schema JSON Schema in the dialect of OpenAPI 3.0 schema objects, describing an object
+	Schema map[string]interface{} `json:"schema"`
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// Attributes Custom attributes of a user, validated against the attribute schema of the organization. Omitting them on an
+// update keeps the current ones.
+type Attributes map[string]interface{}
 
-	var err error
+// BatchGetRequest defines model for BatchGetRequest.
+type BatchGetRequest struct {
+	// Ids User IDs to look up
+	Ids []UserID `json:"ids"`
+}
 
-	// ------------- Path parameter "id" -------------
-	var id uint
+// BatchGetResponse defines model for BatchGetResponse.
+type BatchGetResponse struct {
+	// Missing Requested IDs that do not exist, as given in the request
+	Missing []UserID `json:"missing"`
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	// Users Found users in request order
+	Users []User `json:"users"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r, id)
-	}))
+// BatchOperation defines model for BatchOperation.
+type BatchOperation struct {
+	// Id Public user identifier (UUIDv7). While legacy IDs are enabled (OPENAPI_LEGACY_IDS) the former integer ID of the
+	// user is accepted as well.
+	Id *UserID `json:"id,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Method Kind of operation
+	Method BatchOperationMethod `json:"method"`
 
-	handler.ServeHTTP(w, r)
+	// User Omitting a profile field (phone, locale, time_zone, display_name, avatar_url) on an update keeps its value, an
+	// empty string clears it. Invalid profile fields are rejected with 400 and listed in the details of the error.
+	User *UserRequest `json:"user,omitempty"`
 }
 
-// PutUser operation middleware
-func (siw *ServerInterfaceWrapper) PutUser(w http.ResponseWriter, r *http.Request) {
+// BatchOperationMethod Kind of operation
+type BatchOperationMethod string
 
-	var err error
+// BatchRequest defines model for BatchRequest.
+type BatchRequest struct {
+	// ContinueOnError Roll back only failed operations instead of the whole batch
+	ContinueOnError *bool `json:"continue_on_error,omitempty"`
 
-	// ------------- Path parameter "id" -------------
-	var id uint
+	// Operations Operations executed in the given order
+	Operations []BatchOperation `json:"operations"`
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+// BatchResponse defines model for BatchResponse.
+type BatchResponse struct {
+	// Committed Whether the transaction was committed
+	Committed bool `json:"committed"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutUser(w, r, id)
-	}))
+	// Results One result per operation, in request order
+	Results []BatchResult `json:"results"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	// Error Error message for failed operations
+	Error *string `json:"error,omitempty"`
 
-	handler.ServeHTTP(w, r)
-}
+	// Index Position of the operation in the request
+	Index int `json:"index"`
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+	// Status HTTP status code describing the outcome of the operation
+	Status int   `json:"status"`
+	User   *User `json:"user,omitempty"`
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+// EmailVerifyRequest defines model for EmailVerifyRequest.
+type EmailVerifyRequest struct {
+	// Token Token of the verification mail
+	Token string `json:"token"`
 }
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+// ErasureRequest defines model for ErasureRequest.
+type ErasureRequest struct {
+	// Reason Reason of the erasure, e.g. the reference of the request; must not contain personal data
+	Reason *string `json:"reason,omitempty"`
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+// Error defines model for Error.
+type Error struct {
+	// Details Individual problems, e.g. the violated password policy rules
+	Details *[]string `json:"details,omitempty"`
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+	// Error Error message
+	Error *string `json:"error,omitempty"`
 }
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+// ExportedAPIKey defines model for ExportedAPIKey.
+type ExportedAPIKey struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	ExpiresAt  *time.Time         `json:"expires_at"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	RevokedAt  *time.Time         `json:"revoked_at"`
+	Scopes     []string           `json:"scopes"`
 }
 
-type RequiredParamError struct {
-	ParamName string
+// ExportedCredentials Password metadata; the hash is not exported
+type ExportedCredentials struct {
+	FailedAttempts    int        `json:"failed_attempts"`
+	LastLoginAt       *time.Time `json:"last_login_at"`
+	LockedUntil       *time.Time `json:"locked_until"`
+	PasswordChangedAt time.Time  `json:"password_changed_at"`
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+// ExportedEmailVerification defines model for ExportedEmailVerification.
+type ExportedEmailVerification struct {
+	CreatedAt time.Time  `json:"created_at"`
+	Email     string     `json:"email"`
+	UsedAt    *time.Time `json:"used_at"`
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+// ExportedGroupMembership defines model for ExportedGroupMembership.
+type ExportedGroupMembership struct {
+	AddedAt time.Time          `json:"added_at"`
+	Id      openapi_types.UUID `json:"id"`
+	Name    string             `json:"name"`
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+// ExportedInvitation defines model for ExportedInvitation.
+type ExportedInvitation struct {
+	AcceptedAt    *time.Time         `json:"accepted_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Email         string             `json:"email"`
+	Id            openapi_types.UUID `json:"id"`
+	InvitedById   string             `json:"invited_by_id"`
+	InvitedByKind string             `json:"invited_by_kind"`
+	RevokedAt     *time.Time         `json:"revoked_at"`
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// ExportedJob A bulk job whose payload or artifact holds the user, such as an import listing them or an export
+type ExportedJob struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Id         uint       `json:"id"`
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+	// Records The entries of the payload or artifact about the user
+	Records []map[string]interface{} `json:"records"`
+	Type    string                   `json:"type"`
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+// ExportedMfa MFA state; the TOTP secret and recovery codes are not exported
+type ExportedMfa struct {
+	CreatedAt time.Time  `json:"created_at"`
+	EnabledAt *time.Time `json:"enabled_at"`
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+// ExportedMfaEvent defines model for ExportedMfaEvent.
+type ExportedMfaEvent struct {
+	ActorId   string    `json:"actor_id"`
+	ActorKind string    `json:"actor_kind"`
+	CreatedAt time.Time `json:"created_at"`
+	Event     string    `json:"event"`
+	Reason    *string   `json:"reason"`
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+// ExportedPolicyDecision A policy decision on a request of the user or on the user
+type ExportedPolicyDecision struct {
+	Allowed       bool      `json:"allowed"`
+	CreatedAt     time.Time `json:"created_at"`
+	Operation     string    `json:"operation"`
+	PrincipalId   string    `json:"principal_id"`
+	PrincipalKind string    `json:"principal_kind"`
+	Reason        string    `json:"reason"`
+	Resource      *string   `json:"resource"`
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+// ExportedSession A refresh token issued at login; the token hash is not exported
+type ExportedSession struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	FamilyId  openapi_types.UUID `json:"family_id"`
+	RevokedAt *time.Time         `json:"revoked_at"`
+	UsedAt    *time.Time         `json:"used_at"`
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+// Group defines model for Group.
+type Group struct {
+	CreatedAt   time.Time          `json:"created_at"`
+	Description string             `json:"description"`
+	Id          openapi_types.UUID `json:"id"`
+	Name        string             `json:"name"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// GroupList defines model for GroupList.
+type GroupList struct {
+	Groups []Group `json:"groups"`
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+// GroupMembers defines model for GroupMembers.
+type GroupMembers struct {
+	// Groups Subgroups ordered by name
+	Groups []Group `json:"groups"`
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	// Users Member users ordered by ID
+	Users []User `json:"users"`
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GroupMembership defines model for GroupMembership.
+type GroupMembership struct {
+	// Direct The user is a direct member of the group
+	Direct bool `json:"direct"`
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	// Member The user is a member of the group, directly or through nested subgroups
+	Member bool `json:"member"`
+}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/health", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/users", wrapper.PostUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/users/{id}", wrapper.PutUser)
-	})
+// GroupRequest defines model for GroupRequest.
+type GroupRequest struct {
+	// Description What the group is meant for
+	Description *string `json:"description,omitempty"`
 
-	return r
+	// Name Name of the group, unique within the organization regardless of case
+	Name string `json:"name"`
 }
 
-type HealthRequestObject struct {
+// Health Health response
+type Health struct {
+	// Status Health response
+	Status *string `json:"status,omitempty"`
 }
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
-}
+// Invitation defines model for Invitation.
+type Invitation struct {
+	AcceptedAt *time.Time          `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	Email      openapi_types.Email `json:"email"`
 
-type Health200JSONResponse Health
+	// Events Events of the invitation, oldest first; only returned for a single invitation
+	Events    *[]InvitationEvent `json:"events,omitempty"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	Id        openapi_types.UUID `json:"id"`
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// InvitedById ID of the principal who created the invitation
+	InvitedById string `json:"invited_by_id"`
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// InvitedByKind Kind of the principal who created the invitation, user or service
+	InvitedByKind string     `json:"invited_by_kind"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
 
-type Health500JSONResponse Error
+	// SentAt When the invitation was last mailed
+	SentAt time.Time `json:"sent_at"`
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	// Status State of an invitation; pending invitations past their expiry are expired
+	Status InvitationStatus `json:"status"`
+
+	// UserId The invited user; absent once a revocation deleted the pending user
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// InvitationAcceptRequest defines model for InvitationAcceptRequest.
+type InvitationAcceptRequest struct {
+	// FirstName User's first name
+	FirstName string `json:"first_name"`
+
+	// LastName User's last name
+	LastName string `json:"last_name"`
+
+	// Password Password of the user
+	Password string `json:"password"`
+
+	// Token Token of the invitation mail
+	Token string `json:"token"`
+}
+
+// InvitationEvent defines model for InvitationEvent.
+type InvitationEvent struct {
+	ActorId   string               `json:"actor_id"`
+	ActorKind string               `json:"actor_kind"`
+	CreatedAt time.Time            `json:"created_at"`
+	Event     InvitationEventEvent `json:"event"`
+}
+
+// InvitationEventEvent defines model for InvitationEvent.Event.
+type InvitationEventEvent string
+
+// InvitationList defines model for InvitationList.
+type InvitationList struct {
+	Invitations []Invitation `json:"invitations"`
+}
+
+// InvitationRequest defines model for InvitationRequest.
+type InvitationRequest struct {
+	// Email Email address of the invitee
+	Email openapi_types.Email `json:"email"`
+}
+
+// InvitationStatus State of an invitation; pending invitations past their expiry are expired
+type InvitationStatus string
+
+// Job defines model for Job.
+type Job struct {
+	// CancelRequested Whether cancellation of a running job was requested
+	CancelRequested bool `json:"cancel_requested"`
+
+	// CreatedAt Job creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error Failure reason for failed jobs
+	Error *string `json:"error,omitempty"`
+
+	// FinishedAt Timestamp the job finished
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// HasArtifact Whether a result artifact can be downloaded
+	HasArtifact bool `json:"has_artifact"`
+
+	// Id Unique job identifier
+	Id       uint        `json:"id"`
+	Progress JobProgress `json:"progress"`
+
+	// Result Job type specific result summary
+	Result *map[string]interface{} `json:"result,omitempty"`
+
+	// StartedAt Timestamp the job was picked up by a worker
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Status Current job state
+	Status JobStatus `json:"status"`
+
+	// Type Kind of bulk operation
+	Type string `json:"type"`
+}
+
+// JobStatus Current job state
+type JobStatus string
+
+// JobProgress defines model for JobProgress.
+type JobProgress struct {
+	// Done Number of processed items
+	Done int `json:"done"`
+
+	// Total Total number of items, 0 if unknown yet
+	Total int `json:"total"`
+}
+
+// JobRequest defines model for JobRequest.
+type JobRequest struct {
+	// Payload Job type specific parameters, e.g. the list of users for users.import
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// Type Kind of bulk operation to run. users.reindex recomputes the canonical addresses users are looked up by.
+	Type JobRequestType `json:"type"`
+}
+
+// JobRequestType Kind of bulk operation to run. users.reindex recomputes the canonical addresses users are looked up by.
+type JobRequestType string
+
+// LegalHold defines model for LegalHold.
+type LegalHold struct {
+	CaseReference string             `json:"case_reference"`
+	Id            openapi_types.UUID `json:"id"`
+	PlacedAt      time.Time          `json:"placed_at"`
+
+	// PlacedById ID of the principal who placed the hold
+	PlacedById string `json:"placed_by_id"`
+
+	// PlacedByKind Kind of the principal who placed the hold, user or service
+	PlacedByKind  string  `json:"placed_by_kind"`
+	Reason        string  `json:"reason"`
+	ReleaseReason *string `json:"release_reason"`
+
+	// ReleasedAt When the hold was released; null while it is active
+	ReleasedAt *time.Time `json:"released_at"`
+
+	// ReleasedById ID of the principal who released the hold
+	ReleasedById *string `json:"released_by_id"`
+
+	// ReleasedByKind Kind of the principal who released the hold
+	ReleasedByKind *string            `json:"released_by_kind"`
+	UserId         openapi_types.UUID `json:"user_id"`
+}
+
+// LegalHoldList defines model for LegalHoldList.
+type LegalHoldList struct {
+	Holds []LegalHold `json:"holds"`
+}
+
+// LegalHoldReleaseRequest defines model for LegalHoldReleaseRequest.
+type LegalHoldReleaseRequest struct {
+	// Reason Why the hold is released, recorded for auditing
+	Reason *string `json:"reason,omitempty"`
+}
+
+// LegalHoldRequest defines model for LegalHoldRequest.
+type LegalHoldRequest struct {
+	// CaseReference Reference of the litigation or investigation
+	CaseReference string `json:"case_reference"`
+
+	// Reason Why the user is frozen, recorded for auditing
+	Reason string `json:"reason"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	// Email Email address of the user, in any spelling of its canonical form
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	// AccessToken Signed JWT access token, verifiable with the keys published at /.well-known/jwks.json
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Seconds until the access token expires
+	ExpiresIn int `json:"expires_in"`
+
+	// MfaEnrollmentRequired A role of the user requires MFA, which the user has not enabled yet. Until they enroll, the permissions
+	// of such roles are withheld.
+	MfaEnrollmentRequired *bool `json:"mfa_enrollment_required,omitempty"`
+
+	// RefreshToken Opaque single-use refresh token
+	RefreshToken string `json:"refresh_token"`
+
+	// RefreshTokenExpiresAt Expiry of the refresh token
+	RefreshTokenExpiresAt time.Time              `json:"refresh_token_expires_at"`
+	TokenType             LoginResponseTokenType `json:"token_type"`
+	User                  User                   `json:"user"`
+}
+
+// LoginResponseTokenType defines model for LoginResponse.TokenType.
+type LoginResponseTokenType string
+
+// MfaChallenge defines model for MfaChallenge.
+type MfaChallenge struct {
+	// ExpiresIn Seconds until the challenge expires
+	ExpiresIn int `json:"expires_in"`
+
+	// MfaToken Opaque token identifying the login awaiting its second factor
+	MfaToken string `json:"mfa_token"`
+}
+
+// MfaResetRequest defines model for MfaResetRequest.
+type MfaResetRequest struct {
+	// Reason Why MFA is reset, recorded for auditing
+	Reason string `json:"reason"`
+}
+
+// MfaStatus defines model for MfaStatus.
+type MfaStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+
+	// Required A role of the user requires MFA
+	Required bool `json:"required"`
+}
+
+// MfaVerifyRequest defines model for MfaVerifyRequest.
+type MfaVerifyRequest struct {
+	// Code Current code of the authenticator app
+	Code     *string `json:"code,omitempty"`
+	MfaToken string  `json:"mfa_token"`
+
+	// RecoveryCode Unused recovery code, instead of a code
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+}
+
+// Organization defines model for Organization.
+type Organization struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+}
+
+// OrganizationList defines model for OrganizationList.
+type OrganizationList struct {
+	Organizations []Organization `json:"organizations"`
+}
+
+// OrganizationRequest defines model for OrganizationRequest.
+type OrganizationRequest struct {
+	Name string `json:"name"`
+
+	// Slug Name of the organization in the X-Organization header and as subdomain
+	Slug string `json:"slug"`
+}
+
+// PasswordRequest defines model for PasswordRequest.
+type PasswordRequest struct {
+	// CurrentPassword Current password, required when users change their own password
+	CurrentPassword *string `json:"current_password,omitempty"`
+
+	// Password New password
+	Password string `json:"password"`
+}
+
+// PasswordResetConfirmRequest defines model for PasswordResetConfirmRequest.
+type PasswordResetConfirmRequest struct {
+	// Password New password
+	Password string `json:"password"`
+
+	// Token Token of the password reset mail
+	Token string `json:"token"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	// Email Email address of the user
+	Email openapi_types.Email `json:"email"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// RecoveryCodes One-time codes for logging in without the authenticator app
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Role defines model for Role.
+type Role struct {
+	// Description What the role is meant for
+	Description string `json:"description"`
+
+	// MfaRequired The permissions of the role are only granted to users with MFA enabled
+	MfaRequired bool `json:"mfa_required"`
+
+	// Name Name of a role
+	Name RoleName `json:"name"`
+
+	// Permissions Permissions granted by the role, e.g. users:read
+	Permissions []string `json:"permissions"`
+}
+
+// RoleList defines model for RoleList.
+type RoleList struct {
+	Roles []Role `json:"roles"`
+}
+
+// RoleName Name of a role
+type RoleName = string
+
+// SearchHighlights defines model for SearchHighlights.
+type SearchHighlights struct {
+	// Email Email with matched words highlighted
+	Email *string `json:"email,omitempty"`
+
+	// Name Full name with matched words highlighted
+	Name *string `json:"name,omitempty"`
+}
+
+// SearchResponse defines model for SearchResponse.
+type SearchResponse struct {
+	// Results Matching users, most relevant first
+	Results []SearchResult `json:"results"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	Highlights SearchHighlights `json:"highlights"`
+
+	// Score Relevance score, higher is better
+	Score float64 `json:"score"`
+	User  User    `json:"user"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// AccessToken Signed JWT access token, verifiable with the keys published at /.well-known/jwks.json
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Seconds until the access token expires
+	ExpiresIn int `json:"expires_in"`
+
+	// RefreshToken Opaque single-use refresh token
+	RefreshToken string `json:"refresh_token"`
+
+	// RefreshTokenExpiresAt Expiry of the refresh token
+	RefreshTokenExpiresAt time.Time              `json:"refresh_token_expires_at"`
+	TokenType             TokenResponseTokenType `json:"token_type"`
+}
+
+// TokenResponseTokenType defines model for TokenResponse.TokenType.
+type TokenResponseTokenType string
+
+// TotpCode defines model for TotpCode.
+type TotpCode struct {
+	// Code Current code of the authenticator app
+	Code string `json:"code"`
+}
+
+// TotpEnrollment defines model for TotpEnrollment.
+type TotpEnrollment struct {
+	// OtpauthUri otpauth:// URI to be shown as QR code
+	OtpauthUri string `json:"otpauth_uri"`
+
+	// Secret Base32 encoded secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// User defines model for User.
+type User struct {
+	// Attributes Custom attributes of a user, validated against the attribute schema of the organization. Omitting them on an
+	// update keeps the current ones.
+	Attributes Attributes `json:"attributes"`
+
+	// AvatarUrl URL of the avatar image; set by avatar uploads or to an external image
+	AvatarUrl *string `json:"avatar_url"`
+
+	// CreatedAt User creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+
+	// DisplayName Name shown instead of the first and last name
+	DisplayName *string `json:"display_name"`
+
+	// Email User's email address
+	Email openapi_types.Email `json:"email"`
+
+	// EmailVerifiedAt When the current email address was confirmed; null if it is not verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
+	// ErasedAt When the personal data of the user was erased
+	ErasedAt *time.Time `json:"erased_at"`
+
+	// FirstName User's first name
+	FirstName string `json:"first_name"`
+
+	// Id Unique public user identifier (UUIDv7)
+	Id openapi_types.UUID `json:"id"`
+
+	// LastName User's last name
+	LastName string `json:"last_name"`
+
+	// Locale Preferred locale as BCP 47 language tag
+	Locale *string `json:"locale"`
+
+	// PendingEmail Address that replaces the current one once it is confirmed
+	PendingEmail *openapi_types.Email `json:"pending_email"`
+
+	// Phone Phone number in E.164 format
+	Phone *string `json:"phone"`
+
+	// Status Invited users are pending until they accept their invitation. Only active users can log in and use their
+	// tokens. Active users can be suspended or deactivated; suspended and deactivated users can be reactivated.
+	Status UserStatus `json:"status"`
+
+	// StatusReason Reason of the last suspension, deactivation or reactivation
+	StatusReason *string `json:"status_reason"`
+
+	// SuspendedUntil When a suspended user is reactivated automatically
+	SuspendedUntil *time.Time `json:"suspended_until"`
+
+	// TimeZone IANA time zone
+	TimeZone *string `json:"time_zone"`
+
+	// UpdatedAt User last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserDataExport defines model for UserDataExport.
+type UserDataExport struct {
+	ApiKeys []ExportedAPIKey `json:"api_keys"`
+
+	// Credentials Password metadata; the hash is not exported
+	Credentials        *ExportedCredentials        `json:"credentials,omitempty"`
+	EmailVerifications []ExportedEmailVerification `json:"email_verifications"`
+
+	// Erasure Proof of the erasure of a user
+	Erasure     *UserErasure              `json:"erasure,omitempty"`
+	ExportedAt  time.Time                 `json:"exported_at"`
+	Groups      []ExportedGroupMembership `json:"groups"`
+	Invitations []ExportedInvitation      `json:"invitations"`
+	Jobs        []ExportedJob             `json:"jobs"`
+
+	// Mfa MFA state; the TOTP secret and recovery codes are not exported
+	Mfa             *ExportedMfa             `json:"mfa,omitempty"`
+	MfaEvents       []ExportedMfaEvent       `json:"mfa_events"`
+	PolicyDecisions []ExportedPolicyDecision `json:"policy_decisions"`
+	Roles           []string                 `json:"roles"`
+	Sessions        []ExportedSession        `json:"sessions"`
+	StatusEvents    []UserStatusEvent        `json:"status_events"`
+	User            User                     `json:"user"`
+}
+
+// UserErasure Proof of the erasure of a user
+type UserErasure struct {
+	// ActorId ID of the principal who erased the user
+	ActorId string `json:"actor_id"`
+
+	// ActorKind Kind of the principal who erased the user, user or service
+	ActorKind string `json:"actor_kind"`
+
+	// Affected Number of anonymized or deleted rows per table
+	Affected map[string]int     `json:"affected"`
+	ErasedAt time.Time          `json:"erased_at"`
+	Reason   *string            `json:"reason"`
+	UserId   openapi_types.UUID `json:"user_id"`
+}
+
+// UserID Public user identifier (UUIDv7). While legacy IDs are enabled (OPENAPI_LEGACY_IDS) the former integer ID of the
+// user is accepted as well.
+type UserID = string
+
+// UserList defines model for UserList.
+type UserList struct {
+	// NextAfter Pass as after to fetch the next page; absent on the last page
+	NextAfter *openapi_types.UUID `json:"next_after,omitempty"`
+	Users     []User              `json:"users"`
+}
+
+// UserRequest Omitting a profile field (phone, locale, time_zone, display_name, avatar_url) on an update keeps its value, an
+// empty string clears it. Invalid profile fields are rejected with 400 and listed in the details of the error.
+type UserRequest struct {
+	// Attributes Custom attributes of a user, validated against the attribute schema of the organization. Omitting them on an
+	// update keeps the current ones.
+	Attributes *Attributes `json:"attributes,omitempty"`
+
+	// AvatarUrl Absolute http or https URL of the avatar image
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// DisplayName Name shown instead of the first and last name
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Email User's email address as entered. Addresses are unique within the organization in their canonical form
+	// (lower-cased, Unicode NFC, IDNA-encoded domain), so "John@Example.com" and "john@example.com" belong to the
+	// same user.
+	Email openapi_types.Email `json:"email"`
+
+	// FirstName User's first name
+	FirstName string `json:"first_name"`
+
+	// LastName User's last name
+	LastName string `json:"last_name"`
+
+	// Locale Preferred locale as BCP 47 language tag; stored in canonical form, e.g. "en_us" as "en-US"
+	Locale *string `json:"locale,omitempty"`
+
+	// Phone Phone number with country code; stored in E.164 format, e.g. "+49 30 1234567" as "+49301234567"
+	Phone *string `json:"phone,omitempty"`
+
+	// TimeZone IANA time zone, e.g. "Europe/Berlin"
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// UserStatus Invited users are pending until they accept their invitation. Only active users can log in and use their
+// tokens. Active users can be suspended or deactivated; suspended and deactivated users can be reactivated.
+type UserStatus string
+
+// UserStatusEvent defines model for UserStatusEvent.
+type UserStatusEvent struct {
+	// ActorId ID of the principal who changed the status
+	ActorId string `json:"actor_id"`
+
+	// ActorKind Kind of the principal who changed the status, user, service or system
+	ActorKind string    `json:"actor_kind"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// FromStatus Invited users are pending until they accept their invitation. Only active users can log in and use their
+	// tokens. Active users can be suspended or deactivated; suspended and deactivated users can be reactivated.
+	FromStatus UserStatus `json:"from_status"`
+
+	// Reason Reason given for the change
+	Reason *string `json:"reason,omitempty"`
+
+	// ToStatus Invited users are pending until they accept their invitation. Only active users can log in and use their
+	// tokens. Active users can be suspended or deactivated; suspended and deactivated users can be reactivated.
+	ToStatus UserStatus `json:"to_status"`
+
+	// Until Scheduled reactivation of a suspension
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// UserStatusEventList defines model for UserStatusEventList.
+type UserStatusEventList struct {
+	Events []UserStatusEvent `json:"events"`
+}
+
+// UserStatusRequest defines model for UserStatusRequest.
+type UserStatusRequest struct {
+	// Reason Why the status is changed, recorded for auditing
+	Reason *string `json:"reason,omitempty"`
+}
+
+// UserSuspendRequest defines model for UserSuspendRequest.
+type UserSuspendRequest struct {
+	// Reason Why the user is suspended, recorded for auditing
+	Reason string `json:"reason"`
+
+	// Until Reactivate the user automatically at this time
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// GetAvatarParams defines parameters for GetAvatar.
+type GetAvatarParams struct {
+	// Size Edge length in pixels
+	Size *GetAvatarParamsSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// IfNoneMatch ETag of a cached copy
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetAvatarParamsSize defines parameters for GetAvatar.
+type GetAvatarParamsSize int
+
+// ListGroupMembersParams defines parameters for ListGroupMembers.
+type ListGroupMembersParams struct {
+	// Transitive Include the members of nested subgroups
+	Transitive *bool `form:"transitive,omitempty" json:"transitive,omitempty"`
+}
+
+// ListInvitationsParams defines parameters for ListInvitations.
+type ListInvitationsParams struct {
+	// Status Only return invitations with this status
+	Status *InvitationStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// Status Only return users with this status
+	Status *UserStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// After Return users after this ID
+	After *openapi_types.UUID `form:"after,omitempty" json:"after,omitempty"`
+
+	// Limit Maximum number of users
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Attribute Only return users whose custom attributes contain this value, given as name=value. Values are matched as
+	// JSON if they parse as JSON and as strings otherwise, e.g. employee_id=42 or department=sales. Repeat to
+	// require several attributes.
+	Attribute *[]string `form:"attribute,omitempty" json:"attribute,omitempty"`
+}
+
+// SearchUsersParams defines parameters for SearchUsers.
+type SearchUsersParams struct {
+	// Q Search text
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of results
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutUserAvatarMultipartBody defines parameters for PutUserAvatar.
+type PutUserAvatarMultipartBody struct {
+	// File The image
+	File openapi_types.File `json:"file"`
+}
+
+// ListUserGroupsParams defines parameters for ListUserGroups.
+type ListUserGroupsParams struct {
+	// Transitive Include the groups the user is a member of through nested groups
+	Transitive *bool `form:"transitive,omitempty" json:"transitive,omitempty"`
+}
+
+// BatchGetUsersParams defines parameters for BatchGetUsers.
+type BatchGetUsersParams struct {
+	// Ids Comma separated list of user IDs
+	Ids []UserID `form:"ids" json:"ids"`
+}
+
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = APIKeyRequest
+
+// PutAttributeSchemaJSONRequestBody defines body for PutAttributeSchema for application/json ContentType.
+type PutAttributeSchemaJSONRequestBody = AttributeSchemaRequest
+
+// VerifyEmailJSONRequestBody defines body for VerifyEmail for application/json ContentType.
+type VerifyEmailJSONRequestBody = EmailVerifyRequest
+
+// AcceptInvitationJSONRequestBody defines body for AcceptInvitation for application/json ContentType.
+type AcceptInvitationJSONRequestBody = InvitationAcceptRequest
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// VerifyMfaJSONRequestBody defines body for VerifyMfa for application/json ContentType.
+type VerifyMfaJSONRequestBody = MfaVerifyRequest
+
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// ConfirmPasswordResetJSONRequestBody defines body for ConfirmPasswordReset for application/json ContentType.
+type ConfirmPasswordResetJSONRequestBody = PasswordResetConfirmRequest
+
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
+// RevokeTokenJSONRequestBody defines body for RevokeToken for application/json ContentType.
+type RevokeTokenJSONRequestBody = RefreshTokenRequest
+
+// CreateGroupJSONRequestBody defines body for CreateGroup for application/json ContentType.
+type CreateGroupJSONRequestBody = GroupRequest
+
+// UpdateGroupJSONRequestBody defines body for UpdateGroup for application/json ContentType.
+type UpdateGroupJSONRequestBody = GroupRequest
+
+// CreateInvitationJSONRequestBody defines body for CreateInvitation for application/json ContentType.
+type CreateInvitationJSONRequestBody = InvitationRequest
+
+// PostJobJSONRequestBody defines body for PostJob for application/json ContentType.
+type PostJobJSONRequestBody = JobRequest
+
+// CreateOrganizationJSONRequestBody defines body for CreateOrganization for application/json ContentType.
+type CreateOrganizationJSONRequestBody = OrganizationRequest
+
+// PostUserJSONRequestBody defines body for PostUser for application/json ContentType.
+type PostUserJSONRequestBody = UserRequest
+
+// PutUserJSONRequestBody defines body for PutUser for application/json ContentType.
+type PutUserJSONRequestBody = UserRequest
+
+// CreateUserAPIKeyJSONRequestBody defines body for CreateUserAPIKey for application/json ContentType.
+type CreateUserAPIKeyJSONRequestBody = APIKeyRequest
+
+// PutUserAvatarMultipartRequestBody defines body for PutUserAvatar for multipart/form-data ContentType.
+type PutUserAvatarMultipartRequestBody PutUserAvatarMultipartBody
+
+// DeactivateUserJSONRequestBody defines body for DeactivateUser for application/json ContentType.
+type DeactivateUserJSONRequestBody = UserStatusRequest
+
+// EraseUserJSONRequestBody defines body for EraseUser for application/json ContentType.
+type EraseUserJSONRequestBody = ErasureRequest
+
+// PlaceLegalHoldJSONRequestBody defines body for PlaceLegalHold for application/json ContentType.
+type PlaceLegalHoldJSONRequestBody = LegalHoldRequest
+
+// ReleaseLegalHoldJSONRequestBody defines body for ReleaseLegalHold for application/json ContentType.
+type ReleaseLegalHoldJSONRequestBody = LegalHoldReleaseRequest
+
+// ResetMfaJSONRequestBody defines body for ResetMfa for application/json ContentType.
+type ResetMfaJSONRequestBody = MfaResetRequest
+
+// ConfirmTotpJSONRequestBody defines body for ConfirmTotp for application/json ContentType.
+type ConfirmTotpJSONRequestBody = TotpCode
+
+// SetUserPasswordJSONRequestBody defines body for SetUserPassword for application/json ContentType.
+type SetUserPasswordJSONRequestBody = PasswordRequest
+
+// ReactivateUserJSONRequestBody defines body for ReactivateUser for application/json ContentType.
+type ReactivateUserJSONRequestBody = UserStatusRequest
+
+// SuspendUserJSONRequestBody defines body for SuspendUser for application/json ContentType.
+type SuspendUserJSONRequestBody = UserSuspendRequest
+
+// BatchUsersJSONRequestBody defines body for BatchUsers for application/json ContentType.
+type BatchUsersJSONRequestBody = BatchRequest
+
+// BatchGetUsersPostJSONRequestBody defines body for BatchGetUsersPost for application/json ContentType.
+type BatchGetUsersPostJSONRequestBody = BatchGetRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List API keys of services
+	// (GET /api-keys)
+	ListAPIKeys(w http.ResponseWriter, r *http.Request)
+	// Create an API key of services
+	// (POST /api-keys)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key of services
+	// (DELETE /api-keys/{keyId})
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID)
+	// Rotate an API key of services
+	// (POST /api-keys/{keyId}/rotate)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID)
+	// Remove the attribute schema
+	// (DELETE /attribute-schema)
+	DeleteAttributeSchema(w http.ResponseWriter, r *http.Request)
+	// Get the attribute schema
+	// (GET /attribute-schema)
+	GetAttributeSchema(w http.ResponseWriter, r *http.Request)
+	// Register the attribute schema
+	// (PUT /attribute-schema)
+	PutAttributeSchema(w http.ResponseWriter, r *http.Request)
+	// Confirm an email address
+	// (POST /auth/email/verify)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	// Accept an invitation
+	// (POST /auth/invitations/accept)
+	AcceptInvitation(w http.ResponseWriter, r *http.Request)
+	// Log in with email and password
+	// (POST /auth/login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Complete a login with a second factor
+	// (POST /auth/mfa/verify)
+	VerifyMfa(w http.ResponseWriter, r *http.Request)
+	// Request a password reset
+	// (POST /auth/password-reset)
+	RequestPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Reset a password
+	// (POST /auth/password-reset/confirm)
+	ConfirmPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Refresh access token
+	// (POST /auth/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	// Revoke session
+	// (POST /auth/revoke)
+	RevokeToken(w http.ResponseWriter, r *http.Request)
+	// Get avatar
+	// (GET /avatars/{userId}/{version})
+	GetAvatar(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, version string, params GetAvatarParams)
+	// List groups
+	// (GET /groups)
+	ListGroups(w http.ResponseWriter, r *http.Request)
+	// Create a group
+	// (POST /groups)
+	CreateGroup(w http.ResponseWriter, r *http.Request)
+	// Delete a group
+	// (DELETE /groups/{groupId})
+	DeleteGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID)
+	// Get a group
+	// (GET /groups/{groupId})
+	GetGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID)
+	// Update a group
+	// (PUT /groups/{groupId})
+	UpdateGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID)
+	// List members of a group
+	// (GET /groups/{groupId}/members)
+	ListGroupMembers(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, params ListGroupMembersParams)
+	// Unnest a group
+	// (DELETE /groups/{groupId}/members/groups/{subgroupId})
+	RemoveSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID)
+	// Nest a group
+	// (PUT /groups/{groupId}/members/groups/{subgroupId})
+	AddSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID)
+	// Remove a user from a group
+	// (DELETE /groups/{groupId}/members/users/{id})
+	RemoveGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID)
+	// Check group membership
+	// (GET /groups/{groupId}/members/users/{id})
+	CheckGroupMembership(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID)
+	// Add a user to a group
+	// (PUT /groups/{groupId}/members/users/{id})
+	AddGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID)
+	// Service Health
+	// (GET /health)
+	Health(w http.ResponseWriter, r *http.Request)
+	// List invitations
+	// (GET /invitations)
+	ListInvitations(w http.ResponseWriter, r *http.Request, params ListInvitationsParams)
+	// Invite a user
+	// (POST /invitations)
+	CreateInvitation(w http.ResponseWriter, r *http.Request)
+	// Revoke an invitation
+	// (DELETE /invitations/{invitationId})
+	RevokeInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID)
+	// Get an invitation
+	// (GET /invitations/{invitationId})
+	GetInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID)
+	// Resend an invitation
+	// (POST /invitations/{invitationId}/resend)
+	ResendInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID)
+	// Enqueue job
+	// (POST /jobs)
+	PostJob(w http.ResponseWriter, r *http.Request)
+	// Cancel job
+	// (DELETE /jobs/{id})
+	CancelJob(w http.ResponseWriter, r *http.Request, id uint)
+	// Get job by ID
+	// (GET /jobs/{id})
+	GetJob(w http.ResponseWriter, r *http.Request, id uint)
+	// Download job artifact
+	// (GET /jobs/{id}/artifact)
+	GetJobArtifact(w http.ResponseWriter, r *http.Request, id uint)
+	// List organizations
+	// (GET /organizations)
+	ListOrganizations(w http.ResponseWriter, r *http.Request)
+	// Create an organization
+	// (POST /organizations)
+	CreateOrganization(w http.ResponseWriter, r *http.Request)
+	// Get an organization
+	// (GET /organizations/{orgId})
+	GetOrganization(w http.ResponseWriter, r *http.Request, orgId openapi_types.UUID)
+	// List roles
+	// (GET /roles)
+	ListRoles(w http.ResponseWriter, r *http.Request)
+	// List users
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// Create new user
+	// (POST /users)
+	PostUser(w http.ResponseWriter, r *http.Request)
+	// Get user by email
+	// (GET /users/by-email/{email})
+	GetUserByEmail(w http.ResponseWriter, r *http.Request, email string)
+	// Search users
+	// (GET /users/search)
+	SearchUsers(w http.ResponseWriter, r *http.Request, params SearchUsersParams)
+	// Get user by ID
+	// (GET /users/{id})
+	GetUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// Update user
+	// (PUT /users/{id})
+	PutUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// List API keys of a user
+	// (GET /users/{id}/api-keys)
+	ListUserAPIKeys(w http.ResponseWriter, r *http.Request, id UserID)
+	// Create an API key of a user
+	// (POST /users/{id}/api-keys)
+	CreateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID)
+	// Revoke an API key of a user
+	// (DELETE /users/{id}/api-keys/{keyId})
+	RevokeUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID)
+	// Rotate an API key of a user
+	// (POST /users/{id}/api-keys/{keyId}/rotate)
+	RotateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID)
+	// Delete avatar
+	// (DELETE /users/{id}/avatar)
+	DeleteUserAvatar(w http.ResponseWriter, r *http.Request, id UserID)
+	// Upload avatar
+	// (PUT /users/{id}/avatar)
+	PutUserAvatar(w http.ResponseWriter, r *http.Request, id UserID)
+	// Export user data
+	// (GET /users/{id}/data-export)
+	ExportUserData(w http.ResponseWriter, r *http.Request, id UserID)
+	// Deactivate user
+	// (POST /users/{id}/deactivate)
+	DeactivateUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// Send an email verification mail
+	// (POST /users/{id}/email/verification)
+	SendEmailVerification(w http.ResponseWriter, r *http.Request, id UserID)
+	// Erase user
+	// (POST /users/{id}/erase)
+	EraseUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// List groups of a user
+	// (GET /users/{id}/groups)
+	ListUserGroups(w http.ResponseWriter, r *http.Request, id UserID, params ListUserGroupsParams)
+	// List legal holds of a user
+	// (GET /users/{id}/legal-holds)
+	ListLegalHolds(w http.ResponseWriter, r *http.Request, id UserID)
+	// Place legal hold
+	// (POST /users/{id}/legal-holds)
+	PlaceLegalHold(w http.ResponseWriter, r *http.Request, id UserID)
+	// Release legal hold
+	// (POST /users/{id}/legal-holds/{holdId}/release)
+	ReleaseLegalHold(w http.ResponseWriter, r *http.Request, id UserID, holdId openapi_types.UUID)
+	// Get MFA status
+	// (GET /users/{id}/mfa)
+	GetMfaStatus(w http.ResponseWriter, r *http.Request, id UserID)
+	// Reset MFA
+	// (POST /users/{id}/mfa/reset)
+	ResetMfa(w http.ResponseWriter, r *http.Request, id UserID)
+	// Start TOTP enrollment
+	// (POST /users/{id}/mfa/totp)
+	EnrollTotp(w http.ResponseWriter, r *http.Request, id UserID)
+	// Confirm TOTP enrollment
+	// (POST /users/{id}/mfa/totp/confirm)
+	ConfirmTotp(w http.ResponseWriter, r *http.Request, id UserID)
+	// Set user password
+	// (PUT /users/{id}/password)
+	SetUserPassword(w http.ResponseWriter, r *http.Request, id UserID)
+	// Reactivate user
+	// (POST /users/{id}/reactivate)
+	ReactivateUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// Get roles of a user
+	// (GET /users/{id}/roles)
+	GetUserRoles(w http.ResponseWriter, r *http.Request, id UserID)
+	// Revoke a role
+	// (DELETE /users/{id}/roles/{role})
+	RevokeUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName)
+	// Assign a role
+	// (PUT /users/{id}/roles/{role})
+	AssignUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName)
+	// List status changes of a user
+	// (GET /users/{id}/status/events)
+	ListUserStatusEvents(w http.ResponseWriter, r *http.Request, id UserID)
+	// Suspend user
+	// (POST /users/{id}/suspend)
+	SuspendUser(w http.ResponseWriter, r *http.Request, id UserID)
+	// Batch create, update and delete users
+	// (POST /users:batch)
+	BatchUsers(w http.ResponseWriter, r *http.Request)
+	// Get several users by ID
+	// (GET /users:batchGet)
+	BatchGetUsers(w http.ResponseWriter, r *http.Request, params BatchGetUsersParams)
+	// Get many users by ID
+	// (POST /users:batchGet)
+	BatchGetUsersPost(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List API keys of services
+// (GET /api-keys)
+func (_ Unimplemented) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key of services
+// (POST /api-keys)
+func (_ Unimplemented) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key of services
+// (DELETE /api-keys/{keyId})
+func (_ Unimplemented) RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate an API key of services
+// (POST /api-keys/{keyId}/rotate)
+func (_ Unimplemented) RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove the attribute schema
+// (DELETE /attribute-schema)
+func (_ Unimplemented) DeleteAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the attribute schema
+// (GET /attribute-schema)
+func (_ Unimplemented) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register the attribute schema
+// (PUT /attribute-schema)
+func (_ Unimplemented) PutAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm an email address
+// (POST /auth/email/verify)
+func (_ Unimplemented) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Accept an invitation
+// (POST /auth/invitations/accept)
+func (_ Unimplemented) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Log in with email and password
+// (POST /auth/login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a login with a second factor
+// (POST /auth/mfa/verify)
+func (_ Unimplemented) VerifyMfa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Request a password reset
+// (POST /auth/password-reset)
+func (_ Unimplemented) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset a password
+// (POST /auth/password-reset/confirm)
+func (_ Unimplemented) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh access token
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke session
+// (POST /auth/revoke)
+func (_ Unimplemented) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get avatar
+// (GET /avatars/{userId}/{version})
+func (_ Unimplemented) GetAvatar(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, version string, params GetAvatarParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List groups
+// (GET /groups)
+func (_ Unimplemented) ListGroups(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a group
+// (POST /groups)
+func (_ Unimplemented) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a group
+// (DELETE /groups/{groupId})
+func (_ Unimplemented) DeleteGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a group
+// (GET /groups/{groupId})
+func (_ Unimplemented) GetGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a group
+// (PUT /groups/{groupId})
+func (_ Unimplemented) UpdateGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List members of a group
+// (GET /groups/{groupId}/members)
+func (_ Unimplemented) ListGroupMembers(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, params ListGroupMembersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unnest a group
+// (DELETE /groups/{groupId}/members/groups/{subgroupId})
+func (_ Unimplemented) RemoveSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Nest a group
+// (PUT /groups/{groupId}/members/groups/{subgroupId})
+func (_ Unimplemented) AddSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a user from a group
+// (DELETE /groups/{groupId}/members/users/{id})
+func (_ Unimplemented) RemoveGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check group membership
+// (GET /groups/{groupId}/members/users/{id})
+func (_ Unimplemented) CheckGroupMembership(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a user to a group
+// (PUT /groups/{groupId}/members/users/{id})
+func (_ Unimplemented) AddGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Service Health
+// (GET /health)
+func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List invitations
+// (GET /invitations)
+func (_ Unimplemented) ListInvitations(w http.ResponseWriter, r *http.Request, params ListInvitationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Invite a user
+// (POST /invitations)
+func (_ Unimplemented) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an invitation
+// (DELETE /invitations/{invitationId})
+func (_ Unimplemented) RevokeInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an invitation
+// (GET /invitations/{invitationId})
+func (_ Unimplemented) GetInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Resend an invitation
+// (POST /invitations/{invitationId}/resend)
+func (_ Unimplemented) ResendInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enqueue job
+// (POST /jobs)
+func (_ Unimplemented) PostJob(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel job
+// (DELETE /jobs/{id})
+func (_ Unimplemented) CancelJob(w http.ResponseWriter, r *http.Request, id uint) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get job by ID
+// (GET /jobs/{id})
+func (_ Unimplemented) GetJob(w http.ResponseWriter, r *http.Request, id uint) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download job artifact
+// (GET /jobs/{id}/artifact)
+func (_ Unimplemented) GetJobArtifact(w http.ResponseWriter, r *http.Request, id uint) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List organizations
+// (GET /organizations)
+func (_ Unimplemented) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an organization
+// (POST /organizations)
+func (_ Unimplemented) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an organization
+// (GET /organizations/{orgId})
+func (_ Unimplemented) GetOrganization(w http.ResponseWriter, r *http.Request, orgId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List roles
+// (GET /roles)
+func (_ Unimplemented) ListRoles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List users
+// (GET /users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create new user
+// (POST /users)
+func (_ Unimplemented) PostUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user by email
+// (GET /users/by-email/{email})
+func (_ Unimplemented) GetUserByEmail(w http.ResponseWriter, r *http.Request, email string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search users
+// (GET /users/search)
+func (_ Unimplemented) SearchUsers(w http.ResponseWriter, r *http.Request, params SearchUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user by ID
+// (GET /users/{id})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update user
+// (PUT /users/{id})
+func (_ Unimplemented) PutUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List API keys of a user
+// (GET /users/{id}/api-keys)
+func (_ Unimplemented) ListUserAPIKeys(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key of a user
+// (POST /users/{id}/api-keys)
+func (_ Unimplemented) CreateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key of a user
+// (DELETE /users/{id}/api-keys/{keyId})
+func (_ Unimplemented) RevokeUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate an API key of a user
+// (POST /users/{id}/api-keys/{keyId}/rotate)
+func (_ Unimplemented) RotateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete avatar
+// (DELETE /users/{id}/avatar)
+func (_ Unimplemented) DeleteUserAvatar(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload avatar
+// (PUT /users/{id}/avatar)
+func (_ Unimplemented) PutUserAvatar(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export user data
+// (GET /users/{id}/data-export)
+func (_ Unimplemented) ExportUserData(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deactivate user
+// (POST /users/{id}/deactivate)
+func (_ Unimplemented) DeactivateUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send an email verification mail
+// (POST /users/{id}/email/verification)
+func (_ Unimplemented) SendEmailVerification(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Erase user
+// (POST /users/{id}/erase)
+func (_ Unimplemented) EraseUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List groups of a user
+// (GET /users/{id}/groups)
+func (_ Unimplemented) ListUserGroups(w http.ResponseWriter, r *http.Request, id UserID, params ListUserGroupsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List legal holds of a user
+// (GET /users/{id}/legal-holds)
+func (_ Unimplemented) ListLegalHolds(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Place legal hold
+// (POST /users/{id}/legal-holds)
+func (_ Unimplemented) PlaceLegalHold(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Release legal hold
+// (POST /users/{id}/legal-holds/{holdId}/release)
+func (_ Unimplemented) ReleaseLegalHold(w http.ResponseWriter, r *http.Request, id UserID, holdId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get MFA status
+// (GET /users/{id}/mfa)
+func (_ Unimplemented) GetMfaStatus(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset MFA
+// (POST /users/{id}/mfa/reset)
+func (_ Unimplemented) ResetMfa(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start TOTP enrollment
+// (POST /users/{id}/mfa/totp)
+func (_ Unimplemented) EnrollTotp(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm TOTP enrollment
+// (POST /users/{id}/mfa/totp/confirm)
+func (_ Unimplemented) ConfirmTotp(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set user password
+// (PUT /users/{id}/password)
+func (_ Unimplemented) SetUserPassword(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reactivate user
+// (POST /users/{id}/reactivate)
+func (_ Unimplemented) ReactivateUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get roles of a user
+// (GET /users/{id}/roles)
+func (_ Unimplemented) GetUserRoles(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a role
+// (DELETE /users/{id}/roles/{role})
+func (_ Unimplemented) RevokeUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Assign a role
+// (PUT /users/{id}/roles/{role})
+func (_ Unimplemented) AssignUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List status changes of a user
+// (GET /users/{id}/status/events)
+func (_ Unimplemented) ListUserStatusEvents(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Suspend user
+// (POST /users/{id}/suspend)
+func (_ Unimplemented) SuspendUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Batch create, update and delete users
+// (POST /users:batch)
+func (_ Unimplemented) BatchUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get several users by ID
+// (GET /users:batchGet)
+func (_ Unimplemented) BatchGetUsers(w http.ResponseWriter, r *http.Request, params BatchGetUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get many users by ID
+// (POST /users:batchGet)
+func (_ Unimplemented) BatchGetUsersPost(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAPIKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAPIKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeAPIKey(w, r, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateAPIKey(w, r, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAttributeSchema operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAttributeSchema(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAttributeSchema(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAttributeSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAttributeSchema(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAttributeSchema operation middleware
+func (siw *ServerInterfaceWrapper) PutAttributeSchema(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAttributeSchema(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AcceptInvitation operation middleware
+func (siw *ServerInterfaceWrapper) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptInvitation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyMfa operation middleware
+func (siw *ServerInterfaceWrapper) VerifyMfa(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyMfa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RequestPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAvatar operation middleware
+func (siw *ServerInterfaceWrapper) GetAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", chi.URLParam(r, "version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAvatarParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAvatar(w, r, userId, version, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListGroups(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGroups(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateGroup operation middleware
+func (siw *ServerInterfaceWrapper) CreateGroup(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateGroup(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteGroup(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroup(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateGroup operation middleware
+func (siw *ServerInterfaceWrapper) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateGroup(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListGroupMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListGroupMembers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGroupMembersParams
+
+	// ------------- Optional query parameter "transitive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "transitive", r.URL.Query(), &params.Transitive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transitive", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGroupMembers(w, r, groupId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveSubgroup operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSubgroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subgroupId" -------------
+	var subgroupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subgroupId", chi.URLParam(r, "subgroupId"), &subgroupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subgroupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveSubgroup(w, r, groupId, subgroupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddSubgroup operation middleware
+func (siw *ServerInterfaceWrapper) AddSubgroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subgroupId" -------------
+	var subgroupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subgroupId", chi.URLParam(r, "subgroupId"), &subgroupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subgroupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddSubgroup(w, r, groupId, subgroupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveGroupUser operation middleware
+func (siw *ServerInterfaceWrapper) RemoveGroupUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveGroupUser(w, r, groupId, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckGroupMembership operation middleware
+func (siw *ServerInterfaceWrapper) CheckGroupMembership(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckGroupMembership(w, r, groupId, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddGroupUser operation middleware
+func (siw *ServerInterfaceWrapper) AddGroupUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddGroupUser(w, r, groupId, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListInvitations operation middleware
+func (siw *ServerInterfaceWrapper) ListInvitations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListInvitationsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListInvitations(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateInvitation operation middleware
+func (siw *ServerInterfaceWrapper) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateInvitation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeInvitation operation middleware
+func (siw *ServerInterfaceWrapper) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "invitationId" -------------
+	var invitationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invitationId", chi.URLParam(r, "invitationId"), &invitationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "invitationId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeInvitation(w, r, invitationId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInvitation operation middleware
+func (siw *ServerInterfaceWrapper) GetInvitation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "invitationId" -------------
+	var invitationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invitationId", chi.URLParam(r, "invitationId"), &invitationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "invitationId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInvitation(w, r, invitationId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResendInvitation operation middleware
+func (siw *ServerInterfaceWrapper) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "invitationId" -------------
+	var invitationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invitationId", chi.URLParam(r, "invitationId"), &invitationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "invitationId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResendInvitation(w, r, invitationId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostJob operation middleware
+func (siw *ServerInterfaceWrapper) PostJob(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJob(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelJob(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJob(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJobArtifact operation middleware
+func (siw *ServerInterfaceWrapper) GetJobArtifact(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJobArtifact(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListOrganizations operation middleware
+func (siw *ServerInterfaceWrapper) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrganizations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateOrganization operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOrganization(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrganization operation middleware
+func (siw *ServerInterfaceWrapper) GetOrganization(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orgId" -------------
+	var orgId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orgId", chi.URLParam(r, "orgId"), &orgId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orgId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrganization(w, r, orgId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRoles operation middleware
+func (siw *ServerInterfaceWrapper) ListRoles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "attribute" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "attribute", r.URL.Query(), &params.Attribute)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attribute", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUser operation middleware
+func (siw *ServerInterfaceWrapper) PostUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserByEmail operation middleware
+func (siw *ServerInterfaceWrapper) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "email" -------------
+	var email string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", chi.URLParam(r, "email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserByEmail(w, r, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchUsers operation middleware
+func (siw *ServerInterfaceWrapper) SearchUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchUsersParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutUser operation middleware
+func (siw *ServerInterfaceWrapper) PutUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUserAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListUserAPIKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserAPIKeys(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUserAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateUserAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUserAPIKey(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeUserAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeUserAPIKey(w, r, id, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateUserAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateUserAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateUserAPIKey(w, r, id, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUserAvatar(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) PutUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutUserAvatar(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportUserData operation middleware
+func (siw *ServerInterfaceWrapper) ExportUserData(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportUserData(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeactivateUser operation middleware
+func (siw *ServerInterfaceWrapper) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeactivateUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SendEmailVerification operation middleware
+func (siw *ServerInterfaceWrapper) SendEmailVerification(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendEmailVerification(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EraseUser operation middleware
+func (siw *ServerInterfaceWrapper) EraseUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EraseUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUserGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListUserGroups(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserGroupsParams
+
+	// ------------- Optional query parameter "transitive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "transitive", r.URL.Query(), &params.Transitive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transitive", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserGroups(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLegalHolds operation middleware
+func (siw *ServerInterfaceWrapper) ListLegalHolds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLegalHolds(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PlaceLegalHold operation middleware
+func (siw *ServerInterfaceWrapper) PlaceLegalHold(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PlaceLegalHold(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReleaseLegalHold operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseLegalHold(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "holdId" -------------
+	var holdId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", chi.URLParam(r, "holdId"), &holdId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "holdId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReleaseLegalHold(w, r, id, holdId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMfaStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetMfaStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMfaStatus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetMfa operation middleware
+func (siw *ServerInterfaceWrapper) ResetMfa(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetMfa(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EnrollTotp operation middleware
+func (siw *ServerInterfaceWrapper) EnrollTotp(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnrollTotp(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmTotp operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmTotp(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmTotp(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserPassword operation middleware
+func (siw *ServerInterfaceWrapper) SetUserPassword(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserPassword(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReactivateUser operation middleware
+func (siw *ServerInterfaceWrapper) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReactivateUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserRoles(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeUserRole operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role RoleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeUserRole(w, r, id, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AssignUserRole operation middleware
+func (siw *ServerInterfaceWrapper) AssignUserRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role RoleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AssignUserRole(w, r, id, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUserStatusEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListUserStatusEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserStatusEvents(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SuspendUser operation middleware
+func (siw *ServerInterfaceWrapper) SuspendUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SuspendUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchUsers operation middleware
+func (siw *ServerInterfaceWrapper) BatchUsers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchUsers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchGetUsers operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchGetUsersParams
+
+	// ------------- Required query parameter "ids" -------------
+
+	if paramValue := r.URL.Query().Get("ids"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "ids"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "ids", r.URL.Query(), &params.Ids)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ids", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchGetUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchGetUsersPost operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetUsersPost(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchGetUsersPost(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api-keys", wrapper.ListAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api-keys", wrapper.CreateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api-keys/{keyId}", wrapper.RevokeAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api-keys/{keyId}/rotate", wrapper.RotateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/attribute-schema", wrapper.DeleteAttributeSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/attribute-schema", wrapper.GetAttributeSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/attribute-schema", wrapper.PutAttributeSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/email/verify", wrapper.VerifyEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/invitations/accept", wrapper.AcceptInvitation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/verify", wrapper.VerifyMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password-reset", wrapper.RequestPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password-reset/confirm", wrapper.ConfirmPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/revoke", wrapper.RevokeToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/avatars/{userId}/{version}", wrapper.GetAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups", wrapper.ListGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/groups", wrapper.CreateGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{groupId}", wrapper.DeleteGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{groupId}", wrapper.GetGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/groups/{groupId}", wrapper.UpdateGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{groupId}/members", wrapper.ListGroupMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{groupId}/members/groups/{subgroupId}", wrapper.RemoveSubgroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/groups/{groupId}/members/groups/{subgroupId}", wrapper.AddSubgroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{groupId}/members/users/{id}", wrapper.RemoveGroupUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{groupId}/members/users/{id}", wrapper.CheckGroupMembership)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/groups/{groupId}/members/users/{id}", wrapper.AddGroupUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/invitations", wrapper.ListInvitations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/invitations", wrapper.CreateInvitation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/invitations/{invitationId}", wrapper.RevokeInvitation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/invitations/{invitationId}", wrapper.GetInvitation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/invitations/{invitationId}/resend", wrapper.ResendInvitation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/jobs", wrapper.PostJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/jobs/{id}", wrapper.CancelJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/jobs/{id}", wrapper.GetJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/jobs/{id}/artifact", wrapper.GetJobArtifact)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/organizations", wrapper.ListOrganizations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/organizations", wrapper.CreateOrganization)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/organizations/{orgId}", wrapper.GetOrganization)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles", wrapper.ListRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users", wrapper.PostUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/by-email/{email}", wrapper.GetUserByEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/search", wrapper.SearchUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{id}", wrapper.PutUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/api-keys", wrapper.ListUserAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/api-keys", wrapper.CreateUserAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/api-keys/{keyId}", wrapper.RevokeUserAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/api-keys/{keyId}/rotate", wrapper.RotateUserAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/avatar", wrapper.DeleteUserAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{id}/avatar", wrapper.PutUserAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/data-export", wrapper.ExportUserData)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/deactivate", wrapper.DeactivateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/email/verification", wrapper.SendEmailVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/erase", wrapper.EraseUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/groups", wrapper.ListUserGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/legal-holds", wrapper.ListLegalHolds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/legal-holds", wrapper.PlaceLegalHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/legal-holds/{holdId}/release", wrapper.ReleaseLegalHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/mfa", wrapper.GetMfaStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/mfa/reset", wrapper.ResetMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/mfa/totp", wrapper.EnrollTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/mfa/totp/confirm", wrapper.ConfirmTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{id}/password", wrapper.SetUserPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/reactivate", wrapper.ReactivateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/roles", wrapper.GetUserRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/roles/{role}", wrapper.RevokeUserRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{id}/roles/{role}", wrapper.AssignUserRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}/status/events", wrapper.ListUserStatusEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{id}/suspend", wrapper.SuspendUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users:batch", wrapper.BatchUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users:batchGet", wrapper.BatchGetUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users:batchGet", wrapper.BatchGetUsersPost)
+	})
+
+	return r
+}
+
+type ForbiddenJSONResponse Error
+
+type UnauthorizedResponseHeaders struct {
+	WWWAuthenticate string
+}
+type UnauthorizedJSONResponse struct {
+	Body Error
+
+	Headers UnauthorizedResponseHeaders
+}
+
+type ListAPIKeysRequestObject struct {
+}
+
+type ListAPIKeysResponseObject interface {
+	VisitListAPIKeysResponse(w http.ResponseWriter) error
+}
+
+type ListAPIKeys200JSONResponse APIKeyList
+
+func (response ListAPIKeys200JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListAPIKeys401JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListAPIKeys403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListAPIKeys403JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys500JSONResponse Error
+
+func (response ListAPIKeys500JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKeyRequestObject struct {
+	Body *CreateAPIKeyJSONRequestBody
+}
+
+type CreateAPIKeyResponseObject interface {
+	VisitCreateAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateAPIKey201JSONResponse APIKeySecret
+
+func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey400JSONResponse Error
+
+func (response CreateAPIKey400JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateAPIKey401JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateAPIKey403JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey500JSONResponse Error
+
+func (response CreateAPIKey500JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKeyRequestObject struct {
+	KeyId openapi_types.UUID `json:"keyId"`
+}
+
+type RevokeAPIKeyResponseObject interface {
+	VisitRevokeAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type RevokeAPIKey204Response struct {
+}
+
+func (response RevokeAPIKey204Response) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeAPIKey401JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevokeAPIKey403JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKey404JSONResponse Error
+
+func (response RevokeAPIKey404JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKey500JSONResponse Error
+
+func (response RevokeAPIKey500JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateAPIKeyRequestObject struct {
+	KeyId openapi_types.UUID `json:"keyId"`
+}
+
+type RotateAPIKeyResponseObject interface {
+	VisitRotateAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type RotateAPIKey200JSONResponse APIKeySecret
+
+func (response RotateAPIKey200JSONResponse) VisitRotateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RotateAPIKey401JSONResponse) VisitRotateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RotateAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RotateAPIKey403JSONResponse) VisitRotateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateAPIKey404JSONResponse Error
+
+func (response RotateAPIKey404JSONResponse) VisitRotateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateAPIKey500JSONResponse Error
+
+func (response RotateAPIKey500JSONResponse) VisitRotateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAttributeSchemaRequestObject struct {
+}
+
+type DeleteAttributeSchemaResponseObject interface {
+	VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error
+}
+
+type DeleteAttributeSchema204Response struct {
+}
+
+func (response DeleteAttributeSchema204Response) VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAttributeSchema401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteAttributeSchema401JSONResponse) VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteAttributeSchema403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteAttributeSchema403JSONResponse) VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAttributeSchema404JSONResponse Error
+
+func (response DeleteAttributeSchema404JSONResponse) VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAttributeSchema500JSONResponse Error
+
+func (response DeleteAttributeSchema500JSONResponse) VisitDeleteAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchemaRequestObject struct {
+}
+
+type GetAttributeSchemaResponseObject interface {
+	VisitGetAttributeSchemaResponse(w http.ResponseWriter) error
+}
+
+type GetAttributeSchema200JSONResponse AttributeSchema
+
+func (response GetAttributeSchema200JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchema401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetAttributeSchema401JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAttributeSchema403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetAttributeSchema403JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchema404JSONResponse Error
+
+func (response GetAttributeSchema404JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchema500JSONResponse Error
+
+func (response GetAttributeSchema500JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchemaRequestObject struct {
+	Body *PutAttributeSchemaJSONRequestBody
+}
+
+type PutAttributeSchemaResponseObject interface {
+	VisitPutAttributeSchemaResponse(w http.ResponseWriter) error
+}
+
+type PutAttributeSchema200JSONResponse AttributeSchema
+
+func (response PutAttributeSchema200JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema400JSONResponse Error
+
+func (response PutAttributeSchema400JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutAttributeSchema401JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutAttributeSchema403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutAttributeSchema403JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema500JSONResponse Error
+
+func (response PutAttributeSchema500JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmailRequestObject struct {
+	Body *VerifyEmailJSONRequestBody
+}
+
+type VerifyEmailResponseObject interface {
+	VisitVerifyEmailResponse(w http.ResponseWriter) error
+}
+
+type VerifyEmail200JSONResponse User
+
+func (response VerifyEmail200JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmail400JSONResponse Error
+
+func (response VerifyEmail400JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmail409JSONResponse Error
+
+func (response VerifyEmail409JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmail500JSONResponse Error
+
+func (response VerifyEmail500JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptInvitationRequestObject struct {
+	Body *AcceptInvitationJSONRequestBody
+}
+
+type AcceptInvitationResponseObject interface {
+	VisitAcceptInvitationResponse(w http.ResponseWriter) error
+}
+
+type AcceptInvitation200JSONResponse User
+
+func (response AcceptInvitation200JSONResponse) VisitAcceptInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptInvitation400JSONResponse Error
+
+func (response AcceptInvitation400JSONResponse) VisitAcceptInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptInvitation500JSONResponse Error
+
+func (response AcceptInvitation500JSONResponse) VisitAcceptInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200JSONResponse LoginResponse
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login202JSONResponse MfaChallenge
+
+func (response Login202JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login400JSONResponse Error
+
+func (response Login400JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401JSONResponse Error
+
+func (response Login401JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login403JSONResponse Error
+
+func (response Login403JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Login429JSONResponse struct {
+	Body    Error
+	Headers Login429ResponseHeaders
+}
+
+func (response Login429JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Login500JSONResponse Error
+
+func (response Login500JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyMfaRequestObject struct {
+	Body *VerifyMfaJSONRequestBody
+}
+
+type VerifyMfaResponseObject interface {
+	VisitVerifyMfaResponse(w http.ResponseWriter) error
+}
+
+type VerifyMfa200JSONResponse LoginResponse
+
+func (response VerifyMfa200JSONResponse) VisitVerifyMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyMfa400JSONResponse Error
+
+func (response VerifyMfa400JSONResponse) VisitVerifyMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyMfa401JSONResponse Error
+
+func (response VerifyMfa401JSONResponse) VisitVerifyMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyMfa500JSONResponse Error
+
+func (response VerifyMfa500JSONResponse) VisitVerifyMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestPasswordResetRequestObject struct {
+	Body *RequestPasswordResetJSONRequestBody
+}
+
+type RequestPasswordResetResponseObject interface {
+	VisitRequestPasswordResetResponse(w http.ResponseWriter) error
+}
+
+type RequestPasswordReset202Response struct {
+}
+
+func (response RequestPasswordReset202Response) VisitRequestPasswordResetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type RequestPasswordReset400JSONResponse Error
+
+func (response RequestPasswordReset400JSONResponse) VisitRequestPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestPasswordReset429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type RequestPasswordReset429JSONResponse struct {
+	Body    Error
+	Headers RequestPasswordReset429ResponseHeaders
+}
+
+func (response RequestPasswordReset429JSONResponse) VisitRequestPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RequestPasswordReset500JSONResponse Error
+
+func (response RequestPasswordReset500JSONResponse) VisitRequestPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPasswordResetRequestObject struct {
+	Body *ConfirmPasswordResetJSONRequestBody
+}
+
+type ConfirmPasswordResetResponseObject interface {
+	VisitConfirmPasswordResetResponse(w http.ResponseWriter) error
+}
+
+type ConfirmPasswordReset204Response struct {
+}
+
+func (response ConfirmPasswordReset204Response) VisitConfirmPasswordResetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ConfirmPasswordReset400JSONResponse Error
+
+func (response ConfirmPasswordReset400JSONResponse) VisitConfirmPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPasswordReset500JSONResponse Error
+
+func (response ConfirmPasswordReset500JSONResponse) VisitConfirmPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokenRequestObject struct {
+	Body *RefreshTokenJSONRequestBody
+}
+
+type RefreshTokenResponseObject interface {
+	VisitRefreshTokenResponse(w http.ResponseWriter) error
+}
+
+type RefreshToken200JSONResponse TokenResponse
+
+func (response RefreshToken200JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken400JSONResponse Error
+
+func (response RefreshToken400JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken401JSONResponse Error
+
+func (response RefreshToken401JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken500JSONResponse Error
+
+func (response RefreshToken500JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeTokenRequestObject struct {
+	Body *RevokeTokenJSONRequestBody
+}
+
+type RevokeTokenResponseObject interface {
+	VisitRevokeTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeToken204Response struct {
+}
+
+func (response RevokeToken204Response) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeToken400JSONResponse Error
+
+func (response RevokeToken400JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeToken500JSONResponse Error
+
+func (response RevokeToken500JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAvatarRequestObject struct {
+	UserId  openapi_types.UUID `json:"userId"`
+	Version string             `json:"version"`
+	Params  GetAvatarParams
+}
+
+type GetAvatarResponseObject interface {
+	VisitGetAvatarResponse(w http.ResponseWriter) error
+}
+
+type GetAvatar200ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetAvatar200ImageResponse struct {
+	Body          io.Reader
+	Headers       GetAvatar200ResponseHeaders
+	ContentType   string
+	ContentLength int64
+}
+
+func (response GetAvatar200ImageResponse) VisitGetAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", response.ContentType)
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetAvatar304ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetAvatar304Response struct {
+	Headers GetAvatar304ResponseHeaders
+}
+
+func (response GetAvatar304Response) VisitGetAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetAvatar400JSONResponse Error
+
+func (response GetAvatar400JSONResponse) VisitGetAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAvatar404JSONResponse Error
+
+func (response GetAvatar404JSONResponse) VisitGetAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAvatar500JSONResponse Error
+
+func (response GetAvatar500JSONResponse) VisitGetAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroupsRequestObject struct {
+}
+
+type ListGroupsResponseObject interface {
+	VisitListGroupsResponse(w http.ResponseWriter) error
+}
+
+type ListGroups200JSONResponse GroupList
+
+func (response ListGroups200JSONResponse) VisitListGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroups401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListGroups401JSONResponse) VisitListGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListGroups403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListGroups403JSONResponse) VisitListGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroups500JSONResponse Error
+
+func (response ListGroups500JSONResponse) VisitListGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateGroupRequestObject struct {
+	Body *CreateGroupJSONRequestBody
+}
+
+type CreateGroupResponseObject interface {
+	VisitCreateGroupResponse(w http.ResponseWriter) error
+}
+
+type CreateGroup201JSONResponse Group
+
+func (response CreateGroup201JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateGroup400JSONResponse Error
+
+func (response CreateGroup400JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateGroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateGroup401JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateGroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateGroup403JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateGroup409JSONResponse Error
+
+func (response CreateGroup409JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateGroup500JSONResponse Error
+
+func (response CreateGroup500JSONResponse) VisitCreateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+}
+
+type DeleteGroupResponseObject interface {
+	VisitDeleteGroupResponse(w http.ResponseWriter) error
+}
+
+type DeleteGroup204Response struct {
+}
+
+func (response DeleteGroup204Response) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteGroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteGroup401JSONResponse) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteGroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteGroup403JSONResponse) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroup404JSONResponse Error
+
+func (response DeleteGroup404JSONResponse) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroup500JSONResponse Error
+
+func (response DeleteGroup500JSONResponse) VisitDeleteGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+}
+
+type GetGroupResponseObject interface {
+	VisitGetGroupResponse(w http.ResponseWriter) error
+}
+
+type GetGroup200JSONResponse Group
+
+func (response GetGroup200JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetGroup401JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetGroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetGroup403JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroup404JSONResponse Error
+
+func (response GetGroup404JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroup500JSONResponse Error
+
+func (response GetGroup500JSONResponse) VisitGetGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroupRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+	Body    *UpdateGroupJSONRequestBody
+}
+
+type UpdateGroupResponseObject interface {
+	VisitUpdateGroupResponse(w http.ResponseWriter) error
+}
+
+type UpdateGroup200JSONResponse Group
+
+func (response UpdateGroup200JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroup400JSONResponse Error
+
+func (response UpdateGroup400JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateGroup401JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateGroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateGroup403JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroup404JSONResponse Error
+
+func (response UpdateGroup404JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroup409JSONResponse Error
+
+func (response UpdateGroup409JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateGroup500JSONResponse Error
+
+func (response UpdateGroup500JSONResponse) VisitUpdateGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroupMembersRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+	Params  ListGroupMembersParams
+}
+
+type ListGroupMembersResponseObject interface {
+	VisitListGroupMembersResponse(w http.ResponseWriter) error
+}
+
+type ListGroupMembers200JSONResponse GroupMembers
+
+func (response ListGroupMembers200JSONResponse) VisitListGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroupMembers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListGroupMembers401JSONResponse) VisitListGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListGroupMembers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListGroupMembers403JSONResponse) VisitListGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroupMembers404JSONResponse Error
+
+func (response ListGroupMembers404JSONResponse) VisitListGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGroupMembers500JSONResponse Error
+
+func (response ListGroupMembers500JSONResponse) VisitListGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSubgroupRequestObject struct {
+	GroupId    openapi_types.UUID `json:"groupId"`
+	SubgroupId openapi_types.UUID `json:"subgroupId"`
+}
+
+type RemoveSubgroupResponseObject interface {
+	VisitRemoveSubgroupResponse(w http.ResponseWriter) error
+}
+
+type RemoveSubgroup204Response struct {
+}
+
+func (response RemoveSubgroup204Response) VisitRemoveSubgroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveSubgroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RemoveSubgroup401JSONResponse) VisitRemoveSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveSubgroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RemoveSubgroup403JSONResponse) VisitRemoveSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSubgroup404JSONResponse Error
+
+func (response RemoveSubgroup404JSONResponse) VisitRemoveSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSubgroup500JSONResponse Error
+
+func (response RemoveSubgroup500JSONResponse) VisitRemoveSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSubgroupRequestObject struct {
+	GroupId    openapi_types.UUID `json:"groupId"`
+	SubgroupId openapi_types.UUID `json:"subgroupId"`
+}
+
+type AddSubgroupResponseObject interface {
+	VisitAddSubgroupResponse(w http.ResponseWriter) error
+}
+
+type AddSubgroup204Response struct {
+}
+
+func (response AddSubgroup204Response) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddSubgroup401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AddSubgroup401JSONResponse) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddSubgroup403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response AddSubgroup403JSONResponse) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSubgroup404JSONResponse Error
+
+func (response AddSubgroup404JSONResponse) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSubgroup409JSONResponse Error
+
+func (response AddSubgroup409JSONResponse) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddSubgroup500JSONResponse Error
+
+func (response AddSubgroup500JSONResponse) VisitAddSubgroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveGroupUserRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+	Id      UserID             `json:"id"`
+}
+
+type RemoveGroupUserResponseObject interface {
+	VisitRemoveGroupUserResponse(w http.ResponseWriter) error
+}
+
+type RemoveGroupUser204Response struct {
+}
+
+func (response RemoveGroupUser204Response) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveGroupUser400JSONResponse Error
+
+func (response RemoveGroupUser400JSONResponse) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveGroupUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RemoveGroupUser401JSONResponse) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveGroupUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RemoveGroupUser403JSONResponse) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveGroupUser404JSONResponse Error
+
+func (response RemoveGroupUser404JSONResponse) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveGroupUser500JSONResponse Error
+
+func (response RemoveGroupUser500JSONResponse) VisitRemoveGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckGroupMembershipRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+	Id      UserID             `json:"id"`
+}
+
+type CheckGroupMembershipResponseObject interface {
+	VisitCheckGroupMembershipResponse(w http.ResponseWriter) error
+}
+
+type CheckGroupMembership200JSONResponse GroupMembership
+
+func (response CheckGroupMembership200JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckGroupMembership400JSONResponse Error
+
+func (response CheckGroupMembership400JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckGroupMembership401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CheckGroupMembership401JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CheckGroupMembership403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CheckGroupMembership403JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckGroupMembership404JSONResponse Error
+
+func (response CheckGroupMembership404JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckGroupMembership500JSONResponse Error
+
+func (response CheckGroupMembership500JSONResponse) VisitCheckGroupMembershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddGroupUserRequestObject struct {
+	GroupId openapi_types.UUID `json:"groupId"`
+	Id      UserID             `json:"id"`
+}
+
+type AddGroupUserResponseObject interface {
+	VisitAddGroupUserResponse(w http.ResponseWriter) error
+}
+
+type AddGroupUser204Response struct {
+}
+
+func (response AddGroupUser204Response) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddGroupUser400JSONResponse Error
+
+func (response AddGroupUser400JSONResponse) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddGroupUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AddGroupUser401JSONResponse) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddGroupUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response AddGroupUser403JSONResponse) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddGroupUser404JSONResponse Error
+
+func (response AddGroupUser404JSONResponse) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddGroupUser500JSONResponse Error
+
+func (response AddGroupUser500JSONResponse) VisitAddGroupUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Health500JSONResponse Error
+
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response HealthdefaultJSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListInvitationsRequestObject struct {
+	Params ListInvitationsParams
+}
+
+type ListInvitationsResponseObject interface {
+	VisitListInvitationsResponse(w http.ResponseWriter) error
+}
+
+type ListInvitations200JSONResponse InvitationList
+
+func (response ListInvitations200JSONResponse) VisitListInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInvitations401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListInvitations401JSONResponse) VisitListInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListInvitations403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListInvitations403JSONResponse) VisitListInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInvitations500JSONResponse Error
+
+func (response ListInvitations500JSONResponse) VisitListInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInvitationRequestObject struct {
+	Body *CreateInvitationJSONRequestBody
+}
+
+type CreateInvitationResponseObject interface {
+	VisitCreateInvitationResponse(w http.ResponseWriter) error
+}
+
+type CreateInvitation201JSONResponse Invitation
+
+func (response CreateInvitation201JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInvitation400JSONResponse Error
+
+func (response CreateInvitation400JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInvitation401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateInvitation401JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateInvitation403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateInvitation403JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInvitation409JSONResponse Error
+
+func (response CreateInvitation409JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInvitation500JSONResponse Error
+
+func (response CreateInvitation500JSONResponse) VisitCreateInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeInvitationRequestObject struct {
+	InvitationId openapi_types.UUID `json:"invitationId"`
+}
+
+type RevokeInvitationResponseObject interface {
+	VisitRevokeInvitationResponse(w http.ResponseWriter) error
+}
+
+type RevokeInvitation204Response struct {
+}
+
+func (response RevokeInvitation204Response) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeInvitation401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeInvitation401JSONResponse) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeInvitation403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevokeInvitation403JSONResponse) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeInvitation404JSONResponse Error
+
+func (response RevokeInvitation404JSONResponse) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeInvitation409JSONResponse Error
+
+func (response RevokeInvitation409JSONResponse) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeInvitation500JSONResponse Error
+
+func (response RevokeInvitation500JSONResponse) VisitRevokeInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvitationRequestObject struct {
+	InvitationId openapi_types.UUID `json:"invitationId"`
+}
+
+type GetInvitationResponseObject interface {
+	VisitGetInvitationResponse(w http.ResponseWriter) error
+}
+
+type GetInvitation200JSONResponse Invitation
+
+func (response GetInvitation200JSONResponse) VisitGetInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvitation401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInvitation401JSONResponse) VisitGetInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetInvitation403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInvitation403JSONResponse) VisitGetInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvitation404JSONResponse Error
+
+func (response GetInvitation404JSONResponse) VisitGetInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvitation500JSONResponse Error
+
+func (response GetInvitation500JSONResponse) VisitGetInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendInvitationRequestObject struct {
+	InvitationId openapi_types.UUID `json:"invitationId"`
+}
+
+type ResendInvitationResponseObject interface {
+	VisitResendInvitationResponse(w http.ResponseWriter) error
+}
+
+type ResendInvitation200JSONResponse Invitation
+
+func (response ResendInvitation200JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendInvitation401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ResendInvitation401JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ResendInvitation403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ResendInvitation403JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendInvitation404JSONResponse Error
+
+func (response ResendInvitation404JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendInvitation409JSONResponse Error
+
+func (response ResendInvitation409JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendInvitation500JSONResponse Error
+
+func (response ResendInvitation500JSONResponse) VisitResendInvitationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostJobRequestObject struct {
+	Body *PostJobJSONRequestBody
+}
+
+type PostJobResponseObject interface {
+	VisitPostJobResponse(w http.ResponseWriter) error
+}
+
+type PostJob202ResponseHeaders struct {
+	Location string
+}
+
+type PostJob202JSONResponse struct {
+	Body    Job
+	Headers PostJob202ResponseHeaders
+}
+
+func (response PostJob202JSONResponse) VisitPostJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostJob400JSONResponse Error
+
+func (response PostJob400JSONResponse) VisitPostJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostJob401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostJob401JSONResponse) VisitPostJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostJob403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostJob403JSONResponse) VisitPostJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostJob500JSONResponse Error
+
+func (response PostJob500JSONResponse) VisitPostJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJobRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type CancelJobResponseObject interface {
+	VisitCancelJobResponse(w http.ResponseWriter) error
+}
+
+type CancelJob200JSONResponse Job
+
+func (response CancelJob200JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJob401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CancelJob401JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CancelJob403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CancelJob403JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJob404JSONResponse Error
+
+func (response CancelJob404JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJob409JSONResponse Error
+
+func (response CancelJob409JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelJob500JSONResponse Error
+
+func (response CancelJob500JSONResponse) VisitCancelJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJobRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type GetJobResponseObject interface {
+	VisitGetJobResponse(w http.ResponseWriter) error
+}
+
+type GetJob200JSONResponse Job
+
+func (response GetJob200JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJob401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetJob401JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetJob403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetJob403JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJob404JSONResponse Error
+
+func (response GetJob404JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJob500JSONResponse Error
+
+func (response GetJob500JSONResponse) VisitGetJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJobArtifactRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type GetJobArtifactResponseObject interface {
+	VisitGetJobArtifactResponse(w http.ResponseWriter) error
+}
+
+type GetJobArtifact200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetJobArtifact200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	Headers       GetJobArtifact200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetJobArtifact200ApplicationoctetStreamResponse) VisitGetJobArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetJobArtifact401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetJobArtifact401JSONResponse) VisitGetJobArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetJobArtifact403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetJobArtifact403JSONResponse) VisitGetJobArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJobArtifact404JSONResponse Error
+
+func (response GetJobArtifact404JSONResponse) VisitGetJobArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJobArtifact500JSONResponse Error
+
+func (response GetJobArtifact500JSONResponse) VisitGetJobArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationsRequestObject struct {
+}
+
+type ListOrganizationsResponseObject interface {
+	VisitListOrganizationsResponse(w http.ResponseWriter) error
+}
+
+type ListOrganizations200JSONResponse OrganizationList
+
+func (response ListOrganizations200JSONResponse) VisitListOrganizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizations401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListOrganizations401JSONResponse) VisitListOrganizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListOrganizations403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListOrganizations403JSONResponse) VisitListOrganizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizations500JSONResponse Error
+
+func (response ListOrganizations500JSONResponse) VisitListOrganizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganizationRequestObject struct {
+	Body *CreateOrganizationJSONRequestBody
+}
+
+type CreateOrganizationResponseObject interface {
+	VisitCreateOrganizationResponse(w http.ResponseWriter) error
+}
+
+type CreateOrganization201JSONResponse Organization
+
+func (response CreateOrganization201JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganization400JSONResponse Error
+
+func (response CreateOrganization400JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganization401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateOrganization401JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateOrganization403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateOrganization403JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganization409JSONResponse Error
+
+func (response CreateOrganization409JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganization500JSONResponse Error
+
+func (response CreateOrganization500JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrganizationRequestObject struct {
+	OrgId openapi_types.UUID `json:"orgId"`
+}
+
+type GetOrganizationResponseObject interface {
+	VisitGetOrganizationResponse(w http.ResponseWriter) error
+}
+
+type GetOrganization200JSONResponse Organization
+
+func (response GetOrganization200JSONResponse) VisitGetOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrganization401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetOrganization401JSONResponse) VisitGetOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrganization403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetOrganization403JSONResponse) VisitGetOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrganization404JSONResponse Error
+
+func (response GetOrganization404JSONResponse) VisitGetOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrganization500JSONResponse Error
+
+func (response GetOrganization500JSONResponse) VisitGetOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRolesRequestObject struct {
+}
+
+type ListRolesResponseObject interface {
+	VisitListRolesResponse(w http.ResponseWriter) error
+}
+
+type ListRoles200JSONResponse RoleList
+
+func (response ListRoles200JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRoles401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListRoles401JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListRoles403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListRoles403JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRoles500JSONResponse Error
+
+func (response ListRoles500JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersRequestObject struct {
+	Params ListUsersParams
+}
+
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
+}
+
+type ListUsers200JSONResponse UserList
+
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers400JSONResponse Error
+
+func (response ListUsers400JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListUsers401JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListUsers403JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers500JSONResponse Error
+
+func (response ListUsers500JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUserRequestObject struct {
+	Body *PostUserJSONRequestBody
+}
+
+type PostUserResponseObject interface {
+	VisitPostUserResponse(w http.ResponseWriter) error
+}
+
+type PostUser201JSONResponse User
+
+func (response PostUser201JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUser400JSONResponse Error
+
+func (response PostUser400JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostUser401JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostUser403JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUser409JSONResponse Error
+
+func (response PostUser409JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUser500JSONResponse Error
+
+func (response PostUser500JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserByEmailRequestObject struct {
+	Email string `json:"email"`
+}
+
+type GetUserByEmailResponseObject interface {
+	VisitGetUserByEmailResponse(w http.ResponseWriter) error
+}
+
+type GetUserByEmail200JSONResponse User
+
+func (response GetUserByEmail200JSONResponse) VisitGetUserByEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserByEmail401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetUserByEmail401JSONResponse) VisitGetUserByEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserByEmail403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetUserByEmail403JSONResponse) VisitGetUserByEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserByEmail404JSONResponse Error
+
+func (response GetUserByEmail404JSONResponse) VisitGetUserByEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserByEmail500JSONResponse Error
+
+func (response GetUserByEmail500JSONResponse) VisitGetUserByEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchUsersRequestObject struct {
+	Params SearchUsersParams
+}
+
+type SearchUsersResponseObject interface {
+	VisitSearchUsersResponse(w http.ResponseWriter) error
+}
+
+type SearchUsers200JSONResponse SearchResponse
+
+func (response SearchUsers200JSONResponse) VisitSearchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchUsers400JSONResponse Error
+
+func (response SearchUsers400JSONResponse) VisitSearchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SearchUsers401JSONResponse) VisitSearchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SearchUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SearchUsers403JSONResponse) VisitSearchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchUsers500JSONResponse Error
+
+func (response SearchUsers500JSONResponse) VisitSearchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200JSONResponse User
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser400JSONResponse Error
+
+func (response GetUser400JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetUser401JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetUser403JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404JSONResponse Error
+
+func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser500JSONResponse Error
+
+func (response GetUser500JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *PutUserJSONRequestBody
+}
+
+type PutUserResponseObject interface {
+	VisitPutUserResponse(w http.ResponseWriter) error
+}
+
+type PutUser200JSONResponse User
+
+func (response PutUser200JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUser400JSONResponse Error
+
+func (response PutUser400JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutUser401JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutUser403JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUser404JSONResponse Error
+
+func (response PutUser404JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUser409JSONResponse Error
+
+func (response PutUser409JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUser500JSONResponse Error
+
+func (response PutUser500JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserAPIKeysRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type ListUserAPIKeysResponseObject interface {
+	VisitListUserAPIKeysResponse(w http.ResponseWriter) error
+}
+
+type ListUserAPIKeys200JSONResponse APIKeyList
+
+func (response ListUserAPIKeys200JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserAPIKeys400JSONResponse Error
+
+func (response ListUserAPIKeys400JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserAPIKeys401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListUserAPIKeys401JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUserAPIKeys403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListUserAPIKeys403JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserAPIKeys404JSONResponse Error
+
+func (response ListUserAPIKeys404JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserAPIKeys500JSONResponse Error
+
+func (response ListUserAPIKeys500JSONResponse) VisitListUserAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserAPIKeyRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *CreateUserAPIKeyJSONRequestBody
+}
+
+type CreateUserAPIKeyResponseObject interface {
+	VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateUserAPIKey201JSONResponse APIKeySecret
+
+func (response CreateUserAPIKey201JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserAPIKey400JSONResponse Error
+
+func (response CreateUserAPIKey400JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateUserAPIKey401JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateUserAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateUserAPIKey403JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserAPIKey404JSONResponse Error
+
+func (response CreateUserAPIKey404JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserAPIKey500JSONResponse Error
+
+func (response CreateUserAPIKey500JSONResponse) VisitCreateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserAPIKeyRequestObject struct {
+	Id    UserID             `json:"id"`
+	KeyId openapi_types.UUID `json:"keyId"`
+}
+
+type RevokeUserAPIKeyResponseObject interface {
+	VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type RevokeUserAPIKey204Response struct {
+}
+
+func (response RevokeUserAPIKey204Response) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeUserAPIKey400JSONResponse Error
+
+func (response RevokeUserAPIKey400JSONResponse) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeUserAPIKey401JSONResponse) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeUserAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevokeUserAPIKey403JSONResponse) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserAPIKey404JSONResponse Error
+
+func (response RevokeUserAPIKey404JSONResponse) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserAPIKey500JSONResponse Error
+
+func (response RevokeUserAPIKey500JSONResponse) VisitRevokeUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateUserAPIKeyRequestObject struct {
+	Id    UserID             `json:"id"`
+	KeyId openapi_types.UUID `json:"keyId"`
+}
+
+type RotateUserAPIKeyResponseObject interface {
+	VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type RotateUserAPIKey200JSONResponse APIKeySecret
+
+func (response RotateUserAPIKey200JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateUserAPIKey400JSONResponse Error
+
+func (response RotateUserAPIKey400JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateUserAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RotateUserAPIKey401JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RotateUserAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RotateUserAPIKey403JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateUserAPIKey404JSONResponse Error
+
+func (response RotateUserAPIKey404JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateUserAPIKey500JSONResponse Error
+
+func (response RotateUserAPIKey500JSONResponse) VisitRotateUserAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserAvatarRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type DeleteUserAvatarResponseObject interface {
+	VisitDeleteUserAvatarResponse(w http.ResponseWriter) error
+}
+
+type DeleteUserAvatar204Response struct {
+}
+
+func (response DeleteUserAvatar204Response) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUserAvatar400JSONResponse Error
+
+func (response DeleteUserAvatar400JSONResponse) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserAvatar401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteUserAvatar401JSONResponse) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteUserAvatar403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteUserAvatar403JSONResponse) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserAvatar404JSONResponse Error
+
+func (response DeleteUserAvatar404JSONResponse) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserAvatar500JSONResponse Error
+
+func (response DeleteUserAvatar500JSONResponse) VisitDeleteUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatarRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *multipart.Reader
+}
+
+type PutUserAvatarResponseObject interface {
+	VisitPutUserAvatarResponse(w http.ResponseWriter) error
+}
+
+type PutUserAvatar200JSONResponse User
+
+func (response PutUserAvatar200JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar400JSONResponse Error
+
+func (response PutUserAvatar400JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutUserAvatar401JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutUserAvatar403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutUserAvatar403JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar404JSONResponse Error
+
+func (response PutUserAvatar404JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar413JSONResponse Error
+
+func (response PutUserAvatar413JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar415JSONResponse Error
+
+func (response PutUserAvatar415JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(415)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUserAvatar500JSONResponse Error
+
+func (response PutUserAvatar500JSONResponse) VisitPutUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportUserDataRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type ExportUserDataResponseObject interface {
+	VisitExportUserDataResponse(w http.ResponseWriter) error
+}
+
+type ExportUserData200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type ExportUserData200JSONResponse struct {
+	Body    UserDataExport
+	Headers ExportUserData200ResponseHeaders
+}
+
+func (response ExportUserData200JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ExportUserData400JSONResponse Error
+
+func (response ExportUserData400JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportUserData401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ExportUserData401JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ExportUserData403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ExportUserData403JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportUserData404JSONResponse Error
+
+func (response ExportUserData404JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportUserData500JSONResponse Error
+
+func (response ExportUserData500JSONResponse) VisitExportUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUserRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *DeactivateUserJSONRequestBody
+}
+
+type DeactivateUserResponseObject interface {
+	VisitDeactivateUserResponse(w http.ResponseWriter) error
+}
+
+type DeactivateUser200JSONResponse User
+
+func (response DeactivateUser200JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUser400JSONResponse Error
+
+func (response DeactivateUser400JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeactivateUser401JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeactivateUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeactivateUser403JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUser404JSONResponse Error
+
+func (response DeactivateUser404JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUser409JSONResponse Error
+
+func (response DeactivateUser409JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateUser500JSONResponse Error
+
+func (response DeactivateUser500JSONResponse) VisitDeactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendEmailVerificationRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type SendEmailVerificationResponseObject interface {
+	VisitSendEmailVerificationResponse(w http.ResponseWriter) error
+}
+
+type SendEmailVerification204Response struct {
+}
+
+func (response SendEmailVerification204Response) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SendEmailVerification400JSONResponse Error
+
+func (response SendEmailVerification400JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendEmailVerification401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SendEmailVerification401JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SendEmailVerification403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SendEmailVerification403JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendEmailVerification404JSONResponse Error
+
+func (response SendEmailVerification404JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendEmailVerification409JSONResponse Error
+
+func (response SendEmailVerification409JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendEmailVerification429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type SendEmailVerification429JSONResponse struct {
+	Body    Error
+	Headers SendEmailVerification429ResponseHeaders
+}
+
+func (response SendEmailVerification429JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SendEmailVerification500JSONResponse Error
+
+func (response SendEmailVerification500JSONResponse) VisitSendEmailVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUserRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *EraseUserJSONRequestBody
+}
+
+type EraseUserResponseObject interface {
+	VisitEraseUserResponse(w http.ResponseWriter) error
+}
+
+type EraseUser200JSONResponse UserErasure
+
+func (response EraseUser200JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUser400JSONResponse Error
+
+func (response EraseUser400JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response EraseUser401JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type EraseUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response EraseUser403JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUser404JSONResponse Error
+
+func (response EraseUser404JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUser409JSONResponse Error
+
+func (response EraseUser409JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseUser500JSONResponse Error
+
+func (response EraseUser500JSONResponse) VisitEraseUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroupsRequestObject struct {
+	Id     UserID `json:"id"`
+	Params ListUserGroupsParams
+}
+
+type ListUserGroupsResponseObject interface {
+	VisitListUserGroupsResponse(w http.ResponseWriter) error
+}
+
+type ListUserGroups200JSONResponse GroupList
+
+func (response ListUserGroups200JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroups400JSONResponse Error
+
+func (response ListUserGroups400JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroups401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListUserGroups401JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUserGroups403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListUserGroups403JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroups404JSONResponse Error
+
+func (response ListUserGroups404JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroups500JSONResponse Error
+
+func (response ListUserGroups500JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLegalHoldsRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type ListLegalHoldsResponseObject interface {
+	VisitListLegalHoldsResponse(w http.ResponseWriter) error
+}
+
+type ListLegalHolds200JSONResponse LegalHoldList
+
+func (response ListLegalHolds200JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLegalHolds400JSONResponse Error
+
+func (response ListLegalHolds400JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLegalHolds401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListLegalHolds401JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListLegalHolds403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListLegalHolds403JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLegalHolds404JSONResponse Error
+
+func (response ListLegalHolds404JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLegalHolds500JSONResponse Error
+
+func (response ListLegalHolds500JSONResponse) VisitListLegalHoldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PlaceLegalHoldRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *PlaceLegalHoldJSONRequestBody
+}
+
+type PlaceLegalHoldResponseObject interface {
+	VisitPlaceLegalHoldResponse(w http.ResponseWriter) error
+}
+
+type PlaceLegalHold201JSONResponse LegalHold
+
+func (response PlaceLegalHold201JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PlaceLegalHold400JSONResponse Error
+
+func (response PlaceLegalHold400JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PlaceLegalHold401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PlaceLegalHold401JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PlaceLegalHold403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PlaceLegalHold403JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PlaceLegalHold404JSONResponse Error
+
+func (response PlaceLegalHold404JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PlaceLegalHold500JSONResponse Error
+
+func (response PlaceLegalHold500JSONResponse) VisitPlaceLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHoldRequestObject struct {
+	Id     UserID             `json:"id"`
+	HoldId openapi_types.UUID `json:"holdId"`
+	Body   *ReleaseLegalHoldJSONRequestBody
+}
+
+type ReleaseLegalHoldResponseObject interface {
+	VisitReleaseLegalHoldResponse(w http.ResponseWriter) error
+}
+
+type ReleaseLegalHold200JSONResponse LegalHold
+
+func (response ReleaseLegalHold200JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHold400JSONResponse Error
+
+func (response ReleaseLegalHold400JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHold401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ReleaseLegalHold401JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReleaseLegalHold403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReleaseLegalHold403JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHold404JSONResponse Error
+
+func (response ReleaseLegalHold404JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHold409JSONResponse Error
+
+func (response ReleaseLegalHold409JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseLegalHold500JSONResponse Error
+
+func (response ReleaseLegalHold500JSONResponse) VisitReleaseLegalHoldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMfaStatusRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type GetMfaStatusResponseObject interface {
+	VisitGetMfaStatusResponse(w http.ResponseWriter) error
+}
+
+type GetMfaStatus200JSONResponse MfaStatus
+
+func (response GetMfaStatus200JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMfaStatus400JSONResponse Error
+
+func (response GetMfaStatus400JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMfaStatus401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetMfaStatus401JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetMfaStatus403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetMfaStatus403JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMfaStatus404JSONResponse Error
+
+func (response GetMfaStatus404JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMfaStatus500JSONResponse Error
+
+func (response GetMfaStatus500JSONResponse) VisitGetMfaStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetMfaRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *ResetMfaJSONRequestBody
+}
+
+type ResetMfaResponseObject interface {
+	VisitResetMfaResponse(w http.ResponseWriter) error
+}
+
+type ResetMfa204Response struct {
+}
+
+func (response ResetMfa204Response) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ResetMfa400JSONResponse Error
+
+func (response ResetMfa400JSONResponse) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetMfa401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ResetMfa401JSONResponse) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ResetMfa403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ResetMfa403JSONResponse) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetMfa404JSONResponse Error
+
+func (response ResetMfa404JSONResponse) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetMfa500JSONResponse Error
+
+func (response ResetMfa500JSONResponse) VisitResetMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotpRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type EnrollTotpResponseObject interface {
+	VisitEnrollTotpResponse(w http.ResponseWriter) error
+}
+
+type EnrollTotp200JSONResponse TotpEnrollment
+
+func (response EnrollTotp200JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotp400JSONResponse Error
+
+func (response EnrollTotp400JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotp401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response EnrollTotp401JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type EnrollTotp403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response EnrollTotp403JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotp404JSONResponse Error
+
+func (response EnrollTotp404JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotp409JSONResponse Error
+
+func (response EnrollTotp409JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollTotp500JSONResponse Error
+
+func (response EnrollTotp500JSONResponse) VisitEnrollTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotpRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *ConfirmTotpJSONRequestBody
+}
+
+type ConfirmTotpResponseObject interface {
+	VisitConfirmTotpResponse(w http.ResponseWriter) error
+}
+
+type ConfirmTotp200JSONResponse RecoveryCodes
+
+func (response ConfirmTotp200JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotp400JSONResponse Error
+
+func (response ConfirmTotp400JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotp401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ConfirmTotp401JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ConfirmTotp403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ConfirmTotp403JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotp404JSONResponse Error
+
+func (response ConfirmTotp404JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotp409JSONResponse Error
+
+func (response ConfirmTotp409JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmTotp500JSONResponse Error
+
+func (response ConfirmTotp500JSONResponse) VisitConfirmTotpResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPasswordRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *SetUserPasswordJSONRequestBody
+}
+
+type SetUserPasswordResponseObject interface {
+	VisitSetUserPasswordResponse(w http.ResponseWriter) error
+}
+
+type SetUserPassword204Response struct {
+}
+
+func (response SetUserPassword204Response) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SetUserPassword400JSONResponse Error
+
+func (response SetUserPassword400JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPassword401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SetUserPassword401JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetUserPassword403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SetUserPassword403JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPassword404JSONResponse Error
+
+func (response SetUserPassword404JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPassword429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type SetUserPassword429JSONResponse struct {
+	Body    Error
+	Headers SetUserPassword429ResponseHeaders
+}
+
+func (response SetUserPassword429JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetUserPassword500JSONResponse Error
+
+func (response SetUserPassword500JSONResponse) VisitSetUserPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUserRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *ReactivateUserJSONRequestBody
+}
+
+type ReactivateUserResponseObject interface {
+	VisitReactivateUserResponse(w http.ResponseWriter) error
+}
+
+type ReactivateUser200JSONResponse User
+
+func (response ReactivateUser200JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUser400JSONResponse Error
+
+func (response ReactivateUser400JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ReactivateUser401JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReactivateUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReactivateUser403JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUser404JSONResponse Error
+
+func (response ReactivateUser404JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUser409JSONResponse Error
+
+func (response ReactivateUser409JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReactivateUser500JSONResponse Error
+
+func (response ReactivateUser500JSONResponse) VisitReactivateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRolesRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type GetUserRolesResponseObject interface {
+	VisitGetUserRolesResponse(w http.ResponseWriter) error
+}
+
+type GetUserRoles200JSONResponse RoleList
+
+func (response GetUserRoles200JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRoles400JSONResponse Error
+
+func (response GetUserRoles400JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRoles401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetUserRoles401JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserRoles403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetUserRoles403JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRoles404JSONResponse Error
+
+func (response GetUserRoles404JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRoles500JSONResponse Error
+
+func (response GetUserRoles500JSONResponse) VisitGetUserRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserRoleRequestObject struct {
+	Id   UserID   `json:"id"`
+	Role RoleName `json:"role"`
+}
+
+type RevokeUserRoleResponseObject interface {
+	VisitRevokeUserRoleResponse(w http.ResponseWriter) error
+}
+
+type RevokeUserRole204Response struct {
+}
+
+func (response RevokeUserRole204Response) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeUserRole400JSONResponse Error
+
+func (response RevokeUserRole400JSONResponse) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserRole401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeUserRole401JSONResponse) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeUserRole403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevokeUserRole403JSONResponse) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserRole404JSONResponse Error
+
+func (response RevokeUserRole404JSONResponse) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserRole500JSONResponse Error
+
+func (response RevokeUserRole500JSONResponse) VisitRevokeUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignUserRoleRequestObject struct {
+	Id   UserID   `json:"id"`
+	Role RoleName `json:"role"`
+}
+
+type AssignUserRoleResponseObject interface {
+	VisitAssignUserRoleResponse(w http.ResponseWriter) error
+}
+
+type AssignUserRole204Response struct {
+}
+
+func (response AssignUserRole204Response) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AssignUserRole400JSONResponse Error
+
+func (response AssignUserRole400JSONResponse) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignUserRole401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AssignUserRole401JSONResponse) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AssignUserRole403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response AssignUserRole403JSONResponse) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignUserRole404JSONResponse Error
+
+func (response AssignUserRole404JSONResponse) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignUserRole500JSONResponse Error
+
+func (response AssignUserRole500JSONResponse) VisitAssignUserRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserStatusEventsRequestObject struct {
+	Id UserID `json:"id"`
+}
+
+type ListUserStatusEventsResponseObject interface {
+	VisitListUserStatusEventsResponse(w http.ResponseWriter) error
+}
+
+type ListUserStatusEvents200JSONResponse UserStatusEventList
+
+func (response ListUserStatusEvents200JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserStatusEvents400JSONResponse Error
+
+func (response ListUserStatusEvents400JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserStatusEvents401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListUserStatusEvents401JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUserStatusEvents403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListUserStatusEvents403JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserStatusEvents404JSONResponse Error
+
+func (response ListUserStatusEvents404JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserStatusEvents500JSONResponse Error
+
+func (response ListUserStatusEvents500JSONResponse) VisitListUserStatusEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUserRequestObject struct {
+	Id   UserID `json:"id"`
+	Body *SuspendUserJSONRequestBody
+}
+
+type SuspendUserResponseObject interface {
+	VisitSuspendUserResponse(w http.ResponseWriter) error
+}
+
+type SuspendUser200JSONResponse User
+
+func (response SuspendUser200JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUser400JSONResponse Error
+
+func (response SuspendUser400JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SuspendUser401JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SuspendUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SuspendUser403JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUser404JSONResponse Error
+
+func (response SuspendUser404JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUser409JSONResponse Error
+
+func (response SuspendUser409JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SuspendUser500JSONResponse Error
+
+func (response SuspendUser500JSONResponse) VisitSuspendUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUsersRequestObject struct {
+	Body *BatchUsersJSONRequestBody
+}
+
+type BatchUsersResponseObject interface {
+	VisitBatchUsersResponse(w http.ResponseWriter) error
+}
+
+type BatchUsers200JSONResponse BatchResponse
+
+func (response BatchUsers200JSONResponse) VisitBatchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUsers400JSONResponse Error
+
+func (response BatchUsers400JSONResponse) VisitBatchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchUsers401JSONResponse) VisitBatchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type BatchUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchUsers403JSONResponse) VisitBatchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUsers500JSONResponse Error
+
+func (response BatchUsers500JSONResponse) VisitBatchUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsersRequestObject struct {
+	Params BatchGetUsersParams
+}
+
+type BatchGetUsersResponseObject interface {
+	VisitBatchGetUsersResponse(w http.ResponseWriter) error
+}
+
+type BatchGetUsers200JSONResponse BatchGetResponse
+
+func (response BatchGetUsers200JSONResponse) VisitBatchGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsers400JSONResponse Error
+
+func (response BatchGetUsers400JSONResponse) VisitBatchGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchGetUsers401JSONResponse) VisitBatchGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type BatchGetUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchGetUsers403JSONResponse) VisitBatchGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsers500JSONResponse Error
+
+func (response BatchGetUsers500JSONResponse) VisitBatchGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsersPostRequestObject struct {
+	Body *BatchGetUsersPostJSONRequestBody
+}
+
+type BatchGetUsersPostResponseObject interface {
+	VisitBatchGetUsersPostResponse(w http.ResponseWriter) error
+}
+
+type BatchGetUsersPost200JSONResponse BatchGetResponse
+
+func (response BatchGetUsersPost200JSONResponse) VisitBatchGetUsersPostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsersPost400JSONResponse Error
+
+func (response BatchGetUsersPost400JSONResponse) VisitBatchGetUsersPostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsersPost401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchGetUsersPost401JSONResponse) VisitBatchGetUsersPostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type BatchGetUsersPost403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchGetUsersPost403JSONResponse) VisitBatchGetUsersPostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetUsersPost500JSONResponse Error
+
+func (response BatchGetUsersPost500JSONResponse) VisitBatchGetUsersPostResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List API keys of services
+	// (GET /api-keys)
+	ListAPIKeys(ctx context.Context, request ListAPIKeysRequestObject) (ListAPIKeysResponseObject, error)
+	// Create an API key of services
+	// (POST /api-keys)
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+	// Revoke an API key of services
+	// (DELETE /api-keys/{keyId})
+	RevokeAPIKey(ctx context.Context, request RevokeAPIKeyRequestObject) (RevokeAPIKeyResponseObject, error)
+	// Rotate an API key of services
+	// (POST /api-keys/{keyId}/rotate)
+	RotateAPIKey(ctx context.Context, request RotateAPIKeyRequestObject) (RotateAPIKeyResponseObject, error)
+	// Remove the attribute schema
+	// (DELETE /attribute-schema)
+	DeleteAttributeSchema(ctx context.Context, request DeleteAttributeSchemaRequestObject) (DeleteAttributeSchemaResponseObject, error)
+	// Get the attribute schema
+	// (GET /attribute-schema)
+	GetAttributeSchema(ctx context.Context, request GetAttributeSchemaRequestObject) (GetAttributeSchemaResponseObject, error)
+	// Register the attribute schema
+	// (PUT /attribute-schema)
+	PutAttributeSchema(ctx context.Context, request PutAttributeSchemaRequestObject) (PutAttributeSchemaResponseObject, error)
+	// Confirm an email address
+	// (POST /auth/email/verify)
+	VerifyEmail(ctx context.Context, request VerifyEmailRequestObject) (VerifyEmailResponseObject, error)
+	// Accept an invitation
+	// (POST /auth/invitations/accept)
+	AcceptInvitation(ctx context.Context, request AcceptInvitationRequestObject) (AcceptInvitationResponseObject, error)
+	// Log in with email and password
+	// (POST /auth/login)
+	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+	// Complete a login with a second factor
+	// (POST /auth/mfa/verify)
+	VerifyMfa(ctx context.Context, request VerifyMfaRequestObject) (VerifyMfaResponseObject, error)
+	// Request a password reset
+	// (POST /auth/password-reset)
+	RequestPasswordReset(ctx context.Context, request RequestPasswordResetRequestObject) (RequestPasswordResetResponseObject, error)
+	// Reset a password
+	// (POST /auth/password-reset/confirm)
+	ConfirmPasswordReset(ctx context.Context, request ConfirmPasswordResetRequestObject) (ConfirmPasswordResetResponseObject, error)
+	// Refresh access token
+	// (POST /auth/refresh)
+	RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error)
+	// Revoke session
+	// (POST /auth/revoke)
+	RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error)
+	// Get avatar
+	// (GET /avatars/{userId}/{version})
+	GetAvatar(ctx context.Context, request GetAvatarRequestObject) (GetAvatarResponseObject, error)
+	// List groups
+	// (GET /groups)
+	ListGroups(ctx context.Context, request ListGroupsRequestObject) (ListGroupsResponseObject, error)
+	// Create a group
+	// (POST /groups)
+	CreateGroup(ctx context.Context, request CreateGroupRequestObject) (CreateGroupResponseObject, error)
+	// Delete a group
+	// (DELETE /groups/{groupId})
+	DeleteGroup(ctx context.Context, request DeleteGroupRequestObject) (DeleteGroupResponseObject, error)
+	// Get a group
+	// (GET /groups/{groupId})
+	GetGroup(ctx context.Context, request GetGroupRequestObject) (GetGroupResponseObject, error)
+	// Update a group
+	// (PUT /groups/{groupId})
+	UpdateGroup(ctx context.Context, request UpdateGroupRequestObject) (UpdateGroupResponseObject, error)
+	// List members of a group
+	// (GET /groups/{groupId}/members)
+	ListGroupMembers(ctx context.Context, request ListGroupMembersRequestObject) (ListGroupMembersResponseObject, error)
+	// Unnest a group
+	// (DELETE /groups/{groupId}/members/groups/{subgroupId})
+	RemoveSubgroup(ctx context.Context, request RemoveSubgroupRequestObject) (RemoveSubgroupResponseObject, error)
+	// Nest a group
+	// (PUT /groups/{groupId}/members/groups/{subgroupId})
+	AddSubgroup(ctx context.Context, request AddSubgroupRequestObject) (AddSubgroupResponseObject, error)
+	// Remove a user from a group
+	// (DELETE /groups/{groupId}/members/users/{id})
+	RemoveGroupUser(ctx context.Context, request RemoveGroupUserRequestObject) (RemoveGroupUserResponseObject, error)
+	// Check group membership
+	// (GET /groups/{groupId}/members/users/{id})
+	CheckGroupMembership(ctx context.Context, request CheckGroupMembershipRequestObject) (CheckGroupMembershipResponseObject, error)
+	// Add a user to a group
+	// (PUT /groups/{groupId}/members/users/{id})
+	AddGroupUser(ctx context.Context, request AddGroupUserRequestObject) (AddGroupUserResponseObject, error)
+	// Service Health
+	// (GET /health)
+	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+	// List invitations
+	// (GET /invitations)
+	ListInvitations(ctx context.Context, request ListInvitationsRequestObject) (ListInvitationsResponseObject, error)
+	// Invite a user
+	// (POST /invitations)
+	CreateInvitation(ctx context.Context, request CreateInvitationRequestObject) (CreateInvitationResponseObject, error)
+	// Revoke an invitation
+	// (DELETE /invitations/{invitationId})
+	RevokeInvitation(ctx context.Context, request RevokeInvitationRequestObject) (RevokeInvitationResponseObject, error)
+	// Get an invitation
+	// (GET /invitations/{invitationId})
+	GetInvitation(ctx context.Context, request GetInvitationRequestObject) (GetInvitationResponseObject, error)
+	// Resend an invitation
+	// (POST /invitations/{invitationId}/resend)
+	ResendInvitation(ctx context.Context, request ResendInvitationRequestObject) (ResendInvitationResponseObject, error)
+	// Enqueue job
+	// (POST /jobs)
+	PostJob(ctx context.Context, request PostJobRequestObject) (PostJobResponseObject, error)
+	// Cancel job
+	// (DELETE /jobs/{id})
+	CancelJob(ctx context.Context, request CancelJobRequestObject) (CancelJobResponseObject, error)
+	// Get job by ID
+	// (GET /jobs/{id})
+	GetJob(ctx context.Context, request GetJobRequestObject) (GetJobResponseObject, error)
+	// Download job artifact
+	// (GET /jobs/{id}/artifact)
+	GetJobArtifact(ctx context.Context, request GetJobArtifactRequestObject) (GetJobArtifactResponseObject, error)
+	// List organizations
+	// (GET /organizations)
+	ListOrganizations(ctx context.Context, request ListOrganizationsRequestObject) (ListOrganizationsResponseObject, error)
+	// Create an organization
+	// (POST /organizations)
+	CreateOrganization(ctx context.Context, request CreateOrganizationRequestObject) (CreateOrganizationResponseObject, error)
+	// Get an organization
+	// (GET /organizations/{orgId})
+	GetOrganization(ctx context.Context, request GetOrganizationRequestObject) (GetOrganizationResponseObject, error)
+	// List roles
+	// (GET /roles)
+	ListRoles(ctx context.Context, request ListRolesRequestObject) (ListRolesResponseObject, error)
+	// List users
+	// (GET /users)
+	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
+	// Create new user
+	// (POST /users)
+	PostUser(ctx context.Context, request PostUserRequestObject) (PostUserResponseObject, error)
+	// Get user by email
+	// (GET /users/by-email/{email})
+	GetUserByEmail(ctx context.Context, request GetUserByEmailRequestObject) (GetUserByEmailResponseObject, error)
+	// Search users
+	// (GET /users/search)
+	SearchUsers(ctx context.Context, request SearchUsersRequestObject) (SearchUsersResponseObject, error)
+	// Get user by ID
+	// (GET /users/{id})
+	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
+	// Update user
+	// (PUT /users/{id})
+	PutUser(ctx context.Context, request PutUserRequestObject) (PutUserResponseObject, error)
+	// List API keys of a user
+	// (GET /users/{id}/api-keys)
+	ListUserAPIKeys(ctx context.Context, request ListUserAPIKeysRequestObject) (ListUserAPIKeysResponseObject, error)
+	// Create an API key of a user
+	// (POST /users/{id}/api-keys)
+	CreateUserAPIKey(ctx context.Context, request CreateUserAPIKeyRequestObject) (CreateUserAPIKeyResponseObject, error)
+	// Revoke an API key of a user
+	// (DELETE /users/{id}/api-keys/{keyId})
+	RevokeUserAPIKey(ctx context.Context, request RevokeUserAPIKeyRequestObject) (RevokeUserAPIKeyResponseObject, error)
+	// Rotate an API key of a user
+	// (POST /users/{id}/api-keys/{keyId}/rotate)
+	RotateUserAPIKey(ctx context.Context, request RotateUserAPIKeyRequestObject) (RotateUserAPIKeyResponseObject, error)
+	// Delete avatar
+	// (DELETE /users/{id}/avatar)
+	DeleteUserAvatar(ctx context.Context, request DeleteUserAvatarRequestObject) (DeleteUserAvatarResponseObject, error)
+	// Upload avatar
+	// (PUT /users/{id}/avatar)
+	PutUserAvatar(ctx context.Context, request PutUserAvatarRequestObject) (PutUserAvatarResponseObject, error)
+	// Export user data
+	// (GET /users/{id}/data-export)
+	ExportUserData(ctx context.Context, request ExportUserDataRequestObject) (ExportUserDataResponseObject, error)
+	// Deactivate user
+	// (POST /users/{id}/deactivate)
+	DeactivateUser(ctx context.Context, request DeactivateUserRequestObject) (DeactivateUserResponseObject, error)
+	// Send an email verification mail
+	// (POST /users/{id}/email/verification)
+	SendEmailVerification(ctx context.Context, request SendEmailVerificationRequestObject) (SendEmailVerificationResponseObject, error)
+	// Erase user
+	// (POST /users/{id}/erase)
+	EraseUser(ctx context.Context, request EraseUserRequestObject) (EraseUserResponseObject, error)
+	// List groups of a user
+	// (GET /users/{id}/groups)
+	ListUserGroups(ctx context.Context, request ListUserGroupsRequestObject) (ListUserGroupsResponseObject, error)
+	// List legal holds of a user
+	// (GET /users/{id}/legal-holds)
+	ListLegalHolds(ctx context.Context, request ListLegalHoldsRequestObject) (ListLegalHoldsResponseObject, error)
+	// Place legal hold
+	// (POST /users/{id}/legal-holds)
+	PlaceLegalHold(ctx context.Context, request PlaceLegalHoldRequestObject) (PlaceLegalHoldResponseObject, error)
+	// Release legal hold
+	// (POST /users/{id}/legal-holds/{holdId}/release)
+	ReleaseLegalHold(ctx context.Context, request ReleaseLegalHoldRequestObject) (ReleaseLegalHoldResponseObject, error)
+	// Get MFA status
+	// (GET /users/{id}/mfa)
+	GetMfaStatus(ctx context.Context, request GetMfaStatusRequestObject) (GetMfaStatusResponseObject, error)
+	// Reset MFA
+	// (POST /users/{id}/mfa/reset)
+	ResetMfa(ctx context.Context, request ResetMfaRequestObject) (ResetMfaResponseObject, error)
+	// Start TOTP enrollment
+	// (POST /users/{id}/mfa/totp)
+	EnrollTotp(ctx context.Context, request EnrollTotpRequestObject) (EnrollTotpResponseObject, error)
+	// Confirm TOTP enrollment
+	// (POST /users/{id}/mfa/totp/confirm)
+	ConfirmTotp(ctx context.Context, request ConfirmTotpRequestObject) (ConfirmTotpResponseObject, error)
+	// Set user password
+	// (PUT /users/{id}/password)
+	SetUserPassword(ctx context.Context, request SetUserPasswordRequestObject) (SetUserPasswordResponseObject, error)
+	// Reactivate user
+	// (POST /users/{id}/reactivate)
+	ReactivateUser(ctx context.Context, request ReactivateUserRequestObject) (ReactivateUserResponseObject, error)
+	// Get roles of a user
+	// (GET /users/{id}/roles)
+	GetUserRoles(ctx context.Context, request GetUserRolesRequestObject) (GetUserRolesResponseObject, error)
+	// Revoke a role
+	// (DELETE /users/{id}/roles/{role})
+	RevokeUserRole(ctx context.Context, request RevokeUserRoleRequestObject) (RevokeUserRoleResponseObject, error)
+	// Assign a role
+	// (PUT /users/{id}/roles/{role})
+	AssignUserRole(ctx context.Context, request AssignUserRoleRequestObject) (AssignUserRoleResponseObject, error)
+	// List status changes of a user
+	// (GET /users/{id}/status/events)
+	ListUserStatusEvents(ctx context.Context, request ListUserStatusEventsRequestObject) (ListUserStatusEventsResponseObject, error)
+	// Suspend user
+	// (POST /users/{id}/suspend)
+	SuspendUser(ctx context.Context, request SuspendUserRequestObject) (SuspendUserResponseObject, error)
+	// Batch create, update and delete users
+	// (POST /users:batch)
+	BatchUsers(ctx context.Context, request BatchUsersRequestObject) (BatchUsersResponseObject, error)
+	// Get several users by ID
+	// (GET /users:batchGet)
+	BatchGetUsers(ctx context.Context, request BatchGetUsersRequestObject) (BatchGetUsersResponseObject, error)
+	// Get many users by ID
+	// (POST /users:batchGet)
+	BatchGetUsersPost(ctx context.Context, request BatchGetUsersPostRequestObject) (BatchGetUsersPostResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListAPIKeys operation middleware
+func (sh *strictHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	var request ListAPIKeysRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAPIKeys(ctx, request.(ListAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAPIKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAPIKeysResponseObject); ok {
+		if err := validResponse.VisitListAPIKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAPIKey operation middleware
+func (sh *strictHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request CreateAPIKeyRequestObject
+
+	var body CreateAPIKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAPIKey(ctx, request.(CreateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitCreateAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeAPIKey operation middleware
+func (sh *strictHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	var request RevokeAPIKeyRequestObject
+
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeAPIKey(ctx, request.(RevokeAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeAPIKeyResponseObject); ok {
+		if err := validResponse.VisitRevokeAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateAPIKey operation middleware
+func (sh *strictHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	var request RotateAPIKeyRequestObject
+
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateAPIKey(ctx, request.(RotateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitRotateAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAttributeSchema operation middleware
+func (sh *strictHandler) DeleteAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	var request DeleteAttributeSchemaRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAttributeSchema(ctx, request.(DeleteAttributeSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAttributeSchema")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAttributeSchemaResponseObject); ok {
+		if err := validResponse.VisitDeleteAttributeSchemaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAttributeSchema operation middleware
+func (sh *strictHandler) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	var request GetAttributeSchemaRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAttributeSchema(ctx, request.(GetAttributeSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAttributeSchema")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAttributeSchemaResponseObject); ok {
+		if err := validResponse.VisitGetAttributeSchemaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutAttributeSchema operation middleware
+func (sh *strictHandler) PutAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	var request PutAttributeSchemaRequestObject
+
+	var body PutAttributeSchemaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutAttributeSchema(ctx, request.(PutAttributeSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutAttributeSchema")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutAttributeSchemaResponseObject); ok {
+		if err := validResponse.VisitPutAttributeSchemaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VerifyEmail operation middleware
+func (sh *strictHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var request VerifyEmailRequestObject
+
+	var body VerifyEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyEmail(ctx, request.(VerifyEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifyEmailResponseObject); ok {
+		if err := validResponse.VisitVerifyEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AcceptInvitation operation middleware
+func (sh *strictHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var request AcceptInvitationRequestObject
+
+	var body AcceptInvitationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AcceptInvitation(ctx, request.(AcceptInvitationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AcceptInvitation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AcceptInvitationResponseObject); ok {
+		if err := validResponse.VisitAcceptInvitationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Login operation middleware
+func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequestObject
+
+	var body LoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VerifyMfa operation middleware
+func (sh *strictHandler) VerifyMfa(w http.ResponseWriter, r *http.Request) {
+	var request VerifyMfaRequestObject
+
+	var body VerifyMfaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyMfa(ctx, request.(VerifyMfaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyMfa")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifyMfaResponseObject); ok {
+		if err := validResponse.VisitVerifyMfaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestPasswordReset operation middleware
+func (sh *strictHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var request RequestPasswordResetRequestObject
+
+	var body RequestPasswordResetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestPasswordReset(ctx, request.(RequestPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestPasswordReset")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestPasswordResetResponseObject); ok {
+		if err := validResponse.VisitRequestPasswordResetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ConfirmPasswordReset operation middleware
+func (sh *strictHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var request ConfirmPasswordResetRequestObject
+
+	var body ConfirmPasswordResetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmPasswordReset(ctx, request.(ConfirmPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmPasswordReset")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ConfirmPasswordResetResponseObject); ok {
+		if err := validResponse.VisitConfirmPasswordResetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefreshToken operation middleware
+func (sh *strictHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokenRequestObject
+
+	var body RefreshTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshToken(ctx, request.(RefreshTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeToken operation middleware
+func (sh *strictHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var request RevokeTokenRequestObject
+
+	var body RevokeTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeToken(ctx, request.(RevokeTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeTokenResponseObject); ok {
+		if err := validResponse.VisitRevokeTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAvatar operation middleware
+func (sh *strictHandler) GetAvatar(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, version string, params GetAvatarParams) {
+	var request GetAvatarRequestObject
+
+	request.UserId = userId
+	request.Version = version
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAvatar(ctx, request.(GetAvatarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAvatar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAvatarResponseObject); ok {
+		if err := validResponse.VisitGetAvatarResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListGroups operation middleware
+func (sh *strictHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	var request ListGroupsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListGroups(ctx, request.(ListGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListGroups")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListGroupsResponseObject); ok {
+		if err := validResponse.VisitListGroupsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateGroup operation middleware
+func (sh *strictHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var request CreateGroupRequestObject
+
+	var body CreateGroupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateGroup(ctx, request.(CreateGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateGroupResponseObject); ok {
+		if err := validResponse.VisitCreateGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteGroup operation middleware
+func (sh *strictHandler) DeleteGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	var request DeleteGroupRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteGroup(ctx, request.(DeleteGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteGroupResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroup operation middleware
+func (sh *strictHandler) GetGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	var request GetGroupRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroup(ctx, request.(GetGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetGroupResponseObject); ok {
+		if err := validResponse.VisitGetGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateGroup operation middleware
+func (sh *strictHandler) UpdateGroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID) {
+	var request UpdateGroupRequestObject
+
+	request.GroupId = groupId
+
+	var body UpdateGroupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateGroup(ctx, request.(UpdateGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateGroupResponseObject); ok {
+		if err := validResponse.VisitUpdateGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListGroupMembers operation middleware
+func (sh *strictHandler) ListGroupMembers(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, params ListGroupMembersParams) {
+	var request ListGroupMembersRequestObject
+
+	request.GroupId = groupId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListGroupMembers(ctx, request.(ListGroupMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListGroupMembers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListGroupMembersResponseObject); ok {
+		if err := validResponse.VisitListGroupMembersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveSubgroup operation middleware
+func (sh *strictHandler) RemoveSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID) {
+	var request RemoveSubgroupRequestObject
+
+	request.GroupId = groupId
+	request.SubgroupId = subgroupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveSubgroup(ctx, request.(RemoveSubgroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveSubgroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveSubgroupResponseObject); ok {
+		if err := validResponse.VisitRemoveSubgroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddSubgroup operation middleware
+func (sh *strictHandler) AddSubgroup(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, subgroupId openapi_types.UUID) {
+	var request AddSubgroupRequestObject
+
+	request.GroupId = groupId
+	request.SubgroupId = subgroupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddSubgroup(ctx, request.(AddSubgroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddSubgroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddSubgroupResponseObject); ok {
+		if err := validResponse.VisitAddSubgroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveGroupUser operation middleware
+func (sh *strictHandler) RemoveGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	var request RemoveGroupUserRequestObject
+
+	request.GroupId = groupId
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveGroupUser(ctx, request.(RemoveGroupUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveGroupUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveGroupUserResponseObject); ok {
+		if err := validResponse.VisitRemoveGroupUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CheckGroupMembership operation middleware
+func (sh *strictHandler) CheckGroupMembership(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	var request CheckGroupMembershipRequestObject
+
+	request.GroupId = groupId
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckGroupMembership(ctx, request.(CheckGroupMembershipRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckGroupMembership")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckGroupMembershipResponseObject); ok {
+		if err := validResponse.VisitCheckGroupMembershipResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddGroupUser operation middleware
+func (sh *strictHandler) AddGroupUser(w http.ResponseWriter, r *http.Request, groupId openapi_types.UUID, id UserID) {
+	var request AddGroupUserRequestObject
+
+	request.GroupId = groupId
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddGroupUser(ctx, request.(AddGroupUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddGroupUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddGroupUserResponseObject); ok {
+		if err := validResponse.VisitAddGroupUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListInvitations operation middleware
+func (sh *strictHandler) ListInvitations(w http.ResponseWriter, r *http.Request, params ListInvitationsParams) {
+	var request ListInvitationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListInvitations(ctx, request.(ListInvitationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListInvitations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListInvitationsResponseObject); ok {
+		if err := validResponse.VisitListInvitationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateInvitation operation middleware
+func (sh *strictHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	var request CreateInvitationRequestObject
+
+	var body CreateInvitationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateInvitation(ctx, request.(CreateInvitationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateInvitation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateInvitationResponseObject); ok {
+		if err := validResponse.VisitCreateInvitationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeInvitation operation middleware
+func (sh *strictHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	var request RevokeInvitationRequestObject
+
+	request.InvitationId = invitationId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeInvitation(ctx, request.(RevokeInvitationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeInvitation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeInvitationResponseObject); ok {
+		if err := validResponse.VisitRevokeInvitationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInvitation operation middleware
+func (sh *strictHandler) GetInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	var request GetInvitationRequestObject
+
+	request.InvitationId = invitationId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInvitation(ctx, request.(GetInvitationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInvitation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInvitationResponseObject); ok {
+		if err := validResponse.VisitGetInvitationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResendInvitation operation middleware
+func (sh *strictHandler) ResendInvitation(w http.ResponseWriter, r *http.Request, invitationId openapi_types.UUID) {
+	var request ResendInvitationRequestObject
+
+	request.InvitationId = invitationId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResendInvitation(ctx, request.(ResendInvitationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResendInvitation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResendInvitationResponseObject); ok {
+		if err := validResponse.VisitResendInvitationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostJob operation middleware
+func (sh *strictHandler) PostJob(w http.ResponseWriter, r *http.Request) {
+	var request PostJobRequestObject
+
+	var body PostJobJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostJob(ctx, request.(PostJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostJobResponseObject); ok {
+		if err := validResponse.VisitPostJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelJob operation middleware
+func (sh *strictHandler) CancelJob(w http.ResponseWriter, r *http.Request, id uint) {
+	var request CancelJobRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelJob(ctx, request.(CancelJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelJobResponseObject); ok {
+		if err := validResponse.VisitCancelJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetJob operation middleware
+func (sh *strictHandler) GetJob(w http.ResponseWriter, r *http.Request, id uint) {
+	var request GetJobRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJob(ctx, request.(GetJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJobResponseObject); ok {
+		if err := validResponse.VisitGetJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetJobArtifact operation middleware
+func (sh *strictHandler) GetJobArtifact(w http.ResponseWriter, r *http.Request, id uint) {
+	var request GetJobArtifactRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJobArtifact(ctx, request.(GetJobArtifactRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJobArtifact")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJobArtifactResponseObject); ok {
+		if err := validResponse.VisitGetJobArtifactResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrganizations operation middleware
+func (sh *strictHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	var request ListOrganizationsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrganizations(ctx, request.(ListOrganizationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrganizations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrganizationsResponseObject); ok {
+		if err := validResponse.VisitListOrganizationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateOrganization operation middleware
+func (sh *strictHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var request CreateOrganizationRequestObject
+
+	var body CreateOrganizationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrganization(ctx, request.(CreateOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrganization")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateOrganizationResponseObject); ok {
+		if err := validResponse.VisitCreateOrganizationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOrganization operation middleware
+func (sh *strictHandler) GetOrganization(w http.ResponseWriter, r *http.Request, orgId openapi_types.UUID) {
+	var request GetOrganizationRequestObject
+
+	request.OrgId = orgId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrganization(ctx, request.(GetOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrganization")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOrganizationResponseObject); ok {
+		if err := validResponse.VisitGetOrganizationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListRoles operation middleware
+func (sh *strictHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	var request ListRolesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListRoles(ctx, request.(ListRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListRoles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListRolesResponseObject); ok {
+		if err := validResponse.VisitListRolesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	var request ListUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUser operation middleware
+func (sh *strictHandler) PostUser(w http.ResponseWriter, r *http.Request) {
+	var request PostUserRequestObject
+
+	var body PostUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUser(ctx, request.(PostUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostUserResponseObject); ok {
+		if err := validResponse.VisitPostUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserByEmail operation middleware
+func (sh *strictHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request, email string) {
+	var request GetUserByEmailRequestObject
+
+	request.Email = email
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserByEmail(ctx, request.(GetUserByEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserByEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserByEmailResponseObject); ok {
+		if err := validResponse.VisitGetUserByEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SearchUsers operation middleware
+func (sh *strictHandler) SearchUsers(w http.ResponseWriter, r *http.Request, params SearchUsersParams) {
+	var request SearchUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchUsers(ctx, request.(SearchUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchUsersResponseObject); ok {
+		if err := validResponse.VisitSearchUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request GetUserRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		if err := validResponse.VisitGetUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUser operation middleware
+func (sh *strictHandler) PutUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request PutUserRequestObject
+
+	request.Id = id
+
+	var body PutUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUser(ctx, request.(PutUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutUserResponseObject); ok {
+		if err := validResponse.VisitPutUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUserAPIKeys operation middleware
+func (sh *strictHandler) ListUserAPIKeys(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ListUserAPIKeysRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUserAPIKeys(ctx, request.(ListUserAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUserAPIKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUserAPIKeysResponseObject); ok {
+		if err := validResponse.VisitListUserAPIKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateUserAPIKey operation middleware
+func (sh *strictHandler) CreateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request CreateUserAPIKeyRequestObject
+
+	request.Id = id
+
+	var body CreateUserAPIKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateUserAPIKey(ctx, request.(CreateUserAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateUserAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateUserAPIKeyResponseObject); ok {
+		if err := validResponse.VisitCreateUserAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeUserAPIKey operation middleware
+func (sh *strictHandler) RevokeUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID) {
+	var request RevokeUserAPIKeyRequestObject
+
+	request.Id = id
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeUserAPIKey(ctx, request.(RevokeUserAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeUserAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeUserAPIKeyResponseObject); ok {
+		if err := validResponse.VisitRevokeUserAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateUserAPIKey operation middleware
+func (sh *strictHandler) RotateUserAPIKey(w http.ResponseWriter, r *http.Request, id UserID, keyId openapi_types.UUID) {
+	var request RotateUserAPIKeyRequestObject
+
+	request.Id = id
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateUserAPIKey(ctx, request.(RotateUserAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateUserAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateUserAPIKeyResponseObject); ok {
+		if err := validResponse.VisitRotateUserAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUserAvatar operation middleware
+func (sh *strictHandler) DeleteUserAvatar(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request DeleteUserAvatarRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUserAvatar(ctx, request.(DeleteUserAvatarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUserAvatar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteUserAvatarResponseObject); ok {
+		if err := validResponse.VisitDeleteUserAvatarResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUserAvatar operation middleware
+func (sh *strictHandler) PutUserAvatar(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request PutUserAvatarRequestObject
+
+	request.Id = id
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUserAvatar(ctx, request.(PutUserAvatarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUserAvatar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutUserAvatarResponseObject); ok {
+		if err := validResponse.VisitPutUserAvatarResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type HealthdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+// ExportUserData operation middleware
+func (sh *strictHandler) ExportUserData(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ExportUserDataRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportUserData(ctx, request.(ExportUserDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportUserData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportUserDataResponseObject); ok {
+		if err := validResponse.VisitExportUserDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response HealthdefaultJSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// DeactivateUser operation middleware
+func (sh *strictHandler) DeactivateUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request DeactivateUserRequestObject
 
-	return json.NewEncoder(w).Encode(response.Body)
+	request.Id = id
+
+	var body DeactivateUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeactivateUser(ctx, request.(DeactivateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeactivateUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeactivateUserResponseObject); ok {
+		if err := validResponse.VisitDeactivateUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostUserRequestObject struct {
-	Body *PostUserJSONRequestBody
+// SendEmailVerification operation middleware
+func (sh *strictHandler) SendEmailVerification(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request SendEmailVerificationRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SendEmailVerification(ctx, request.(SendEmailVerificationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SendEmailVerification")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SendEmailVerificationResponseObject); ok {
+		if err := validResponse.VisitSendEmailVerificationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostUserResponseObject interface {
-	VisitPostUserResponse(w http.ResponseWriter) error
+// EraseUser operation middleware
+func (sh *strictHandler) EraseUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request EraseUserRequestObject
+
+	request.Id = id
+
+	var body EraseUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EraseUser(ctx, request.(EraseUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EraseUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EraseUserResponseObject); ok {
+		if err := validResponse.VisitEraseUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostUser201JSONResponse User
+// ListUserGroups operation middleware
+func (sh *strictHandler) ListUserGroups(w http.ResponseWriter, r *http.Request, id UserID, params ListUserGroupsParams) {
+	var request ListUserGroupsRequestObject
 
-func (response PostUser201JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	request.Id = id
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUserGroups(ctx, request.(ListUserGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUserGroups")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUserGroupsResponseObject); ok {
+		if err := validResponse.VisitListUserGroupsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListLegalHolds operation middleware
+func (sh *strictHandler) ListLegalHolds(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ListLegalHoldsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListLegalHolds(ctx, request.(ListLegalHoldsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListLegalHolds")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListLegalHoldsResponseObject); ok {
+		if err := validResponse.VisitListLegalHoldsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PlaceLegalHold operation middleware
+func (sh *strictHandler) PlaceLegalHold(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request PlaceLegalHoldRequestObject
+
+	request.Id = id
+
+	var body PlaceLegalHoldJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PlaceLegalHold(ctx, request.(PlaceLegalHoldRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PlaceLegalHold")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PlaceLegalHoldResponseObject); ok {
+		if err := validResponse.VisitPlaceLegalHoldResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReleaseLegalHold operation middleware
+func (sh *strictHandler) ReleaseLegalHold(w http.ResponseWriter, r *http.Request, id UserID, holdId openapi_types.UUID) {
+	var request ReleaseLegalHoldRequestObject
+
+	request.Id = id
+	request.HoldId = holdId
+
+	var body ReleaseLegalHoldJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReleaseLegalHold(ctx, request.(ReleaseLegalHoldRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReleaseLegalHold")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReleaseLegalHoldResponseObject); ok {
+		if err := validResponse.VisitReleaseLegalHoldResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMfaStatus operation middleware
+func (sh *strictHandler) GetMfaStatus(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request GetMfaStatusRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMfaStatus(ctx, request.(GetMfaStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMfaStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMfaStatusResponseObject); ok {
+		if err := validResponse.VisitGetMfaStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResetMfa operation middleware
+func (sh *strictHandler) ResetMfa(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ResetMfaRequestObject
+
+	request.Id = id
+
+	var body ResetMfaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetMfa(ctx, request.(ResetMfaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetMfa")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetMfaResponseObject); ok {
+		if err := validResponse.VisitResetMfaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// EnrollTotp operation middleware
+func (sh *strictHandler) EnrollTotp(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request EnrollTotpRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EnrollTotp(ctx, request.(EnrollTotpRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EnrollTotp")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EnrollTotpResponseObject); ok {
+		if err := validResponse.VisitEnrollTotpResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostUser400JSONResponse Error
+// ConfirmTotp operation middleware
+func (sh *strictHandler) ConfirmTotp(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ConfirmTotpRequestObject
 
-func (response PostUser400JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ConfirmTotpJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type PostUser409JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmTotp(ctx, request.(ConfirmTotpRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmTotp")
+	}
 
-func (response PostUser409JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ConfirmTotpResponseObject); ok {
+		if err := validResponse.VisitConfirmTotpResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostUser500JSONResponse Error
+// SetUserPassword operation middleware
+func (sh *strictHandler) SetUserPassword(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request SetUserPasswordRequestObject
 
-func (response PostUser500JSONResponse) VisitPostUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SetUserPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetUserRequestObject struct {
-	Id uint `json:"id"`
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetUserPassword(ctx, request.(SetUserPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetUserPassword")
+	}
 
-type GetUserResponseObject interface {
-	VisitGetUserResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetUserPasswordResponseObject); ok {
+		if err := validResponse.VisitSetUserPasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetUser200JSONResponse User
+// ReactivateUser operation middleware
+func (sh *strictHandler) ReactivateUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ReactivateUserRequestObject
 
-func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ReactivateUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetUser404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReactivateUser(ctx, request.(ReactivateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReactivateUser")
+	}
 
-func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReactivateUserResponseObject); ok {
+		if err := validResponse.VisitReactivateUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetUser500JSONResponse Error
+// GetUserRoles operation middleware
+func (sh *strictHandler) GetUserRoles(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request GetUserRolesRequestObject
 
-func (response GetUser500JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserRoles(ctx, request.(GetUserRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserRoles")
+	}
 
-type PutUserRequestObject struct {
-	Id   uint `json:"id"`
-	Body *PutUserJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PutUserResponseObject interface {
-	VisitPutUserResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserRolesResponseObject); ok {
+		if err := validResponse.VisitGetUserRolesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PutUser200JSONResponse User
-
-func (response PutUser200JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// RevokeUserRole operation middleware
+func (sh *strictHandler) RevokeUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName) {
+	var request RevokeUserRoleRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
+	request.Role = role
 
-type PutUser400JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeUserRole(ctx, request.(RevokeUserRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeUserRole")
+	}
 
-func (response PutUser400JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeUserRoleResponseObject); ok {
+		if err := validResponse.VisitRevokeUserRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PutUser404JSONResponse Error
-
-func (response PutUser404JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// AssignUserRole operation middleware
+func (sh *strictHandler) AssignUserRole(w http.ResponseWriter, r *http.Request, id UserID, role RoleName) {
+	var request AssignUserRoleRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
+	request.Role = role
 
-type PutUser500JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AssignUserRole(ctx, request.(AssignUserRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AssignUserRole")
+	}
 
-func (response PutUser500JSONResponse) VisitPutUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AssignUserRoleResponseObject); ok {
+		if err := validResponse.VisitAssignUserRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Service Health
-	// (GET /health)
-	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
-	// Create new user
-	// (POST /users)
-	PostUser(ctx context.Context, request PostUserRequestObject) (PostUserResponseObject, error)
-	// Get user by ID
-	// (GET /users/{id})
-	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
-	// Update user
-	// (PUT /users/{id})
-	PutUser(ctx context.Context, request PutUserRequestObject) (PutUserResponseObject, error)
-}
+// ListUserStatusEvents operation middleware
+func (sh *strictHandler) ListUserStatusEvents(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request ListUserStatusEventsRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	request.Id = id
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUserStatusEvents(ctx, request.(ListUserStatusEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUserStatusEvents")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUserStatusEventsResponseObject); ok {
+		if err := validResponse.VisitListUserStatusEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// SuspendUser operation middleware
+func (sh *strictHandler) SuspendUser(w http.ResponseWriter, r *http.Request, id UserID) {
+	var request SuspendUserRequestObject
 
-// Health operation middleware
-func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
-	var request HealthRequestObject
+	request.Id = id
+
+	var body SuspendUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+		return sh.ssi.SuspendUser(ctx, request.(SuspendUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "SuspendUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(SuspendUserResponseObject); ok {
+		if err := validResponse.VisitSuspendUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -564,11 +11264,11 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// PostUser operation middleware
-func (sh *strictHandler) PostUser(w http.ResponseWriter, r *http.Request) {
-	var request PostUserRequestObject
+// BatchUsers operation middleware
+func (sh *strictHandler) BatchUsers(w http.ResponseWriter, r *http.Request) {
+	var request BatchUsersRequestObject
 
-	var body PostUserJSONRequestBody
+	var body BatchUsersJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -576,18 +11276,18 @@ func (sh *strictHandler) PostUser(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostUser(ctx, request.(PostUserRequestObject))
+		return sh.ssi.BatchUsers(ctx, request.(BatchUsersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostUser")
+		handler = middleware(handler, "BatchUsers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostUserResponseObject); ok {
-		if err := validResponse.VisitPostUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchUsersResponseObject); ok {
+		if err := validResponse.VisitBatchUsersResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -595,25 +11295,25 @@ func (sh *strictHandler) PostUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetUser operation middleware
-func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, id uint) {
-	var request GetUserRequestObject
+// BatchGetUsers operation middleware
+func (sh *strictHandler) BatchGetUsers(w http.ResponseWriter, r *http.Request, params BatchGetUsersParams) {
+	var request BatchGetUsersRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+		return sh.ssi.BatchGetUsers(ctx, request.(BatchGetUsersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetUser")
+		handler = middleware(handler, "BatchGetUsers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetUserResponseObject); ok {
-		if err := validResponse.VisitGetUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchGetUsersResponseObject); ok {
+		if err := validResponse.VisitBatchGetUsersResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -621,13 +11321,11 @@ func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, id uint
 	}
 }
 
-// PutUser operation middleware
-func (sh *strictHandler) PutUser(w http.ResponseWriter, r *http.Request, id uint) {
-	var request PutUserRequestObject
-
-	request.Id = id
+// BatchGetUsersPost operation middleware
+func (sh *strictHandler) BatchGetUsersPost(w http.ResponseWriter, r *http.Request) {
+	var request BatchGetUsersPostRequestObject
 
-	var body PutUserJSONRequestBody
+	var body BatchGetUsersPostJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -635,18 +11333,18 @@ func (sh *strictHandler) PutUser(w http.ResponseWriter, r *http.Request, id uint
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PutUser(ctx, request.(PutUserRequestObject))
+		return sh.ssi.BatchGetUsersPost(ctx, request.(BatchGetUsersPostRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PutUser")
+		handler = middleware(handler, "BatchGetUsersPost")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PutUserResponseObject); ok {
-		if err := validResponse.VisitPutUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchGetUsersPostResponseObject); ok {
+		if err := validResponse.VisitBatchGetUsersPostResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -657,23 +11355,270 @@ func (sh *strictHandler) PutUser(w http.ResponseWriter, r *http.Request, id uint
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RYUW/bNhD+K8RtQF6UWE6zh+lp3Tpk3h4WtOvLiqBgpbPNViIZHunVMPTfhyNlW7bk",
-	"ZcWSJsCAPCjU8e7j3XcfT95AaRprNGpPUGyAyiU2Mj7+7Jxx/ICfZWNrjI9pDd4SOqGNF3MTdAVtBtYZ",
-	"i84rpJ7dBiqk0inrldFQJJeiQSK5QMjAry1CAeSd0gto292K+fARS89+f0FZ++XQVVoXDskaTeysB5O8",
-	"9IHYz6chtu1L9jiXofbJLrs3wL9Ay3k5SlnpUHqs3kuOc5lfXp3nL84v8z+meZHz358MvZGqhgICofuh",
-	"23tRmgYymCtH/r2WDQf61Sw1ZKAqKKYZ1HL/5pVhhMFW98QaZKMP7zjHscrRQBktvGqQvGwsozKuiUE4",
-	"3jm/GeZnd6wxt2ck4lshq8ohUd9n2jbir5+LE06jiYgmIw44cYONWt0FFJx6oSrUXs0Vuj6eoLTfe1Pa",
-	"4wIdu+sV4AQctjiJpl+t0dTH3cnqi7PfZuDwLiiHFRTv+OTZLrG9PPYPkfXJcADv9gTVX+NdQPLHIvFl",
-	"bD6m8VBMnhuN/lPdjwpzT02GmWcHSs/NMPbLm5mYG5eo3EgtF9hgoq7yXJqIjsTLmxlksEJHad/0Ir/I",
-	"+VzGopZWQQEv4lIGVvplrMFkudPhBY7w9Q26lSpRdHIdfbmoG7NqJ6bAR09yGp1e5nmUIKM94yw2IK2t",
-	"VRn3TT4Se95eSfz0rcM5FPDNZH9nTboLa9JFiOk5hPYafXBanP3+25lQc0HoVtzqJIIVUgsXtO7q+t0D",
-	"4km35wicmfbotKzFmwSkM8z2t9FjI7hGjU7W28htBhSaRrr1WB29XBDztFu4ZfMJMyxW0BoaIcNPUUZI",
-	"SKHxr05ZtfBLFLQmj82AHjeGfLw6U28g+R9NtX6wTPSlqj1sQO8CtgNaTh809FgNor5TKEskmoe6XotO",
-	"e5kJV1+HhytZq0oobYMXlfQyhf7+8UPHw8vaoazWAj8r8vTU7XfQBIm/O/L2uiAKaL8JJhtVtSdVMUkP",
-	"bVsgXU08S31Yi9mrQRtc474LHkkm/5GPu2H+Kr/6Siw4+IZ4NgS4Rp9qtq3Tcf35ZnSyQR918N3o9BY3",
-	"Kv6Xb1HIoJtx4ih2qEBZ71D3jZ3tbQY2jJLN1rLEAdmGahueldjmTyC23Wz75GL7/26zt+nT5pTGsm3c",
-	"nFosOP6omEirJqspcBt0O8a6j856E7BAXVmjtKd9F6YobTb4iWJrGkfpNPWel0ssPwmpK9H9eLBzsx07",
-	"b9u/AwAA///X12lCShEAAA==",
+	"H4sIAAAAAAAC/+y9CXPbRrYo/Fe6+G7VzLwLbV6SiV2p9xRbSeTxomvJybyJ/KmaQJNEBHYz3Q3JjMv/",
+	"/atzTjfQABskqM2MraqpiUUAvZ59/ThI1XSmpJDWDJ58HGhhZkoagX/8qPQwzzIh4Y9USSukhX/y2azI",
+	"U25zJXd+Nwofm3Qiphz+9V9ajAZPBv9rpx55h56anQOtlR58+vQpGWTCpDqfwSCDJ4OTiWCpFpmQNueF",
+	"YUozOxFsJvQ0NyZX0jA1wp9SXhRCs0wxqSzjRaEumZ3khqmZ0Limwadk8E7y0k6Uzv8U2e2v/hWsUY5h",
+	"1bm84EWehZsZJIOJ4JnQeKi//vrr1n5pJ/Aw5VY0p7fzmRg8GRirczmGqWAyNz883z86/JeYw79mGjZs",
+	"c7qqVAtuRXbGcYsjpafwr0HGrdiy+VQMkvbQyUB8mOVamLW+ybPGu2WZZ7HXCm7sWWmqBTWP6yU3lpVG",
+	"+Cs9F/OElTOYOGPcsqkylimZCsbZNJelhaX0W5/kUxE5x2Qw02KUf1hcyy+5yYeFYMZybYMFsRGAoCgK",
+	"uNdzMTeMz7i2Azg2Pp0VMPq4PHs4+o7vpQ+G32aPousxqZrRDeVWTE10ae4HrjWfw9+lEfoszxbX+uZS",
+	"Cg1r5IAYRkleMJ6mwhhm1bmQTxkfGiEtrt0IfZGnuBczSFZd2adkoMUfZa4BXX4b4Ct4ltXJVXtJQmB7",
+	"X42khr+L1ML6CUZf5sYuwimf5We4ovBEluEcDbZ4TK0FV+N2L+it+KMUsTU18aB55L9OhKxAwlg1M+xS",
+	"6fNcjp8SVFzmdqJKy3AQeIXPGVGAUtq8YFpcqHORrQ3AzWW85lPB6KchAOTlhNtqWbkBZMrg1gfJYMo/",
+	"vBRybCeDJ3u7u8lgmsvq76UA2pzxKKC8fqIpn8NMCRPb4234lzZPtOBZiBS/DYLf3ye9wb51nw723Oq6",
+	"L/VYpFoQWS+KN6PBk996glMbCEw1UIQxKdibdYQKMSwnqPj31v7R4da/xJwRhd9mh5alXAJrGgqmhdW5",
+	"uACqNua53F6Jd24Vi/t9Dzu2VufD0orjil209lD9zrMshw3w4ih4w+pSRE7SUd41+EB71TRvY6TolTU3",
+	"0ImQ/fbRvKcXx29eMxrXX06W80KkSNTfzITcPzpkD7d3GY3OaFkmCdGKS/dzCNFdqxjxwoiktfRMAJeY",
+	"OlFjkeVOZ4WaC+HIu3ueSyvGQi+cazBY7DhbP8QvZek9mPXO+FlprJoyXn1OvAgQPiGix62HdUP0qXq3",
+	"OnbisEqPucz/RClsm72Z5tbCBdiJmDIlGZenkoCJnQsxIxKUlloD7ikpzPapbN5RePADwwtkVI3jfvTg",
+	"U+QsfuA2nfwkbACMwah5ZuAsd/e+e/Atfyi2/jl6kG59O3yYbX0nHo22HvNvht+m/8y+E7ujQdLjtb3d",
+	"wftPbZjBSdp0550Rmh0+B87OCqXOWTkbJP1YJnx6+Bw2N+UfDumLvV3HC/zfK+gvrOn90uMiPWEReack",
+	"Cy/uyJ2wyGhbwMGcEC8+5MYmjBs2zi+E9Pir6YP1tx0TqCJH/KMqZUZsDKZ00zGlM6HXmXQlM6P5k+po",
+	"Og/2TaXCLBxrnvVZirt2YScqIj/+K5cZIGCtKSUDIcsprJFkuoqGDwD3C2FFsNiajMGG+izHI1X7QNwC",
+	"O88hjoygw+WyFGdKnglUxTwNrjZkUADw+w825RYspjwvBk/w7//rht5O1XSQDEa5NvaMxK/BCzWRA6fG",
+	"uJ+eKwH7oJvoh+r1TdSn2l7J71yKpSvhUlx3JXvhSty1flqkQ5HzBQga8bKw1VG3cFoVBRvy9JwpWczZ",
+	"iOeFyGr4ArwyVvDMk/3LiSoEG8Id11LFUKlCcFTbw4tc0H7qUcUHkZZASRylILKxFuK28O1axDJY9RKQ",
+	"7iKYqZoC+xNZVPuwE0GmEKu5NDyFJ+ySG1Z/FjtILUxZ2NgpSsHoIZsJXV9VcmUS6LcHULKKEoaL9ktc",
+	"dmQIee0DC2Az3BlaZ9hUGMPHAlXgBXCMWjRkJiJ2gSNlUCqqBBY/yCJ3aktxycBYbsvI4f98cnLE6CFL",
+	"VdZQ6XCS0qZqKhbmjE7Slwgv8nbccbXK2PkfAHX6Reh81K02o8khojDBz34HFzCEM7YxGHOlLkGjRtek",
+	"uSm16FyPFtyoyILe4u9+RYJGcTosXeRIaAHWJveKu9mnbFoaiwIKUEaey9rqknHLG6Ygt7gKg/7Xo2/3",
+	"9pra+OPd3WpXweYj+3TgHbA+B/IkFcKKRiC7DD4tqh+W50UE8g5lll/kWckLNtNqWIipCY7gIlcFiu4z",
+	"bsyl0hmbqSJP50yXJEj3N171Qc5Bv3P4MFPaiuyOTZ6yLAo+LIRXgW7KBHq1yXrYMxceOZPTteZd3255",
+	"kxZEf/XPAiP6IoX2sDoVlgNCPkVYnnAzYblxigWNM2jjCXGGM26tmM6sieni7gYLNc7ltY6yUCncBpoD",
+	"rz6Kx8yzdMLl+DrWmthIycKJLLuWmjs40n5DyEki8ceovnEdcG4dgHCMqCcQ/qRVOXslpkOhzSSfLe6V",
+	"Z9maO+1JQjqQfwmmVUtZtqFDeZHbjnsDd8LMXpN43Ozl9zysHHYlsrPhvGlci75xnsvslmhn7HY8xLVX",
+	"0F51b5h8oYaLBHGfDcvinP2uhqBnGcFmfF4oUL4049rmI55aNlFFRuY0MtuZMp2A5YVLlk9hcFbkJrDF",
+	"aXhCdHSBil7lnke5zM3kmgDWBolcxuVwLVKlMxO36gtpdS4qv3LssPgQXDv+sEI5aC0De1tGor974TW+",
+	"0gCLelfLAOTViC/u+tWP+6h9COKUJ29AG0GPA+MyYzDuhdBzVE0M41osZ6JXQnMJF3uD+NUTYV6N+MGF",
+	"s8q3KZ5Vuotk0MNOanGlE7jocg7UCsya/AxHbCw2qbfVm6Ycocj/XKS5yWN61L5XCjL3Chrqa5vBqEIU",
+	"wCElQ7xpHTkEbYjwSAPLxVXOVIV204ionMs0n/Gi65brF5bwBX83kUdGlToV619cqN+31tBadVKdWbWU",
+	"3vd6LEzXhWox0sJMKHqA5caUFICBQi8RCXrUS6i+qxiUEZ/mhefyK+WCm9CGblb+rNffIuzBYcQuFAXR",
+	"mxG1G4Dw8aYF1BtwKYdCbbjY1omtcDjjicUDUcbwqH8YCp39KqXXDdq5FKdFLFtNE0WPyyE9IpOsyNhw",
+	"ztyxXGfdnT4xWqFzigVzHj6/FY9YzwOLql1ZruHVqGyHXCg3jDN6i01pX45J4bRRuzm9t2rQyGiJm6mY",
+	"U+yiVuV4wiQ5PI2/xsicC64xXEDit9d5NJ120BZutz0KLnAI1wO7mQpOEWOrrZVLo5Oah1HK/I9SYHRU",
+	"Lhc8/kyLMddZIQyK3yk3omFNPSq4BaLBTgSfrhvTFIskip3iz4IXdrK4G/qd+UjYZpiBt+wP1Pmi9TU0",
+	"+zuvGbyXrJyghzX0ukr7zSrp1esibtR3Ym6EwKAUXildebWrhKkiE8Yy9H4+JX+iFrbUkkLbGGfgPC/C",
+	"j/rSpPrwcPqo3fr2gmEXLBMt0/zzSgX1Ih/o78xdUOucVkzgBdi447/vJEklxrso0isKVgvfGCHt8jjL",
+	"ehHo5QQbLDqO1gihrNGwH0wc0/vLIm9P/MoEBYxUkbYuShnOwnm5yL9OJzoTMgNTitOCrhCD6/HL7WlN",
+	"E1J94CuFy/o49pGadLKXMDohFrT0N0Mo7MWUFfGnBV81GoJAv8G8aXuJsyBQU8Mbqb6MjNrH0RmA7Rpu",
+	"zkasR3gWwVaWX9amGTWa4UTOzS+kHVT0Am0TxK8G71cd0zVNG/U5xZWA+tb6awL1mKt9YMHwy5fXiW0V",
+	"x21xUfiZ8SzTToSqYFA0ciVEL2Ckt5av8LgjpAF+R+EPDMjV208r0lf/ZsC7jMJnrn24PNeC/olw4YHH",
+	"fRtCSgg+/oNYZJozjIdRY1ymojjTPvqwimQK4XzwYPfBo63dh1sPdk/2dp/swv/+M0gGE27OvDW4+hDQ",
+	"ag9lvzEcP56JksLLzMryYvDkwe7uLvEivWSSvf/UtP3JQJdS0r7dvlBN2nbW98V4rYWddcUP0ZsF9wEt",
+	"nLmpyFnADasHSVba5FqB12pIUgSMDfTBWD6d9WbWHfECP/K8oHgKDN4Ionl+V0PTw7HQotV+XYgosGn/",
+	"eu+FNkGh66S5D67yr8LZQypApi4l+Bc6TjgmdLwjHQpWm6MbfJS3xIguv0cImsto2Qs1PPKvVkFja8bd",
+	"qyGDBTAzEyk4hP0BmHI65Xo+iFCVEClW3xSA5ywHHzorZ2CL4JiCI3Tvq+sKx3rmAsphFnhHBDToj1KU",
+	"RHcqpDRlmgpBNzjyIqlDrQ5y5D09cXEc3XWRIK8eDqFKHqyuOlmkBy2gXckuQ3BYNCsoGdnK69JbQmZa",
+	"pcIYkTFiozG4dLRxUZKyvGCyGgoHSNguy0eslOdSXUo2FzFQb50QrtFP07HDeGyx8/7BP51l7LfrRwu/",
+	"/9Si5ORhXaTkwezXwbwZ13wqrNBhbFeRk3MGV4CEtLGWTndlP6BlVgEvcdlg21pgcCG6EqczTBGhnF2p",
+	"ZJ7ySmQRxq2Ha4HpDR65XV6HQ8IG/0sGrYU3plwtS+LTGFC8FGNe/KyKLGJb50acVQGC1zGVzwqerilU",
+	"u0/WNBvQV/gAfP3LB17XXNAavKedYInrrBB0wj09n9Un2XIrAizOyTX0+lMGI7PLSV4Ills046Y2v+jM",
+	"LO6/kDXvx38X3tBak617Z1eaMLCEXMFq4b8OnZVNTFoAwha4hwizFGfjih1ss79KVw22UqOjcZcu6C0d",
+	"99phyr9O5jXo5jXkJoziPbwVtAT+IMdNq3jf2OJglR3LW6R47WjqVrR0kdt87DQLLH8gjP+hYdN/tn98",
+	"sEUa0N7et83lP+iRqLzq3Lx3ZqTVn0L2P7V1fAld0BwFCPChx4WNJXJFbcEapEqj62qitMGcGSsowRxG",
+	"WRAh1jEUUORXDmmWc2ZmrsgBCl4m4NWA+IMVVrYeBrSO6MulBi53eHXKTL/sbjTJVZ8tJnlPR/xMSK2K",
+	"Ygp20XpZkdAIVYjwvJh72bBXP+4nwEjSSf1wwl1sBIU3gbS6zd5hAQA7EXNGkybtmianUo0oAg+mI2kI",
+	"XGYTUWQkCS1qildO+8AP45nlr0b82YQXhZBj0V0fIY9g37FIlcyMK3aAsp4fyBl24poA3ESHXfXNjIPa",
+	"i0+94jv3GTIYmcL4JUd0RoA1uAQ2QrPgSvCrJ07CjcWA8NWIvxVG2CuRcoi1QypuhF1CjNYnPh0rre1z",
+	"rcsjiIzHWvWIxovQYYoSPMMowTMN+Cxdwm8sAvOKKLbaU+53Fvy6ZHUdx7YixwlG6bYbwFO/AV6X9IEr",
+	"ns0azO/BP7/d/eeD2HE2EGH5YccsRFj4oxG4mYQ5lxx/aizl2/M/HmxNv/uQbT2azOwa+BI7wTeBU/9m",
+	"IpOuG3lkinLcM7YWX62ijFbYRsKdxmXOMMChv+zZOMFV4mdzilXL7IRqf3g1UOynU8GeKT1TlS1qTfHM",
+	"n3t3ZEi4+Lp+SrhgV0QFg5G5gaiZTAH+NuCXp84/Zq3QMMf/9xvf+nN367v3f3f/2Hr/cTf5Zu+T//0f",
+	"/+e/VoJ5CAuxc/X+w25KQTThrNsF6amGfyPx9C5jl6C3kkGEknKclwQMX4Fg1ctb2b2A1+JyzdE6ModW",
+	"nJAR9pmSo1xPO0/rJhfZz0HrvyeOvJ6Ttv++b8SP1/ZNX9eJ99axh2fAEmNSTMgyoxnjSK1dWgBIMIUa",
+	"j8m5V9W/6uKBV0wfbC0qvi0MXnYyf6eIhi91stiFacPXo7OqQlw15g4FnlbIXVQg6JaaTuJFEXFkrgWF",
+	"TY01lxiCohxRgUtCibSWmRaFQc8VlnEr2D3QdHg/WMby8mF+OcN5tdb+JcScmffJpc6pGMn1Coo1Y4nD",
+	"LbROvuvu47wf9tSf58M4qzEAh+xaxuulgZgcD7nBNk05c2bzgLF/82iBkb53XPNs6/3//q8YfB4LrtPJ",
+	"z/l4UuTjiTVr0joExSmUdwC+p3Rm2MSPJbL+FfF+BHMuPFp7xJhdjDbVXaOjs5zGK5jXh3iZhEpXalGI",
+	"C0TxXPevX1StoU8hjWXFMxoDNS1Pk8a1uYsanJa7uw/TKdfn+C/xu5pI+m2n/rFlqKJbWfj0RexTht4w",
+	"TP/WYvBkb/vRg9qO0ScMoyqWoyayhyPOBWi0/XHNtIT4XJ8WDGvNI1t9gQFm1FtetKIihKSC4QsJQizZ",
+	"L4fC2pZvW5XDIlCWyFF6fVuQX10S7jEGUE2zWjT82Jguc85xPpYiYy9+PWnUKk1cbRDgR4TCrsqkYbNy",
+	"WGBsBuOW7WxfiqLYQifwzu+X52b7dxOPgl3PTBWuZamlakGCiFqrKDR5qzSimVQVt6AEI54tqz56QFFS",
+	"nsm3Bu6nUNMs3qfrPas/CK4b5sAOgahxt43BGgfePqYlm4wDmJ09U1kEtq5ngFm+Nxy7azkHlZl4cVHK",
+	"zmC2s1Lni2tzD5/s7LB3bw9BBhsKZiagynHD/uftokWm/sIqO9sZqy0SedrU7v/wYqx0bifT749/3t8D",
+	"2vrgmywf59Z8/w39hSl8+ns/BP04EzpX2fcPd+lPyvj9/qeD/zz/6fUPv/z0/x6e/M+bF//T/jsevh0v",
+	"kvoDN+LhAyYk7C3zOcWgKUy5hJozQlo9XxwxXv00aRxw7ILeOboXhvmtwUZ6xnP0LK+GpR8XeU0V1Vf5",
+	"mXtwn4VM2UalzqWFbes3PyUDfsEt12eljshh796+rHAGX2P5lI/FU2aExfAq+rGcQUgKVWJXVATACg3F",
+	"j/D19etAROpbXiNuMMvNrODzsyUZSYR0rQp0eNFoYQrjyldupUOmdSHqIlTje6jubrwzYoGrghl83dPG",
+	"LK4GHJpZqvCGfORiG8AX5Qe/coSD0KsDLRoVsRrGfFgfjXDlBaybatBhTI5GVaKQkdJS6+hK9vd37w6f",
+	"X3z7j9XZGuumLsTqAvEi8u0R+pW1yBi9AWzjh2dH7NG3rOByXPKxYJaPGyxEyK13x32O1IVWn3XA874D",
+	"LqzOqgXGYCwU36WkFwK0CgJjUL96NZNoUOER/OzjAXPJDrb3vnnE3PDhtv9779He48ePHz/45tu9PvP1",
+	"ywyCy6tzguibs37V5fDCTWlmQhrMo8oEkv8qPkIHf/daMY6VhfWjIpjIWfVeFQdRzQRydGnVlINsVBTz",
+	"K6MjvHf2Z/TGDvdf7yMRZ/g8vKP9qdB5yndeKnO2L8eCCsqtnKyZQR7hHXjU9Nba7KNXplVnak7AlNdL",
+	"R4d1P+eWU2WGG2iO0CqRF0lrTJsV1PoMFhZda3GqdE3nVnfBsMhSXWXGPtjpqi06rU/pdZ2Mayb/d1UC",
+	"i2ziKhlFkbpckZEx72HdISEdJjLWdMT7jgCFhOiLszqld601VKV3IguhijJnvqLM+oO3itZEplg0za6s",
+	"YWmEudpqfKmV2JjERtY8w5oVdR7hlW1AIeYklUVIVOZwOrgKWZKaPMWJQhP42ztugFDk4h2Ad5HNg5o4",
+	"tKUlpUae/ToSUrclWCw9FCRG9ovXJRk2dMytSKjsG5fbGrhXLDUfjURqxZJMgVgQTlfKBkT6zafQIgum",
+	"9YnLWl0arA1t+bBolT9oWHXM4MnDqpDIXsys3tAg+oYX9Q4Fv3KMsv+wO6+0OudwD12wefg8ApbL1Ytt",
+	"9itGohdizNM5NkTAVEgXPvj3N0cHr/ePDs9eHvy0/+z/nR0+P/4Haa9KT2FEultWweyprIqTuIxJ0BrA",
+	"ctpqlNHXnhE4iR5+Ezl72HfcFSbFB3vGRzZWRQV85bAwfAxmhZGwLowSPmMztENUqfW1SD0ji8NKlayq",
+	"anPTpWq67j5wPLfswr6lCWczrUZw2aNcFBn7Oyo9iVPuElZJ1QkLbRoJq204/6CWKKzRESW3BnqulPCm",
+	"PJViOrNzRifB0kJwDa9ss0PXj66xCII2LX5HICfz+6PdXTKL5CaorO8KStcEViu90HvlmnlStSpc6bFO",
+	"MRz89x57tPeYPX78mDkdL1BCoqrFnZjQ9odGFaUVbGLtDGgn/NewDstaO5jq0T9v3Z7VLphzHXsWIKyQ",
+	"VmiRbbP9Kn8LIGhFlR/6LdetmPJT+XeoZae3UspveCdzNOS//vFZwg6fv97f8oZkCv/6R8KMYqcIPv/3",
+	"oAav0wHu+3TBLXg6YENRKAgZVkQdDZwfgCfB7mr73LVNT5/XOPSUGas0oXHz8F3kxelAyLPSwBEa/GPr",
+	"3fHpYHBFGw0SkFSVYOVH/0Y4f2i9qWb/70ffsYe7bO/Bw0ePv/nWL+O/H333cLf6bXAdG0Q100EJ5GDn",
+	"B6GLXMYG7chP6FD/uxhBV32Ew6BqDCFNVRSmTg8gpu2QpZait9kbCOchB4IbAdLJCzWm7A0clj47lSST",
+	"bbP99utDEZiHUNCrLENPgycwXPCoOUBgTWqmaIa1GpyfoxpykAyCAaMJ0m0FZ2klk551k6isOT6pjDk3",
+	"JLMvjp34Osqu3SbI73NjxfSmam6NtJqeXcVwucJiST1zRq7RLm0s7j++0uwd5kro0JeVBQath5bRUWXD",
+	"NGQZvYIZLzypcN1XLh/TAs64vHvTGn2sAo5ZsbwrJxvSCaEhnyD7RrMNcYFEDK68Qq/XVETlhjL7OiH0",
+	"bUWx6vkb9nOG0Zy5YQ4orwCpnak85GMvdW7n2Muysgv/S8yhaXREFD06xN6shEGV5SCHZxROXwdrVd1K",
+	"6wXS0HAcQ8G10H4S+utHv7UXv54slDHcDyNoXO3g4ZztgOd+h/K0lHZ/OrvBwPWyhplphnolIEJTb+1c",
+	"jlR8o756AYQV8LGYCglpdhR5R2aqpFFwCFgamJUCcZDLVu/Jk7bUCid5Kn1Z65zcX+j2ogTJRuSQ0l2t",
+	"mJWdCH2ZG+8pgzuoYmCbeQ+nkm4qoUqrMCjkIyTwD/dBlQuBW7nE39D3Ni6xQis3wknL28zhWt2buLXn",
+	"5FTmMi1KlELwouikKJtXnhtqcTucY5Q8sDbBpAsldlmJuUV1DY+e7R8dDpLBhdBUZnqwt727vevKcks+",
+	"ywdPBg/xJww2nSBI7/BZvuV9HeNYTMlbrP1o6rAwNfLw7fyTE35BFeqHQkjfaHmbUUtgV79eXAhdlZHc",
+	"HgSlwg8z6ESeG0vOE0Olyurm9w92d2+scXzQETvSPd6hMLLNR7t7XYNVq9tpNLfHjx6u/qhu5v8pGTy+",
+	"wc11dsU/lC5g5FhouAb/YjLwtYHoApg/gPCKB8nA8jF2X4XHeEPYslDFrC3PkJEbxpEWUjMDAh+XIaqF",
+	"JVSnfyNHQXUaA+ZRUaVsTTwtV34UvvWVz0mf2T6VJwSPYbwZTuwFQATNvI56Fx84VgWu+xRSFyTCpCY4",
+	"0jYIWlxeozD2B5XNbxgU68acDc5kdSk+LeDB3g1PTgi6BBN8VVKC7TuBVDKS5XJWWmru9hXiIkEfcItA",
+	"qFiOj5+SmpLvfDwX88PsE2FnIWw07hmItMPTp0yHnAr4ZMMsCfI8k+qSqUVMoYECTGlA7KNuOcmxiTu8",
+	"30e7j27/fv32Gr0BNway6LbWgqxkUFezwroP8f1SXXqJCYJ2Uou6CIqDNm1Lgs2uchm9j8H2jlaWW1cx",
+	"7PMur4sVvg2jthy3Q+XgXMwT9B34wgnkZiBuhDyTinISn5xpcZGr0jNP4H8zg4X3cjk+lfl0KrKcW1G4",
+	"96W47MdbY3zvLZ5qFzbv3jn/oVu+JxJ3SyTw0NdnP95rs1WvsZv9TNWFQ43qO0bfPSWv5CXXmQlsnwjD",
+	"Qy+6kZetEQDWhOTnOHPlSTqmJfViUK31MI2L/eKA8LVaOHoKVxznBp1MG8a54BKiABOA5DvyEX9KVquy",
+	"L47fvGYEFu3uz2lprJrW8zQSwk2sjMIC+P0k7ErYu0Fy2poqRnLah3YPzZ8Rmn8Stj8oz8ooKNPGDAUz",
+	"B6JGCNjWZ7xHQbp2haHWRRHKY55LY6kSBvnEtDiVvj0FeSlyHY5EFuttN6fxrjCW5bwQKQo9b2ZCArt4",
+	"uL3rL4csrSTvYH9vh4QCoyjo6TY7+OCaYAbz+X6IrUXH5JmjMoqGt6DNN2dZS63/rHSghSF3quXfOSXa",
+	"IG5Gh96XCKB8BfZ7dIrvYLwnAm+HFY6SQYgeiHZpE87CeFG0LW+z/cod7t/syjrZZpiGbIgwuFZAgPOV",
+	"5iJcXNnBq/3Dl2e/HLw9/PHw2f7J4ZvXZycnL12duKjdjaqAHTjP/20gah0BP/9MSOpigBfgplmCpkoa",
+	"u2OkTNwVUv/dQguezRkWN6OEYlzOd3egIkn02zi3n1vHhBvSYRswvQmY7XyFgye/vW9Y8ggRMWuzlZpY",
+	"aVHg6AswPHCb7VA4yjI8h0grX9w7bHK00AikSu3PNVXrAGytK2BRGU3kw4bb3IzmjWJNp5LixMnEgBMM",
+	"Raqmwldv9n6rXLfoDQYjYeiah+gY3lOro8OwudZtIH9Xb6UNoQD18qro4Q1Df/SEeqDwbYgvclV4K82G",
+	"4iFddxMjluAg+mK70Y4SqDzWRRpaVchWWSsaYZwQ1DRDDspcmkfi3GQUp2yoUge5sV08GX7Fm6Unttk+",
+	"8lktZiSdj6hZi/GFNQD3QAsqFHbtoOZ9qVIFNEEh5YBqmjjDv4BKX6dyVGqkvW4459mvi1dpkQrE+aDG",
+	"rIuG9ZQEM1KJOFH1EDzX6Yg72SVGBLDW8C1hfqMI9B2je7OGcgSeX6rxGEM0AYMe7D64sZkb5YQjE1fN",
+	"2Dy5SZpFfKvCiJvhALybqQlZAzoX6Bq3LPPUGAv6LXFWnP3Bd3c3uyMWfJG0iMxFqQwSF9KEuPJWWD3f",
+	"2o9nmkQL/vhdlpImG4QOl4XWMp82l6+8VFXtRU/kA7FqCYepKWEPNsPZyZuTI1dkh1pbBXWGw9BRR4yr",
+	"dq0uaMkV6674jp0Iz3m22f6prL90UcY17aa+msB7oO89rKXIpxhIXbcJ4taK6cwu0eggefV2CPtC6eiN",
+	"Ju5fCw1VupIja9hSGuF1o9U1AnuAcjX2iM0XK9t3YLVH/C0tjFiit73CVC6OQX7YuAleb0qTVvWTJkkj",
+	"8+DtoyQxzeaSWuGdSqWRn2B/A0rHZQLsqgZCFHHmyqDDltlzjvaPj3998/b52duD44OThi2ninU8lTCK",
+	"pxEzoSPCL/yaFrmQlh0eRd3QNFijou8t0Y9o1eBeNORBzCiP31fi1FNoUQ+7z10oZ+6S0txhUDSsgcum",
+	"e/ncNOIu5IwTpSBseN6uBF3FIBE/a1t5mPJAc2XpgzubkpuJTTl6lKc8E39ZCaQCudZx9qZSO65yUDe1",
+	"OsYgXgwsqeaoSJL1tb15rLK3C2HxDzrtS86UsH0q993XLnPWE2KQE1VpMarcuX0M87cZkLBQCafoUxfn",
+	"VhTOPOU0aJ+m5RVWAWBCb/jSCyT7hNbvMLDcONoYDR+lI71z+tWq9t6LjD1a0qObIOneBHUziApgyvso",
+	"Bw5EuzHy4AP5XE3bIuRMPICqjewIMh7Bzy0D0gGqEc0xnA4AR34qAQeeshk1DyfzlGt1En6CDtgK2QBl",
+	"LieqqJWMKI+vq8bfEm7ECtPfsXrQaoQViVsQl56eUOrOV6QmNDDdxQM3AWuj8ZnWGeLZUpyG7XWj9NsA",
+	"dxzWEFdtnEfA/xAXi6L53AMR0gGoOrHf4FiOmp5K95axfE5hFGEStONr7J3r+BtoB+2iJuzvb398xr7d",
+	"3f3uH3Ech01tJIo/isk5dOyNyPTPhogbC/ZwOB5EOwAea26YnY+lERqCtT+6nLRPnUlmOL+h2irUnd1V",
+	"7iAJEgp6eJZXeyrcu1iVorpeL9WnHPsQ5DITo1zmVYD0qRQym6mcLJBUfTQBeKb5YCqCdTEdiizDIbDs",
+	"jQtVAtV9+zQe8YcjDFZEzB9kY8EKzIfFofMPojA+Ov2PUmB9ZheebvI/m6pJJkYcuwnsPfhnlfv/zaME",
+	"/ny89+B9rDP3wgJO+Nj1JKMzStVs3pWqejjaeq2k2ML+CjEtKQyEX8FGsQjLzv9uwm0VXz/MJY/Wpl60",
+	"VDdrugTK4DPY0NYzJa1WRXOexURgOIfl78BbD2Ok4mQiwsNDdxfpCLe+nruN0AL4u7OoeLrWjQqK76CC",
+	"EL/JPbZHIjaX4X9YkawrLYUI57XyUpKP8eN1pJiSuoLaStF1uJeXLiTsW7O79R3fGr3/uPfNp//qSuWp",
+	"a34ujdEG2YZeZUpnQpM3w5WBWUwe/olGvUVRHmfoSh1203+ticNVaUqPC+48+iQK47fbDL/A++1V0kqL",
+	"MddZ4UIaU27EdkcWLw58S+Injv2ZcnhpX12w+HWl7t5NSCKBqrd9ulISlYmKXCobmUhMC4+hZ02Qdz7i",
+	"f1fkDVNaVYW2zKoxOpl84rBh06oss9lmhxa7hzo6Dmh9LmaWDUvLpGLg/BCaSeELHeY2QSEbC3VA6Q9M",
+	"vCJWhf4vGie32NSg+i4mjtNCa9xfpfwR0tC2v7hUL0dbNzLbkC5qGYxWyVwLClfH9e7eEY29B5O7zZha",
+	"CiNLZW7aWZe07QjftdPA43laTqRxBFPpypAB1DJ8uU3C3mGV2c0SX+4KtVyJ3a9GfPk82H0vNkVpDSHe",
+	"umLTjpN8ehXScqmXMgvEIzTJOW3oVzwszaXJbX4hvgdsxA/dJPCyk3/qAbhlEFeRiZmdUBwOlRdrFyHv",
+	"0J5dQ41V9stDGnPVajosmvWe4nbNES+MWOyK3MO6eE1S5HcfgZlX9S4rKfSe99+xiSEAtY2WA5aRhuqB",
+	"x5IFbavtPwMF6Ni9vIYeU0pCxy8TSn18PMtyLVJb0ZzNYiJ4BRsNq0l3JWdHzf3aIwuoQfhW5OZX/DyQ",
+	"mnl1x+jso9J+tbEBLQs1ZynmIbWoSHbFBNlrQUUFYJxTeanKInP2MnCKzdNCJKyuzCnd234xaKgwohgl",
+	"VGGCqqTVo/r37GWeCoi6TYXIqiKgp9LJ/9E8yCy7ArZ/ybiudHX1n0F29VcaA5GNojavV9CapVwJhdGd",
+	"j/mqkoFUs6kiu/T509Dix+xEq3I8aRn9kFq7Tj7bkSgRGBiX+o4aO60GfHixWZjpDnVE77r7whDunavw",
+	"3eCtdLkLou+m1YVyaQ3k1OxhSmyD9kxpa3zGhB8tB2CvD8CNmzCR41t0QsWciuIQ3C/VyaKhwhORnrf7",
+	"Gd6RrgNTdas7k3wWhlPfY9kNszXc32YqXAiUToqZhmD5F5Bh3y2Lq8iXT7aqaMPh8yUS636WVfHRGpuU",
+	"5FXE5QI9bUuF3ii8HRMJr8AaeZbdM8avCWX3s8yDHmaTLZUEJ4IX1F6iMxgTVJef6bU2RFY/3xqTcjNE",
+	"joJsqOxvb/71N5ZTVVJi1KDeSaZLKV1Xkc95M0ltz7x12BRSaF70iFlbuFUPGu4HAo1W3+Slhuzg3QSS",
+	"S4Sx1IwtYWpGHVGLORVutRNlRDuRlZrexJtBHDZa2C5lMtibi1LewyUFDoeq/1A0xtY/XLeGkO+xdKu2",
+	"6Xq6rtCz8KS+1vizZsNjD9jhyfSJRGsU0FpZwmeKSdx2IqZBJnfdQK7RPs5XzTqVxvK5WdJ6LrfVzLFK",
+	"XdhHtILwRnb24etfDk+qKnunckmZPdryHZbb+kwhcsEOlyKOD5ar7vU+bu5GHcAhQi3kdW+wIxghRNQt",
+	"zOOEpcU2dz7Wf/TtwtGo1Od6Kpm6pZJPu6KAOqQ1jTYAVdeoBgXLjY9nS04lZTmPtBCRqqDduVstGrFK",
+	"96hf/1L7ewQ7vHtzdDB5pVj6jLw6hXJDO45EK++1WfTylIAmmiyEnroWjZHMrGVgvHvn7OZrwYnNCh7s",
+	"CYErYl+qnXaamALif/1Igm6+AjcuqGnu5iw5WVHwCCoQIPvCOgU9uV5A2hLGC6NA88+tTx5vtcmB4U9l",
+	"nD0G09HHpiU1+3pGVcurOGeEg98skuKkVirEcM9zv3aeCwDak+IBlYHWrEuqjcg/SlEKKsYjx1vOyMaG",
+	"ZXHOKtRoNnusu1It9mRQxr5Qw1vSOV+o4fpVvG5q5tiFvVDDGmCA7M20SoUx1Co5yBV+qWjaiEX/7Uvv",
+	"iftdDX2vaC2MKnUqliZlf7rv3HinWcLNHtG/vf/UMME6TIJbDPDxhRqGiLgyEOMZl6lAdoqjYWdlpnRd",
+	"QC3FF4qgo7tHWSUXs2dpOI+Rt8THliCHWy2R08bS3Ya+PCUStn33nAwpkWNho1zmZrJpBbOWYw8Bahx5",
+	"VmiONdlMgP6OKyOuFgbcNBGV8fMhRAAVXzDI/1WgDnRHAJ+hayK6AHhLtS/Y9hWDEWplK5d2ECns8r7B",
+	"MXa4tjlUqO2Vf0Jwz/w3gBRZmVKNBV5RB9g4qVfVi7VRkcojQicBKthDqbvoiziVL978cHy2//bk8Mf9",
+	"Z41KsU53u3SKXv15R02dF2q47ze2Fjqq1Aq7ZawWfHrtijNIOutlhBVeaPat57mZKZPHBbjjcjymwLBR",
+	"Xgjy6jiBLhh1lRT3ZREDpau914QhcQ3m6kcAKJ5p1cASFJj+K9GS524DSFCCm98wmhIW2uhXqKXxRViv",
+	"xRTlOOrff9OY4xYZbThRlwu9uZiv1YmuWlfiobJ5OqEjPeZVftNsi3obOn44xWfyLDd2uQKi7kux3EJ3",
+	"uAa0hqE+RTn+K5Rkke3+wV3YtkCQdz4qPXaO5a7CGAtIeAfEdRUafGkaTQPFN9rv1RPSVoXZhfvtkj8Q",
+	"NK/v9tKqEP0kD3yz5QumSAg9zV0dd4yvGmsubVQUeYuT3SKWwARdogdN/rWKHNodvQdJOg0iepiV1gkD",
+	"8LnxDdlrefPw+Xqxp+wIK7vCceSy9KF08FrBjWWYEHsqbehghFqwQZu7qtcSVqT6YM9oiMsJaHpTpX2t",
+	"h5GCBkpdBRioduQaga5l3ZLvxkNcYTFVcGsSxz63AH9euQlIQmt2fGdwraSOV/xDPi2nQc+p0h1YbELs",
+	"PhMvL/FgNxlMabDBk71d+CuX7q8+tXMjV4DQtdhfHUCKY+XC3ECN7VIkDvQ4VUD5Hn/cZr/AfyhPcsot",
+	"1pTl5lRiG3fqFwOtUrQR8CH+inYWw+i4XBuMy9yIhInt8TYT01mh5kKc5dn3jx6AqpyJGdd2KqT93vBC",
+	"YLuemeCWWXUqHalmRlxgJHm9BwJW8YFPZwWcS3sYfDgrVCY8mY9evx+vcSO5FVMTsXVUl8C15nP429g5",
+	"zg5AM7jVWGuA+y46TQh67027cxbhET1SZXdFODcEm1AgJNUPNXNjxTTqj66Sq25eWYWhP5OS2tVn+J3x",
+	"GWjGjErgk/ca6q0kU2++HupxJIJglQy2M5xvYbjwzkf8z6eVgrnDOmLzoC4M5wv9zxd0Vpj3h6D3/x03",
+	"38YL+yJdb+82N2sRFFTqJu8AZP1y6vDa38wCeEV0U1GBVpduGtVFCQeM4DrtTpn8sSyKLSs+WBTORuWf",
+	"f84ZfcIUbN4VgZSuvfA2+1XpzJDAB5ufaTHKPyQMdNaZKAoU7LCqjSoAT0TmSwycSqvzseZTZvJpXnBw",
+	"PGAfx7Kw9EmgDmlRiAvuW7imXOs5m+TjSZGPJ5YZmc9mguoeBNLnJS6Na3EqLzWfzag272m5u/swnXJ9",
+	"jv8SDO7pqR+D4d5d8YifT1693BIm5bO4W+8YD6aXykOv4ugdwv4fS290yj+8xFYbgycPHj9Gad//vXcl",
+	"vYMcp7evedymnEuHuqwr1VsuoReTg2C/53vp9y5powP9Lvm3Jk0+Xmxtlnz4fAE1HR/eBAZ8X7fgK2H8",
+	"jbCaNbj+Z6ny8VbMCp6KBXzaZvuuhkfWym/MlCC2aPm5YALLYIUB0k+o3D0zVmln+3FpfGd1S3nOsFt8",
+	"ntbR/lVTYauw1dtJ0FhY0ypNo3upkqJuaYpZGKQYn0o/nGsFG+faR+VGqcm7n0FNvi9Kffsk6o78x9Q7",
+	"uAy1dEg+WsxP3sTK1Cs1dgoInOVb52LeryA1vEix4ngmLhvrQtTpWC7TZZsdi1QLp2tIMBpXPpDtTt/G",
+	"/tHhv2Apt4jGNEWX/Xb/6BD3eC9bfPGyBZqN/X3XMB32qjw6ZAiOmyFnrLBjn4u5i1V3yVUWGD/gIDF9",
+	"+jeKAxPo14pOT+958jDy1P1sDdC5SS1wbJ/KE8J/BoAnpIUrE3WZ+oShVov9t0MHaqpmYkl1kRrrb0li",
+	"oME/k2mdJidKuITcbIBhvapbdk+DPkuYl4eEFXSog3fvfDwX875VRGCep3UamKuLgHzaV6muEpuZ6ir5",
+	"0ULcVSU//P4+V9fkLxS2/bFuJnjXVT16gvfnZrNJF9h2TYpod/1AtiUovaOV5YTPX9/ZJJ1t8APDhRNs",
+	"ELTOxTxh50LMII0VpBhYS+KkEHKsQD7IvFUNwg3RKAdxKgPrC70PbtBeYlS0HgTe4zKyuXvncgfB1j01",
+	"/jqoMV72NYQN6mTdo+0n6gTNBv31XORjLATXptlYOtaZE9HFd9DuIWXQXI3+nPdg/QUL0L4t6BWbrH82",
+	"/8A7xA4Qxl8cHfyUsKPXP4EG9qsYHrF8yscYxUm7ItZDrJLyam0go6Plnq4hwTuCHzKRFlyDKj6fCae5",
+	"06i5YS4OuZxp9O7zNFUaqwGig8Cwg38f/siUzoWk6i8JS7VC9z5WjE4FXIvImPmjBJUBsNn5JHJ5Kk3+",
+	"p0BryjePErb34J/4/PHeAzbLP4iiLmQO3Q6mwnLQPbcDOhD2EiDXhfUGBeC+9KLLIT6VnoGj0b1Jb+oM",
+	"5G1WnbYWoZVi/5f9k/23Z6/2/312fPifAzacWyckBE+ODv998PL4VNL6l3g8AjLVZcWYloXNZ1zbHbjO",
+	"Ldh7E09mGoa2OZE4yAJeBJ3qMgdJjxzlEIJ/oxHfV2+pIah7m+JIccTbX+Tnot6Ah5gTIseYh/0V+FL2",
+	"Ht7+tDUNEh+opwHK7vmfAg4csYtwk1b0+G5WBBdcN7PpoMUb5tvB1PBOjtcS2oDGbIkPM6VXV3wAJ83c",
+	"TgDwvZd5iLTayW3ArgAtCkEJBplKyykyHuAM0lwKTbZEUyJhAd5CbmYyNT0hN/JMKzh24CsiE9LmvDAV",
+	"L0iYEZSPlVDaT7LQYMQkldk+Ya9+3MekFpEsur5NciobxeddSSyg8PAdFQJNKBFMFXkKDXjSHGdnU54h",
+	"1wVQULLmSVUNXa3UCLiV0NyUWmAGEPxbECUxtQfMlOkETs9X50bTOuzMqnMh3V848MmbkyOnYTpuJUaW",
+	"qdLG+M4B3irc+3NgJLdMsWEOmjEGufCUOTC70cIX1ZgbVLzsXsy/a6JHgEcHnxGwb5yk3ya8gqc2v9gI",
+	"012nHe15tUjjqLxLDrucCEk5ZYXgFyKp5HaUqakppphus3qAzKf6YY9NqpNf5OeCmdLMhMxEdiqVFKbR",
+	"zABdDtUIMSJXT3DLUUWUyRj4CT9bLFENOfcWjL92/NCJ6zXrMv85pPY2FHcXcucSbzfLsOKBsGcYEaX+",
+	"hDLYBtO9YyHR+LIYLelsDb5DQjNGs7JhUgEq1bhE/xJVLiOd4lTSDCJjc4jDOOC6yIV2TWGgyQtzeFcT",
+	"RSpYtc1cMWwgpxOtrC1ExmYuGC2eLiEzTIv6JbyDPlbTXxZOwQhp78MX/+rk5yDaQKWCSFA4pHLhyB7i",
+	"cXEP7qTdyyLyQRU3F6usmBapkLaYN/WJt8Lq+dY+1gqIoDUVDyRs4i56E4ee8jkbChw9pkzUGTWfNiu5",
+	"gwp0ExlaOLAeVFlzs8kC6KHWYHUw+RALJlDraCLA2ig4HQpOGoVdC8nS4PVub12o22cxrS6BAJ+Lma3a",
+	"fpzKWTks8hRrfxgAr5HQQqZO8+ZlllsmpNW5CClzwoalpby8pMkNBBWJRY11lIsiM0lQFOFUekOBs0eT",
+	"rAsbzCobQt1/p5aB2bPaKhIaQxomD7KN4DhoHTmVYXdt+NkzMDQxEBtx5vCnje53ZMJXcj4FGoqfjrQQ",
+	"lKSoBTfwkhq1TCenkmwn5MfzzRX8jaAjPFUarPFVPRWeWqXJ1kLDVqdQZ8355t9wgQaZneVD2KdBq5Ea",
+	"bZ9KBHRWykxoVogxL9hEQdN1LikEmZENJmowgSe3qEYc0P7vUIdwM3byHDqLey3ir61FeEoxCYqcYqy9",
+	"Mzcqam3aQonNMuDASntqEmPqQdsnHcF3Tw8asbcb0idhojPQ8W32K9Ikp5tdiO+BpYXDuZo8GANEVaEi",
+	"XdqRcuYyLcqMqjpdiqLYZkSeQNqAe3L9GSFOiIZYVtjJ9d5d2VsIpwzXG7IS3mjF3+g2P/bjx1KS6+OI",
+	"5yWPeGFE5TgcKlUILm85ARkPpCtT4yfa+33r+a8uZ2Nc3fxC8FLfhvN3bg1GsrwFZLkfYavJuGEY6pgx",
+	"Jd1+E4bSonDZHoUAJpAwVWRVZ+V4htVLGPRnXMMtYm01Sxfmvqz3do+yXwfKhuAcw9tNCteK6qk/aiH+",
+	"rJwk6BcucpuPXVL3r1jGkZMqkhuPoBVjrrUT32o1kN1mpR77OixwWbmSp1KXWIBvv/oePiYRz1fgw+NM",
+	"MF97hu9A+lYoC4y0+lNIbw0pfGzV1OmiRDhQdTuVfuXrKG4wY61HR0OjgHRVBOGW1K5q/M+U5FXvbymx",
+	"c3R8E8Ka3D0q3brCe7J4Z2QRMSPU1laqRYEIsfMR/kPdPRGLNzA7JAD8rnlpE7eVIYIHg90Iq5WQmQrX",
+	"RA32lXQEr0XunGhFWDJytA8H3GZvZCqYVJ7Wn8oIsY9RehjSkXpsvxnvGYqT3CHFxPnuyGLVl1B61nQv",
+	"Hd4gGVQNc+nnsWIFC1iwZYWXvkEJjLiotej0dMRXqniXE6pDjwHhWyNHc+q8+lxJEMaEBPM39QMNkmf8",
+	"11ipZ+RsTFTh3lFSw/Jot7pXI37si3zfGprXk0RO3cdrlve639dRviu48CDJrLSTDbTSTEccrpIwd0M1",
+	"0beBkzQIF3YSS6oghpulKhONcBUM6gu6FihjatKRiYs8FegVxYI+GIaScnkqhdSqKJy8AtVD4a59f+L8",
+	"Ii8EijPZNJfmqe8YSNmxEUUSXquUR5KuuhqnA6W6JeHn1YjjDGtpi5GoGQBsApaN0ei+bNIG+4VDhx8I",
+	"MDewoToSvEVKFyE0VtnZBtOZZoH8kNKMXPNFoixUQxBgkNdRc3g7UyEXSg+FbVCG3IiHD7BoL0VLcMOU",
+	"nQFksndvD53U8z9vkZpt49UHMhGWKOT4zKto9TR1hUKGrTjw6OGdqRHFBURngJJGy4xGCuCTE7ihWxSU",
+	"YPyD6qiiEVzVU+Ch+j4i+K/uy3dAXFX8J2DerILCAGiE8DUe96ZoOw71NpiyHeChGyQoJBJhYN2caFhI",
+	"UDw9a4hYpMEpKbZsPhUtmYvoXfO3U8m16FF0jYQxnw2mhUvCi9ZOo0OuCNTNS0kw9DOVibtODn7rjg7m",
+	"7lQgA7T5XIIX3CxGgUsVYXv31PJroZYOE9emlz4XdDPoZBnNkbAGALxRJNovu9YtXSUl//u0NJYZbnMz",
+	"mje/oAxbyIq11omL7hE0FzMwG2eFSs9VaVnKS0M+0RHPQdwr1DiXxpFgV8gO+jqKkRZm4sNcg2ighDms",
+	"JLLqA2mrCFIMe6+fA3FubsIxMmxDDyqsy/M48289ZZdaybH/vfoapM9SWgxabSw+nrSBoV9H7ttbIuZ+",
+	"+OuqvH4cZsTnTA9Bp3kTrNhFroo6Y+6Lprx3kp2REhADPorMiUkauwGC/ScE6yvnZ5C3jebB+FWa7C+V",
+	"nuFykmc1/i4n+/qvkBX8tpEVXOXvUovIZr5v3H731aXs6vuU3fuU3c9vflwzZbdf+2zYNb7JuDH5WPpC",
+	"YCT8dca74yfbXZ2DPn8z7fvI8a/OFan9vS/En/qG4pvmkMQV73yE/6yo7U1OQY57JGpVbbKrejdsuld+",
+	"OLx4X7j7tl1beHPwiyezG1nKG5e5gZiTRIEWJ4jOqT3wX2VWGPs1jNNtPdnHS6wwMuCY9IQsIPSsKi0W",
+	"dBjChlIiqwtmUpp+hKPSeOsjdAhm9xh9c1F2FR5vHhMkUOlG4hb3IRl3h1K9e8mJ9IWH1SBtHy1oQfqA",
+	"SypYnbNU63kHtIxbVvGCubrEx+PGJu/R5+tIYeoC7b9AKTpnwdnkeky0QsO49LlTC3FrfFhC+P1xZY4q",
+	"yY5PqVWFGrNcJi1rv8t0QsnVR57l+lS6wqS+OIbMqvIajTY4LlW8Nlf6zKrA3gNhu2rKbZ5y6P2I4XPw",
+	"f/k0mhHlln/bljGaZTM7Zbr7+1yEM4mkQdENAxxRr1M2Ki3W1bi3qX3dNjWHSCsNak+G3FLf/46Ykw8i",
+	"Lam6fFWPogC2okYElxWVMACCVZ1lLMvAU8oy/WHOXD0GgtFcg+zE8wKAuRrgFJ3QEF6XnuN7lxOQSHGB",
+	"jqDBueayFGdKngnYNRM8ndRDMF3SOnJr0Jhn+IWYqVzaxPt+6vVSeAuFY9KkuczyizwriSBW8b5TqqnB",
+	"sAoofJOq6TS3HYU/f4D1+ub/t0EncYLPRCHd3N2d9Y+E3gqugzrre5Mlwdp9m/07IAQiLXVu5ygs8Vn+",
+	"LzFHv96T395/eh/SCbxQ18YxcQ2gEfTJYre6PT8RkJ+E7aVnlc7qbtOJC2Fw7TYPnyPeOnwhUsOsGlPC",
+	"UBWKD6+hmHIpdKisRpHQmexXVqZ5pqZTzoyAl2ybvh0+h+/Fh1mhMlGVk4lVoskzs1TSzK2Ymr4iZzKY",
+	"8g+H9MXe7m4ymObS/1llk3Kt+RzeNXZewA+QdTq41dI2/mCX0YAf0TJIVw2g5MUWOMp75L9r54GvukD3",
+	"MXRt7yKqX1zB4VNs6QPI99PBCbvgOufSYnFBaPhvKrSspCKPw0OVzZlVzJQzLIJecD0WzAhrlqPrESzk",
+	"FlnnT2um0Nwjz9eLPFMom7YccxbY7VBwLXTFbpMoA8ZpiRmVuhg8GezwWb5zsYfU200RM3OYv8Ga+Fhg",
+	"boWQGUq3puZCtKxFt8KBfxXTUyaCF3aylU5Eeu46YblMRzfMz/hCZJx9M5fpRCupSsN+V0Mar1ByvKVL",
+	"iXLysCzOAzm7HvSFGsaWVkXnpWEFUQzSwrWRtaMeBg8y7jgxCZsJjTijpKktJ85pMKUavZVU4wbEL2ML",
+	"87Vcm2YXJxq07C9qxOBW81SES/VNCRcHP4RipnBcFXgJVxrXfXtYVzuNfF6XkcPvE3Y5ydOJLxdMlfPq",
+	"sejtyDDPSmPVFB0AYy7zP33HmUssH1h3RsgNhSkSmSetC0Szeoo34QCDT+8//f8DANS3wjbzpQEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/apikey"
+	"go-users/internal/ownErrors"
+	"go-users/internal/policy"
+)
+
+// ListUserAPIKeys returns the personal access tokens of a user
+func (h *UserHandler) ListUserAPIKeys(ctx context.Context, request ListUserAPIKeysRequestObject) (ListUserAPIKeysResponseObject, error) {
+	var keys []APIKey
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		keys, err = h.repo.ListAPIKeys(ctx, &id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ListUserAPIKeys400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ListUserAPIKeys404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ListUserAPIKeys500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListUserAPIKeys200JSONResponse{ApiKeys: keys}, nil
+}
+
+// CreateUserAPIKey creates a personal access token for a user
+func (h *UserHandler) CreateUserAPIKey(ctx context.Context, request CreateUserAPIKeyRequestObject) (CreateUserAPIKeyResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return CreateUserAPIKey400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if errorMsg := validateAPIKeyRequest(request.Body); errorMsg != "" {
+		return CreateUserAPIKey400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	var key *APIKeySecret
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		key, err = h.createAPIKey(ctx, &id, request.Body)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return CreateUserAPIKey400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return CreateUserAPIKey404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return CreateUserAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CreateUserAPIKey201JSONResponse(*key), nil
+}
+
+// RevokeUserAPIKey revokes a personal access token of a user
+func (h *UserHandler) RevokeUserAPIKey(ctx context.Context, request RevokeUserAPIKeyRequestObject) (RevokeUserAPIKeyResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.RevokeAPIKey(ctx, &id, request.KeyId)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return RevokeUserAPIKey400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "API key not found"
+			return RevokeUserAPIKey404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RevokeUserAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RevokeUserAPIKey204Response{}, nil
+}
+
+// RotateUserAPIKey replaces the secret of a personal access token of a user
+func (h *UserHandler) RotateUserAPIKey(ctx context.Context, request RotateUserAPIKeyRequestObject) (RotateUserAPIKeyResponseObject, error) {
+	var key *APIKeySecret
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		key, err = h.rotateAPIKey(ctx, &id, request.KeyId)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return RotateUserAPIKey400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "API key not found"
+			return RotateUserAPIKey404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RotateUserAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RotateUserAPIKey200JSONResponse(*key), nil
+}
+
+// ListAPIKeys returns the API keys of services
+func (h *UserHandler) ListAPIKeys(ctx context.Context, _ ListAPIKeysRequestObject) (ListAPIKeysResponseObject, error) {
+	keys, err := h.repo.ListAPIKeys(ctx, nil)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListAPIKeys500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListAPIKeys200JSONResponse{ApiKeys: keys}, nil
+}
+
+// CreateAPIKey creates an API key for a service
+func (h *UserHandler) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return CreateAPIKey400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if errorMsg := validateAPIKeyRequest(request.Body); errorMsg != "" {
+		return CreateAPIKey400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	exceeding, err := h.exceedingScopes(ctx, request.Body.Scopes)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return CreateAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if len(exceeding) > 0 {
+		errorMsg := fmt.Sprintf("Scopes exceed your permissions: %s", strings.Join(exceeding, ", "))
+		return CreateAPIKey403JSONResponse{ForbiddenJSONResponse{
+			Error: &errorMsg,
+		}}, nil
+	}
+
+	key, err := h.createAPIKey(ctx, nil, request.Body)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return CreateAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CreateAPIKey201JSONResponse(*key), nil
+}
+
+// RevokeAPIKey revokes an API key of a service
+func (h *UserHandler) RevokeAPIKey(ctx context.Context, request RevokeAPIKeyRequestObject) (RevokeAPIKeyResponseObject, error) {
+	if err := h.repo.RevokeAPIKey(ctx, nil, request.KeyId); err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "API key not found"
+			return RevokeAPIKey404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RevokeAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RevokeAPIKey204Response{}, nil
+}
+
+// RotateAPIKey replaces the secret of an API key of a service. Like at creation, the caller must hold every scope of
+// the key, since the new secret is handed to them.
+func (h *UserHandler) RotateAPIKey(ctx context.Context, request RotateAPIKeyRequestObject) (RotateAPIKeyResponseObject, error) {
+	keys, err := h.repo.ListAPIKeys(ctx, nil)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return RotateAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	i := slices.IndexFunc(keys, func(k APIKey) bool { return k.Id == request.KeyId })
+	if i < 0 {
+		errorMsg := "API key not found"
+		return RotateAPIKey404JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	exceeding, err := h.exceedingScopes(ctx, keys[i].Scopes)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return RotateAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if len(exceeding) > 0 {
+		errorMsg := fmt.Sprintf("Scopes exceed your permissions: %s", strings.Join(exceeding, ", "))
+		return RotateAPIKey403JSONResponse{ForbiddenJSONResponse{
+			Error: &errorMsg,
+		}}, nil
+	}
+
+	key, err := h.rotateAPIKey(ctx, nil, request.KeyId)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "API key not found"
+			return RotateAPIKey404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RotateAPIKey500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RotateAPIKey200JSONResponse(*key), nil
+}
+
+// validateAPIKeyRequest returns the reason an API key request is rejected, or an empty string.
+func validateAPIKeyRequest(r *APIKeyRequest) string {
+	if r.Name == "" {
+		return "Missing API key name"
+	}
+	for _, scope := range r.Scopes {
+		if !slices.Contains(policy.AllPermissions(), scope) {
+			return fmt.Sprintf("Unknown scope %q", scope)
+		}
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return "Expiry must be in the future"
+	}
+	return ""
+}
+
+// exceedingScopes returns the scopes of a service key that the caller does not hold. Only static keys, which hold every
+// permission, may hand out any scope; everyone else could otherwise escalate their privileges through a new key.
+func (h *UserHandler) exceedingScopes(ctx context.Context, scopes []string) ([]string, error) {
+	granted, err := h.grantedPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exceeding := make([]string, 0)
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			exceeding = append(exceeding, scope)
+		}
+	}
+	return exceeding, nil
+}
+
+// createAPIKey stores a new key for the given owner, or for a service if owner is nil.
+func (h *UserHandler) createAPIKey(ctx context.Context, owner *openapi_types.UUID, r *APIKeyRequest) (*APIKeySecret, error) {
+	secret, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.repo.CreateAPIKey(ctx, owner, r, prefix, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSecret(key, secret), nil
+}
+
+// rotateAPIKey replaces the secret of a key of the given owner, or of a service if owner is nil.
+func (h *UserHandler) rotateAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID) (*APIKeySecret, error) {
+	secret, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.repo.RotateAPIKey(ctx, owner, id, prefix, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSecret(key, secret), nil
+}
+
+// withSecret returns the key together with its secret.
+func withSecret(k *APIKey, secret string) *APIKeySecret {
+	return &APIKeySecret{
+		Id:         k.Id,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		UserId:     k.UserId,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		Secret:     secret,
+	}
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/apikey"
+	"go-users/internal/ownErrors"
+	"go-users/internal/policy"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_CreateUserAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("Secret returned once and stored as hash", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		owner := userID(1)
+		body := &APIKeyRequest{Name: "ci", Scopes: []string{"users:read"}}
+		stored := &APIKey{Id: userID(9), Name: "ci", Scopes: []string{"users:read"}, UserId: &owner}
+
+		var prefix string
+		var hash []byte
+		mockRepo.On("CreateAPIKey", mock.Anything, &owner, body, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				prefix = args.String(3)
+				hash = args.Get(4).([]byte)
+				stored.Prefix = prefix
+			}).
+			Return(stored, nil)
+
+		resp, err := handler.CreateUserAPIKey(context.Background(), CreateUserAPIKeyRequestObject{
+			Id:   owner.String(),
+			Body: body,
+		})
+
+		require.NoError(t, err)
+		created, ok := resp.(CreateUserAPIKey201JSONResponse)
+		require.True(t, ok)
+		assert.Equal(t, prefix, created.Prefix)
+		assert.Equal(t, &owner, created.UserId)
+		parsed, ok := apikey.Prefix(created.Secret)
+		assert.True(t, ok)
+		assert.Equal(t, prefix, parsed)
+		assert.Equal(t, apikey.Hash(created.Secret), hash)
+	})
+
+	tests := []struct {
+		name         string
+		body         *APIKeyRequest
+		mockError    error
+		expectedType interface{}
+	}{
+		{name: "Missing body", expectedType: CreateUserAPIKey400JSONResponse{}},
+		{name: "Missing name", body: &APIKeyRequest{Scopes: []string{}}, expectedType: CreateUserAPIKey400JSONResponse{}},
+		{name: "Unknown scope", body: &APIKeyRequest{Name: "ci", Scopes: []string{"users:*"}}, expectedType: CreateUserAPIKey400JSONResponse{}},
+		{name: "Expiry in the past", body: &APIKeyRequest{Name: "ci", Scopes: []string{}, ExpiresAt: &past}, expectedType: CreateUserAPIKey400JSONResponse{}},
+		{name: "Unknown user", body: &APIKeyRequest{Name: "ci", Scopes: []string{}}, mockError: ownErrors.ErrNotFound, expectedType: CreateUserAPIKey404JSONResponse{}},
+		{name: "Database error", body: &APIKeyRequest{Name: "ci", Scopes: []string{}}, mockError: errors.New("connection refused"), expectedType: CreateUserAPIKey500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			if tc.mockError != nil {
+				mockRepo.On("CreateAPIKey", mock.Anything, mock.Anything, tc.body, mock.Anything, mock.Anything).Return(nil, tc.mockError)
+			}
+
+			resp, err := handler.CreateUserAPIKey(context.Background(), CreateUserAPIKeyRequestObject{
+				Id:   userID(1).String(),
+				Body: tc.body,
+			})
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ListAPIKeys(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := &UserHandler{repo: mockRepo}
+	keys := []APIKey{{Id: userID(9), Name: "ci", Prefix: "gu_0123456789ab", Scopes: []string{"jobs:manage"}}}
+	mockRepo.On("ListAPIKeys", mock.Anything, (*types.UUID)(nil)).Return(keys, nil)
+
+	resp, err := handler.ListAPIKeys(context.Background(), ListAPIKeysRequestObject{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ListAPIKeys200JSONResponse{ApiKeys: keys}, resp)
+}
+
+func TestUserHandler_CreateAPIKey(t *testing.T) {
+	body := &APIKeyRequest{Name: "ci", Scopes: []string{policy.UsersRead, policy.OrgsManage}}
+
+	tests := []struct {
+		name         string
+		principal    *router.Principal
+		permissions  []string
+		expectedType interface{}
+	}{
+		{
+			name:         "Static key grants any scope",
+			principal:    &router.Principal{Kind: router.PrincipalService, ID: "ops", KeyID: "ops"},
+			expectedType: CreateAPIKey201JSONResponse{},
+		},
+		{
+			name:         "User grants scopes they hold",
+			principal:    &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()},
+			permissions:  []string{policy.APIKeysManage, policy.UsersRead, policy.OrgsManage},
+			expectedType: CreateAPIKey201JSONResponse{},
+		},
+		{
+			name:         "User with fewer permissions",
+			principal:    &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()},
+			permissions:  []string{policy.APIKeysManage, policy.UsersRead},
+			expectedType: CreateAPIKey403JSONResponse{},
+		},
+		{
+			name:         "Personal access token limited by its scopes",
+			principal:    &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String(), KeyID: "pat", Scopes: []string{policy.APIKeysManage, policy.UsersRead}},
+			permissions:  []string{policy.APIKeysManage, policy.UsersRead, policy.OrgsManage},
+			expectedType: CreateAPIKey403JSONResponse{},
+		},
+		{
+			name:         "Service key with fewer scopes",
+			principal:    &router.Principal{Kind: router.PrincipalService, ID: "ci", KeyID: "ci", Scopes: []string{policy.APIKeysManage, policy.UsersRead}},
+			expectedType: CreateAPIKey403JSONResponse{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			if tc.permissions != nil {
+				mockRepo.On("GetUserPermissions", mock.Anything, userID(1)).Return(tc.permissions, nil)
+			}
+			mockRepo.On("CreateAPIKey", mock.Anything, (*types.UUID)(nil), body, mock.Anything, mock.Anything).
+				Return(&APIKey{Id: userID(9), Name: "ci", Scopes: body.Scopes}, nil)
+
+			ctx := router.WithPrincipal(context.Background(), tc.principal)
+			resp, err := handler.CreateAPIKey(ctx, CreateAPIKeyRequestObject{Body: body})
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, resp)
+			if _, ok := tc.expectedType.(CreateAPIKey403JSONResponse); ok {
+				mockRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUserHandler_RotateAPIKey(t *testing.T) {
+	keys := []APIKey{{Id: userID(9), Name: "ci", Prefix: "gu_0123456789ab", Scopes: []string{policy.UsersErase}}}
+	static := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalService, ID: "ops", KeyID: "ops"})
+
+	t.Run("New secret returned", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListAPIKeys", mock.Anything, (*types.UUID)(nil)).Return(keys, nil)
+		mockRepo.On("RotateAPIKey", mock.Anything, (*types.UUID)(nil), userID(9), mock.Anything, mock.Anything).
+			Return(&APIKey{Id: userID(9), Name: "ci", Prefix: "gu_0123456789ab"}, nil)
+
+		resp, err := handler.RotateAPIKey(static, RotateAPIKeyRequestObject{KeyId: userID(9)})
+
+		require.NoError(t, err)
+		rotated, ok := resp.(RotateAPIKey200JSONResponse)
+		require.True(t, ok)
+		assert.NotEmpty(t, rotated.Secret)
+	})
+
+	t.Run("Key with scopes the caller lacks", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListAPIKeys", mock.Anything, (*types.UUID)(nil)).Return(keys, nil)
+		mockRepo.On("GetUserPermissions", mock.Anything, userID(1)).Return([]string{policy.APIKeysManage}, nil)
+		ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()})
+
+		resp, err := handler.RotateAPIKey(ctx, RotateAPIKeyRequestObject{KeyId: userID(9)})
+
+		assert.NoError(t, err)
+		assert.IsType(t, RotateAPIKey403JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "RotateAPIKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListAPIKeys", mock.Anything, (*types.UUID)(nil)).Return([]APIKey{}, nil)
+
+		resp, err := handler.RotateAPIKey(static, RotateAPIKeyRequestObject{KeyId: userID(9)})
+
+		assert.NoError(t, err)
+		assert.IsType(t, RotateAPIKey404JSONResponse{}, resp)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListAPIKeys", mock.Anything, (*types.UUID)(nil)).Return(keys, nil)
+		mockRepo.On("RotateAPIKey", mock.Anything, (*types.UUID)(nil), userID(9), mock.Anything, mock.Anything).
+			Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.RotateAPIKey(static, RotateAPIKeyRequestObject{KeyId: userID(9)})
+
+		assert.NoError(t, err)
+		assert.IsType(t, RotateAPIKey404JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_RevokeUserAPIKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedType interface{}
+	}{
+		{name: "Revoked", expectedType: RevokeUserAPIKey204Response{}},
+		{name: "Key of another user", mockError: ownErrors.ErrNotFound, expectedType: RevokeUserAPIKey404JSONResponse{}},
+		{name: "Database error", mockError: errors.New("connection refused"), expectedType: RevokeUserAPIKey500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			owner := userID(1)
+			mockRepo.On("RevokeAPIKey", mock.Anything, &owner, userID(9)).Return(tc.mockError)
+
+			resp, err := handler.RevokeUserAPIKey(context.Background(), RevokeUserAPIKeyRequestObject{
+				Id:    owner.String(),
+				KeyId: userID(9),
+			})
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-users/internal/attributes"
+	"go-users/internal/ownErrors"
+)
+
+// GetAttributeSchema returns the attribute schema of the organization
+func (h *UserHandler) GetAttributeSchema(ctx context.Context, _ GetAttributeSchemaRequestObject) (GetAttributeSchemaResponseObject, error) {
+	schema, err := h.repo.GetAttributeSchema(ctx)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Attribute schema not found"
+			return GetAttributeSchema404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetAttributeSchema500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetAttributeSchema200JSONResponse(*schema), nil
+}
+
+// PutAttributeSchema registers or replaces the attribute schema of the organization
+func (h *UserHandler) PutAttributeSchema(ctx context.Context, request PutAttributeSchemaRequestObject) (PutAttributeSchemaResponseObject, error) {
+	if request.Body == nil || request.Body.Schema == nil {
+		errorMsg := "Missing attribute schema"
+		return PutAttributeSchema400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	schema, err := h.repo.PutAttributeSchema(ctx, request.Body.Schema)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidAttributeSchema) {
+			errorMsg := "Invalid attribute schema"
+			details := []string{strings.TrimPrefix(err.Error(), ownErrors.ErrInvalidAttributeSchema.Error()+": ")}
+			return PutAttributeSchema400JSONResponse{
+				Error:   &errorMsg,
+				Details: &details,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return PutAttributeSchema500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return PutAttributeSchema200JSONResponse(*schema), nil
+}
+
+// DeleteAttributeSchema removes the attribute schema of the organization
+func (h *UserHandler) DeleteAttributeSchema(ctx context.Context, _ DeleteAttributeSchemaRequestObject) (DeleteAttributeSchemaResponseObject, error) {
+	if err := h.repo.DeleteAttributeSchema(ctx); err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Attribute schema not found"
+			return DeleteAttributeSchema404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return DeleteAttributeSchema500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return DeleteAttributeSchema204Response{}, nil
+}
+
+// attributeProblems returns the violations of the attribute schema reported by err, if it reports any.
+func attributeProblems(err error) ([]string, bool) {
+	var invalid *attributes.InvalidError
+	if !errors.As(err, &invalid) {
+		return nil, false
+	}
+	return invalid.Problems, true
+}
+
+// parseAttributeFilter parses attribute filters given as name=value. Values that are valid JSON are matched as such,
+// so employee_id=42 matches the number and employee_id="42" the string; anything else is matched as a string.
+func parseAttributeFilter(params []string) (Attributes, error) {
+	attrs := make(Attributes, len(params))
+	for _, p := range params {
+		name, raw, ok := strings.Cut(p, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not of the form name=value", p)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		attrs[name] = value
+	}
+	return attrs, nil
+}
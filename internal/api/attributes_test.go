@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/attributes"
+	"go-users/internal/ownErrors"
+)
+
+func TestUserHandler_PutAttributeSchema(t *testing.T) {
+	schema := map[string]interface{}{"type": "object"}
+
+	t.Run("Schema registered", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		stored := &AttributeSchema{Schema: schema, UpdatedAt: time.Now()}
+		mockRepo.On("PutAttributeSchema", mock.Anything, schema).Return(stored, nil)
+
+		resp, err := handler.PutAttributeSchema(context.Background(), PutAttributeSchemaRequestObject{
+			Body: &AttributeSchemaRequest{Schema: schema},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, PutAttributeSchema200JSONResponse(*stored), resp)
+	})
+
+	t.Run("Invalid schema", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("PutAttributeSchema", mock.Anything, mock.Anything).
+			Return(nil, attributesCompileErr(t, `{"type": "string"}`))
+
+		resp, err := handler.PutAttributeSchema(context.Background(), PutAttributeSchemaRequestObject{
+			Body: &AttributeSchemaRequest{Schema: map[string]interface{}{"type": "string"}},
+		})
+		require.NoError(t, err)
+		require.IsType(t, PutAttributeSchema400JSONResponse{}, resp)
+		assert.Equal(t, []string{"type must be object"}, *resp.(PutAttributeSchema400JSONResponse).Details)
+	})
+
+	t.Run("Missing schema", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.PutAttributeSchema(context.Background(), PutAttributeSchemaRequestObject{})
+		require.NoError(t, err)
+		assert.IsType(t, PutAttributeSchema400JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_GetAttributeSchema_NotFound(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := testAuthHandler(t, mockRepo)
+	mockRepo.On("GetAttributeSchema", mock.Anything).Return(nil, ownErrors.ErrNotFound)
+
+	resp, err := handler.GetAttributeSchema(context.Background(), GetAttributeSchemaRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, GetAttributeSchema404JSONResponse{}, resp)
+}
+
+func TestUserHandler_DeleteAttributeSchema(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := testAuthHandler(t, mockRepo)
+	mockRepo.On("DeleteAttributeSchema", mock.Anything).Return(errors.New("db down"))
+
+	resp, err := handler.DeleteAttributeSchema(context.Background(), DeleteAttributeSchemaRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, DeleteAttributeSchema500JSONResponse{}, resp)
+}
+
+func TestUserHandler_PostUser_InvalidAttributes(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := testAuthHandler(t, mockRepo)
+	problems := []string{"department: property \"department\" is missing"}
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil, &attributes.InvalidError{Problems: problems})
+
+	resp, err := handler.PostUser(context.Background(), PostUserRequestObject{
+		Body: &UserRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com", Attributes: &Attributes{}},
+	})
+	require.NoError(t, err)
+	require.IsType(t, PostUser400JSONResponse{}, resp)
+	assert.Equal(t, problems, *resp.(PostUser400JSONResponse).Details)
+}
+
+func TestUserHandler_ListUsers_AttributeFilter(t *testing.T) {
+	t.Run("Values parsed as JSON", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		filter := &UserFilter{Attributes: Attributes{"department": "sales", "employee_id": float64(42), "remote": true}}
+		mockRepo.On("ListUsers", mock.Anything, uuid.Nil, defaultListLimit+1, filter).Return([]User{}, nil)
+
+		resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{
+			Params: ListUsersParams{Attribute: &[]string{"department=sales", "employee_id=42", "remote=true"}},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, ListUsers200JSONResponse{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Malformed filter", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		for _, attr := range []string{"department", "=sales"} {
+			resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{
+				Params: ListUsersParams{Attribute: &[]string{attr}},
+			})
+			require.NoError(t, err)
+			assert.IsType(t, ListUsers400JSONResponse{}, resp)
+		}
+	})
+}
+
+// attributesCompileErr returns the error of compiling an invalid attribute schema.
+func attributesCompileErr(t *testing.T, raw string) error {
+	t.Helper()
+	_, err := attributes.Compile([]byte(raw))
+	require.Error(t, err)
+	return err
+}
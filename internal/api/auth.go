@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/lifecycle"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+	"go-users/internal/tenant"
+)
+
+const (
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+	opaqueTokenLength  = 32
+)
+
+// Credentials represents a user together with their password hash and login lockout state.
+type Credentials struct {
+	User           User
+	PasswordHash   string
+	FailedAttempts int
+	LockedUntil    *time.Time
+	// MFAEnabled tells that logins must be completed with a second factor.
+	MFAEnabled bool
+	// MFARequired tells that a role of the user requires MFA.
+	MFARequired bool
+}
+
+// SetUserPassword sets or replaces the password of a user
+func (h *UserHandler) SetUserPassword(ctx context.Context, request SetUserPasswordRequestObject) (SetUserPasswordResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return SetUserPassword400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	id, err := h.resolveUserID(ctx, request.Id)
+	var user *User
+	if err == nil {
+		user, err = h.repo.GetUser(ctx, id)
+	}
+	if err != nil {
+		return setUserPasswordError(err), nil
+	}
+
+	if violations := h.policy.Validate(request.Body.Password, string(user.Email)); len(violations) > 0 {
+		errorMsg := "Password does not satisfy the policy"
+		return SetUserPassword400JSONResponse{
+			Error:   &errorMsg,
+			Details: &violations,
+		}, nil
+	}
+
+	if principal, ok := router.PrincipalFromContext(ctx); ok && principal.Kind == router.PrincipalUser &&
+		principal.ID == id.String() {
+		if resp := h.verifyCurrentPassword(ctx, id, request.Body.CurrentPassword); resp != nil {
+			return resp, nil
+		}
+	}
+
+	hash, err := h.hasher.Hash(request.Body.Password)
+	if err == nil {
+		err = h.repo.SetPassword(ctx, id, hash)
+	}
+	if err != nil {
+		return setUserPasswordError(err), nil
+	}
+
+	return SetUserPassword204Response{}, nil
+}
+
+// verifyCurrentPassword confirms a user changing their own password, so a stolen access token alone cannot take
+// over the account. Wrong passwords count as failed logins. Returns nil if the current password matches.
+func (h *UserHandler) verifyCurrentPassword(ctx context.Context, id openapi_types.UUID, current *string) SetUserPasswordResponseObject {
+	if current == nil || *current == "" {
+		errorMsg := "Missing current password"
+		return SetUserPassword400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	incorrect := func() SetUserPasswordResponseObject {
+		errorMsg := "Current password is incorrect"
+		return SetUserPassword403JSONResponse{ForbiddenJSONResponse{
+			Error: &errorMsg,
+		}}
+	}
+
+	creds, err := h.repo.GetCredentials(ctx, id)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			// The user has no password yet, so none can be confirmed.
+			_, _, _ = h.hasher.Verify(*current, "")
+			return incorrect()
+		}
+		return setUserPasswordError(err)
+	}
+
+	now := time.Now()
+	if creds.LockedUntil != nil && creds.LockedUntil.After(now) {
+		errorMsg := "Too many failed login attempts"
+		return SetUserPassword429JSONResponse{
+			Body:    Error{Error: &errorMsg},
+			Headers: SetUserPassword429ResponseHeaders{RetryAfter: int(math.Ceil(creds.LockedUntil.Sub(now).Seconds()))},
+		}
+	}
+
+	match, _, err := h.hasher.Verify(*current, creds.PasswordHash)
+	if err == nil && !match {
+		if err = h.recordPasswordFailure(ctx, id, now); err == nil {
+			return incorrect()
+		}
+	}
+	if err != nil {
+		return setUserPasswordError(err)
+	}
+
+	return nil
+}
+
+// recordPasswordFailure counts a wrong password of a user and locks their credentials once the lockout policy
+// demands a cooldown.
+func (h *UserHandler) recordPasswordFailure(ctx context.Context, id openapi_types.UUID, now time.Time) error {
+	failures, err := h.repo.RecordLoginFailure(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cooldown := h.lockout.Cooldown(failures); cooldown > 0 {
+		return h.repo.LockCredentials(ctx, id, now.Add(cooldown))
+	}
+	return nil
+}
+
+// setUserPasswordError maps an error of SetUserPassword to its response.
+func setUserPasswordError(err error) SetUserPasswordResponseObject {
+	if errors.Is(err, errInvalidUserID) {
+		errorMsg := "Invalid user ID"
+		return SetUserPassword400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		errorMsg := "User not found"
+		return SetUserPassword404JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+
+	errorMsg := "Internal server error"
+	return SetUserPassword500JSONResponse{
+		Error: &errorMsg,
+	}
+}
+
+// Login verifies the email address and password of a user and opens a session, or starts an MFA challenge if the
+// user enabled MFA
+func (h *UserHandler) Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return Login400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	internalError := func() (LoginResponseObject, error) {
+		errorMsg := "Internal server error"
+		return Login500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	invalidCredentials := func() (LoginResponseObject, error) {
+		errorMsg := "Invalid email or password"
+		return Login401JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	creds, err := h.repo.GetCredentialsByEmail(ctx, request.Body.Email)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			// Spend the same time on unknown accounts as on wrong passwords.
+			_, _, _ = h.hasher.Verify(request.Body.Password, "")
+			return invalidCredentials()
+		}
+		return internalError()
+	}
+
+	now := time.Now()
+	if creds.LockedUntil != nil && creds.LockedUntil.After(now) {
+		errorMsg := "Too many failed login attempts"
+		return Login429JSONResponse{
+			Body:    Error{Error: &errorMsg},
+			Headers: Login429ResponseHeaders{RetryAfter: int(math.Ceil(creds.LockedUntil.Sub(now).Seconds()))},
+		}, nil
+	}
+
+	match, needsRehash, err := h.hasher.Verify(request.Body.Password, creds.PasswordHash)
+	if err != nil {
+		return internalError()
+	}
+
+	if !match {
+		if err := h.recordPasswordFailure(ctx, creds.User.Id, now); err != nil {
+			return internalError()
+		}
+		return invalidCredentials()
+	}
+
+	if !lifecycle.CanLogIn(lifecycle.Status(creds.User.Status)) {
+		errorMsg := "Account is not active"
+		return Login403JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	var rehash string
+	if needsRehash {
+		if rehash, err = h.hasher.Hash(request.Body.Password); err != nil {
+			return internalError()
+		}
+	}
+	if err := h.repo.RecordLoginSuccess(ctx, creds.User.Id, rehash); err != nil {
+		return internalError()
+	}
+
+	if creds.MFAEnabled {
+		challenge, challengeHash, err := newOpaqueToken()
+		if err != nil {
+			return internalError()
+		}
+		expiresAt := now.Add(h.mfaChallengeTTL)
+		if err := h.repo.CreateMFAChallenge(ctx, creds.User.Id, challengeHash, expiresAt); err != nil {
+			return internalError()
+		}
+		return Login202JSONResponse{
+			MfaToken:  challenge,
+			ExpiresIn: expiresIn(expiresAt),
+		}, nil
+	}
+
+	session, err := h.openSession(ctx, &creds.User)
+	if err != nil {
+		return internalError()
+	}
+	if creds.MFARequired {
+		session.MfaEnrollmentRequired = &creds.MFARequired
+	}
+
+	return Login200JSONResponse(*session), nil
+}
+
+// openSession issues access and refresh tokens for a user who completed the login.
+func (h *UserHandler) openSession(ctx context.Context, user *User) (*LoginResponse, error) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshExpiresAt := time.Now().Add(h.sessionTTL).UTC()
+
+	if err := h.repo.CreateRefreshToken(ctx, user.Id, refreshHash, refreshExpiresAt); err != nil {
+		return nil, err
+	}
+
+	accessToken, accessExpiresAt, err := h.keys.Issue(user.Id.String(), tokenOrg(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		AccessToken:           accessToken,
+		TokenType:             LoginResponseTokenTypeBearer,
+		ExpiresIn:             expiresIn(accessExpiresAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+		User:                  *user,
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for new access and refresh tokens
+func (h *UserHandler) RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return RefreshToken400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	tokenHash, ok := hashOpaqueToken(request.Body.RefreshToken)
+	if !ok {
+		errorMsg := "Invalid refresh token"
+		return RefreshToken401JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	refreshToken, refreshHash, err := newOpaqueToken()
+	var userID openapi_types.UUID
+	refreshExpiresAt := time.Now().Add(h.sessionTTL).UTC()
+	if err == nil {
+		userID, err = h.repo.RotateRefreshToken(ctx, tokenHash, refreshHash, refreshExpiresAt)
+	}
+	var accessToken string
+	var accessExpiresAt time.Time
+	if err == nil {
+		accessToken, accessExpiresAt, err = h.keys.Issue(userID.String(), tokenOrg(ctx))
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			errorMsg := "Invalid refresh token"
+			return RefreshToken401JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RefreshToken500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RefreshToken200JSONResponse{
+		AccessToken:           accessToken,
+		TokenType:             TokenResponseTokenTypeBearer,
+		ExpiresIn:             expiresIn(accessExpiresAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// RevokeToken revokes the session of a refresh token
+func (h *UserHandler) RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return RevokeToken400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	tokenHash, ok := hashOpaqueToken(request.Body.RefreshToken)
+	if !ok {
+		return RevokeToken204Response{}, nil
+	}
+
+	if err := h.repo.RevokeRefreshToken(ctx, tokenHash); err != nil && !errors.Is(err, ownErrors.ErrNotFound) {
+		errorMsg := "Internal server error"
+		return RevokeToken500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RevokeToken204Response{}, nil
+}
+
+// newOpaqueToken returns a random refresh or MFA challenge token together with the hash under which it is stored.
+func newOpaqueToken() (string, []byte, error) {
+	raw := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+
+	hash := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(raw), hash[:], nil
+}
+
+// hashOpaqueToken returns the stored hash of a refresh or MFA challenge token, or false if the token is malformed.
+func hashOpaqueToken(token string) ([]byte, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != opaqueTokenLength {
+		return nil, false
+	}
+
+	hash := sha256.Sum256(raw)
+	return hash[:], true
+}
+
+// expiresIn returns the seconds until the given time, rounded up.
+func expiresIn(t time.Time) int {
+	return int(math.Ceil(time.Until(t).Seconds()))
+}
+
+// tokenOrg returns the organization of the request for the org claim of an access token, or "" without one.
+func tokenOrg(ctx context.Context) string {
+	if org, ok := tenant.FromContext(ctx); ok {
+		return org.String()
+	}
+	return ""
+}
@@ -0,0 +1,528 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/config"
+	"go-users/internal/ownErrors"
+	"go-users/internal/password"
+	"go-users/internal/router"
+	"go-users/internal/token"
+)
+
+// memoryKeyStore is an in-memory token.Store.
+type memoryKeyStore struct {
+	keys []token.Key
+}
+
+func (s *memoryKeyStore) ListSigningKeys(context.Context, time.Time) ([]token.Key, error) {
+	return s.keys, nil
+}
+
+func (s *memoryKeyStore) CreateSigningKey(_ context.Context, key *token.Key) error {
+	s.keys = append(s.keys, *key)
+	return nil
+}
+
+// testKeyring returns a keyring with a single EdDSA signing key.
+func testKeyring(t *testing.T) *token.Keyring {
+	keys, err := token.NewKeyring(&memoryKeyStore{}, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		config.JWT{Algorithm: token.EdDSA, Issuer: "go-users", Audience: "go-users", AccessTTL: 900},
+		config.SigningKeys{RotationInterval: 7 * 86400, Overlap: 86400, CheckInterval: 60},
+	)
+	require.NoError(t, err)
+	require.NoError(t, keys.Refresh(context.Background()))
+	return keys
+}
+
+// testAuthHandler returns a handler with cheap hashing parameters.
+func testAuthHandler(t *testing.T, repo DB) *UserHandler {
+	hasher, err := password.NewHasher(password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.NoError(t, err)
+
+	return &UserHandler{
+		repo:       repo,
+		hasher:     hasher,
+		policy:     password.Policy{MinLength: 12, MaxLength: 128, MinClasses: 3},
+		lockout:    password.Lockout{Threshold: 3, BaseCooldown: time.Minute, MaxCooldown: time.Hour},
+		sessionTTL: time.Hour,
+		keys:       testKeyring(t),
+
+		mfaIssuer:       "go-users",
+		mfaChallengeTTL: 5 * time.Minute,
+		mfaRetries:      3,
+		recoveryCodes:   4,
+	}
+}
+
+func TestUserHandler_SetUserPassword(t *testing.T) {
+	john := &User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: types.Email("john@example.com")}
+
+	t.Run("Password stored as argon2id hash", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("SetPassword", mock.Anything, userID(1), mock.MatchedBy(func(hash string) bool {
+			match, _, err := handler.hasher.Verify("Correct-Horse-42", hash)
+			return err == nil && match
+		})).Return(nil)
+
+		resp, err := handler.SetUserPassword(context.Background(), SetUserPasswordRequestObject{
+			Id:   userID(1).String(),
+			Body: &SetUserPasswordJSONRequestBody{Password: "Correct-Horse-42"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword204Response{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Policy violations", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+
+		resp, err := handler.SetUserPassword(context.Background(), SetUserPasswordRequestObject{
+			Id:   userID(1).String(),
+			Body: &SetUserPasswordJSONRequestBody{Password: "john"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword400JSONResponse{
+			Error: stringPtr("Password does not satisfy the policy"),
+			Details: &[]string{
+				"must be at least 12 characters long",
+				"must mix at least 3 of lower case letters, upper case letters, digits and other characters",
+				"must not contain the email address",
+			},
+		}, resp)
+		mockRepo.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(2)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.SetUserPassword(context.Background(), SetUserPasswordRequestObject{
+			Id:   userID(2).String(),
+			Body: &SetUserPasswordJSONRequestBody{Password: "Correct-Horse-42"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword404JSONResponse{Error: stringPtr("User not found")}, resp)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.SetUserPassword(context.Background(), SetUserPasswordRequestObject{
+			Id:   "1",
+			Body: &SetUserPasswordJSONRequestBody{Password: "Correct-Horse-42"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword400JSONResponse{Error: stringPtr("Invalid user ID")}, resp)
+	})
+}
+
+func TestUserHandler_SetUserPassword_Self(t *testing.T) {
+	john := &User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: types.Email("john@example.com")}
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()})
+	newPassword := "Battery-Staple-7"
+
+	setup := func(t *testing.T, creds *Credentials) (*UserHandler, *MockUserRepository) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		if creds != nil {
+			mockRepo.On("GetCredentials", mock.Anything, userID(1)).Return(creds, nil)
+		}
+		return handler, mockRepo
+	}
+	request := func(current *string) SetUserPasswordRequestObject {
+		return SetUserPasswordRequestObject{
+			Id:   userID(1).String(),
+			Body: &SetUserPasswordJSONRequestBody{Password: newPassword, CurrentPassword: current},
+		}
+	}
+
+	t.Run("Current password confirmed", func(t *testing.T) {
+		handler, mockRepo := setup(t, nil)
+		hash, err := handler.hasher.Hash("Correct-Horse-42")
+		require.NoError(t, err)
+		mockRepo.On("GetCredentials", mock.Anything, userID(1)).Return(&Credentials{User: *john, PasswordHash: hash}, nil)
+		mockRepo.On("SetPassword", mock.Anything, userID(1), mock.Anything).Return(nil)
+
+		resp, err := handler.SetUserPassword(ctx, request(stringPtr("Correct-Horse-42")))
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword204Response{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Current password missing", func(t *testing.T) {
+		handler, mockRepo := setup(t, nil)
+
+		resp, err := handler.SetUserPassword(ctx, request(nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword400JSONResponse{Error: stringPtr("Missing current password")}, resp)
+		mockRepo.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Current password wrong", func(t *testing.T) {
+		handler, mockRepo := setup(t, nil)
+		hash, err := handler.hasher.Hash("Correct-Horse-42")
+		require.NoError(t, err)
+		mockRepo.On("GetCredentials", mock.Anything, userID(1)).Return(&Credentials{User: *john, PasswordHash: hash}, nil)
+		mockRepo.On("RecordLoginFailure", mock.Anything, userID(1)).Return(3, nil)
+		mockRepo.On("LockCredentials", mock.Anything, userID(1), mock.Anything).Return(nil)
+
+		resp, err := handler.SetUserPassword(ctx, request(stringPtr("Wrong-Horse-42")))
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetUserPassword403JSONResponse{ForbiddenJSONResponse{Error: stringPtr("Current password is incorrect")}}, resp)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		lockedUntil := time.Now().Add(time.Minute)
+		handler, mockRepo := setup(t, &Credentials{User: *john, PasswordHash: "hash", LockedUntil: &lockedUntil})
+
+		resp, err := handler.SetUserPassword(ctx, request(stringPtr("Correct-Horse-42")))
+
+		assert.NoError(t, err)
+		assert.IsType(t, SetUserPassword429JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("No password yet", func(t *testing.T) {
+		handler, mockRepo := setup(t, nil)
+		mockRepo.On("GetCredentials", mock.Anything, userID(1)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.SetUserPassword(ctx, request(stringPtr("Correct-Horse-42")))
+
+		assert.NoError(t, err)
+		assert.IsType(t, SetUserPassword403JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	john := User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: types.Email("john@example.com"), Status: UserStatusActive}
+	body := &LoginJSONRequestBody{Email: "John@Example.com", Password: "Correct-Horse-42"}
+
+	t.Run("Successful login issues tokens", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash(body.Password)
+		require.NoError(t, err)
+
+		var refreshHash []byte
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).
+			Return(&Credentials{User: john, PasswordHash: hash, FailedAttempts: 2}, nil)
+		mockRepo.On("RecordLoginSuccess", mock.Anything, userID(1), "").Return(nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.AnythingOfType("[]uint8"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { refreshHash = args.Get(2).([]byte) }).
+			Return(nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		require.IsType(t, Login200JSONResponse{}, resp)
+		login := resp.(Login200JSONResponse)
+		assert.Equal(t, john, login.User)
+		assert.Equal(t, LoginResponseTokenTypeBearer, login.TokenType)
+		assert.Equal(t, 900, login.ExpiresIn)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), login.RefreshTokenExpiresAt, time.Minute)
+
+		claims, err := handler.keys.Verify(login.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, userID(1).String(), claims.Subject)
+
+		stored, ok := hashOpaqueToken(login.RefreshToken)
+		require.True(t, ok)
+		assert.Equal(t, refreshHash, stored)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("MFA enabled starts a challenge", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash(body.Password)
+		require.NoError(t, err)
+
+		var challengeHash []byte
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).
+			Return(&Credentials{User: john, PasswordHash: hash, MFAEnabled: true}, nil)
+		mockRepo.On("RecordLoginSuccess", mock.Anything, userID(1), "").Return(nil)
+		mockRepo.On("CreateMFAChallenge", mock.Anything, userID(1), mock.AnythingOfType("[]uint8"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { challengeHash = args.Get(2).([]byte) }).
+			Return(nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		require.IsType(t, Login202JSONResponse{}, resp)
+		challenge := resp.(Login202JSONResponse)
+		assert.Equal(t, 300, challenge.ExpiresIn)
+		stored, ok := hashOpaqueToken(challenge.MfaToken)
+		require.True(t, ok)
+		assert.Equal(t, challengeHash, stored)
+		mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Required MFA is reported", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash(body.Password)
+		require.NoError(t, err)
+
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).
+			Return(&Credentials{User: john, PasswordHash: hash, MFARequired: true}, nil)
+		mockRepo.On("RecordLoginSuccess", mock.Anything, userID(1), "").Return(nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		require.IsType(t, Login200JSONResponse{}, resp)
+		assert.Equal(t, boolPtr(true), resp.(Login200JSONResponse).MfaEnrollmentRequired)
+	})
+
+	t.Run("Outdated hash is upgraded", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		outdated, err := password.NewHasher(password.Params{Memory: 32, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+		require.NoError(t, err)
+		hash, err := outdated.Hash(body.Password)
+		require.NoError(t, err)
+
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(&Credentials{User: john, PasswordHash: hash}, nil)
+		mockRepo.On("RecordLoginSuccess", mock.Anything, userID(1), mock.MatchedBy(func(rehash string) bool {
+			match, needsRehash, err := handler.hasher.Verify(body.Password, rehash)
+			return err == nil && match && !needsRehash
+		})).Return(nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.IsType(t, Login200JSONResponse{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Suspended user is rejected", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash(body.Password)
+		require.NoError(t, err)
+
+		suspended := john
+		suspended.Status = UserStatusSuspended
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(&Credentials{User: suspended, PasswordHash: hash}, nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Login403JSONResponse{Error: stringPtr("Account is not active")}, resp)
+		mockRepo.AssertNotCalled(t, "RecordLoginSuccess", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash("Another-Horse-42")
+		require.NoError(t, err)
+
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(&Credentials{User: john, PasswordHash: hash}, nil)
+		mockRepo.On("RecordLoginFailure", mock.Anything, userID(1)).Return(1, nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Login401JSONResponse{Error: stringPtr("Invalid email or password")}, resp)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "LockCredentials", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure threshold locks the account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		hash, err := handler.hasher.Hash("Another-Horse-42")
+		require.NoError(t, err)
+
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(&Credentials{User: john, PasswordHash: hash}, nil)
+		mockRepo.On("RecordLoginFailure", mock.Anything, userID(1)).Return(4, nil)
+		mockRepo.On("LockCredentials", mock.Anything, userID(1), mock.MatchedBy(func(until time.Time) bool {
+			return time.Until(until) > time.Minute && time.Until(until) <= 2*time.Minute
+		})).Return(nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Login401JSONResponse{Error: stringPtr("Invalid email or password")}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Locked account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		lockedUntil := time.Now().Add(90 * time.Second)
+
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).
+			Return(&Credentials{User: john, PasswordHash: "unused", LockedUntil: &lockedUntil}, nil)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		require.IsType(t, Login429JSONResponse{}, resp)
+		locked := resp.(Login429JSONResponse)
+		assert.Equal(t, "Too many failed login attempts", *locked.Body.Error)
+		assert.InDelta(t, 90, locked.Headers.RetryAfter, 1)
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Login401JSONResponse{Error: stringPtr("Invalid email or password")}, resp)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetCredentialsByEmail", mock.Anything, body.Email).Return(nil, errors.New("database connection error"))
+
+		resp, err := handler.Login(context.Background(), LoginRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Login500JSONResponse{Error: stringPtr("Internal server error")}, resp)
+	})
+}
+
+func TestUserHandler_RefreshToken(t *testing.T) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	require.NoError(t, err)
+
+	t.Run("Refresh token is rotated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		var newHash []byte
+		mockRepo.On("RotateRefreshToken", mock.Anything, refreshHash, mock.AnythingOfType("[]uint8"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { newHash = args.Get(2).([]byte) }).
+			Return(userID(1), nil)
+
+		resp, err := handler.RefreshToken(context.Background(), RefreshTokenRequestObject{
+			Body: &RefreshTokenJSONRequestBody{RefreshToken: refreshToken},
+		})
+
+		assert.NoError(t, err)
+		require.IsType(t, RefreshToken200JSONResponse{}, resp)
+		tokens := resp.(RefreshToken200JSONResponse)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+		stored, ok := hashOpaqueToken(tokens.RefreshToken)
+		require.True(t, ok)
+		assert.Equal(t, newHash, stored)
+
+		claims, err := handler.keys.Verify(tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, userID(1).String(), claims.Subject)
+	})
+
+	t.Run("Used or revoked refresh token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("RotateRefreshToken", mock.Anything, refreshHash, mock.Anything, mock.Anything).
+			Return(types.UUID{}, ownErrors.ErrInvalidToken)
+
+		resp, err := handler.RefreshToken(context.Background(), RefreshTokenRequestObject{
+			Body: &RefreshTokenJSONRequestBody{RefreshToken: refreshToken},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RefreshToken401JSONResponse{Error: stringPtr("Invalid refresh token")}, resp)
+	})
+
+	t.Run("Malformed refresh token", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.RefreshToken(context.Background(), RefreshTokenRequestObject{
+			Body: &RefreshTokenJSONRequestBody{RefreshToken: "not a token"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RefreshToken401JSONResponse{Error: stringPtr("Invalid refresh token")}, resp)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("RotateRefreshToken", mock.Anything, refreshHash, mock.Anything, mock.Anything).
+			Return(types.UUID{}, errors.New("database connection error"))
+
+		resp, err := handler.RefreshToken(context.Background(), RefreshTokenRequestObject{
+			Body: &RefreshTokenJSONRequestBody{RefreshToken: refreshToken},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RefreshToken500JSONResponse{Error: stringPtr("Internal server error")}, resp)
+	})
+}
+
+func TestUserHandler_RevokeToken(t *testing.T) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		token          string
+		mockError      error
+		expectedOutput RevokeTokenResponseObject
+	}{
+		{name: "Revoked", token: refreshToken, expectedOutput: RevokeToken204Response{}},
+		{name: "Unknown token", token: refreshToken, mockError: ownErrors.ErrNotFound, expectedOutput: RevokeToken204Response{}},
+		{name: "Malformed token", token: "not a token", expectedOutput: RevokeToken204Response{}},
+		{
+			name:           "Repository error",
+			token:          refreshToken,
+			mockError:      errors.New("database connection error"),
+			expectedOutput: RevokeToken500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			if tc.token == refreshToken {
+				mockRepo.On("RevokeRefreshToken", mock.Anything, refreshHash).Return(tc.mockError)
+			}
+
+			resp, err := handler.RevokeToken(context.Background(), RevokeTokenRequestObject{
+				Body: &RevokeTokenJSONRequestBody{RefreshToken: tc.token},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"go-users/internal/policy"
+	"go-users/internal/router"
+)
+
+// PolicyDecision represents an entry of the policy decision log.
+type PolicyDecision struct {
+	Operation     string
+	PrincipalKind string
+	PrincipalID   string
+	// KeyID is the API key the principal was authenticated with, if any.
+	KeyID string
+	// Resource is the ID of the user the operation targets, if any.
+	Resource  string
+	Allowed   bool
+	Reason    string
+	RequestID string
+}
+
+// enforcePolicy is a strict middleware that evaluates the access policy of an operation before it is handled and
+// records the decision. Operations are looked up by their OpenAPI operationId. Services are granted the scopes of
+// their API key, or every permission for static keys; users are granted the permissions of their roles, limited to
+// the scopes of their personal access token if they used one. Users who are not active are denied every operation, so
+// their access tokens and personal access tokens stop working at once.
+func (h *UserHandler) enforcePolicy(next StrictHandlerFunc, operationID string) StrictHandlerFunc {
+	operation := strings.ToLower(operationID[:1]) + operationID[1:]
+
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		if rule, ok := h.access.Rule(operation); ok && rule.Public {
+			return next(ctx, w, r, request)
+		}
+
+		in := policy.Input{Operation: operation}
+		entry := PolicyDecision{Operation: operation, RequestID: middleware.GetReqID(ctx)}
+
+		principal, ok := router.PrincipalFromContext(ctx)
+		if ok {
+			in.Authenticated = true
+			entry.PrincipalKind = string(principal.Kind)
+			entry.PrincipalID = principal.ID
+			entry.KeyID = principal.KeyID
+			in.Scopes = principal.Scopes
+		}
+
+		target, hasTarget := targetUserID(request)
+		if hasTarget {
+			entry.Resource = target
+			if id, err := h.resolveUserID(ctx, target); err == nil {
+				entry.Resource = id.String()
+			}
+		}
+
+		switch {
+		case !ok:
+		case principal.Kind == router.PrincipalService && principal.Scopes != nil:
+			in.Permissions = principal.Scopes
+		case principal.Kind == router.PrincipalService:
+			in.Permissions = policy.AllPermissions()
+		case principal.Kind == router.PrincipalUser:
+			uid, err := uuid.Parse(principal.ID)
+			if err != nil {
+				break
+			}
+			in.Self = hasTarget && entry.Resource == uid.String()
+			active, err := h.userIsActive(ctx, uid)
+			if err == nil {
+				in.Inactive = !active
+				in.Permissions, err = h.repo.GetUserPermissions(ctx, uid)
+			}
+			if err != nil {
+				h.logger.Error("Failed to load user permissions", "error", err)
+				writeError(w, http.StatusInternalServerError, "Internal server error")
+				return nil, nil
+			}
+		}
+
+		decision := h.access.Evaluate(in)
+		entry.Allowed = decision.Allowed
+		entry.Reason = decision.Reason
+
+		// A decision that cannot be recorded is still enforced.
+		if err := h.repo.LogPolicyDecision(ctx, &entry); err != nil {
+			h.logger.Error("Failed to record policy decision", "error", err, "operation", operation,
+				"principal", entry.PrincipalID, "allowed", entry.Allowed)
+		}
+
+		switch {
+		case decision.Allowed:
+			return next(ctx, w, r, request)
+		case !in.Authenticated:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "Authentication required")
+		case in.Inactive:
+			writeError(w, http.StatusUnauthorized, "Account is not active")
+		default:
+			writeError(w, http.StatusForbidden, "Permission denied")
+		}
+		return nil, nil
+	}
+}
+
+// targetUserID returns the user ID from the path of operations on a single user.
+func targetUserID(request interface{}) (UserID, bool) {
+	switch r := request.(type) {
+	case GetUserRequestObject:
+		return r.Id, true
+	case PutUserRequestObject:
+		return r.Id, true
+	case SetUserPasswordRequestObject:
+		return r.Id, true
+	case GetUserRolesRequestObject:
+		return r.Id, true
+	case AssignUserRoleRequestObject:
+		return r.Id, true
+	case RevokeUserRoleRequestObject:
+		return r.Id, true
+	case ListUserAPIKeysRequestObject:
+		return r.Id, true
+	case CreateUserAPIKeyRequestObject:
+		return r.Id, true
+	case RevokeUserAPIKeyRequestObject:
+		return r.Id, true
+	case RotateUserAPIKeyRequestObject:
+		return r.Id, true
+	case GetMfaStatusRequestObject:
+		return r.Id, true
+	case EnrollTotpRequestObject:
+		return r.Id, true
+	case ConfirmTotpRequestObject:
+		return r.Id, true
+	case ResetMfaRequestObject:
+		return r.Id, true
+	case SendEmailVerificationRequestObject:
+		return r.Id, true
+	case SuspendUserRequestObject:
+		return r.Id, true
+	case ReactivateUserRequestObject:
+		return r.Id, true
+	case DeactivateUserRequestObject:
+		return r.Id, true
+	case ListUserStatusEventsRequestObject:
+		return r.Id, true
+	case ListUserGroupsRequestObject:
+		return r.Id, true
+	case CheckGroupMembershipRequestObject:
+		return r.Id, true
+	case AddGroupUserRequestObject:
+		return r.Id, true
+	case RemoveGroupUserRequestObject:
+		return r.Id, true
+	case PutUserAvatarRequestObject:
+		return r.Id, true
+	case DeleteUserAvatarRequestObject:
+		return r.Id, true
+	case ExportUserDataRequestObject:
+		return r.Id, true
+	case EraseUserRequestObject:
+		return r.Id, true
+	case ListLegalHoldsRequestObject:
+		return r.Id, true
+	case PlaceLegalHoldRequestObject:
+		return r.Id, true
+	case ReleaseLegalHoldRequestObject:
+		return r.Id, true
+	}
+	return "", false
+}
+
+// grantedPermissions returns the permissions the principal of ctx holds, the same way enforcePolicy grants them:
+// every permission for static keys, the scopes of other API keys, and the permissions of their roles for users,
+// limited to the scopes of their personal access token if they used one.
+func (h *UserHandler) grantedPermissions(ctx context.Context) ([]string, error) {
+	principal, ok := router.PrincipalFromContext(ctx)
+	if !ok {
+		return []string{}, nil
+	}
+
+	granted := policy.AllPermissions()
+	if principal.Kind == router.PrincipalUser {
+		uid, err := uuid.Parse(principal.ID)
+		if err != nil {
+			return []string{}, nil
+		}
+		if granted, err = h.repo.GetUserPermissions(ctx, uid); err != nil {
+			return nil, err
+		}
+	}
+	if principal.Scopes == nil {
+		return granted, nil
+	}
+
+	scoped := make([]string, 0, len(principal.Scopes))
+	for _, p := range granted {
+		if slices.Contains(principal.Scopes, p) {
+			scoped = append(scoped, p)
+		}
+	}
+	return scoped, nil
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/policy"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_EnforcePolicy(t *testing.T) {
+	user := func(n int) *router.Principal {
+		return &router.Principal{Kind: router.PrincipalUser, ID: userID(n).String()}
+	}
+	service := &router.Principal{Kind: router.PrincipalService, ID: "ci", KeyID: "ci"}
+
+	tests := []struct {
+		name           string
+		operationID    string
+		principal      *router.Principal
+		request        interface{}
+		status         UserStatus
+		permissions    []string
+		permissionsErr error
+		logErr         error
+		expectedStatus int
+		expectedEntry  *PolicyDecision
+	}{
+		{
+			name:           "Public operation is not logged",
+			operationID:    "Health",
+			request:        HealthRequestObject{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "User updates themselves",
+			operationID:    "PutUser",
+			principal:      user(1),
+			request:        PutUserRequestObject{Id: userID(1).String()},
+			permissions:    []string{},
+			expectedStatus: http.StatusOK,
+			expectedEntry: &PolicyDecision{Operation: "putUser", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				Resource: userID(1).String(), Allowed: true, Reason: "own user"},
+		},
+		{
+			name:           "Suspended user is rejected",
+			operationID:    "PutUser",
+			principal:      user(1),
+			request:        PutUserRequestObject{Id: userID(1).String()},
+			status:         UserStatusSuspended,
+			permissions:    []string{policy.UsersWrite},
+			expectedStatus: http.StatusUnauthorized,
+			expectedEntry: &PolicyDecision{Operation: "putUser", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				Resource: userID(1).String(), Reason: "user not active"},
+		},
+		{
+			name:           "User updates another user",
+			operationID:    "PutUser",
+			principal:      user(1),
+			request:        PutUserRequestObject{Id: userID(2).String()},
+			permissions:    []string{policy.UsersRead},
+			expectedStatus: http.StatusForbidden,
+			expectedEntry: &PolicyDecision{Operation: "putUser", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				Resource: userID(2).String(), Reason: "missing permission users:write"},
+		},
+		{
+			name:           "Support agent updates another user",
+			operationID:    "PutUser",
+			principal:      user(1),
+			request:        PutUserRequestObject{Id: userID(2).String()},
+			permissions:    []string{policy.UsersRead, policy.UsersWrite},
+			expectedStatus: http.StatusOK,
+			expectedEntry: &PolicyDecision{Operation: "putUser", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				Resource: userID(2).String(), Allowed: true, Reason: "granted users:write"},
+		},
+		{
+			name:           "Service is granted every permission",
+			operationID:    "BatchUsers",
+			principal:      service,
+			request:        BatchUsersRequestObject{},
+			expectedStatus: http.StatusOK,
+			expectedEntry: &PolicyDecision{Operation: "batchUsers", PrincipalKind: "service", PrincipalID: "ci",
+				KeyID: "ci", Allowed: true, Reason: "granted users:write, users:delete"},
+		},
+		{
+			name:           "Service key is granted its scopes",
+			operationID:    "SearchUsers",
+			principal:      &router.Principal{Kind: router.PrincipalService, ID: "k1", KeyID: "k1", Scopes: []string{policy.UsersRead}},
+			request:        SearchUsersRequestObject{},
+			expectedStatus: http.StatusOK,
+			expectedEntry: &PolicyDecision{Operation: "searchUsers", PrincipalKind: "service", PrincipalID: "k1",
+				KeyID: "k1", Allowed: true, Reason: "granted users:read"},
+		},
+		{
+			name:        "Personal access token is limited to its scopes",
+			operationID: "PutUser",
+			principal: &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String(), KeyID: "k2",
+				Scopes: []string{policy.UsersRead}},
+			request:        PutUserRequestObject{Id: userID(1).String()},
+			permissions:    []string{policy.UsersRead, policy.UsersWrite},
+			expectedStatus: http.StatusForbidden,
+			expectedEntry: &PolicyDecision{Operation: "putUser", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				KeyID: "k2", Resource: userID(1).String(), Reason: "missing scope users:write"},
+		},
+		{
+			name:           "Missing principal",
+			operationID:    "SearchUsers",
+			request:        SearchUsersRequestObject{},
+			expectedStatus: http.StatusUnauthorized,
+			expectedEntry:  &PolicyDecision{Operation: "searchUsers", Reason: "not authenticated"},
+		},
+		{
+			name:           "Permissions cannot be loaded",
+			operationID:    "SearchUsers",
+			principal:      user(1),
+			request:        SearchUsersRequestObject{},
+			permissionsErr: errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Decision is enforced when it cannot be recorded",
+			operationID:    "SearchUsers",
+			principal:      user(1),
+			request:        SearchUsersRequestObject{},
+			permissions:    []string{},
+			logErr:         errors.New("connection refused"),
+			expectedStatus: http.StatusForbidden,
+			expectedEntry: &PolicyDecision{Operation: "searchUsers", PrincipalKind: "user", PrincipalID: userID(1).String(),
+				Reason: "missing permission users:read"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{
+				repo:   mockRepo,
+				logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+				access: policy.New(policy.DefaultRules()),
+			}
+			if tc.principal != nil && tc.principal.Kind == router.PrincipalUser {
+				status := tc.status
+				if status == "" {
+					status = UserStatusActive
+				}
+				mockRepo.On("GetUserStatus", mock.Anything, userID(1)).Return(status, nil)
+				mockRepo.On("GetUserPermissions", mock.Anything, userID(1)).Return(tc.permissions, tc.permissionsErr)
+			}
+			if tc.expectedEntry != nil {
+				mockRepo.On("LogPolicyDecision", mock.Anything, tc.expectedEntry).Return(tc.logErr)
+			}
+
+			called := false
+			next := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+				return nil, nil
+			}
+
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = router.WithPrincipal(ctx, tc.principal)
+			}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+			_, err := handler.enforcePolicy(next, tc.operationID)(ctx, rec, req, tc.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, called)
+			mockRepo.AssertExpectations(t)
+			if tc.expectedEntry == nil {
+				mockRepo.AssertNotCalled(t, "LogPolicyDecision", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/avatar"
+	"go-users/internal/ownErrors"
+)
+
+// avatarCacheControl allows caching avatars forever, as every upload gets a new URL.
+const avatarCacheControl = "public, max-age=31536000, immutable"
+
+// errMissingAvatarFile is returned for uploads without a file part.
+var errMissingAvatarFile = errors.New("missing file")
+
+// PutUserAvatar processes an uploaded image and makes it the avatar of a user
+func (h *UserHandler) PutUserAvatar(ctx context.Context, request PutUserAvatarRequestObject) (PutUserAvatarResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err != nil {
+		return putUserAvatarError(err), nil
+	}
+
+	data, err := readAvatarFile(request.Body, h.avatarLimits.MaxSize)
+	if err != nil {
+		return putUserAvatarError(err), nil
+	}
+
+	processed, err := avatar.Process(data, h.avatarLimits)
+	if err != nil {
+		return putUserAvatarError(err), nil
+	}
+
+	for _, v := range processed.Variants {
+		if err = h.blobs.Put(ctx, avatar.Key(id.String(), processed.Version, v.Size), v.Data, v.ContentType); err != nil {
+			h.logger.Error("Failed to store avatar", "error", err, "user", id)
+			return putUserAvatarError(err), nil
+		}
+	}
+
+	url := strings.TrimSuffix(h.avatarURL, "/") + "/" + id.String() + "/" + processed.Version
+	user, previous, err := h.repo.SetAvatar(ctx, id, &processed.Version, &url)
+	if err != nil {
+		// The variants of a user who no longer exists are deleted. Otherwise they are kept, as the same image may
+		// already be the current avatar.
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			h.deleteAvatarBlobs(ctx, id, processed.Version)
+		}
+		return putUserAvatarError(err), nil
+	}
+
+	if previous != nil && *previous != processed.Version {
+		h.deleteAvatarBlobs(ctx, id, *previous)
+	}
+
+	return PutUserAvatar200JSONResponse(*user), nil
+}
+
+// putUserAvatarError maps an error of PutUserAvatar to its response.
+func putUserAvatarError(err error) PutUserAvatarResponseObject {
+	if errors.Is(err, errInvalidUserID) {
+		errorMsg := "Invalid user ID"
+		return PutUserAvatar400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, errMissingAvatarFile) {
+		errorMsg := "Missing file"
+		return PutUserAvatar400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		errorMsg := "User not found"
+		return PutUserAvatar404JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrImageTooLarge) {
+		errorMsg := "Image too large"
+		details := []string{err.Error()}
+		return PutUserAvatar413JSONResponse{
+			Error:   &errorMsg,
+			Details: &details,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrUnsupportedImage) {
+		errorMsg := "Unsupported image format, use JPEG, PNG or WebP"
+		return PutUserAvatar415JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+
+	errorMsg := "Internal server error"
+	return PutUserAvatar500JSONResponse{
+		Error: &errorMsg,
+	}
+}
+
+// readAvatarFile returns the content of the file part of an upload. Returns ErrImageTooLarge if it has more than
+// maxSize bytes, without reading further.
+func readAvatarFile(r *multipart.Reader, maxSize int64) ([]byte, error) {
+	if r == nil {
+		return nil, errMissingAvatarFile
+	}
+
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errMissingAvatarFile
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errMissingAvatarFile, err)
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errMissingAvatarFile, err)
+		}
+		if int64(len(data)) > maxSize {
+			return nil, fmt.Errorf("%w: more than %d bytes", ownErrors.ErrImageTooLarge, maxSize)
+		}
+		return data, nil
+	}
+}
+
+// DeleteUserAvatar removes the uploaded avatar of a user
+func (h *UserHandler) DeleteUserAvatar(ctx context.Context, request DeleteUserAvatarRequestObject) (DeleteUserAvatarResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	var previous *string
+	if err == nil {
+		_, previous, err = h.repo.SetAvatar(ctx, id, nil, nil)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return DeleteUserAvatar400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return DeleteUserAvatar404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return DeleteUserAvatar500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	if previous != nil {
+		h.deleteAvatarBlobs(ctx, id, *previous)
+	}
+
+	return DeleteUserAvatar204Response{}, nil
+}
+
+// deleteAvatarBlobs deletes the variants of an avatar. Failures are only logged: the avatar is no longer referenced,
+// so a leftover blob just takes up space.
+func (h *UserHandler) deleteAvatarBlobs(ctx context.Context, id openapi_types.UUID, version string) {
+	for _, size := range avatar.Sizes {
+		if err := h.blobs.Delete(ctx, avatar.Key(id.String(), version, size)); err != nil {
+			h.logger.Error("Failed to delete avatar", "error", err, "user", id, "version", version)
+		}
+	}
+}
+
+// GetAvatar serves a variant of an uploaded avatar
+func (h *UserHandler) GetAvatar(ctx context.Context, request GetAvatarRequestObject) (GetAvatarResponseObject, error) {
+	size := avatar.DefaultSize
+	if request.Params.Size != nil {
+		size = int(*request.Params.Size)
+	}
+	if !avatar.ValidSize(size) {
+		errorMsg := "Invalid size"
+		return GetAvatar400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	// Variants never change, so the version and size identify the content.
+	etag := `"` + request.Version + "-" + strconv.Itoa(size) + `"`
+	if request.Params.IfNoneMatch != nil && etagMatches(*request.Params.IfNoneMatch, etag) {
+		return GetAvatar304Response{
+			Headers: GetAvatar304ResponseHeaders{CacheControl: avatarCacheControl, ETag: etag},
+		}, nil
+	}
+
+	obj, err := h.blobs.Get(ctx, avatar.Key(request.UserId.String(), request.Version, size))
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Avatar not found"
+			return GetAvatar404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		h.logger.Error("Failed to load avatar", "error", err, "user", request.UserId)
+		errorMsg := "Internal server error"
+		return GetAvatar500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetAvatar200ImageResponse{
+		Body:          bytes.NewReader(obj.Data),
+		ContentType:   obj.ContentType,
+		ContentLength: int64(len(obj.Data)),
+		Headers:       GetAvatar200ResponseHeaders{CacheControl: avatarCacheControl, ETag: etag},
+	}, nil
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, also as weak validator, or is "*".
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
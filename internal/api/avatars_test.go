@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/avatar"
+	"go-users/internal/ownErrors"
+)
+
+// avatarUpload returns a multipart body with data as file part.
+func avatarUpload(t *testing.T, field string, data []byte) *multipart.Reader {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return multipart.NewReader(&buf, w.Boundary())
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 30))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUserHandler_PutUserAvatar(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Variants stored and previous avatar deleted", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, store := testHandler(t, mockRepo)
+		data := testPNG(t)
+		processed, err := avatar.Process(data, handler.avatarLimits)
+		require.NoError(t, err)
+
+		previous := "fedcba9876543210"
+		for _, size := range avatar.Sizes {
+			require.NoError(t, store.Put(ctx, avatar.Key(userID(1).String(), previous, size), []byte("old"), "image/png"))
+		}
+		url := "https://users.example.com/api/v1/avatars/" + userID(1).String() + "/" + processed.Version
+		user := &User{Id: userID(1), AvatarUrl: &url}
+		mockRepo.On("SetAvatar", mock.Anything, userID(1), &processed.Version, &url).Return(user, &previous, nil)
+
+		resp, err := handler.PutUserAvatar(ctx, PutUserAvatarRequestObject{
+			Id:   userID(1).String(),
+			Body: avatarUpload(t, "file", data),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, PutUserAvatar200JSONResponse(*user), resp)
+		for _, size := range avatar.Sizes {
+			obj, err := store.Get(ctx, avatar.Key(userID(1).String(), processed.Version, size))
+			require.NoError(t, err)
+			assert.Equal(t, "image/jpeg", obj.ContentType)
+
+			_, err = store.Get(ctx, avatar.Key(userID(1).String(), previous, size))
+			assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		}
+	})
+
+	t.Run("Rejected uploads", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			field    string
+			data     []byte
+			maxSize  int64
+			expected PutUserAvatarResponseObject
+		}{
+			{name: "Unsupported format", field: "file", data: []byte("GIF89a"), maxSize: 1 << 20, expected: PutUserAvatar415JSONResponse{}},
+			{name: "Too large", field: "file", data: testPNG(t), maxSize: 100, expected: PutUserAvatar413JSONResponse{}},
+			{name: "Missing file", field: "image", data: testPNG(t), maxSize: 1 << 20, expected: PutUserAvatar400JSONResponse{}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				handler, _ := testHandler(t, mockRepo)
+				handler.avatarLimits.MaxSize = tc.maxSize
+
+				resp, err := handler.PutUserAvatar(ctx, PutUserAvatarRequestObject{
+					Id:   userID(1).String(),
+					Body: avatarUpload(t, tc.field, tc.data),
+				})
+
+				require.NoError(t, err)
+				assert.IsType(t, tc.expected, resp)
+				mockRepo.AssertNotCalled(t, "SetAvatar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, store := testHandler(t, mockRepo)
+		data := testPNG(t)
+		processed, err := avatar.Process(data, handler.avatarLimits)
+		require.NoError(t, err)
+		mockRepo.On("SetAvatar", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil, nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.PutUserAvatar(ctx, PutUserAvatarRequestObject{
+			Id:   userID(1).String(),
+			Body: avatarUpload(t, "file", data),
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, PutUserAvatar404JSONResponse{}, resp)
+		_, err = store.Get(ctx, avatar.Key(userID(1).String(), processed.Version, avatar.DefaultSize))
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound, "the variants are deleted again")
+	})
+}
+
+func TestUserHandler_DeleteUserAvatar(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockUserRepository)
+	handler, store := testHandler(t, mockRepo)
+	version := "0123456789abcdef"
+	key := avatar.Key(userID(1).String(), version, 64)
+	require.NoError(t, store.Put(ctx, key, []byte("old"), "image/png"))
+	mockRepo.On("SetAvatar", mock.Anything, userID(1), (*string)(nil), (*string)(nil)).Return(&User{Id: userID(1)}, &version, nil)
+
+	resp, err := handler.DeleteUserAvatar(ctx, DeleteUserAvatarRequestObject{Id: userID(1).String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, DeleteUserAvatar204Response{}, resp)
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+}
+
+func TestUserHandler_GetAvatar(t *testing.T) {
+	ctx := context.Background()
+	handler, store := testHandler(t, new(MockUserRepository))
+	version := "0123456789abcdef"
+	require.NoError(t, store.Put(ctx, avatar.Key(userID(1).String(), version, 128), testPNG(t), "image/png"))
+	size := func(s int) *GetAvatarParamsSize {
+		size := GetAvatarParamsSize(s)
+		return &size
+	}
+
+	t.Run("Default size served with cache headers", func(t *testing.T) {
+		resp, err := handler.GetAvatar(ctx, GetAvatarRequestObject{UserId: userID(1), Version: version})
+
+		require.NoError(t, err)
+		require.IsType(t, GetAvatar200ImageResponse{}, resp)
+		image := resp.(GetAvatar200ImageResponse)
+		assert.Equal(t, "image/png", image.ContentType)
+		assert.Equal(t, GetAvatar200ResponseHeaders{CacheControl: avatarCacheControl, ETag: `"0123456789abcdef-128"`}, image.Headers)
+	})
+
+	t.Run("Cached copy current", func(t *testing.T) {
+		etag := `W/"0123456789abcdef-128"`
+		resp, err := handler.GetAvatar(ctx, GetAvatarRequestObject{UserId: userID(1), Version: version,
+			Params: GetAvatarParams{IfNoneMatch: &etag}})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetAvatar304Response{}, resp)
+	})
+
+	t.Run("Missing variant", func(t *testing.T) {
+		resp, err := handler.GetAvatar(ctx, GetAvatarRequestObject{UserId: userID(1), Version: version,
+			Params: GetAvatarParams{Size: size(512)}})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetAvatar404JSONResponse{}, resp)
+	})
+
+	t.Run("Invalid size", func(t *testing.T) {
+		resp, err := handler.GetAvatar(ctx, GetAvatarRequestObject{UserId: userID(1), Version: version,
+			Params: GetAvatarParams{Size: size(100)}})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetAvatar400JSONResponse{}, resp)
+	})
+}
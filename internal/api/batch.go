@@ -0,0 +1,363 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/ownErrors"
+)
+
+const (
+	// maxBatchOperations is the maximum number of operations accepted in a single batch request.
+	maxBatchOperations = 1000
+	// maxBatchGetQueryIDs is the maximum number of IDs accepted in the query string of a batch get request.
+	maxBatchGetQueryIDs = 100
+	// maxBatchGetBodyIDs is the maximum number of IDs accepted in the body of a batch get request.
+	maxBatchGetBodyIDs = 1000
+)
+
+// BatchOperationResult represents the outcome of a single batch operation as reported by the repository.
+type BatchOperationResult struct {
+	User *User
+	Err  error
+}
+
+// BatchUsers executes an ordered list of user operations in a single transaction
+func (h *UserHandler) BatchUsers(ctx context.Context, request BatchUsersRequestObject) (BatchUsersResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return BatchUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	ops := request.Body.Operations
+	if len(ops) == 0 || len(ops) > maxBatchOperations {
+		errorMsg := fmt.Sprintf("Batch must contain between 1 and %d operations", maxBatchOperations)
+		return BatchUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	continueOnError := request.Body.ContinueOnError != nil && *request.Body.ContinueOnError
+
+	results := make([]BatchResult, len(ops))
+	valid := make([]BatchOperation, 0, len(ops))
+	indexes := make([]int, 0, len(ops))
+	for i := range ops {
+		results[i].Index = i
+		if errorMsg := h.validateBatchOperation(&ops[i]); errorMsg != "" {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = &errorMsg
+			continue
+		}
+		valid = append(valid, ops[i])
+		indexes = append(indexes, i)
+	}
+
+	// Invalid operations abort an atomic batch before anything is sent to the database.
+	if len(valid) == 0 || (len(valid) < len(ops) && !continueOnError) {
+		return BatchUsers200JSONResponse{
+			Committed: false,
+			Results:   abortBatchResults(results),
+		}, nil
+	}
+
+	err := h.resolveBatchOperationIDs(ctx, valid)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return BatchUsers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	opResults, committed, err := h.repo.RunBatch(ctx, valid, continueOnError)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return BatchUsers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	for j := range opResults {
+		results[indexes[j]] = batchResult(indexes[j], valid[j].Method, opResults[j])
+	}
+
+	if !committed {
+		results = abortBatchResults(results)
+	} else {
+		h.notifyBatchEmailVerifications(ctx, valid, opResults)
+	}
+
+	return BatchUsers200JSONResponse{
+		Committed: committed,
+		Results:   results,
+	}, nil
+}
+
+// BatchGetUsers returns the users matching the IDs passed in the query string
+func (h *UserHandler) BatchGetUsers(ctx context.Context, request BatchGetUsersRequestObject) (BatchGetUsersResponseObject, error) {
+	if len(request.Params.Ids) == 0 || len(request.Params.Ids) > maxBatchGetQueryIDs {
+		errorMsg := fmt.Sprintf("Between 1 and %d ids are required", maxBatchGetQueryIDs)
+		return BatchGetUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	resp, err := h.batchGetUsers(ctx, request.Params.Ids)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return BatchGetUsers400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return BatchGetUsers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return BatchGetUsers200JSONResponse(*resp), nil
+}
+
+// BatchGetUsersPost returns the users matching the IDs passed in the request body
+func (h *UserHandler) BatchGetUsersPost(ctx context.Context, request BatchGetUsersPostRequestObject) (BatchGetUsersPostResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return BatchGetUsersPost400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	if len(request.Body.Ids) == 0 || len(request.Body.Ids) > maxBatchGetBodyIDs {
+		errorMsg := fmt.Sprintf("Between 1 and %d ids are required", maxBatchGetBodyIDs)
+		return BatchGetUsersPost400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	resp, err := h.batchGetUsers(ctx, request.Body.Ids)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return BatchGetUsersPost400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return BatchGetUsersPost500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return BatchGetUsersPost200JSONResponse(*resp), nil
+}
+
+// batchGetUsers looks up the deduplicated IDs with a single repository call and returns the found users in request
+// order together with the IDs that do not exist. Returns errInvalidUserID if any ID is malformed.
+func (h *UserHandler) batchGetUsers(ctx context.Context, ids []UserID) (*BatchGetResponse, error) {
+	resolved, err := h.resolveUserIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make([]openapi_types.UUID, 0, len(resolved))
+	seen := make(map[openapi_types.UUID]struct{}, len(resolved))
+	for _, id := range ids {
+		uid, ok := resolved[id]
+		if !ok {
+			continue
+		}
+		if _, ok = seen[uid]; ok {
+			continue
+		}
+		seen[uid] = struct{}{}
+		unique = append(unique, uid)
+	}
+
+	users, err := h.repo.GetUsersByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[openapi_types.UUID]User, len(users))
+	for _, user := range users {
+		byID[user.Id] = user
+	}
+
+	resp := &BatchGetResponse{
+		Users:   make([]User, 0, len(users)),
+		Missing: make([]UserID, 0),
+	}
+	// A user requested several times, possibly by both its UUID and its legacy ID, is returned once.
+	requested := make(map[UserID]struct{}, len(ids))
+	returned := make(map[openapi_types.UUID]struct{}, len(users))
+	for _, id := range ids {
+		if _, ok := requested[id]; ok {
+			continue
+		}
+		requested[id] = struct{}{}
+
+		user, ok := byID[resolved[id]]
+		if !ok {
+			resp.Missing = append(resp.Missing, id)
+			continue
+		}
+		if _, ok = returned[user.Id]; ok {
+			continue
+		}
+		returned[user.Id] = struct{}{}
+		resp.Users = append(resp.Users, user)
+	}
+
+	return resp, nil
+}
+
+// resolveBatchOperationIDs replaces the user IDs of validated operations by UUIDs. Operations on unknown legacy IDs get
+// the nil UUID, which matches no user, so the repository reports them as not found.
+func (h *UserHandler) resolveBatchOperationIDs(ctx context.Context, ops []BatchOperation) error {
+	ids := make([]UserID, 0, len(ops))
+	for _, op := range ops {
+		if op.Id != nil {
+			ids = append(ids, *op.Id)
+		}
+	}
+
+	resolved, err := h.resolveUserIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range ops {
+		if ops[i].Id == nil {
+			continue
+		}
+		id := resolved[*ops[i].Id].String()
+		ops[i].Id = &id
+	}
+
+	return nil
+}
+
+// notifyBatchEmailVerifications sends the verification mails of a committed batch, like PostUser and PutUser do, to the
+// created users and the updated users with a pending address.
+func (h *UserHandler) notifyBatchEmailVerifications(ctx context.Context, ops []BatchOperation, opResults []BatchOperationResult) {
+	for j, r := range opResults {
+		if r.Err != nil || r.User == nil {
+			continue
+		}
+		switch ops[j].Method {
+		case BatchOperationMethodCreate:
+			h.notifyEmailVerification(ctx, r.User)
+		case BatchOperationMethodUpdate:
+			if r.User.PendingEmail != nil {
+				h.notifyEmailVerification(ctx, r.User)
+			}
+		}
+	}
+}
+
+// validateBatchOperation checks that an operation carries the fields required by its method and returns an error
+// message if it does not.
+func (h *UserHandler) validateBatchOperation(op *BatchOperation) string {
+	switch op.Method {
+	case BatchOperationMethodCreate:
+		if op.User == nil {
+			return "Create operation requires user"
+		}
+	case BatchOperationMethodUpdate:
+		if op.Id == nil || op.User == nil {
+			return "Update operation requires id and user"
+		}
+	case BatchOperationMethodDelete:
+		if op.Id == nil {
+			return "Delete operation requires id"
+		}
+	default:
+		return fmt.Sprintf("Unknown method %q", op.Method)
+	}
+
+	if op.Id != nil {
+		if _, _, err := h.parseUserID(*op.Id); err != nil {
+			return "Invalid user ID"
+		}
+	}
+
+	return ""
+}
+
+// batchResult converts the repository outcome of an operation into its API representation.
+func batchResult(index int, method BatchOperationMethod, r BatchOperationResult) BatchResult {
+	result := BatchResult{Index: index}
+
+	var errorMsg string
+	switch {
+	case r.Err == nil:
+		result.User = r.User
+		switch method {
+		case BatchOperationMethodCreate:
+			result.Status = http.StatusCreated
+		case BatchOperationMethodDelete:
+			result.Status = http.StatusNoContent
+		default:
+			result.Status = http.StatusOK
+		}
+		return result
+	case errors.Is(r.Err, ownErrors.ErrNotFound):
+		result.Status = http.StatusNotFound
+		errorMsg = "User not found"
+	case errors.Is(r.Err, ownErrors.ErrInvalidEmail):
+		result.Status = http.StatusBadRequest
+		errorMsg = "Invalid email address"
+	case errors.Is(r.Err, ownErrors.ErrUserAlreadyExists):
+		result.Status = http.StatusConflict
+		errorMsg = "User already exists"
+	case errors.Is(r.Err, ownErrors.ErrLegalHold):
+		result.Status = http.StatusConflict
+		errorMsg = "User is under legal hold"
+	case errors.Is(r.Err, ownErrors.ErrInvalidProfile):
+		result.Status = http.StatusBadRequest
+		errorMsg = "Invalid profile"
+		if problems, ok := profileProblems(r.Err); ok {
+			errorMsg += ": " + strings.Join(problems, "; ")
+		}
+	case errors.Is(r.Err, ownErrors.ErrInvalidAttributes):
+		result.Status = http.StatusBadRequest
+		errorMsg = "Invalid attributes"
+		if problems, ok := attributeProblems(r.Err); ok {
+			errorMsg += ": " + strings.Join(problems, "; ")
+		}
+	default:
+		result.Status = http.StatusInternalServerError
+		errorMsg = "Internal server error"
+	}
+
+	result.Error = &errorMsg
+	return result
+}
+
+// abortBatchResults marks every operation of a rolled back batch that did not fail itself as a failed dependency.
+func abortBatchResults(results []BatchResult) []BatchResult {
+	for i := range results {
+		var errorMsg string
+		switch {
+		case results[i].Status == 0:
+			errorMsg = "Operation not executed"
+		case results[i].Status < http.StatusBadRequest:
+			errorMsg = "Operation rolled back"
+		default:
+			continue
+		}
+		results[i].Status = http.StatusFailedDependency
+		results[i].User = nil
+		results[i].Error = &errorMsg
+	}
+
+	return results
+}
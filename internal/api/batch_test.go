@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestUserHandler_BatchUsers(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	john := &User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: types.Email("john@example.com"), CreatedAt: fixedTime, UpdatedAt: fixedTime}
+	jane := &User{Id: userID(2), FirstName: "Jane", LastName: "Doe", Email: types.Email("jane@example.com"), CreatedAt: fixedTime, UpdatedAt: fixedTime}
+	pending := types.Email("jane.new@example.com")
+	janeMoving := &User{Id: userID(2), FirstName: "Jane", LastName: "Doe", Email: types.Email("jane@example.com"), PendingEmail: &pending, EmailVerifiedAt: &fixedTime}
+
+	id := func(v string) *UserID { return &v }
+	create := BatchOperation{Method: BatchOperationMethodCreate, User: &UserRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com"}}
+	update := BatchOperation{Method: BatchOperationMethodUpdate, Id: id(userID(2).String()), User: &UserRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}}
+	remove := BatchOperation{Method: BatchOperationMethodDelete, Id: id(userID(3).String())}
+	invalid := BatchOperation{Method: BatchOperationMethodUpdate, Id: id(userID(4).String())}
+	legacyRemove := BatchOperation{Method: BatchOperationMethodDelete, Id: id("3")}
+	unknownLegacyRemove := BatchOperation{Method: BatchOperationMethodDelete, Id: id("5")}
+	unknownRemove := BatchOperation{Method: BatchOperationMethodDelete, Id: id(uuid.Nil.String())}
+
+	testCases := []struct {
+		name            string
+		legacyIDs       bool
+		legacyUIDs      map[uint]types.UUID
+		body            *BatchUsersJSONRequestBody
+		expectedOps     []BatchOperation
+		continueOnError bool
+		mockResults     []BatchOperationResult
+		mockCommitted   bool
+		mockError       error
+		expectedOutput  BatchUsersResponseObject
+		expectedMails   []string
+	}{
+		{
+			name:          "All operations committed",
+			body:          &BatchUsersJSONRequestBody{Operations: []BatchOperation{create, update, remove}},
+			expectedOps:   []BatchOperation{create, update, remove},
+			mockResults:   []BatchOperationResult{{User: john}, {User: jane}, {}},
+			mockCommitted: true,
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: true,
+				Results: []BatchResult{
+					{Index: 0, Status: 201, User: john},
+					{Index: 1, Status: 200, User: jane},
+					{Index: 2, Status: 204},
+				},
+			},
+			expectedMails: []string{"john@example.com"},
+		},
+		{
+			name:          "Address changes are verified",
+			body:          &BatchUsersJSONRequestBody{Operations: []BatchOperation{update}},
+			expectedOps:   []BatchOperation{update},
+			mockResults:   []BatchOperationResult{{User: janeMoving}},
+			mockCommitted: true,
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: true,
+				Results:   []BatchResult{{Index: 0, Status: 200, User: janeMoving}},
+			},
+			expectedMails: []string{"jane.new@example.com"},
+		},
+		{
+			name:          "Failing operation rolls back the batch",
+			body:          &BatchUsersJSONRequestBody{Operations: []BatchOperation{create, update, remove}},
+			expectedOps:   []BatchOperation{create, update, remove},
+			mockResults:   []BatchOperationResult{{User: john}, {Err: ownErrors.ErrUserAlreadyExists}},
+			mockCommitted: false,
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: false,
+				Results: []BatchResult{
+					{Index: 0, Status: 424, Error: stringPtr("Operation rolled back")},
+					{Index: 1, Status: 409, Error: stringPtr("User already exists")},
+					{Index: 2, Status: 424, Error: stringPtr("Operation not executed")},
+				},
+			},
+		},
+		{
+			name: "Invalid operation aborts an atomic batch",
+			body: &BatchUsersJSONRequestBody{Operations: []BatchOperation{create, invalid}},
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: false,
+				Results: []BatchResult{
+					{Index: 0, Status: 424, Error: stringPtr("Operation not executed")},
+					{Index: 1, Status: 400, Error: stringPtr("Update operation requires id and user")},
+				},
+			},
+		},
+		{
+			name:            "Continue on error skips failed operations",
+			body:            &BatchUsersJSONRequestBody{Operations: []BatchOperation{invalid, create, remove}, ContinueOnError: boolPtr(true)},
+			expectedOps:     []BatchOperation{create, remove},
+			continueOnError: true,
+			mockResults:     []BatchOperationResult{{User: john}, {Err: ownErrors.ErrNotFound}},
+			mockCommitted:   true,
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: true,
+				Results: []BatchResult{
+					{Index: 0, Status: 400, Error: stringPtr("Update operation requires id and user")},
+					{Index: 1, Status: 201, User: john},
+					{Index: 2, Status: 404, Error: stringPtr("User not found")},
+				},
+			},
+			expectedMails: []string{"john@example.com"},
+		},
+		{
+			name:            "Legacy ids are resolved",
+			legacyIDs:       true,
+			legacyUIDs:      map[uint]types.UUID{3: userID(3)},
+			body:            &BatchUsersJSONRequestBody{Operations: []BatchOperation{legacyRemove, unknownLegacyRemove}, ContinueOnError: boolPtr(true)},
+			expectedOps:     []BatchOperation{remove, unknownRemove},
+			mockResults:     []BatchOperationResult{{}, {Err: ownErrors.ErrNotFound}},
+			mockCommitted:   true,
+			continueOnError: true,
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: true,
+				Results: []BatchResult{
+					{Index: 0, Status: 204},
+					{Index: 1, Status: 404, Error: stringPtr("User not found")},
+				},
+			},
+		},
+		{
+			name: "Legacy ids are rejected when disabled",
+			body: &BatchUsersJSONRequestBody{Operations: []BatchOperation{legacyRemove}},
+			expectedOutput: BatchUsers200JSONResponse{
+				Committed: false,
+				Results: []BatchResult{
+					{Index: 0, Status: 400, Error: stringPtr("Invalid user ID")},
+				},
+			},
+		},
+		{
+			name:           "Missing request body",
+			expectedOutput: BatchUsers400JSONResponse{Error: stringPtr("Missing request body")},
+		},
+		{
+			name:           "Empty batch",
+			body:           &BatchUsersJSONRequestBody{},
+			expectedOutput: BatchUsers400JSONResponse{Error: stringPtr("Batch must contain between 1 and 1000 operations")},
+		},
+		{
+			name:           "Repository error",
+			body:           &BatchUsersJSONRequestBody{Operations: []BatchOperation{create}},
+			expectedOps:    []BatchOperation{create},
+			mockError:      errors.New("database connection error"),
+			expectedOutput: BatchUsers500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo, legacyIDs: tc.legacyIDs}
+			mailbox := withMailbox(t, handler)
+
+			if tc.legacyUIDs != nil {
+				mockRepo.On("ResolveLegacyUserIDs", mock.Anything, mock.Anything).Return(tc.legacyUIDs, nil)
+			}
+			if tc.expectedOps != nil {
+				mockRepo.On("RunBatch", mock.Anything, tc.expectedOps, tc.continueOnError).
+					Return(tc.mockResults, tc.mockCommitted, tc.mockError)
+			}
+			if tc.expectedMails != nil {
+				mockRepo.On("LastEmailVerificationSentAt", mock.Anything, mock.Anything).Return(nil, nil)
+				mockRepo.On("CreateEmailVerification", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			resp, err := handler.BatchUsers(context.Background(), BatchUsersRequestObject{Body: tc.body})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+			mockRepo.AssertExpectations(t)
+
+			messages, err := mailbox.Messages()
+			require.NoError(t, err)
+			mailed := make([]string, 0, len(messages))
+			for _, m := range messages {
+				mailed = append(mailed, m.To)
+			}
+			assert.ElementsMatch(t, tc.expectedMails, mailed)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// userID returns a deterministic UUID for the given number.
+func userID(n int) types.UUID {
+	return uuid.MustParse(fmt.Sprintf("01927a3e-8f2c-7b3d-9e4f-%012d", n))
+}
+
+func TestUserHandler_BatchGetUsers(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	john := User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: types.Email("john@example.com"), CreatedAt: fixedTime, UpdatedAt: fixedTime}
+	jane := User{Id: userID(3), FirstName: "Jane", LastName: "Doe", Email: types.Email("jane@example.com"), CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	t.Run("Found and missing users in request order", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetUsersByIDs", mock.Anything, []types.UUID{userID(3), userID(2), userID(1)}).Return([]User{john, jane}, nil)
+
+		resp, err := handler.BatchGetUsers(context.Background(), BatchGetUsersRequestObject{
+			Params: BatchGetUsersParams{Ids: []UserID{userID(3).String(), userID(2).String(), userID(1).String(), userID(3).String()}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsers200JSONResponse{
+			Users:   []User{jane, john},
+			Missing: []UserID{userID(2).String()},
+		}, resp)
+	})
+
+	t.Run("Legacy ids", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo, legacyIDs: true}
+		mockRepo.On("ResolveLegacyUserIDs", mock.Anything, []uint{3, 7}).Return(map[uint]types.UUID{3: userID(3)}, nil)
+		mockRepo.On("GetUsersByIDs", mock.Anything, []types.UUID{userID(3), userID(1)}).Return([]User{john, jane}, nil)
+
+		resp, err := handler.BatchGetUsers(context.Background(), BatchGetUsersRequestObject{
+			Params: BatchGetUsersParams{Ids: []UserID{"3", "7", userID(1).String(), userID(3).String()}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsers200JSONResponse{
+			Users:   []User{jane, john},
+			Missing: []UserID{"7"},
+		}, resp)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		handler := &UserHandler{repo: new(MockUserRepository)}
+
+		resp, err := handler.BatchGetUsers(context.Background(), BatchGetUsersRequestObject{
+			Params: BatchGetUsersParams{Ids: []UserID{"1"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsers400JSONResponse{Error: stringPtr("Invalid user ID")}, resp)
+	})
+
+	t.Run("Too many ids in query", func(t *testing.T) {
+		handler := &UserHandler{repo: new(MockUserRepository)}
+
+		resp, err := handler.BatchGetUsers(context.Background(), BatchGetUsersRequestObject{
+			Params: BatchGetUsersParams{Ids: make([]UserID, maxBatchGetQueryIDs+1)},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsers400JSONResponse{Error: stringPtr("Between 1 and 100 ids are required")}, resp)
+	})
+
+	t.Run("Body variant", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetUsersByIDs", mock.Anything, []types.UUID{userID(1)}).Return([]User{john}, nil)
+
+		resp, err := handler.BatchGetUsersPost(context.Background(), BatchGetUsersPostRequestObject{
+			Body: &BatchGetUsersPostJSONRequestBody{Ids: []UserID{userID(1).String()}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsersPost200JSONResponse{Users: []User{john}, Missing: []UserID{}}, resp)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetUsersByIDs", mock.Anything, []types.UUID{userID(1)}).Return(nil, errors.New("database connection error"))
+
+		resp, err := handler.BatchGetUsersPost(context.Background(), BatchGetUsersPostRequestObject{
+			Body: &BatchGetUsersPostJSONRequestBody{Ids: []UserID{userID(1).String()}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BatchGetUsersPost500JSONResponse{Error: stringPtr("Internal server error")}, resp)
+	})
+}
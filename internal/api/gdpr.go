@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"strings"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// Erasure represents the erasure of the personal data of a user, recorded with its reason and actor.
+type Erasure struct {
+	Reason    string
+	ActorKind string
+	ActorID   string
+}
+
+// ExportUserData returns everything held about a user as a downloadable JSON document
+func (h *UserHandler) ExportUserData(ctx context.Context, request ExportUserDataRequestObject) (ExportUserDataResponseObject, error) {
+	var export *UserDataExport
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		export, err = h.repo.ExportUserData(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ExportUserData400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ExportUserData404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to export user data", "error", err, "user", request.Id)
+		errorMsg := "Internal server error"
+		return ExportUserData500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ExportUserData200JSONResponse{
+		Body: *export,
+		Headers: ExportUserData200ResponseHeaders{
+			ContentDisposition: mime.FormatMediaType("attachment", map[string]string{"filename": "user-" + id.String() + ".json"}),
+		},
+	}, nil
+}
+
+// EraseUser irreversibly erases the personal data of a user and returns the proof of erasure
+func (h *UserHandler) EraseUser(ctx context.Context, request EraseUserRequestObject) (EraseUserResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err != nil {
+		return eraseUserError(err), nil
+	}
+
+	e := &Erasure{}
+	if request.Body != nil && request.Body.Reason != nil {
+		e.Reason = strings.TrimSpace(*request.Body.Reason)
+	}
+	if principal, ok := router.PrincipalFromContext(ctx); ok {
+		e.ActorKind = string(principal.Kind)
+		e.ActorID = principal.ID
+	}
+
+	erasure, avatarVersion, err := h.repo.EraseUser(ctx, id, e)
+	if err != nil {
+		if !errors.Is(err, ownErrors.ErrNotFound) && !errors.Is(err, ownErrors.ErrUserErased) &&
+			!errors.Is(err, ownErrors.ErrLegalHold) {
+			h.logger.Error("Failed to erase user", "error", err, "user", id)
+		}
+		return eraseUserError(err), nil
+	}
+
+	if avatarVersion != nil {
+		h.deleteAvatarBlobs(ctx, id, *avatarVersion)
+	}
+
+	return EraseUser200JSONResponse(*erasure), nil
+}
+
+// eraseUserError maps an error of EraseUser to its response.
+func eraseUserError(err error) EraseUserResponseObject {
+	if errors.Is(err, errInvalidUserID) {
+		errorMsg := "Invalid user ID"
+		return EraseUser400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		errorMsg := "User not found"
+		return EraseUser404JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrUserErased) {
+		errorMsg := "User is already erased"
+		return EraseUser409JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrLegalHold) {
+		errorMsg := "User is under legal hold"
+		return EraseUser409JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+
+	errorMsg := "Internal server error"
+	return EraseUser500JSONResponse{
+		Error: &errorMsg,
+	}
+}
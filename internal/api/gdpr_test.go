@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/avatar"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_ExportUserData(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Export downloaded as attachment", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, _ := testHandler(t, mockRepo)
+		export := &UserDataExport{User: User{Id: userID(1)}, Roles: []string{"admin"}}
+		mockRepo.On("ExportUserData", mock.Anything, userID(1)).Return(export, nil)
+
+		resp, err := handler.ExportUserData(ctx, ExportUserDataRequestObject{Id: userID(1).String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, ExportUserData200JSONResponse{
+			Body:    *export,
+			Headers: ExportUserData200ResponseHeaders{ContentDisposition: `attachment; filename=user-` + userID(1).String() + `.json`},
+		}, resp)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, _ := testHandler(t, mockRepo)
+		mockRepo.On("ExportUserData", mock.Anything, userID(1)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.ExportUserData(ctx, ExportUserDataRequestObject{Id: userID(1).String()})
+
+		require.NoError(t, err)
+		assert.IsType(t, ExportUserData404JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_EraseUser(t *testing.T) {
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: "admin"})
+	reason := "  Ticket 42 "
+
+	t.Run("Erased with actor and avatar deleted", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, store := testHandler(t, mockRepo)
+		version := "0123456789abcdef"
+		key := avatar.Key(userID(1).String(), version, 64)
+		require.NoError(t, store.Put(ctx, key, []byte("old"), "image/png"))
+		trimmed := "Ticket 42"
+		proof := &UserErasure{UserId: userID(1), Reason: &trimmed, ActorKind: "user", ActorId: "admin",
+			Affected: map[string]int{"users": 1}, ErasedAt: time.Now()}
+		mockRepo.On("EraseUser", mock.Anything, userID(1),
+			&Erasure{Reason: "Ticket 42", ActorKind: "user", ActorID: "admin"}).Return(proof, &version, nil)
+
+		resp, err := handler.EraseUser(ctx, EraseUserRequestObject{Id: userID(1).String(), Body: &EraseUserJSONRequestBody{Reason: &reason}})
+
+		require.NoError(t, err)
+		assert.Equal(t, EraseUser200JSONResponse(*proof), resp)
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+
+	t.Run("Errors mapped", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			err      error
+			expected EraseUserResponseObject
+		}{
+			{name: "User not found", err: ownErrors.ErrNotFound, expected: EraseUser404JSONResponse{}},
+			{name: "User already erased", err: ownErrors.ErrUserErased, expected: EraseUser409JSONResponse{}},
+			{name: "User under legal hold", err: ownErrors.ErrLegalHold, expected: EraseUser409JSONResponse{}},
+			{name: "Database failure", err: assert.AnError, expected: EraseUser500JSONResponse{}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				handler, _ := testHandler(t, mockRepo)
+				mockRepo.On("EraseUser", mock.Anything, userID(1), mock.Anything).Return(nil, nil, tc.err)
+
+				resp, err := handler.EraseUser(ctx, EraseUserRequestObject{Id: userID(1).String()})
+
+				require.NoError(t, err)
+				assert.IsType(t, tc.expected, resp)
+			})
+		}
+	})
+}
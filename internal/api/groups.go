@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-users/internal/ownErrors"
+)
+
+// ListGroups returns all groups
+func (h *UserHandler) ListGroups(ctx context.Context, _ ListGroupsRequestObject) (ListGroupsResponseObject, error) {
+	groups, err := h.repo.ListGroups(ctx)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListGroups500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListGroups200JSONResponse{Groups: groups}, nil
+}
+
+// CreateGroup creates a group
+func (h *UserHandler) CreateGroup(ctx context.Context, request CreateGroupRequestObject) (CreateGroupResponseObject, error) {
+	g, ok := groupRequest(request.Body)
+	if !ok {
+		errorMsg := "Missing group name"
+		return CreateGroup400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	group, err := h.repo.CreateGroup(ctx, g)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrGroupAlreadyExists) {
+			errorMsg := "Group already exists"
+			return CreateGroup409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return CreateGroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CreateGroup201JSONResponse(*group), nil
+}
+
+// GetGroup returns a group
+func (h *UserHandler) GetGroup(ctx context.Context, request GetGroupRequestObject) (GetGroupResponseObject, error) {
+	group, err := h.repo.GetGroup(ctx, request.GroupId)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group not found"
+			return GetGroup404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetGroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetGroup200JSONResponse(*group), nil
+}
+
+// UpdateGroup renames a group or changes its description
+func (h *UserHandler) UpdateGroup(ctx context.Context, request UpdateGroupRequestObject) (UpdateGroupResponseObject, error) {
+	g, ok := groupRequest(request.Body)
+	if !ok {
+		errorMsg := "Missing group name"
+		return UpdateGroup400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	group, err := h.repo.UpdateGroup(ctx, request.GroupId, g)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group not found"
+			return UpdateGroup404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrGroupAlreadyExists) {
+			errorMsg := "Group already exists"
+			return UpdateGroup409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return UpdateGroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return UpdateGroup200JSONResponse(*group), nil
+}
+
+// DeleteGroup deletes a group with its memberships
+func (h *UserHandler) DeleteGroup(ctx context.Context, request DeleteGroupRequestObject) (DeleteGroupResponseObject, error) {
+	if err := h.repo.DeleteGroup(ctx, request.GroupId); err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group not found"
+			return DeleteGroup404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return DeleteGroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return DeleteGroup204Response{}, nil
+}
+
+// ListGroupMembers returns the users and subgroups of a group
+func (h *UserHandler) ListGroupMembers(ctx context.Context, request ListGroupMembersRequestObject) (ListGroupMembersResponseObject, error) {
+	transitive := request.Params.Transitive != nil && *request.Params.Transitive
+
+	members, err := h.repo.ListGroupMembers(ctx, request.GroupId, transitive)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group not found"
+			return ListGroupMembers404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ListGroupMembers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListGroupMembers200JSONResponse(*members), nil
+}
+
+// CheckGroupMembership reports whether a user is a member of a group
+func (h *UserHandler) CheckGroupMembership(ctx context.Context, request CheckGroupMembershipRequestObject) (CheckGroupMembershipResponseObject, error) {
+	var membership *GroupMembership
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		membership, err = h.repo.IsGroupMember(ctx, request.GroupId, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return CheckGroupMembership400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group or user not found"
+			return CheckGroupMembership404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return CheckGroupMembership500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CheckGroupMembership200JSONResponse(*membership), nil
+}
+
+// AddGroupUser adds a user to a group
+func (h *UserHandler) AddGroupUser(ctx context.Context, request AddGroupUserRequestObject) (AddGroupUserResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.AddGroupUser(ctx, request.GroupId, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return AddGroupUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group or user not found"
+			return AddGroupUser404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return AddGroupUser500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return AddGroupUser204Response{}, nil
+}
+
+// RemoveGroupUser removes a user from a group
+func (h *UserHandler) RemoveGroupUser(ctx context.Context, request RemoveGroupUserRequestObject) (RemoveGroupUserResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.RemoveGroupUser(ctx, request.GroupId, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return RemoveGroupUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User is not a member of the group"
+			return RemoveGroupUser404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RemoveGroupUser500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RemoveGroupUser204Response{}, nil
+}
+
+// AddSubgroup nests a group in another one
+func (h *UserHandler) AddSubgroup(ctx context.Context, request AddSubgroupRequestObject) (AddSubgroupResponseObject, error) {
+	if err := h.repo.AddSubgroup(ctx, request.GroupId, request.SubgroupId); err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group not found"
+			return AddSubgroup404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrGroupCycle) {
+			errorMsg := "Nesting would create a cycle"
+			return AddSubgroup409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return AddSubgroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return AddSubgroup204Response{}, nil
+}
+
+// RemoveSubgroup unnests a group
+func (h *UserHandler) RemoveSubgroup(ctx context.Context, request RemoveSubgroupRequestObject) (RemoveSubgroupResponseObject, error) {
+	if err := h.repo.RemoveSubgroup(ctx, request.GroupId, request.SubgroupId); err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Group is not a subgroup"
+			return RemoveSubgroup404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RemoveSubgroup500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RemoveSubgroup204Response{}, nil
+}
+
+// ListUserGroups returns the groups of a user
+func (h *UserHandler) ListUserGroups(ctx context.Context, request ListUserGroupsRequestObject) (ListUserGroupsResponseObject, error) {
+	transitive := request.Params.Transitive != nil && *request.Params.Transitive
+
+	var groups []Group
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		groups, err = h.repo.ListUserGroups(ctx, id, transitive)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ListUserGroups400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ListUserGroups404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ListUserGroups500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListUserGroups200JSONResponse{Groups: groups}, nil
+}
+
+// groupRequest returns the body with a trimmed name. It reports false if the body or the name is missing.
+func groupRequest(body *GroupRequest) (*GroupRequest, bool) {
+	if body == nil || strings.TrimSpace(body.Name) == "" {
+		return nil, false
+	}
+
+	g := *body
+	g.Name = strings.TrimSpace(g.Name)
+	return &g, true
+}
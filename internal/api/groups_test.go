@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestUserHandler_CreateGroup(t *testing.T) {
+	t.Run("Group created", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		group := &Group{Id: userID(5), Name: "Platform"}
+		mockRepo.On("CreateGroup", mock.Anything, &GroupRequest{Name: "Platform"}).Return(group, nil)
+
+		resp, err := handler.CreateGroup(context.Background(), CreateGroupRequestObject{Body: &GroupRequest{Name: " Platform "}})
+		require.NoError(t, err)
+		assert.Equal(t, CreateGroup201JSONResponse(*group), resp)
+	})
+
+	t.Run("Name already taken", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("CreateGroup", mock.Anything, mock.Anything).Return(nil, ownErrors.ErrGroupAlreadyExists)
+
+		resp, err := handler.CreateGroup(context.Background(), CreateGroupRequestObject{Body: &GroupRequest{Name: "Platform"}})
+		require.NoError(t, err)
+		assert.IsType(t, CreateGroup409JSONResponse{}, resp)
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		for _, body := range []*GroupRequest{nil, {Name: "  "}} {
+			resp, err := handler.CreateGroup(context.Background(), CreateGroupRequestObject{Body: body})
+			require.NoError(t, err)
+			assert.IsType(t, CreateGroup400JSONResponse{}, resp)
+		}
+	})
+}
+
+func TestUserHandler_ListGroupMembers(t *testing.T) {
+	t.Run("Transitive members", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		transitive := true
+		members := &GroupMembers{Users: []User{{Id: userID(1)}}, Groups: []Group{{Id: userID(6), Name: "Backend"}}}
+		mockRepo.On("ListGroupMembers", mock.Anything, userID(5), true).Return(members, nil)
+
+		resp, err := handler.ListGroupMembers(context.Background(), ListGroupMembersRequestObject{
+			GroupId: userID(5), Params: ListGroupMembersParams{Transitive: &transitive},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ListGroupMembers200JSONResponse(*members), resp)
+	})
+
+	t.Run("Group not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListGroupMembers", mock.Anything, userID(5), false).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.ListGroupMembers(context.Background(), ListGroupMembersRequestObject{GroupId: userID(5)})
+		require.NoError(t, err)
+		assert.IsType(t, ListGroupMembers404JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_CheckGroupMembership(t *testing.T) {
+	t.Run("Member through a subgroup", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("IsGroupMember", mock.Anything, userID(5), userID(1)).Return(&GroupMembership{Member: true}, nil)
+
+		resp, err := handler.CheckGroupMembership(context.Background(), CheckGroupMembershipRequestObject{
+			GroupId: userID(5), Id: userID(1).String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, CheckGroupMembership200JSONResponse{Member: true}, resp)
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.CheckGroupMembership(context.Background(), CheckGroupMembershipRequestObject{
+			GroupId: userID(5), Id: "not-a-uuid",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, CheckGroupMembership400JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_AddSubgroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected AddSubgroupResponseObject
+	}{
+		{name: "Nested", expected: AddSubgroup204Response{}},
+		{name: "Group not found", err: ownErrors.ErrNotFound, expected: AddSubgroup404JSONResponse{}},
+		{name: "Cycle", err: ownErrors.ErrGroupCycle, expected: AddSubgroup409JSONResponse{}},
+		{name: "Database error", err: errors.New("db down"), expected: AddSubgroup500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			mockRepo.On("AddSubgroup", mock.Anything, userID(5), userID(6)).Return(tc.err)
+
+			resp, err := handler.AddSubgroup(context.Background(), AddSubgroupRequestObject{GroupId: userID(5), SubgroupId: userID(6)})
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestUserHandler_ListUserGroups(t *testing.T) {
+	t.Run("Direct groups", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		groups := []Group{{Id: userID(5), Name: "Platform"}}
+		mockRepo.On("ListUserGroups", mock.Anything, userID(1), false).Return(groups, nil)
+
+		resp, err := handler.ListUserGroups(context.Background(), ListUserGroupsRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.Equal(t, ListUserGroups200JSONResponse{Groups: groups}, resp)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListUserGroups", mock.Anything, userID(1), false).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.ListUserGroups(context.Background(), ListUserGroupsRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.IsType(t, ListUserGroups404JSONResponse{}, resp)
+	})
+}
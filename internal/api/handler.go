@@ -9,18 +9,112 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 
+	"go-users/internal/apikey"
+	"go-users/internal/avatar"
+	"go-users/internal/blob"
 	"go-users/internal/config"
+	"go-users/internal/mail"
 	"go-users/internal/ownErrors"
+	"go-users/internal/password"
+	"go-users/internal/policy"
+	"go-users/internal/profile"
 	"go-users/internal/router"
+	"go-users/internal/token"
+	"go-users/internal/verification"
 )
 
 type DB interface {
+	apikey.Store
 	CreateUser(ctx context.Context, user *UserRequest) (*User, error)
-	GetUser(ctx context.Context, id uint) (*User, error)
-	UpdateUser(ctx context.Context, u *UserRequest, id uint) (*User, error)
+	GetUser(ctx context.Context, id openapi_types.UUID) (*User, error)
+	UpdateUser(ctx context.Context, u *UserRequest, id openapi_types.UUID) (*User, error)
+	GetUsersByIDs(ctx context.Context, ids []openapi_types.UUID) ([]User, error)
+	ResolveLegacyUserIDs(ctx context.Context, ids []uint) (map[uint]openapi_types.UUID, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	ListUsers(ctx context.Context, after openapi_types.UUID, limit int, filter *UserFilter) ([]User, error)
+	GetUserStatus(ctx context.Context, id openapi_types.UUID) (UserStatus, error)
+	ChangeUserStatus(ctx context.Context, id openapi_types.UUID, c *StatusChange) (*User, error)
+	ListUserStatusEvents(ctx context.Context, id openapi_types.UUID) ([]UserStatusEvent, error)
+	SearchUsers(ctx context.Context, q string, limit int) ([]SearchResult, error)
+	RunBatch(ctx context.Context, ops []BatchOperation, continueOnError bool) ([]BatchOperationResult, bool, error)
+	SetPassword(ctx context.Context, id openapi_types.UUID, passwordHash string) error
+	GetCredentialsByEmail(ctx context.Context, email string) (*Credentials, error)
+	GetCredentials(ctx context.Context, id openapi_types.UUID) (*Credentials, error)
+	RecordLoginFailure(ctx context.Context, id openapi_types.UUID) (int, error)
+	LockCredentials(ctx context.Context, id openapi_types.UUID, until time.Time) error
+	RecordLoginSuccess(ctx context.Context, id openapi_types.UUID, rehash string) error
+	PasswordResetUsage(ctx context.Context, email, clientIP string, since time.Time) (*PasswordResetUsage, error)
+	CreatePasswordReset(ctx context.Context, r *PasswordReset) (*User, error)
+	GetPasswordResetUser(ctx context.Context, tokenHash []byte) (*User, error)
+	ResetPassword(ctx context.Context, tokenHash []byte, passwordHash string) error
+	CreateInvitation(ctx context.Context, inv *NewInvitation) (*Invitation, error)
+	ListInvitations(ctx context.Context, status *InvitationStatus) ([]Invitation, error)
+	GetInvitation(ctx context.Context, id openapi_types.UUID) (*Invitation, error)
+	GetInvitationByToken(ctx context.Context, tokenHash []byte) (*Invitation, error)
+	RevokeInvitation(ctx context.Context, id openapi_types.UUID, e *InvitationEvent) error
+	ResendInvitation(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time, e *InvitationEvent) (*Invitation, error)
+	AcceptInvitation(ctx context.Context, tokenHash []byte, a *InvitationAcceptance) (*User, error)
+	GetAttributeSchema(ctx context.Context) (*AttributeSchema, error)
+	PutAttributeSchema(ctx context.Context, schema map[string]interface{}) (*AttributeSchema, error)
+	DeleteAttributeSchema(ctx context.Context) error
+	SetAvatar(ctx context.Context, id openapi_types.UUID, version, url *string) (*User, *string, error)
+	ExportUserData(ctx context.Context, id openapi_types.UUID) (*UserDataExport, error)
+	EraseUser(ctx context.Context, id openapi_types.UUID, e *Erasure) (*UserErasure, *string, error)
+	ListLegalHolds(ctx context.Context, id openapi_types.UUID) ([]LegalHold, error)
+	PlaceLegalHold(ctx context.Context, id openapi_types.UUID, c *LegalHoldChange) (*LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, id, holdID openapi_types.UUID, c *LegalHoldChange) (*LegalHold, error)
+	CreateRefreshToken(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error
+	RotateRefreshToken(ctx context.Context, tokenHash, newHash []byte, expiresAt time.Time) (openapi_types.UUID, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash []byte) error
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetUserRoles(ctx context.Context, id openapi_types.UUID) ([]Role, error)
+	AssignRole(ctx context.Context, id openapi_types.UUID, role string) error
+	RevokeRole(ctx context.Context, id openapi_types.UUID, role string) error
+	GetUserPermissions(ctx context.Context, id openapi_types.UUID) ([]string, error)
+	ListGroups(ctx context.Context) ([]Group, error)
+	CreateGroup(ctx context.Context, g *GroupRequest) (*Group, error)
+	GetGroup(ctx context.Context, id openapi_types.UUID) (*Group, error)
+	UpdateGroup(ctx context.Context, id openapi_types.UUID, g *GroupRequest) (*Group, error)
+	DeleteGroup(ctx context.Context, id openapi_types.UUID) error
+	ListGroupMembers(ctx context.Context, id openapi_types.UUID, transitive bool) (*GroupMembers, error)
+	ListUserGroups(ctx context.Context, id openapi_types.UUID, transitive bool) ([]Group, error)
+	IsGroupMember(ctx context.Context, groupID, id openapi_types.UUID) (*GroupMembership, error)
+	AddGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error
+	RemoveGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error
+	AddSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error
+	RemoveSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error
+	LogPolicyDecision(ctx context.Context, d *PolicyDecision) error
+	CreateAPIKey(ctx context.Context, owner *openapi_types.UUID, k *APIKeyRequest, prefix string, secretHash []byte) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, owner *openapi_types.UUID) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID) error
+	RotateAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID, prefix string, secretHash []byte) (*APIKey, error)
+	CreateEmailVerification(ctx context.Context, v *EmailVerification) error
+	LastEmailVerificationSentAt(ctx context.Context, id openapi_types.UUID) (*time.Time, error)
+	ConfirmEmail(ctx context.Context, id openapi_types.UUID, address string) (*User, error)
+	GetMFA(ctx context.Context, id openapi_types.UUID) (*MFA, error)
+	StartTOTPEnrollment(ctx context.Context, id openapi_types.UUID, secret []byte) error
+	EnableMFA(ctx context.Context, id openapi_types.UUID, step int64, recoveryHashes [][]byte, e *MFAEvent) error
+	ResetMFA(ctx context.Context, id openapi_types.UUID, e *MFAEvent) error
+	UseTOTPStep(ctx context.Context, id openapi_types.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, id openapi_types.UUID, codeHash []byte) error
+	CreateMFAChallenge(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error
+	GetMFAChallenge(ctx context.Context, tokenHash []byte) (*MFAChallenge, error)
+	RecordMFAChallengeFailure(ctx context.Context, tokenHash []byte) error
+	DeleteMFAChallenge(ctx context.Context, tokenHash []byte) error
+	CreateJob(ctx context.Context, jobType string, payload map[string]interface{}) (*Job, error)
+	GetJob(ctx context.Context, id uint) (*Job, error)
+	CancelJob(ctx context.Context, id uint) (*Job, error)
+	GetJobArtifact(ctx context.Context, id uint) (*JobArtifact, error)
+	ResolveOrg(ctx context.Context, ref string) (openapi_types.UUID, error)
+	ListOrganizations(ctx context.Context) ([]Organization, error)
+	CreateOrganization(ctx context.Context, o *OrganizationRequest) (*Organization, error)
+	GetOrganization(ctx context.Context, id openapi_types.UUID) (*Organization, error)
 	Close()
 }
 
@@ -29,11 +123,62 @@ var _ StrictServerInterface = (*UserHandler)(nil)
 
 // UserHandler implements the StrictServerInterface
 type UserHandler struct {
-	repo DB
+	repo       DB
+	logger     *slog.Logger
+	apiPrefix  string
+	legacyIDs  bool
+	hasher     *password.Hasher
+	policy     password.Policy
+	lockout    password.Lockout
+	sessionTTL time.Duration
+	keys       *token.Keyring
+	access     *policy.Engine
+	// mfaIssuer names the service in authenticator apps.
+	mfaIssuer       string
+	mfaChallengeTTL time.Duration
+	mfaRetries      int
+	recoveryCodes   int
+	mailer          mail.Sender
+	verifier        *verification.Signer
+	// verificationURL is the page of the verification link, which receives the token as query parameter.
+	verificationURL    string
+	verificationTTL    time.Duration
+	verificationResend time.Duration
+	// resetURL is the page of the password reset link, which receives the token as query parameter.
+	resetURL         string
+	resetTTL         time.Duration
+	resetWindow      time.Duration
+	resetMaxPerEmail int
+	resetMaxPerIP    int
+	// invitationURL is the page of the invitation link, which receives the token as query parameter.
+	invitationURL string
+	invitationTTL time.Duration
+	// blobs stores the variants of uploaded avatars.
+	blobs        blob.Store
+	avatarLimits avatar.Limits
+	// avatarURL is the public address of GetAvatar, below which avatar URLs are formed.
+	avatarURL string
+	// background tracks mails sent in the background.
+	background sync.WaitGroup
 }
 
-// NewHandler creates a new HTTP handler
-func NewHandler(openAPICfg config.OpenAPI, logger *slog.Logger, repo DB) (http.Handler, error) {
+// NewHandler creates a new HTTP handler. Access tokens are issued with the given keyring, whose public keys are
+// served at /.well-known/jwks.json, and avatars are uploaded to blobs. Every operation is authorized by the policy
+// engine before it is handled.
+func NewHandler(cfg *config.Config, logger *slog.Logger, repo DB, keys *token.Keyring, blobs blob.Store) (http.Handler, error) {
+	openAPICfg := cfg.OpenAPI
+
+	hasher, err := password.NewHasher(password.Params{
+		Memory:      cfg.Password.Memory,
+		Iterations:  cfg.Password.Iterations,
+		Parallelism: cfg.Password.Parallelism,
+		SaltLength:  passwordSaltLength,
+		KeyLength:   passwordKeyLength,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password hasher: %w", err)
+	}
+
 	specPath := openAPICfg.SpecPath
 	if specPath == "" {
 		execPath, err := os.Executable()
@@ -47,16 +192,90 @@ func NewHandler(openAPICfg config.OpenAPI, logger *slog.Logger, repo DB) (http.H
 		return nil, fmt.Errorf("OpenAPI specification file not found at %s", specPath)
 	}
 
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mail sender: %w", err)
+	}
+
+	if cfg.EmailVerification.Secret == "" {
+		logger.Warn("EMAIL_VERIFICATION_SECRET is not set; verification links only work until the next restart")
+	}
+	verifier, err := verification.NewSigner(cfg.EmailVerification.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create verification signer: %w", err)
+	}
+
+	staticKeys, err := router.NewStaticAPIKeys(cfg.Auth.APIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API keys: %w", err)
+	}
+
+	trustedProxies, err := router.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	r := router.New(router.Options{
-		Logger: logger,
+		Logger:  logger,
+		Tokens:  keys,
+		APIKeys: apikey.NewVerifier(repo, staticKeys, logger),
+		Orgs:    repo,
+
+		BaseDomain:     cfg.Tenancy.BaseDomain,
+		TrustedProxies: trustedProxies,
 	})
 
-	handler := &UserHandler{repo: repo}
+	handler := &UserHandler{
+		repo:      repo,
+		logger:    logger,
+		apiPrefix: openAPICfg.APIPrefix,
+		legacyIDs: openAPICfg.LegacyIDs,
+		hasher:    hasher,
+		policy: password.Policy{
+			MinLength:  cfg.Password.MinLength,
+			MaxLength:  cfg.Password.MaxLength,
+			MinClasses: cfg.Password.MinClasses,
+		},
+		lockout: password.Lockout{
+			Threshold:    cfg.Password.LockoutThreshold,
+			BaseCooldown: time.Duration(cfg.Password.LockoutBaseCooldown) * time.Second,
+			MaxCooldown:  time.Duration(cfg.Password.LockoutMaxCooldown) * time.Second,
+		},
+		sessionTTL: time.Duration(cfg.Session.TTL) * time.Second,
+		keys:       keys,
+		access:     policy.New(policy.DefaultRules()),
+
+		mfaIssuer:       cfg.MFA.Issuer,
+		mfaChallengeTTL: time.Duration(cfg.MFA.ChallengeTTL) * time.Second,
+		mfaRetries:      cfg.MFA.ChallengeRetries,
+		recoveryCodes:   cfg.MFA.RecoveryCodes,
+
+		mailer:             mailer,
+		verifier:           verifier,
+		verificationURL:    cfg.EmailVerification.URL,
+		verificationTTL:    time.Duration(cfg.EmailVerification.TTL) * time.Second,
+		verificationResend: time.Duration(cfg.EmailVerification.ResendInterval) * time.Second,
+
+		resetURL:         cfg.PasswordReset.URL,
+		resetTTL:         time.Duration(cfg.PasswordReset.TTL) * time.Second,
+		resetWindow:      time.Duration(cfg.PasswordReset.Window) * time.Second,
+		resetMaxPerEmail: cfg.PasswordReset.MaxPerEmail,
+		resetMaxPerIP:    cfg.PasswordReset.MaxPerIP,
+
+		invitationURL: cfg.Invitation.URL,
+		invitationTTL: time.Duration(cfg.Invitation.TTL) * time.Second,
+
+		blobs:        blobs,
+		avatarLimits: avatar.Limits{MaxSize: int64(cfg.Avatar.MaxSize), MaxPixels: cfg.Avatar.MaxPixels},
+		avatarURL:    cfg.Avatar.URL,
+	}
 
 	RegisterSwaggerRoutes(r)
+	RegisterJWKSRoute(r, keys)
+	handler.RegisterMetricsRoute(r)
 
 	r.Route(openAPICfg.APIPrefix, func(r chi.Router) {
-		ownStrictHandler := NewStrictHandlerWithOptions(handler, nil, StrictHTTPServerOptions{
+		ownStrictHandler := NewStrictHandlerWithOptions(handler, []StrictMiddlewareFunc{handler.enforcePolicy}, StrictHTTPServerOptions{
 			RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 				logger.Error("request validation error", "error", err)
 				http.Error(w, "Invalid request", http.StatusBadRequest)
@@ -66,7 +285,10 @@ func NewHandler(openAPICfg config.OpenAPI, logger *slog.Logger, repo DB) (http.H
 				http.Error(w, "Internal server error", http.StatusInternalServerError)
 			},
 		})
-		HandlerFromMux(ownStrictHandler, r)
+		HandlerWithOptions(ownStrictHandler, ChiServerOptions{
+			BaseRouter:  r,
+			Middlewares: []MiddlewareFunc{authorize},
+		})
 	})
 
 	return r, nil
@@ -80,7 +302,7 @@ func (h *UserHandler) Health(_ context.Context, _ HealthRequestObject) (HealthRe
 	}, nil
 }
 
-// PostUser creates a new user
+// PostUser creates a new user and sends a verification mail to their email address
 func (h *UserHandler) PostUser(ctx context.Context, request PostUserRequestObject) (PostUserResponseObject, error) {
 	if request.Body == nil {
 		errorMsg := "Missing request body"
@@ -91,6 +313,26 @@ func (h *UserHandler) PostUser(ctx context.Context, request PostUserRequestObjec
 
 	user, err := h.repo.CreateUser(ctx, request.Body)
 	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidEmail) {
+			errorMsg := "Invalid email address"
+			return PostUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if problems, ok := profileProblems(err); ok {
+			errorMsg := "Invalid profile"
+			return PostUser400JSONResponse{
+				Error:   &errorMsg,
+				Details: &problems,
+			}, nil
+		}
+		if problems, ok := attributeProblems(err); ok {
+			errorMsg := "Invalid attributes"
+			return PostUser400JSONResponse{
+				Error:   &errorMsg,
+				Details: &problems,
+			}, nil
+		}
 		if errors.Is(err, ownErrors.ErrUserAlreadyExists) {
 			errorMsg := "User already exists"
 			return PostUser409JSONResponse{
@@ -104,13 +346,25 @@ func (h *UserHandler) PostUser(ctx context.Context, request PostUserRequestObjec
 		}, nil
 	}
 
+	h.notifyEmailVerification(ctx, user)
+
 	return PostUser201JSONResponse(*user), nil
 }
 
 // GetUser fetches a user by their ID
 func (h *UserHandler) GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error) {
-	user, err := h.repo.GetUser(ctx, request.Id)
+	var user *User
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		user, err = h.repo.GetUser(ctx, id)
+	}
 	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return GetUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
 		if errors.Is(err, ownErrors.ErrNotFound) {
 			errorMsg := "User not found"
 			return GetUser404JSONResponse{
@@ -127,7 +381,8 @@ func (h *UserHandler) GetUser(ctx context.Context, request GetUserRequestObject)
 	return GetUser200JSONResponse(*user), nil
 }
 
-// PutUser updates user entity with replacing user data
+// PutUser updates user entity with replacing user data. A changed email address is kept pending and a verification
+// mail is sent to it
 func (h *UserHandler) PutUser(ctx context.Context, request PutUserRequestObject) (PutUserResponseObject, error) {
 	if request.Body == nil {
 		errorMsg := "Missing request body"
@@ -136,19 +391,88 @@ func (h *UserHandler) PutUser(ctx context.Context, request PutUserRequestObject)
 		}, nil
 	}
 
-	user, err := h.repo.UpdateUser(ctx, request.Body, request.Id)
+	var user *User
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		user, err = h.repo.UpdateUser(ctx, request.Body, id)
+	}
 	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return PutUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
 		if errors.Is(err, ownErrors.ErrNotFound) {
 			errorMsg := "User not found"
 			return PutUser404JSONResponse{
 				Error: &errorMsg,
 			}, nil
 		}
+		if errors.Is(err, ownErrors.ErrInvalidEmail) {
+			errorMsg := "Invalid email address"
+			return PutUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if problems, ok := profileProblems(err); ok {
+			errorMsg := "Invalid profile"
+			return PutUser400JSONResponse{
+				Error:   &errorMsg,
+				Details: &problems,
+			}, nil
+		}
+		if problems, ok := attributeProblems(err); ok {
+			errorMsg := "Invalid attributes"
+			return PutUser400JSONResponse{
+				Error:   &errorMsg,
+				Details: &problems,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrUserAlreadyExists) {
+			errorMsg := "User already exists"
+			return PutUser409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
 		errorMsg := "Internal server error"
 		return PutUser500JSONResponse{
 			Error: &errorMsg,
 		}, nil
 	}
 
+	if user.PendingEmail != nil {
+		h.notifyEmailVerification(ctx, user)
+	}
+
 	return PutUser200JSONResponse(*user), nil
 }
+
+// GetUserByEmail fetches a user by their email address
+func (h *UserHandler) GetUserByEmail(ctx context.Context, request GetUserByEmailRequestObject) (GetUserByEmailResponseObject, error) {
+	user, err := h.repo.GetUserByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return GetUserByEmail404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetUserByEmail500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetUserByEmail200JSONResponse(*user), nil
+}
+
+// profileProblems returns the invalid profile fields reported by err, if it reports any.
+func profileProblems(err error) ([]string, bool) {
+	var invalid *profile.InvalidError
+	if !errors.As(err, &invalid) {
+		return nil, false
+	}
+	return invalid.Problems, true
+}
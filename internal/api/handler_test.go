@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -16,13 +17,23 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"go-users/internal/apikey"
+	"go-users/internal/avatar"
+	"go-users/internal/blob"
 	"go-users/internal/config"
 	"go-users/internal/ownErrors"
+	"go-users/internal/profile"
 )
 
+// testAPIKey is the static API key configured in TestNewHandler.
+const testAPIKey = "0123456789abcdef0123456789abcdef"
+
 func TestNewHandler(t *testing.T) {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	var db DB
+	keys := testKeyring(t)
+	testAccessToken, _, err := keys.Issue(userID(1).String(), "")
+	require.NoError(t, err)
 
 	tests := []struct {
 		name           string
@@ -83,12 +94,99 @@ func TestNewHandler(t *testing.T) {
 				client := &http.Client{}
 				req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/users", nil)
 				require.NoError(t, err)
+				req.Header.Set("X-API-Key", testAPIKey)
 				resp, err := client.Do(req)
 				require.NoError(t, err)
 				return resp
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Missing credentials",
+			openAPICfg: config.OpenAPI{
+				SpecPath:  "",
+				APIPrefix: "/api",
+			},
+			setupFunc: func() string {
+				tmpDir := t.TempDir()
+				specFile := filepath.Join(tmpDir, "openapi.yaml")
+				err := os.WriteFile(specFile, []byte("openapi: '3.0.0'"), 0644)
+				require.NoError(t, err)
+				return specFile
+			},
+			sendRequest: func(ts *httptest.Server) *http.Response {
+				resp, err := http.Post(ts.URL+"/api/users", "application/json", nil)
+				require.NoError(t, err)
+				return resp
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Invalid API key",
+			openAPICfg: config.OpenAPI{
+				SpecPath:  "",
+				APIPrefix: "/api",
+			},
+			setupFunc: func() string {
+				tmpDir := t.TempDir()
+				specFile := filepath.Join(tmpDir, "openapi.yaml")
+				err := os.WriteFile(specFile, []byte("openapi: '3.0.0'"), 0644)
+				require.NoError(t, err)
+				return specFile
+			},
+			sendRequest: func(ts *httptest.Server) *http.Response {
+				req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/health", nil)
+				require.NoError(t, err)
+				req.Header.Set("X-API-Key", "wrong")
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				return resp
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Bearer token is not accepted for service operations",
+			openAPICfg: config.OpenAPI{
+				SpecPath:  "",
+				APIPrefix: "/api",
+			},
+			setupFunc: func() string {
+				tmpDir := t.TempDir()
+				specFile := filepath.Join(tmpDir, "openapi.yaml")
+				err := os.WriteFile(specFile, []byte("openapi: '3.0.0'"), 0644)
+				require.NoError(t, err)
+				return specFile
+			},
+			sendRequest: func(ts *httptest.Server) *http.Response {
+				req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/jobs/1", nil)
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+testAccessToken)
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				return resp
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Public operation without credentials",
+			openAPICfg: config.OpenAPI{
+				SpecPath:  "",
+				APIPrefix: "/api",
+			},
+			setupFunc: func() string {
+				tmpDir := t.TempDir()
+				specFile := filepath.Join(tmpDir, "openapi.yaml")
+				err := os.WriteFile(specFile, []byte("openapi: '3.0.0'"), 0644)
+				require.NoError(t, err)
+				return specFile
+			},
+			sendRequest: func(ts *httptest.Server) *http.Response {
+				resp, err := http.Get(ts.URL + "/api/health")
+				require.NoError(t, err)
+				return resp
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name: "SpecPath is empty, defaults to executable path",
 			openAPICfg: config.OpenAPI{
@@ -119,6 +217,26 @@ func TestNewHandler(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name: "JWKS is served at the root",
+			openAPICfg: config.OpenAPI{
+				SpecPath:  "",
+				APIPrefix: "/api",
+			},
+			setupFunc: func() string {
+				tmpDir := t.TempDir()
+				specFile := filepath.Join(tmpDir, "openapi.yaml")
+				err := os.WriteFile(specFile, []byte("openapi: '3.0.0'"), 0644)
+				require.NoError(t, err)
+				return specFile
+			},
+			sendRequest: func(ts *httptest.Server) *http.Response {
+				resp, err := http.Get(ts.URL + "/.well-known/jwks.json")
+				require.NoError(t, err)
+				return resp
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name: "Middleware and response error handler",
 			openAPICfg: config.OpenAPI{
@@ -147,7 +265,13 @@ func TestNewHandler(t *testing.T) {
 				tc.openAPICfg.SpecPath = tc.setupFunc()
 			}
 
-			handler, err := NewHandler(tc.openAPICfg, logger, db)
+			cfg := &config.Config{
+				OpenAPI:  tc.openAPICfg,
+				Password: config.Password{Memory: 64, Iterations: 1, Parallelism: 1},
+				Auth:     config.Auth{APIKeys: []string{"ci:" + testAPIKey}},
+				Mail:     config.Mail{Driver: "file", From: "no-reply@example.com", Dir: t.TempDir()},
+			}
+			handler, err := NewHandler(cfg, logger, db, keys, blob.NewFileStore(t.TempDir()))
 
 			if tc.expectedError != "" {
 				require.Error(t, err)
@@ -190,7 +314,7 @@ func (m *MockUserRepository) Close() {
 	panic("implement me")
 }
 
-func (m *MockUserRepository) GetUser(ctx context.Context, id uint) (*User, error) {
+func (m *MockUserRepository) GetUser(ctx context.Context, id types.UUID) (*User, error) {
 	args := m.Called(ctx, id)
 	if result := args.Get(0); result != nil {
 		return result.(*User), args.Error(1)
@@ -206,7 +330,7 @@ func (m *MockUserRepository) CreateUser(ctx context.Context, user *UserRequest)
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateUser(ctx context.Context, u *UserRequest, id uint) (*User, error) {
+func (m *MockUserRepository) UpdateUser(ctx context.Context, u *UserRequest, id types.UUID) (*User, error) {
 	args := m.Called(ctx, u, id)
 	if result := args.Get(0); result != nil {
 		return result.(*User), args.Error(1)
@@ -214,10 +338,582 @@ func (m *MockUserRepository) UpdateUser(ctx context.Context, u *UserRequest, id
 	return nil, args.Error(1)
 }
 
+func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []types.UUID) ([]User, error) {
+	args := m.Called(ctx, ids)
+	if result := args.Get(0); result != nil {
+		return result.([]User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ResolveLegacyUserIDs(ctx context.Context, ids []uint) (map[uint]types.UUID, error) {
+	args := m.Called(ctx, ids)
+	if result := args.Get(0); result != nil {
+		return result.(map[uint]types.UUID), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) SetPassword(ctx context.Context, id types.UUID, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetCredentialsByEmail(ctx context.Context, email string) (*Credentials, error) {
+	args := m.Called(ctx, email)
+	if result := args.Get(0); result != nil {
+		return result.(*Credentials), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetCredentials(ctx context.Context, id types.UUID) (*Credentials, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Credentials), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) RecordLoginFailure(ctx context.Context, id types.UUID) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepository) LockCredentials(ctx context.Context, id types.UUID, until time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RecordLoginSuccess(ctx context.Context, id types.UUID, rehash string) error {
+	args := m.Called(ctx, id, rehash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateRefreshToken(ctx context.Context, id types.UUID, tokenHash []byte, expiresAt time.Time) error {
+	args := m.Called(ctx, id, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RotateRefreshToken(ctx context.Context, tokenHash, newHash []byte, expiresAt time.Time) (types.UUID, error) {
+	args := m.Called(ctx, tokenHash, newHash, expiresAt)
+	return args.Get(0).(types.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeRefreshToken(ctx context.Context, tokenHash []byte) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListRoles(ctx context.Context) ([]Role, error) {
+	args := m.Called(ctx)
+	if result := args.Get(0); result != nil {
+		return result.([]Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserRoles(ctx context.Context, id types.UUID) ([]Role, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.([]Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) AssignRole(ctx context.Context, id types.UUID, role string) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeRole(ctx context.Context, id types.UUID, role string) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserPermissions(ctx context.Context, id types.UUID) ([]string, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.([]string), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) LogPolicyDecision(ctx context.Context, d *PolicyDecision) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateAPIKey(ctx context.Context, owner *types.UUID, k *APIKeyRequest, prefix string, secretHash []byte) (*APIKey, error) {
+	args := m.Called(ctx, owner, k, prefix, secretHash)
+	if result := args.Get(0); result != nil {
+		return result.(*APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ListAPIKeys(ctx context.Context, owner *types.UUID) ([]APIKey, error) {
+	args := m.Called(ctx, owner)
+	if result := args.Get(0); result != nil {
+		return result.([]APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeAPIKey(ctx context.Context, owner *types.UUID, id types.UUID) error {
+	args := m.Called(ctx, owner, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RotateAPIKey(ctx context.Context, owner *types.UUID, id types.UUID, prefix string, secretHash []byte) (*APIKey, error) {
+	args := m.Called(ctx, owner, id, prefix, secretHash)
+	if result := args.Get(0); result != nil {
+		return result.(*APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) PasswordResetUsage(ctx context.Context, email, clientIP string, since time.Time) (*PasswordResetUsage, error) {
+	args := m.Called(ctx, email, clientIP, since)
+	if result := args.Get(0); result != nil {
+		return result.(*PasswordResetUsage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CreatePasswordReset(ctx context.Context, r *PasswordReset) (*User, error) {
+	args := m.Called(ctx, r)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetPasswordResetUser(ctx context.Context, tokenHash []byte) (*User, error) {
+	args := m.Called(ctx, tokenHash)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ResetPassword(ctx context.Context, tokenHash []byte, passwordHash string) error {
+	args := m.Called(ctx, tokenHash, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListUsers(ctx context.Context, after types.UUID, limit int, filter *UserFilter) ([]User, error) {
+	args := m.Called(ctx, after, limit, filter)
+	if result := args.Get(0); result != nil {
+		return result.([]User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserStatus(ctx context.Context, id types.UUID) (UserStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(UserStatus), args.Error(1)
+}
+
+func (m *MockUserRepository) ChangeUserStatus(ctx context.Context, id types.UUID, c *StatusChange) (*User, error) {
+	args := m.Called(ctx, id, c)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ListUserStatusEvents(ctx context.Context, id types.UUID) ([]UserStatusEvent, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.([]UserStatusEvent), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ResolveOrg(ctx context.Context, ref string) (types.UUID, error) {
+	args := m.Called(ctx, ref)
+	return args.Get(0).(types.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	args := m.Called(ctx)
+	if result := args.Get(0); result != nil {
+		return result.([]Organization), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CreateOrganization(ctx context.Context, o *OrganizationRequest) (*Organization, error) {
+	args := m.Called(ctx, o)
+	if result := args.Get(0); result != nil {
+		return result.(*Organization), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetOrganization(ctx context.Context, id types.UUID) (*Organization, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Organization), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ListGroups(ctx context.Context) ([]Group, error) {
+	args := m.Called(ctx)
+	if result := args.Get(0); result != nil {
+		return result.([]Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CreateGroup(ctx context.Context, g *GroupRequest) (*Group, error) {
+	args := m.Called(ctx, g)
+	if result := args.Get(0); result != nil {
+		return result.(*Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetGroup(ctx context.Context, id types.UUID) (*Group, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateGroup(ctx context.Context, id types.UUID, g *GroupRequest) (*Group, error) {
+	args := m.Called(ctx, id, g)
+	if result := args.Get(0); result != nil {
+		return result.(*Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteGroup(ctx context.Context, id types.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListGroupMembers(ctx context.Context, id types.UUID, transitive bool) (*GroupMembers, error) {
+	args := m.Called(ctx, id, transitive)
+	if result := args.Get(0); result != nil {
+		return result.(*GroupMembers), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ListUserGroups(ctx context.Context, id types.UUID, transitive bool) ([]Group, error) {
+	args := m.Called(ctx, id, transitive)
+	if result := args.Get(0); result != nil {
+		return result.([]Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) IsGroupMember(ctx context.Context, groupID, id types.UUID) (*GroupMembership, error) {
+	args := m.Called(ctx, groupID, id)
+	if result := args.Get(0); result != nil {
+		return result.(*GroupMembership), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) AddGroupUser(ctx context.Context, groupID, id types.UUID) error {
+	args := m.Called(ctx, groupID, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveGroupUser(ctx context.Context, groupID, id types.UUID) error {
+	args := m.Called(ctx, groupID, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) AddSubgroup(ctx context.Context, parentID, childID types.UUID) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveSubgroup(ctx context.Context, parentID, childID types.UUID) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateInvitation(ctx context.Context, inv *NewInvitation) (*Invitation, error) {
+	args := m.Called(ctx, inv)
+	if result := args.Get(0); result != nil {
+		return result.(*Invitation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ListInvitations(ctx context.Context, status *InvitationStatus) ([]Invitation, error) {
+	args := m.Called(ctx, status)
+	if result := args.Get(0); result != nil {
+		return result.([]Invitation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetInvitation(ctx context.Context, id types.UUID) (*Invitation, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Invitation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetInvitationByToken(ctx context.Context, tokenHash []byte) (*Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if result := args.Get(0); result != nil {
+		return result.(*Invitation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeInvitation(ctx context.Context, id types.UUID, e *InvitationEvent) error {
+	args := m.Called(ctx, id, e)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ResendInvitation(ctx context.Context, id types.UUID, tokenHash []byte, expiresAt time.Time, e *InvitationEvent) (*Invitation, error) {
+	args := m.Called(ctx, id, tokenHash, expiresAt, e)
+	if result := args.Get(0); result != nil {
+		return result.(*Invitation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) AcceptInvitation(ctx context.Context, tokenHash []byte, a *InvitationAcceptance) (*User, error) {
+	args := m.Called(ctx, tokenHash, a)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetAttributeSchema(ctx context.Context) (*AttributeSchema, error) {
+	args := m.Called(ctx)
+	if result := args.Get(0); result != nil {
+		return result.(*AttributeSchema), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) PutAttributeSchema(ctx context.Context, schema map[string]interface{}) (*AttributeSchema, error) {
+	args := m.Called(ctx, schema)
+	if result := args.Get(0); result != nil {
+		return result.(*AttributeSchema), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteAttributeSchema(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetAvatar(ctx context.Context, id types.UUID, version, url *string) (*User, *string, error) {
+	args := m.Called(ctx, id, version, url)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*User), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockUserRepository) ExportUserData(ctx context.Context, id types.UUID) (*UserDataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*UserDataExport), args.Error(1)
+}
+
+func (m *MockUserRepository) EraseUser(ctx context.Context, id types.UUID, e *Erasure) (*UserErasure, *string, error) {
+	args := m.Called(ctx, id, e)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*UserErasure), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockUserRepository) ListLegalHolds(ctx context.Context, id types.UUID) ([]LegalHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]LegalHold), args.Error(1)
+}
+
+func (m *MockUserRepository) PlaceLegalHold(ctx context.Context, id types.UUID, c *LegalHoldChange) (*LegalHold, error) {
+	args := m.Called(ctx, id, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LegalHold), args.Error(1)
+}
+
+func (m *MockUserRepository) ReleaseLegalHold(ctx context.Context, id, holdID types.UUID, c *LegalHoldChange) (*LegalHold, error) {
+	args := m.Called(ctx, id, holdID, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LegalHold), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateEmailVerification(ctx context.Context, v *EmailVerification) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) LastEmailVerificationSentAt(ctx context.Context, id types.UUID) (*time.Time, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*time.Time), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) ConfirmEmail(ctx context.Context, id types.UUID, address string) (*User, error) {
+	args := m.Called(ctx, id, address)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetMFA(ctx context.Context, id types.UUID) (*MFA, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*MFA), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) StartTOTPEnrollment(ctx context.Context, id types.UUID, secret []byte) error {
+	args := m.Called(ctx, id, secret)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) EnableMFA(ctx context.Context, id types.UUID, step int64, recoveryHashes [][]byte, e *MFAEvent) error {
+	args := m.Called(ctx, id, step, recoveryHashes, e)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ResetMFA(ctx context.Context, id types.UUID, e *MFAEvent) error {
+	args := m.Called(ctx, id, e)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UseTOTPStep(ctx context.Context, id types.UUID, step int64) error {
+	args := m.Called(ctx, id, step)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UseRecoveryCode(ctx context.Context, id types.UUID, codeHash []byte) error {
+	args := m.Called(ctx, id, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateMFAChallenge(ctx context.Context, id types.UUID, tokenHash []byte, expiresAt time.Time) error {
+	args := m.Called(ctx, id, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetMFAChallenge(ctx context.Context, tokenHash []byte) (*MFAChallenge, error) {
+	args := m.Called(ctx, tokenHash)
+	if result := args.Get(0); result != nil {
+		return result.(*MFAChallenge), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) RecordMFAChallengeFailure(ctx context.Context, tokenHash []byte) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteMFAChallenge(ctx context.Context, tokenHash []byte) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*apikey.Key, error) {
+	args := m.Called(ctx, prefix)
+	if result := args.Get(0); result != nil {
+		return result.(*apikey.Key), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) TouchAPIKey(ctx context.Context, id types.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	args := m.Called(ctx, email)
+	if result := args.Get(0); result != nil {
+		return result.(*User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) SearchUsers(ctx context.Context, q string, limit int) ([]SearchResult, error) {
+	args := m.Called(ctx, q, limit)
+	if result := args.Get(0); result != nil {
+		return result.([]SearchResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) RunBatch(ctx context.Context, ops []BatchOperation, continueOnError bool) ([]BatchOperationResult, bool, error) {
+	args := m.Called(ctx, ops, continueOnError)
+	if result := args.Get(0); result != nil {
+		return result.([]BatchOperationResult), args.Bool(1), args.Error(2)
+	}
+	return nil, args.Bool(1), args.Error(2)
+}
+
+func (m *MockUserRepository) CreateJob(ctx context.Context, jobType string, payload map[string]interface{}) (*Job, error) {
+	args := m.Called(ctx, jobType, payload)
+	if result := args.Get(0); result != nil {
+		return result.(*Job), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetJob(ctx context.Context, id uint) (*Job, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Job), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CancelJob(ctx context.Context, id uint) (*Job, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*Job), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) GetJobArtifact(ctx context.Context, id uint) (*JobArtifact, error) {
+	args := m.Called(ctx, id)
+	if result := args.Get(0); result != nil {
+		return result.(*JobArtifact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func TestUserHandler_PostUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	fixedTime := time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC)
 	handler := &UserHandler{repo: mockRepo}
+	mailbox := withMailbox(t, handler)
 
 	testCases := []struct {
 		name           string
@@ -237,7 +933,7 @@ func TestUserHandler_PostUser(t *testing.T) {
 				},
 			},
 			mockResponse: &User{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
@@ -246,7 +942,7 @@ func TestUserHandler_PostUser(t *testing.T) {
 			},
 			mockError: nil,
 			expectedOutput: PostUser201JSONResponse{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
@@ -271,6 +967,8 @@ func TestUserHandler_PostUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.mockResponse != nil {
 				mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(tc.mockResponse, tc.mockError)
+				mockRepo.On("LastEmailVerificationSentAt", mock.Anything, tc.mockResponse.Id).Return(nil, nil)
+				mockRepo.On("CreateEmailVerification", mock.Anything, mock.Anything).Return(nil)
 			}
 
 			resp, err := handler.PostUser(context.Background(), tc.input)
@@ -288,6 +986,29 @@ func TestUserHandler_PostUser(t *testing.T) {
 			mockRepo.AssertExpectations(t)
 		})
 	}
+
+	messages, err := mailbox.Messages()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "john.doe@example.com", messages[0].To)
+}
+
+func TestUserHandler_PostUser_InvalidProfile(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := &UserHandler{repo: mockRepo}
+	phone := "555-0100"
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).
+		Return(nil, &profile.InvalidError{Problems: []string{"phone: must start with + and the country code"}})
+
+	resp, err := handler.PostUser(context.Background(), PostUserRequestObject{
+		Body: &PostUserJSONRequestBody{FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: &phone},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, PostUser400JSONResponse{
+		Error:   stringPtr("Invalid profile"),
+		Details: &[]string{"phone: must start with + and the country code"},
+	}, resp)
 }
 
 func TestUserHandler_GetUser(t *testing.T) {
@@ -297,7 +1018,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 
 	testCases := []struct {
 		name           string
-		inputID        uint
+		inputID        types.UUID
 		mockResponse   *User
 		mockError      error
 		expectedOutput GetUserResponseObject
@@ -305,9 +1026,9 @@ func TestUserHandler_GetUser(t *testing.T) {
 	}{
 		{
 			name:    "Successful retrieval",
-			inputID: 1,
+			inputID: userID(1),
 			mockResponse: &User{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     "john.doe@example.com",
@@ -316,7 +1037,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 			},
 			mockError: nil,
 			expectedOutput: GetUser200JSONResponse{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
@@ -327,7 +1048,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		},
 		{
 			name:           "User not found",
-			inputID:        2,
+			inputID:        userID(2),
 			mockResponse:   nil,
 			mockError:      ownErrors.ErrNotFound,
 			expectedOutput: GetUser404JSONResponse{Error: stringPtr("User not found")},
@@ -335,7 +1056,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		},
 		{
 			name:           "Internal server error",
-			inputID:        3,
+			inputID:        userID(3),
 			mockResponse:   nil,
 			mockError:      errors.New("database connection error"),
 			expectedOutput: GetUser500JSONResponse{Error: stringPtr("Internal server error")},
@@ -346,7 +1067,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			mockRepo.On("GetUser", mock.Anything, tc.inputID).Return(tc.mockResponse, tc.mockError)
-			resp, err := handler.GetUser(context.Background(), GetUserRequestObject{Id: tc.inputID})
+			resp, err := handler.GetUser(context.Background(), GetUserRequestObject{Id: tc.inputID.String()})
 
 			assert.Equal(t, tc.expectedError, err)
 			assert.Equal(t, tc.expectedOutput, resp)
@@ -355,6 +1076,103 @@ func TestUserHandler_GetUser(t *testing.T) {
 	}
 }
 
+func TestUserHandler_GetUser_LegacyIDs(t *testing.T) {
+	user := &User{Id: userID(7), FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+
+	t.Run("Resolved when enabled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo, legacyIDs: true}
+		mockRepo.On("ResolveLegacyUserIDs", mock.Anything, []uint{7}).Return(map[uint]types.UUID{7: userID(7)}, nil)
+		mockRepo.On("GetUser", mock.Anything, userID(7)).Return(user, nil)
+
+		resp, err := handler.GetUser(context.Background(), GetUserRequestObject{Id: "7"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, GetUser200JSONResponse(*user), resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown legacy id", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo, legacyIDs: true}
+		mockRepo.On("ResolveLegacyUserIDs", mock.Anything, []uint{8}).Return(map[uint]types.UUID{}, nil)
+
+		resp, err := handler.GetUser(context.Background(), GetUserRequestObject{Id: "8"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, GetUser404JSONResponse{Error: stringPtr("User not found")}, resp)
+		mockRepo.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejected when disabled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+
+		resp, err := handler.GetUser(context.Background(), GetUserRequestObject{Id: "7"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, GetUser400JSONResponse{Error: stringPtr("Invalid user ID")}, resp)
+		mockRepo.AssertNotCalled(t, "ResolveLegacyUserIDs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_GetUserByEmail(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	fixedTime := time.Date(2023, time.November, 10, 12, 0, 0, 0, time.UTC)
+	handler := &UserHandler{repo: mockRepo}
+
+	testCases := []struct {
+		name           string
+		email          string
+		mockResponse   *User
+		mockError      error
+		expectedOutput GetUserByEmailResponseObject
+	}{
+		{
+			name:  "Successful retrieval",
+			email: "john.doe@example.com",
+			mockResponse: &User{
+				Id:        userID(1),
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     "john.doe@example.com",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			},
+			expectedOutput: GetUserByEmail200JSONResponse{
+				Id:        userID(1),
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     types.Email("john.doe@example.com"),
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			},
+		},
+		{
+			name:           "User not found",
+			email:          "nobody@example.com",
+			mockError:      ownErrors.ErrNotFound,
+			expectedOutput: GetUserByEmail404JSONResponse{Error: stringPtr("User not found")},
+		},
+		{
+			name:           "Internal server error",
+			email:          "error@example.com",
+			mockError:      errors.New("database connection error"),
+			expectedOutput: GetUserByEmail500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo.On("GetUserByEmail", mock.Anything, tc.email).Return(tc.mockResponse, tc.mockError)
+			resp, err := handler.GetUserByEmail(context.Background(), GetUserByEmailRequestObject{Email: tc.email})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+		})
+	}
+}
+
 func TestUserHandler_PutUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	fixedTime := time.Date(2023, time.November, 10, 12, 0, 0, 0, time.UTC)
@@ -362,7 +1180,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 
 	testCases := []struct {
 		name           string
-		inputID        uint
+		inputID        types.UUID
 		inputBody      *PutUserJSONRequestBody
 		mockResponse   *User
 		mockError      error
@@ -371,14 +1189,14 @@ func TestUserHandler_PutUser(t *testing.T) {
 	}{
 		{
 			name:    "Successful update",
-			inputID: 1,
+			inputID: userID(1),
 			inputBody: &PutUserJSONRequestBody{
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
 			},
 			mockResponse: &User{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
@@ -387,7 +1205,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 			},
 			mockError: nil,
 			expectedOutput: PutUser200JSONResponse{
-				Id:        1,
+				Id:        userID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     types.Email("john.doe@example.com"),
@@ -398,7 +1216,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 		},
 		{
 			name:           "Missing request body",
-			inputID:        1,
+			inputID:        userID(1),
 			inputBody:      nil,
 			mockResponse:   nil,
 			mockError:      nil,
@@ -407,7 +1225,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 		},
 		{
 			name:    "User not found",
-			inputID: 2,
+			inputID: userID(2),
 			inputBody: &PutUserJSONRequestBody{
 				FirstName: "Jane",
 				LastName:  "Doe",
@@ -420,7 +1238,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 		},
 		{
 			name:    "Repository error",
-			inputID: 3,
+			inputID: userID(3),
 			inputBody: &PutUserJSONRequestBody{
 				FirstName: "Jane",
 				LastName:  "Doe",
@@ -431,6 +1249,32 @@ func TestUserHandler_PutUser(t *testing.T) {
 			expectedOutput: PutUser500JSONResponse{Error: stringPtr("Internal server error")},
 			expectedError:  nil,
 		},
+		{
+			name:    "Invalid email",
+			inputID: userID(4),
+			inputBody: &PutUserJSONRequestBody{
+				FirstName: "Jane",
+				LastName:  "Doe",
+				Email:     types.Email("jane@exa mple.com"),
+			},
+			mockResponse:   nil,
+			mockError:      ownErrors.ErrInvalidEmail,
+			expectedOutput: PutUser400JSONResponse{Error: stringPtr("Invalid email address")},
+			expectedError:  nil,
+		},
+		{
+			name:    "Email taken by another user",
+			inputID: userID(5),
+			inputBody: &PutUserJSONRequestBody{
+				FirstName: "Jane",
+				LastName:  "Doe",
+				Email:     types.Email("John.Doe@example.com"),
+			},
+			mockResponse:   nil,
+			mockError:      ownErrors.ErrUserAlreadyExists,
+			expectedOutput: PutUser409JSONResponse{Error: stringPtr("User already exists")},
+			expectedError:  nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -440,7 +1284,7 @@ func TestUserHandler_PutUser(t *testing.T) {
 			}
 
 			resp, err := handler.PutUser(context.Background(), PutUserRequestObject{
-				Id:   tc.inputID,
+				Id:   tc.inputID.String(),
 				Body: tc.inputBody,
 			})
 
@@ -461,6 +1305,23 @@ func TestUserHandler_PutUser(t *testing.T) {
 	}
 }
 
+// testHandler returns a handler with a discarded log and avatars stored in a temporary directory, together with the
+// blob store.
+func testHandler(t *testing.T, repo DB) (*UserHandler, blob.Store) {
+	store := blob.NewFileStore(t.TempDir())
+	return &UserHandler{
+		repo:         repo,
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		blobs:        store,
+		avatarLimits: avatar.Limits{MaxSize: 1 << 20, MaxPixels: 1 << 20},
+		avatarURL:    "https://users.example.com/api/v1/avatars/",
+	}, store
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/ownErrors"
+)
+
+// errInvalidUserID is returned for user IDs that are neither a UUID nor, while legacy IDs are enabled, an integer.
+var errInvalidUserID = errors.New("invalid user id")
+
+// parseUserID parses a public user ID. It returns the UUID of the user, or the legacy integer ID if legacy IDs are
+// enabled and id is one.
+func (h *UserHandler) parseUserID(id UserID) (openapi_types.UUID, uint, error) {
+	if uid, err := uuid.Parse(id); err == nil {
+		return uid, 0, nil
+	}
+
+	if h.legacyIDs {
+		if legacyID, err := strconv.ParseUint(id, 10, 32); err == nil && legacyID > 0 {
+			return uuid.Nil, uint(legacyID), nil
+		}
+	}
+
+	return uuid.Nil, 0, fmt.Errorf("%w: %q", errInvalidUserID, id)
+}
+
+// resolveUserIDs converts public user IDs into UUIDs. Legacy integer IDs are looked up with a single repository call;
+// IDs of unknown legacy users are left out of the result.
+func (h *UserHandler) resolveUserIDs(ctx context.Context, ids []UserID) (map[UserID]openapi_types.UUID, error) {
+	resolved := make(map[UserID]openapi_types.UUID, len(ids))
+	legacy := make(map[UserID]uint)
+	legacyIDs := make([]uint, 0)
+
+	for _, id := range ids {
+		uid, legacyID, err := h.parseUserID(id)
+		if err != nil {
+			return nil, err
+		}
+		if legacyID == 0 {
+			resolved[id] = uid
+			continue
+		}
+		if _, ok := legacy[id]; !ok {
+			legacy[id] = legacyID
+			legacyIDs = append(legacyIDs, legacyID)
+		}
+	}
+
+	if len(legacyIDs) == 0 {
+		return resolved, nil
+	}
+
+	uids, err := h.repo.ResolveLegacyUserIDs(ctx, legacyIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, legacyID := range legacy {
+		if uid, ok := uids[legacyID]; ok {
+			resolved[id] = uid
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveUserID converts a single public user ID into a UUID. Returns ErrNotFound for unknown legacy IDs.
+func (h *UserHandler) resolveUserID(ctx context.Context, id UserID) (openapi_types.UUID, error) {
+	resolved, err := h.resolveUserIDs(ctx, []UserID{id})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	uid, ok := resolved[id]
+	if !ok {
+		return uuid.Nil, ownErrors.ErrNotFound
+	}
+
+	return uid, nil
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-users/internal/mail"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// NewInvitation represents an invitation to be created together with its pending user.
+type NewInvitation struct {
+	Email         string
+	TokenHash     []byte
+	ExpiresAt     time.Time
+	InvitedByKind string
+	InvitedByID   string
+}
+
+// InvitationAcceptance represents the data an invitee completes their pending user with.
+type InvitationAcceptance struct {
+	FirstName    string
+	LastName     string
+	PasswordHash string
+}
+
+// ListInvitations returns invitations, optionally filtered by status
+func (h *UserHandler) ListInvitations(ctx context.Context, request ListInvitationsRequestObject) (ListInvitationsResponseObject, error) {
+	invitations, err := h.repo.ListInvitations(ctx, request.Params.Status)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListInvitations500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListInvitations200JSONResponse{Invitations: invitations}, nil
+}
+
+// CreateInvitation creates a pending user and mails them an invitation
+func (h *UserHandler) CreateInvitation(ctx context.Context, request CreateInvitationRequestObject) (CreateInvitationResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return CreateInvitation400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	var invitation *Invitation
+	if err == nil {
+		actor := newInvitationEvent(ctx, InvitationEventEventCreated)
+		invitation, err = h.repo.CreateInvitation(ctx, &NewInvitation{
+			Email:         string(request.Body.Email),
+			TokenHash:     tokenHash,
+			ExpiresAt:     time.Now().Add(h.invitationTTL).UTC(),
+			InvitedByKind: actor.ActorKind,
+			InvitedByID:   actor.ActorId,
+		})
+	}
+	if err == nil {
+		err = h.mailInvitation(ctx, invitation, token)
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidEmail) {
+			errorMsg := "Invalid email address"
+			return CreateInvitation400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrUserAlreadyExists) {
+			errorMsg := "User already exists"
+			return CreateInvitation409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to create invitation", "error", err)
+		errorMsg := "Internal server error"
+		return CreateInvitation500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CreateInvitation201JSONResponse(*invitation), nil
+}
+
+// GetInvitation returns an invitation with its events
+func (h *UserHandler) GetInvitation(ctx context.Context, request GetInvitationRequestObject) (GetInvitationResponseObject, error) {
+	invitation, err := h.repo.GetInvitation(ctx, request.InvitationId)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Invitation not found"
+			return GetInvitation404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetInvitation500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetInvitation200JSONResponse(*invitation), nil
+}
+
+// RevokeInvitation revokes an invitation and deletes its pending user
+func (h *UserHandler) RevokeInvitation(ctx context.Context, request RevokeInvitationRequestObject) (RevokeInvitationResponseObject, error) {
+	err := h.repo.RevokeInvitation(ctx, request.InvitationId, newInvitationEvent(ctx, InvitationEventEventRevoked))
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Invitation not found"
+			return RevokeInvitation404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrInvitationClosed) {
+			errorMsg := "Invitation already accepted or revoked"
+			return RevokeInvitation409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RevokeInvitation500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RevokeInvitation204Response{}, nil
+}
+
+// ResendInvitation mails a new link for an open invitation
+func (h *UserHandler) ResendInvitation(ctx context.Context, request ResendInvitationRequestObject) (ResendInvitationResponseObject, error) {
+	token, tokenHash, err := newOpaqueToken()
+	var invitation *Invitation
+	if err == nil {
+		invitation, err = h.repo.ResendInvitation(ctx, request.InvitationId, tokenHash, time.Now().Add(h.invitationTTL).UTC(),
+			newInvitationEvent(ctx, InvitationEventEventResent))
+	}
+	if err == nil {
+		err = h.mailInvitation(ctx, invitation, token)
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Invitation not found"
+			return ResendInvitation404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrInvitationClosed) {
+			errorMsg := "Invitation already accepted or revoked"
+			return ResendInvitation409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to resend invitation", "error", err, "invitation_id", request.InvitationId)
+		errorMsg := "Internal server error"
+		return ResendInvitation500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ResendInvitation200JSONResponse(*invitation), nil
+}
+
+// AcceptInvitation completes the pending user of an invitation and activates them
+func (h *UserHandler) AcceptInvitation(ctx context.Context, request AcceptInvitationRequestObject) (AcceptInvitationResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return AcceptInvitation400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	invalidToken := func() (AcceptInvitationResponseObject, error) {
+		errorMsg := "Invalid or expired token"
+		return AcceptInvitation400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	internalError := func() (AcceptInvitationResponseObject, error) {
+		errorMsg := "Internal server error"
+		return AcceptInvitation500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	firstName := strings.TrimSpace(request.Body.FirstName)
+	lastName := strings.TrimSpace(request.Body.LastName)
+	if firstName == "" || lastName == "" {
+		errorMsg := "First and last name are required"
+		return AcceptInvitation400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	tokenHash, ok := hashOpaqueToken(request.Body.Token)
+	if !ok {
+		return invalidToken()
+	}
+
+	invitation, err := h.repo.GetInvitationByToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			return invalidToken()
+		}
+		return internalError()
+	}
+
+	if violations := h.policy.Validate(request.Body.Password, string(invitation.Email)); len(violations) > 0 {
+		errorMsg := "Password does not satisfy the policy"
+		return AcceptInvitation400JSONResponse{
+			Error:   &errorMsg,
+			Details: &violations,
+		}, nil
+	}
+
+	hash, err := h.hasher.Hash(request.Body.Password)
+	var user *User
+	if err == nil {
+		user, err = h.repo.AcceptInvitation(ctx, tokenHash, &InvitationAcceptance{
+			FirstName:    firstName,
+			LastName:     lastName,
+			PasswordHash: hash,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			return invalidToken()
+		}
+		return internalError()
+	}
+
+	return AcceptInvitation200JSONResponse(*user), nil
+}
+
+// mailInvitation sends the link of an invitation in the background.
+func (h *UserHandler) mailInvitation(ctx context.Context, invitation *Invitation, token string) error {
+	link, err := tokenLink(h.invitationURL, token)
+	if err != nil {
+		return err
+	}
+
+	h.sendMailInBackground(ctx, &mail.Message{
+		To:      string(invitation.Email),
+		Subject: "You have been invited",
+		Body: fmt.Sprintf("Hello,\n\nyou have been invited to create an account. To accept the invitation and choose "+
+			"your password, open this link:\n\n%s\n\nThe link expires on %s.\n",
+			link, invitation.ExpiresAt.Format(time.RFC1123)),
+	})
+	return nil
+}
+
+// newInvitationEvent returns an event of an invitation caused by the principal of ctx.
+func newInvitationEvent(ctx context.Context, event InvitationEventEvent) *InvitationEvent {
+	e := &InvitationEvent{Event: event}
+	if principal, ok := router.PrincipalFromContext(ctx); ok {
+		e.ActorKind = string(principal.Kind)
+		e.ActorId = principal.ID
+	}
+	return e
+}
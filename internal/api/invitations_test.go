@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_CreateInvitation(t *testing.T) {
+	admin := &router.Principal{Kind: router.PrincipalUser, ID: userID(9).String()}
+	ctx := router.WithPrincipal(context.Background(), admin)
+	request := CreateInvitationRequestObject{Body: &InvitationRequest{Email: "john@example.com"}}
+
+	t.Run("Invitation mailed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		var stored *NewInvitation
+		invitation := &Invitation{Id: userID(5), Email: "john@example.com", Status: InvitationStatusPending,
+			ExpiresAt: time.Now().Add(7 * 24 * time.Hour)}
+		mockRepo.On("CreateInvitation", ctx, mock.MatchedBy(func(inv *NewInvitation) bool {
+			stored = inv
+			return inv.Email == "john@example.com" && inv.InvitedByKind == "user" && inv.InvitedByID == admin.ID &&
+				inv.ExpiresAt.After(time.Now().Add(6*24*time.Hour))
+		})).Return(invitation, nil)
+
+		resp, err := handler.CreateInvitation(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, CreateInvitation201JSONResponse(*invitation), resp)
+		handler.background.Wait()
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "john@example.com", messages[0].To)
+		assert.Contains(t, messages[0].Body, "https://example.com/accept-invitation?token=")
+
+		// Only the hash of the mailed token is stored.
+		raw, err := base64.RawURLEncoding.DecodeString(mailedToken(t, messages[0]))
+		require.NoError(t, err)
+		hash := sha256.Sum256(raw)
+		assert.Equal(t, hash[:], stored.TokenHash)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("User already exists", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		mockRepo.On("CreateInvitation", ctx, mock.Anything).Return(nil, ownErrors.ErrUserAlreadyExists)
+
+		resp, err := handler.CreateInvitation(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, CreateInvitation409JSONResponse{}, resp)
+		handler.background.Wait()
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		mockRepo.On("CreateInvitation", ctx, mock.Anything).Return(nil, ownErrors.ErrInvalidEmail)
+
+		resp, err := handler.CreateInvitation(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, CreateInvitation400JSONResponse{}, resp)
+	})
+
+	t.Run("Missing body", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.CreateInvitation(ctx, CreateInvitationRequestObject{})
+		require.NoError(t, err)
+		assert.IsType(t, CreateInvitation400JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_ListInvitations(t *testing.T) {
+	pending := InvitationStatusPending
+
+	t.Run("Filtered by status", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		invitations := []Invitation{{Id: userID(5), Email: "john@example.com", Status: InvitationStatusPending}}
+		mockRepo.On("ListInvitations", mock.Anything, &pending).Return(invitations, nil)
+
+		resp, err := handler.ListInvitations(context.Background(), ListInvitationsRequestObject{
+			Params: ListInvitationsParams{Status: &pending},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ListInvitations200JSONResponse{Invitations: invitations}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListInvitations", mock.Anything, (*InvitationStatus)(nil)).Return(nil, errors.New("db down"))
+
+		resp, err := handler.ListInvitations(context.Background(), ListInvitationsRequestObject{})
+		require.NoError(t, err)
+		assert.IsType(t, ListInvitations500JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_RevokeInvitation(t *testing.T) {
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalService, ID: "ci"})
+	event := &InvitationEvent{Event: InvitationEventEventRevoked, ActorKind: "service", ActorId: "ci"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected RevokeInvitationResponseObject
+	}{
+		{name: "Revoked", expected: RevokeInvitation204Response{}},
+		{name: "Not found", err: ownErrors.ErrNotFound, expected: RevokeInvitation404JSONResponse{}},
+		{name: "Already closed", err: ownErrors.ErrInvitationClosed, expected: RevokeInvitation409JSONResponse{}},
+		{name: "Database error", err: errors.New("db down"), expected: RevokeInvitation500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			mockRepo.On("RevokeInvitation", ctx, userID(5), event).Return(tc.err)
+
+			resp, err := handler.RevokeInvitation(ctx, RevokeInvitationRequestObject{InvitationId: userID(5)})
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ResendInvitation(t *testing.T) {
+	t.Run("New link mailed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		invitation := &Invitation{Id: userID(5), Email: "john@example.com", Status: InvitationStatusPending}
+		mockRepo.On("ResendInvitation", mock.Anything, userID(5), mock.Anything, mock.Anything, mock.MatchedBy(func(e *InvitationEvent) bool {
+			return e.Event == InvitationEventEventResent
+		})).Return(invitation, nil)
+
+		resp, err := handler.ResendInvitation(context.Background(), ResendInvitationRequestObject{InvitationId: userID(5)})
+		require.NoError(t, err)
+		assert.Equal(t, ResendInvitation200JSONResponse(*invitation), resp)
+		handler.background.Wait()
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.NotEmpty(t, mailedToken(t, messages[0]))
+	})
+
+	t.Run("Already accepted", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		mockRepo.On("ResendInvitation", mock.Anything, userID(5), mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, ownErrors.ErrInvitationClosed)
+
+		resp, err := handler.ResendInvitation(context.Background(), ResendInvitationRequestObject{InvitationId: userID(5)})
+		require.NoError(t, err)
+		assert.IsType(t, ResendInvitation409JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_AcceptInvitation(t *testing.T) {
+	token, tokenHash, err := newOpaqueToken()
+	require.NoError(t, err)
+	invitation := &Invitation{Id: userID(5), Email: "john@example.com", Status: InvitationStatusPending}
+	body := func(password string) AcceptInvitationRequestObject {
+		return AcceptInvitationRequestObject{Body: &InvitationAcceptRequest{
+			Token: token, FirstName: " John ", LastName: "Doe", Password: password,
+		}}
+	}
+
+	t.Run("User activated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		john := &User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: "john@example.com", Status: UserStatusActive}
+		mockRepo.On("GetInvitationByToken", mock.Anything, tokenHash).Return(invitation, nil)
+		mockRepo.On("AcceptInvitation", mock.Anything, tokenHash, mock.MatchedBy(func(a *InvitationAcceptance) bool {
+			match, _, err := handler.hasher.Verify("Correct-Horse-42", a.PasswordHash)
+			return err == nil && match && a.FirstName == "John" && a.LastName == "Doe"
+		})).Return(john, nil)
+
+		resp, err := handler.AcceptInvitation(context.Background(), body("Correct-Horse-42"))
+		require.NoError(t, err)
+		assert.Equal(t, AcceptInvitation200JSONResponse(*john), resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Password policy violated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetInvitationByToken", mock.Anything, tokenHash).Return(invitation, nil)
+
+		resp, err := handler.AcceptInvitation(context.Background(), body("short"))
+		require.NoError(t, err)
+		require.IsType(t, AcceptInvitation400JSONResponse{}, resp)
+		assert.NotEmpty(t, *resp.(AcceptInvitation400JSONResponse).Details)
+		mockRepo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid or expired token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetInvitationByToken", mock.Anything, tokenHash).Return(nil, ownErrors.ErrInvalidToken)
+
+		for _, token := range []string{token, "malformed"} {
+			request := body("Correct-Horse-42")
+			request.Body.Token = token
+			resp, err := handler.AcceptInvitation(context.Background(), request)
+			require.NoError(t, err)
+			assert.Equal(t, "Invalid or expired token", *resp.(AcceptInvitation400JSONResponse).Error)
+		}
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		request := body("Correct-Horse-42")
+		request.Body.LastName = " "
+
+		resp, err := handler.AcceptInvitation(context.Background(), request)
+		require.NoError(t, err)
+		assert.IsType(t, AcceptInvitation400JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "GetInvitationByToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetInvitationByToken", mock.Anything, tokenHash).Return(invitation, nil)
+		mockRepo.On("AcceptInvitation", mock.Anything, tokenHash, mock.Anything).Return(nil, errors.New("db down"))
+
+		resp, err := handler.AcceptInvitation(context.Background(), body("Correct-Horse-42"))
+		require.NoError(t, err)
+		assert.IsType(t, AcceptInvitation500JSONResponse{}, resp)
+	})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+
+	"go-users/internal/ownErrors"
+)
+
+// JobArtifact represents a file produced by a finished job.
+type JobArtifact struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// PostJob enqueues a bulk operation and returns its location for polling
+func (h *UserHandler) PostJob(ctx context.Context, request PostJobRequestObject) (PostJobResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return PostJob400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	var payload map[string]interface{}
+	if request.Body.Payload != nil {
+		payload = *request.Body.Payload
+	}
+
+	switch request.Body.Type {
+	case JobRequestTypeUsersExport, JobRequestTypeUsersReindex:
+	case JobRequestTypeUsersImport:
+		if users, ok := payload["users"].([]interface{}); !ok || len(users) == 0 {
+			errorMsg := "Payload must contain a non-empty users list"
+			return PostJob400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+	default:
+		errorMsg := fmt.Sprintf("Unknown job type %q", request.Body.Type)
+		return PostJob400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	job, err := h.repo.CreateJob(ctx, string(request.Body.Type), payload)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return PostJob500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return PostJob202JSONResponse{
+		Body: *job,
+		Headers: PostJob202ResponseHeaders{
+			Location: fmt.Sprintf("%s/jobs/%d", h.apiPrefix, job.Id),
+		},
+	}, nil
+}
+
+// GetJob returns the status, progress and result of a job
+func (h *UserHandler) GetJob(ctx context.Context, request GetJobRequestObject) (GetJobResponseObject, error) {
+	job, err := h.repo.GetJob(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Job not found"
+			return GetJob404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return GetJob500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetJob200JSONResponse(*job), nil
+}
+
+// CancelJob cancels a queued job or requests cancellation of a running one
+func (h *UserHandler) CancelJob(ctx context.Context, request CancelJobRequestObject) (CancelJobResponseObject, error) {
+	job, err := h.repo.CancelJob(ctx, request.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, ownErrors.ErrNotFound):
+			errorMsg := "Job not found"
+			return CancelJob404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		case errors.Is(err, ownErrors.ErrJobFinished):
+			errorMsg := "Job already finished"
+			return CancelJob409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return CancelJob500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CancelJob200JSONResponse(*job), nil
+}
+
+// GetJobArtifact streams the artifact produced by a finished job, which is deleted once downloaded
+func (h *UserHandler) GetJobArtifact(ctx context.Context, request GetJobArtifactRequestObject) (GetJobArtifactResponseObject, error) {
+	artifact, err := h.repo.GetJobArtifact(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Job artifact not found"
+			return GetJobArtifact404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		errorMsg := "Internal server error"
+		return GetJobArtifact500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetJobArtifact200ApplicationoctetStreamResponse{
+		Body:          bytes.NewReader(artifact.Data),
+		ContentLength: int64(len(artifact.Data)),
+		Headers: GetJobArtifact200ResponseHeaders{
+			ContentDisposition: mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}),
+		},
+	}, nil
+}
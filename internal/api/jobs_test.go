@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestUserHandler_PostJob(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	importPayload := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"email": "john@example.com", "first_name": "John", "last_name": "Doe"},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		body           *PostJobJSONRequestBody
+		mockResponse   *Job
+		mockError      error
+		expectedOutput PostJobResponseObject
+	}{
+		{
+			name: "Export accepted",
+			body: &PostJobJSONRequestBody{Type: JobRequestTypeUsersExport},
+			mockResponse: &Job{
+				Id:        7,
+				Type:      "users.export",
+				Status:    JobStatusQueued,
+				CreatedAt: fixedTime,
+			},
+			expectedOutput: PostJob202JSONResponse{
+				Body: Job{
+					Id:        7,
+					Type:      "users.export",
+					Status:    JobStatusQueued,
+					CreatedAt: fixedTime,
+				},
+				Headers: PostJob202ResponseHeaders{Location: "/api/v1/jobs/7"},
+			},
+		},
+		{
+			name: "Reindex accepted",
+			body: &PostJobJSONRequestBody{Type: JobRequestTypeUsersReindex},
+			mockResponse: &Job{
+				Id:        9,
+				Type:      "users.reindex",
+				Status:    JobStatusQueued,
+				CreatedAt: fixedTime,
+			},
+			expectedOutput: PostJob202JSONResponse{
+				Body: Job{
+					Id:        9,
+					Type:      "users.reindex",
+					Status:    JobStatusQueued,
+					CreatedAt: fixedTime,
+				},
+				Headers: PostJob202ResponseHeaders{Location: "/api/v1/jobs/9"},
+			},
+		},
+		{
+			name: "Import accepted",
+			body: &PostJobJSONRequestBody{Type: JobRequestTypeUsersImport, Payload: &importPayload},
+			mockResponse: &Job{
+				Id:        8,
+				Type:      "users.import",
+				Status:    JobStatusQueued,
+				CreatedAt: fixedTime,
+			},
+			expectedOutput: PostJob202JSONResponse{
+				Body: Job{
+					Id:        8,
+					Type:      "users.import",
+					Status:    JobStatusQueued,
+					CreatedAt: fixedTime,
+				},
+				Headers: PostJob202ResponseHeaders{Location: "/api/v1/jobs/8"},
+			},
+		},
+		{
+			name:           "Missing request body",
+			expectedOutput: PostJob400JSONResponse{Error: stringPtr("Missing request body")},
+		},
+		{
+			name:           "Import without users",
+			body:           &PostJobJSONRequestBody{Type: JobRequestTypeUsersImport},
+			expectedOutput: PostJob400JSONResponse{Error: stringPtr("Payload must contain a non-empty users list")},
+		},
+		{
+			name:           "Unknown job type",
+			body:           &PostJobJSONRequestBody{Type: "users.merge"},
+			expectedOutput: PostJob400JSONResponse{Error: stringPtr(`Unknown job type "users.merge"`)},
+		},
+		{
+			name:           "Repository error",
+			body:           &PostJobJSONRequestBody{Type: JobRequestTypeUsersExport},
+			mockError:      errors.New("database connection error"),
+			expectedOutput: PostJob500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo, apiPrefix: "/api/v1"}
+
+			if tc.mockResponse != nil || tc.mockError != nil {
+				mockRepo.On("CreateJob", mock.Anything, string(tc.body.Type), mock.Anything).Return(tc.mockResponse, tc.mockError)
+			}
+
+			resp, err := handler.PostJob(context.Background(), PostJobRequestObject{Body: tc.body})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_GetJob(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	job := &Job{
+		Id:        1,
+		Type:      "users.export",
+		Status:    JobStatusRunning,
+		Progress:  JobProgress{Done: 500, Total: 2000},
+		CreatedAt: fixedTime,
+		StartedAt: &fixedTime,
+	}
+
+	testCases := []struct {
+		name           string
+		mockResponse   *Job
+		mockError      error
+		expectedOutput GetJobResponseObject
+	}{
+		{
+			name:           "Job found",
+			mockResponse:   job,
+			expectedOutput: GetJob200JSONResponse(*job),
+		},
+		{
+			name:           "Job not found",
+			mockError:      ownErrors.ErrNotFound,
+			expectedOutput: GetJob404JSONResponse{Error: stringPtr("Job not found")},
+		},
+		{
+			name:           "Internal server error",
+			mockError:      errors.New("database connection error"),
+			expectedOutput: GetJob500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			mockRepo.On("GetJob", mock.Anything, uint(1)).Return(tc.mockResponse, tc.mockError)
+
+			resp, err := handler.GetJob(context.Background(), GetJobRequestObject{Id: 1})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+		})
+	}
+}
+
+func TestUserHandler_CancelJob(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	job := &Job{
+		Id:              1,
+		Type:            "users.import",
+		Status:          JobStatusRunning,
+		CancelRequested: true,
+		CreatedAt:       fixedTime,
+	}
+
+	testCases := []struct {
+		name           string
+		mockResponse   *Job
+		mockError      error
+		expectedOutput CancelJobResponseObject
+	}{
+		{
+			name:           "Cancellation requested",
+			mockResponse:   job,
+			expectedOutput: CancelJob200JSONResponse(*job),
+		},
+		{
+			name:           "Job not found",
+			mockError:      ownErrors.ErrNotFound,
+			expectedOutput: CancelJob404JSONResponse{Error: stringPtr("Job not found")},
+		},
+		{
+			name:           "Job already finished",
+			mockError:      ownErrors.ErrJobFinished,
+			expectedOutput: CancelJob409JSONResponse{Error: stringPtr("Job already finished")},
+		},
+		{
+			name:           "Internal server error",
+			mockError:      errors.New("database connection error"),
+			expectedOutput: CancelJob500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			mockRepo.On("CancelJob", mock.Anything, uint(1)).Return(tc.mockResponse, tc.mockError)
+
+			resp, err := handler.CancelJob(context.Background(), CancelJobRequestObject{Id: 1})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+		})
+	}
+}
+
+func TestUserHandler_GetJobArtifact(t *testing.T) {
+	t.Run("Artifact found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetJobArtifact", mock.Anything, uint(1)).Return(&JobArtifact{
+			Name:        "users.ndjson",
+			ContentType: "application/x-ndjson",
+			Data:        []byte(`{"id":1}` + "\n"),
+		}, nil)
+
+		resp, err := handler.GetJobArtifact(context.Background(), GetJobArtifactRequestObject{Id: 1})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitGetJobArtifactResponse(rec))
+
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, `attachment; filename=users.ndjson`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "9", rec.Header().Get("Content-Length"))
+		assert.Equal(t, `{"id":1}`+"\n", string(body))
+	})
+
+	t.Run("Artifact not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetJobArtifact", mock.Anything, uint(2)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.GetJobArtifact(context.Background(), GetJobArtifactRequestObject{Id: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, GetJobArtifact404JSONResponse{Error: stringPtr("Job artifact not found")}, resp)
+	})
+}
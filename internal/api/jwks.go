@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"go-users/internal/token"
+)
+
+// jwksMaxAge is how long clients may cache the key set. New keys are published well ahead of their use, so caching
+// for a few minutes never hides a key that signs tokens.
+const jwksMaxAge = "max-age=300"
+
+// JWKS returns an HTTP handler serving the public access token signing keys as a JSON Web Key Set.
+func JWKS(keys *token.Keyring) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, "+jwksMaxAge)
+		json.NewEncoder(w).Encode(keys.JWKS())
+	}
+}
+
+// RegisterJWKSRoute registers the well-known JWKS route in the provided router.
+func RegisterJWKSRoute(r chi.Router, keys *token.Keyring) {
+	r.Get("/.well-known/jwks.json", JWKS(keys))
+}
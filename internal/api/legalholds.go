@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// LegalHoldChange represents placing or releasing a legal hold, recorded with its reason and actor.
+type LegalHoldChange struct {
+	Reason string
+	// CaseReference identifies the litigation of a placed hold; it is not used on release.
+	CaseReference string
+	ActorKind     string
+	ActorID       string
+}
+
+// newLegalHoldChange returns a legal hold change with the principal of ctx as actor.
+func newLegalHoldChange(ctx context.Context, reason, caseReference string) *LegalHoldChange {
+	c := &LegalHoldChange{Reason: reason, CaseReference: caseReference}
+	if principal, ok := router.PrincipalFromContext(ctx); ok {
+		c.ActorKind = string(principal.Kind)
+		c.ActorID = principal.ID
+	}
+	return c
+}
+
+// ListLegalHolds returns the legal holds placed on a user, active and released
+func (h *UserHandler) ListLegalHolds(ctx context.Context, request ListLegalHoldsRequestObject) (ListLegalHoldsResponseObject, error) {
+	var holds []LegalHold
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		holds, err = h.repo.ListLegalHolds(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ListLegalHolds400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ListLegalHolds404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ListLegalHolds500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListLegalHolds200JSONResponse{Holds: holds}, nil
+}
+
+// PlaceLegalHold freezes a user for litigation, so they can no longer be deleted, erased or purged
+func (h *UserHandler) PlaceLegalHold(ctx context.Context, request PlaceLegalHoldRequestObject) (PlaceLegalHoldResponseObject, error) {
+	if request.Body == nil || strings.TrimSpace(request.Body.Reason) == "" {
+		errorMsg := "Missing legal hold reason"
+		return PlaceLegalHold400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if strings.TrimSpace(request.Body.CaseReference) == "" {
+		errorMsg := "Missing case reference"
+		return PlaceLegalHold400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	var hold *LegalHold
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		c := newLegalHoldChange(ctx, strings.TrimSpace(request.Body.Reason), strings.TrimSpace(request.Body.CaseReference))
+		hold, err = h.repo.PlaceLegalHold(ctx, id, c)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return PlaceLegalHold400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return PlaceLegalHold404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to place legal hold", "error", err, "user", request.Id)
+		errorMsg := "Internal server error"
+		return PlaceLegalHold500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return PlaceLegalHold201JSONResponse(*hold), nil
+}
+
+// ReleaseLegalHold releases a legal hold of a user, keeping it on record
+func (h *UserHandler) ReleaseLegalHold(ctx context.Context, request ReleaseLegalHoldRequestObject) (ReleaseLegalHoldResponseObject, error) {
+	var reason string
+	if request.Body != nil && request.Body.Reason != nil {
+		reason = strings.TrimSpace(*request.Body.Reason)
+	}
+
+	var hold *LegalHold
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		hold, err = h.repo.ReleaseLegalHold(ctx, id, request.HoldId, newLegalHoldChange(ctx, reason, ""))
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ReleaseLegalHold400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Legal hold not found"
+			return ReleaseLegalHold404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrLegalHoldReleased) {
+			errorMsg := "Legal hold is already released"
+			return ReleaseLegalHold409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to release legal hold", "error", err, "user", request.Id, "hold", request.HoldId)
+		errorMsg := "Internal server error"
+		return ReleaseLegalHold500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ReleaseLegalHold200JSONResponse(*hold), nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_ListLegalHolds(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler, _ := testHandler(t, mockRepo)
+	holds := []LegalHold{{Id: uuid.New(), UserId: userID(1), Reason: "Litigation", CaseReference: "CASE-1"}}
+	mockRepo.On("ListLegalHolds", mock.Anything, userID(1)).Return(holds, nil)
+	mockRepo.On("ListLegalHolds", mock.Anything, userID(2)).Return(nil, ownErrors.ErrNotFound)
+
+	resp, err := handler.ListLegalHolds(context.Background(), ListLegalHoldsRequestObject{Id: userID(1).String()})
+	require.NoError(t, err)
+	assert.Equal(t, ListLegalHolds200JSONResponse{Holds: holds}, resp)
+
+	resp, err = handler.ListLegalHolds(context.Background(), ListLegalHoldsRequestObject{Id: userID(2).String()})
+	require.NoError(t, err)
+	assert.IsType(t, ListLegalHolds404JSONResponse{}, resp)
+}
+
+func TestUserHandler_PlaceLegalHold(t *testing.T) {
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: "counsel"})
+
+	t.Run("Placed with actor", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, _ := testHandler(t, mockRepo)
+		hold := &LegalHold{Id: uuid.New(), UserId: userID(1), Reason: "Litigation", CaseReference: "CASE-1",
+			PlacedByKind: "user", PlacedById: "counsel", PlacedAt: time.Now()}
+		mockRepo.On("PlaceLegalHold", mock.Anything, userID(1),
+			&LegalHoldChange{Reason: "Litigation", CaseReference: "CASE-1", ActorKind: "user", ActorID: "counsel"}).Return(hold, nil)
+
+		resp, err := handler.PlaceLegalHold(ctx, PlaceLegalHoldRequestObject{
+			Id:   userID(1).String(),
+			Body: &PlaceLegalHoldJSONRequestBody{Reason: " Litigation ", CaseReference: " CASE-1 "},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, PlaceLegalHold201JSONResponse(*hold), resp)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			body *PlaceLegalHoldJSONRequestBody
+		}{
+			{name: "Missing body"},
+			{name: "Missing reason", body: &PlaceLegalHoldJSONRequestBody{Reason: " ", CaseReference: "CASE-1"}},
+			{name: "Missing case reference", body: &PlaceLegalHoldJSONRequestBody{Reason: "Litigation"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				handler, _ := testHandler(t, mockRepo)
+
+				resp, err := handler.PlaceLegalHold(ctx, PlaceLegalHoldRequestObject{Id: userID(1).String(), Body: tc.body})
+
+				require.NoError(t, err)
+				assert.IsType(t, PlaceLegalHold400JSONResponse{}, resp)
+				mockRepo.AssertNotCalled(t, "PlaceLegalHold", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, _ := testHandler(t, mockRepo)
+		mockRepo.On("PlaceLegalHold", mock.Anything, userID(1), mock.Anything).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.PlaceLegalHold(ctx, PlaceLegalHoldRequestObject{
+			Id:   userID(1).String(),
+			Body: &PlaceLegalHoldJSONRequestBody{Reason: "Litigation", CaseReference: "CASE-1"},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, PlaceLegalHold404JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_ReleaseLegalHold(t *testing.T) {
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalService, ID: "legal"})
+	holdID := uuid.New()
+
+	t.Run("Released with actor", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler, _ := testHandler(t, mockRepo)
+		hold := &LegalHold{Id: holdID, UserId: userID(1)}
+		reason := " Case closed "
+		mockRepo.On("ReleaseLegalHold", mock.Anything, userID(1), holdID,
+			&LegalHoldChange{Reason: "Case closed", ActorKind: "service", ActorID: "legal"}).Return(hold, nil)
+
+		resp, err := handler.ReleaseLegalHold(ctx, ReleaseLegalHoldRequestObject{
+			Id:     userID(1).String(),
+			HoldId: holdID,
+			Body:   &ReleaseLegalHoldJSONRequestBody{Reason: &reason},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ReleaseLegalHold200JSONResponse(*hold), resp)
+	})
+
+	t.Run("Errors mapped", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			err      error
+			expected ReleaseLegalHoldResponseObject
+		}{
+			{name: "Hold not found", err: ownErrors.ErrNotFound, expected: ReleaseLegalHold404JSONResponse{}},
+			{name: "Hold already released", err: ownErrors.ErrLegalHoldReleased, expected: ReleaseLegalHold409JSONResponse{}},
+			{name: "Database failure", err: assert.AnError, expected: ReleaseLegalHold500JSONResponse{}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				handler, _ := testHandler(t, mockRepo)
+				mockRepo.On("ReleaseLegalHold", mock.Anything, userID(1), holdID, mock.Anything).Return(nil, tc.err)
+
+				resp, err := handler.ReleaseLegalHold(ctx, ReleaseLegalHoldRequestObject{Id: userID(1).String(), HoldId: holdID})
+
+				require.NoError(t, err)
+				assert.IsType(t, tc.expected, resp)
+			})
+		}
+	})
+}
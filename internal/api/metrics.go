@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterMetricsRoute registers the route serving the metrics published with expvar, such as the retention counts,
+// as JSON in the provided router. The metrics are only served to API keys granted the getMetrics operation.
+func (h *UserHandler) RegisterMetricsRoute(r chi.Router) {
+	serve := h.enforcePolicy(func(_ context.Context, w http.ResponseWriter, r *http.Request, _ interface{}) (interface{}, error) {
+		expvar.Handler().ServeHTTP(w, r)
+		return nil, nil
+	}, "GetMetrics")
+	handler := authorize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(r.Context(), w, r, nil)
+	}))
+
+	r.Get("/debug/vars", func(w http.ResponseWriter, r *http.Request) {
+		// Marked like the operations secured by an API key, so that authorize rejects bearer tokens.
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ApiKeyAuthScopes, []string{})))
+	})
+}
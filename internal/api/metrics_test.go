@@ -0,0 +1,71 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/policy"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_RegisterMetricsRoute(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      *router.Principal
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous request is rejected",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Bearer token is rejected",
+			principal:      &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "API key without the scope is rejected",
+			principal:      &router.Principal{Kind: router.PrincipalService, ID: "ci", KeyID: "ci", Scopes: []string{policy.UsersRead}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Static API key reads the metrics",
+			principal:      &router.Principal{Kind: router.PrincipalService, ID: "ci", KeyID: "ci"},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("LogPolicyDecision", mock.Anything, mock.Anything).Return(nil)
+			handler := &UserHandler{
+				repo:   mockRepo,
+				logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+				access: policy.New(policy.DefaultRules()),
+			}
+			r := chi.NewRouter()
+			handler.RegisterMetricsRoute(r)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+			if tc.principal != nil {
+				req = req.WithContext(router.WithPrincipal(req.Context(), tc.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"memstats"`)
+			}
+		})
+	}
+}
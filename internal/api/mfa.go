@@ -0,0 +1,337 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/mfa"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// MFA event types recorded in the MFA audit log.
+const (
+	MFAEventEnrolled         = "enrolled"
+	MFAEventReset            = "reset"
+	MFAEventRecoveryCodeUsed = "recovery_code_used"
+)
+
+// MFA represents the multi-factor authentication state of a user.
+type MFA struct {
+	// Secret is the TOTP secret of an enabled or pending enrollment; nil if the user never enrolled.
+	Secret []byte
+	// EnabledAt is nil while the enrollment awaits its confirmation.
+	EnabledAt              *time.Time
+	RecoveryCodesRemaining int
+	// Required tells that a role of the user requires MFA.
+	Required bool
+}
+
+// MFAChallenge represents a login awaiting its second factor.
+type MFAChallenge struct {
+	UserID    openapi_types.UUID
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+// MFAEvent represents an entry of the MFA audit log.
+type MFAEvent struct {
+	Event     string
+	ActorKind string
+	ActorID   string
+	Reason    string
+}
+
+// GetMfaStatus returns the MFA state of a user
+func (h *UserHandler) GetMfaStatus(ctx context.Context, request GetMfaStatusRequestObject) (GetMfaStatusResponseObject, error) {
+	var state *MFA
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		state, err = h.repo.GetMFA(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return GetMfaStatus400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return GetMfaStatus404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetMfaStatus500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetMfaStatus200JSONResponse{
+		Enabled:                state.EnabledAt != nil,
+		EnabledAt:              state.EnabledAt,
+		Required:               state.Required,
+		RecoveryCodesRemaining: state.RecoveryCodesRemaining,
+	}, nil
+}
+
+// EnrollTotp starts a TOTP enrollment of a user, replacing a pending one
+func (h *UserHandler) EnrollTotp(ctx context.Context, request EnrollTotpRequestObject) (EnrollTotpResponseObject, error) {
+	var user *User
+	var secret []byte
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		user, err = h.repo.GetUser(ctx, id)
+	}
+	if err == nil {
+		secret, err = mfa.GenerateSecret()
+	}
+	if err == nil {
+		err = h.repo.StartTOTPEnrollment(ctx, id, secret)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return EnrollTotp400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return EnrollTotp404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrMFAEnabled) {
+			errorMsg := "MFA already enabled"
+			return EnrollTotp409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return EnrollTotp500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return EnrollTotp200JSONResponse{
+		Secret:     mfa.EncodeSecret(secret),
+		OtpauthUri: mfa.URI(h.mfaIssuer, string(user.Email), secret),
+	}, nil
+}
+
+// ConfirmTotp enables a pending TOTP enrollment with a first code and returns new recovery codes
+func (h *UserHandler) ConfirmTotp(ctx context.Context, request ConfirmTotpRequestObject) (ConfirmTotpResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return ConfirmTotp400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	var state *MFA
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		state, err = h.repo.GetMFA(ctx, id)
+	}
+	if err == nil && state.EnabledAt != nil {
+		err = ownErrors.ErrMFAEnabled
+	}
+	if err != nil {
+		return confirmTotpError(err), nil
+	}
+
+	if state.Secret == nil {
+		errorMsg := "No pending enrollment"
+		return ConfirmTotp400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	step, ok := mfa.Validate(state.Secret, request.Body.Code, time.Now())
+	if !ok {
+		errorMsg := "Invalid code"
+		return ConfirmTotp400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	codes, hashes, err := mfa.GenerateRecoveryCodes(h.recoveryCodes)
+	if err == nil {
+		err = h.repo.EnableMFA(ctx, id, step, hashes, newMFAEvent(ctx, MFAEventEnrolled, ""))
+	}
+	if err != nil {
+		return confirmTotpError(err), nil
+	}
+
+	return ConfirmTotp200JSONResponse{RecoveryCodes: codes}, nil
+}
+
+// confirmTotpError maps an error of ConfirmTotp to its response.
+func confirmTotpError(err error) ConfirmTotpResponseObject {
+	if errors.Is(err, errInvalidUserID) {
+		errorMsg := "Invalid user ID"
+		return ConfirmTotp400JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		errorMsg := "User not found"
+		return ConfirmTotp404JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+	if errors.Is(err, ownErrors.ErrMFAEnabled) {
+		errorMsg := "MFA already enabled"
+		return ConfirmTotp409JSONResponse{
+			Error: &errorMsg,
+		}
+	}
+
+	errorMsg := "Internal server error"
+	return ConfirmTotp500JSONResponse{
+		Error: &errorMsg,
+	}
+}
+
+// ResetMfa removes the MFA of a user, e.g. after they lost their authenticator app and recovery codes
+func (h *UserHandler) ResetMfa(ctx context.Context, request ResetMfaRequestObject) (ResetMfaResponseObject, error) {
+	if request.Body == nil || request.Body.Reason == "" {
+		errorMsg := "Missing reset reason"
+		return ResetMfa400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.ResetMFA(ctx, id, newMFAEvent(ctx, MFAEventReset, request.Body.Reason))
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ResetMfa400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found or MFA not enabled"
+			return ResetMfa404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ResetMfa500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ResetMfa204Response{}, nil
+}
+
+// VerifyMfa completes a login with a code of the authenticator app or a recovery code
+func (h *UserHandler) VerifyMfa(ctx context.Context, request VerifyMfaRequestObject) (VerifyMfaResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return VerifyMfa400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if (request.Body.Code == nil) == (request.Body.RecoveryCode == nil) {
+		errorMsg := "Either a code or a recovery code is required"
+		return VerifyMfa400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	internalError := func() (VerifyMfaResponseObject, error) {
+		errorMsg := "Internal server error"
+		return VerifyMfa500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	invalidChallenge := func() (VerifyMfaResponseObject, error) {
+		errorMsg := "Invalid or expired MFA challenge"
+		return VerifyMfa401JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	challengeHash, ok := hashOpaqueToken(request.Body.MfaToken)
+	if !ok {
+		return invalidChallenge()
+	}
+	challenge, err := h.repo.GetMFAChallenge(ctx, challengeHash)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			return invalidChallenge()
+		}
+		return internalError()
+	}
+	now := time.Now()
+	if !challenge.ExpiresAt.After(now) || challenge.Attempts >= h.mfaRetries {
+		return invalidChallenge()
+	}
+
+	if request.Body.Code != nil {
+		var state *MFA
+		if state, err = h.repo.GetMFA(ctx, challenge.UserID); err != nil {
+			return internalError()
+		}
+		if step, ok := mfa.Validate(state.Secret, *request.Body.Code, now); ok {
+			// A step must not be used twice, which rejects replayed codes.
+			err = h.repo.UseTOTPStep(ctx, challenge.UserID, step)
+		} else {
+			err = ownErrors.ErrInvalidToken
+		}
+	} else {
+		err = h.repo.UseRecoveryCode(ctx, challenge.UserID, mfa.HashRecoveryCode(*request.Body.RecoveryCode))
+	}
+	if errors.Is(err, ownErrors.ErrInvalidToken) {
+		if err := h.repo.RecordMFAChallengeFailure(ctx, challengeHash); err != nil {
+			return internalError()
+		}
+		errorMsg := "Invalid code"
+		return VerifyMfa401JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if err != nil {
+		return internalError()
+	}
+
+	// Challenges are single use; losing a race against a concurrent verification fails this one.
+	var user *User
+	err = h.repo.DeleteMFAChallenge(ctx, challengeHash)
+	if err == nil {
+		user, err = h.repo.GetUser(ctx, challenge.UserID)
+	}
+	var session *LoginResponse
+	if err == nil {
+		session, err = h.openSession(ctx, user)
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) || errors.Is(err, ownErrors.ErrNotFound) {
+			return invalidChallenge()
+		}
+		return internalError()
+	}
+
+	return VerifyMfa200JSONResponse(*session), nil
+}
+
+// newMFAEvent returns an audit log entry attributed to the principal of the request.
+func newMFAEvent(ctx context.Context, event, reason string) *MFAEvent {
+	e := &MFAEvent{Event: event, Reason: reason}
+	if principal, ok := router.PrincipalFromContext(ctx); ok {
+		e.ActorKind = string(principal.Kind)
+		e.ActorID = principal.ID
+	}
+	return e
+}
@@ -0,0 +1,336 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/mfa"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// testSecret is the RFC 6238 SHA-1 test secret.
+var testSecret = []byte("12345678901234567890")
+
+func TestUserHandler_GetMfaStatus(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := testAuthHandler(t, mockRepo)
+	enabledAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	mockRepo.On("GetMFA", mock.Anything, userID(1)).
+		Return(&MFA{Secret: testSecret, EnabledAt: &enabledAt, RecoveryCodesRemaining: 3, Required: true}, nil)
+
+	resp, err := handler.GetMfaStatus(context.Background(), GetMfaStatusRequestObject{Id: userID(1).String()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, GetMfaStatus200JSONResponse{
+		Enabled:                true,
+		EnabledAt:              &enabledAt,
+		Required:               true,
+		RecoveryCodesRemaining: 3,
+	}, resp)
+}
+
+func TestUserHandler_EnrollTotp(t *testing.T) {
+	john := &User{Id: userID(1), Email: types.Email("john@example.com")}
+
+	t.Run("Secret and URI returned", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		var secret []byte
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("StartTOTPEnrollment", mock.Anything, userID(1), mock.AnythingOfType("[]uint8")).
+			Run(func(args mock.Arguments) { secret = args.Get(2).([]byte) }).
+			Return(nil)
+
+		resp, err := handler.EnrollTotp(context.Background(), EnrollTotpRequestObject{Id: userID(1).String()})
+
+		assert.NoError(t, err)
+		require.IsType(t, EnrollTotp200JSONResponse{}, resp)
+		enrollment := resp.(EnrollTotp200JSONResponse)
+		assert.Equal(t, mfa.EncodeSecret(secret), enrollment.Secret)
+		assert.Equal(t, mfa.URI("go-users", "john@example.com", secret), enrollment.OtpauthUri)
+	})
+
+	t.Run("Already enabled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("StartTOTPEnrollment", mock.Anything, userID(1), mock.Anything).Return(ownErrors.ErrMFAEnabled)
+
+		resp, err := handler.EnrollTotp(context.Background(), EnrollTotpRequestObject{Id: userID(1).String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, EnrollTotp409JSONResponse{Error: stringPtr("MFA already enabled")}, resp)
+	})
+}
+
+func TestUserHandler_ConfirmTotp(t *testing.T) {
+	t.Run("Valid code enables MFA", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: userID(1).String()})
+		step := mfa.Step(time.Now())
+
+		var hashes [][]byte
+		mockRepo.On("GetMFA", mock.Anything, userID(1)).Return(&MFA{Secret: testSecret}, nil)
+		mockRepo.On("EnableMFA", mock.Anything, userID(1), step, mock.Anything,
+			&MFAEvent{Event: MFAEventEnrolled, ActorKind: "user", ActorID: userID(1).String()}).
+			Run(func(args mock.Arguments) { hashes = args.Get(3).([][]byte) }).
+			Return(nil)
+
+		resp, err := handler.ConfirmTotp(ctx, ConfirmTotpRequestObject{
+			Id:   userID(1).String(),
+			Body: &ConfirmTotpJSONRequestBody{Code: mfa.Code(testSecret, step)},
+		})
+
+		assert.NoError(t, err)
+		require.IsType(t, ConfirmTotp200JSONResponse{}, resp)
+		codes := resp.(ConfirmTotp200JSONResponse).RecoveryCodes
+		require.Len(t, codes, 4)
+		require.Len(t, hashes, 4)
+		for i, code := range codes {
+			assert.Equal(t, mfa.HashRecoveryCode(code), hashes[i])
+		}
+	})
+
+	tests := []struct {
+		name     string
+		state    *MFA
+		code     string
+		expected ConfirmTotpResponseObject
+	}{
+		{
+			name:     "Invalid code",
+			state:    &MFA{Secret: testSecret},
+			code:     "000000",
+			expected: ConfirmTotp400JSONResponse{Error: stringPtr("Invalid code")},
+		},
+		{
+			name:     "No pending enrollment",
+			state:    &MFA{},
+			code:     "000000",
+			expected: ConfirmTotp400JSONResponse{Error: stringPtr("No pending enrollment")},
+		},
+		{
+			name:     "Already enabled",
+			state:    &MFA{Secret: testSecret, EnabledAt: &time.Time{}},
+			code:     mfa.Code(testSecret, mfa.Step(time.Now())),
+			expected: ConfirmTotp409JSONResponse{Error: stringPtr("MFA already enabled")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			mockRepo.On("GetMFA", mock.Anything, userID(1)).Return(tc.state, nil)
+
+			resp, err := handler.ConfirmTotp(context.Background(), ConfirmTotpRequestObject{
+				Id:   userID(1).String(),
+				Body: &ConfirmTotpJSONRequestBody{Code: tc.code},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, resp)
+			mockRepo.AssertNotCalled(t, "EnableMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserHandler_ResetMfa(t *testing.T) {
+	admin := &router.Principal{Kind: router.PrincipalUser, ID: userID(9).String()}
+
+	t.Run("Reset is audited", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ResetMFA", mock.Anything, userID(1),
+			&MFAEvent{Event: MFAEventReset, ActorKind: "user", ActorID: userID(9).String(), Reason: "Lost phone"}).
+			Return(nil)
+
+		resp, err := handler.ResetMfa(router.WithPrincipal(context.Background(), admin), ResetMfaRequestObject{
+			Id:   userID(1).String(),
+			Body: &ResetMfaJSONRequestBody{Reason: "Lost phone"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ResetMfa204Response{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reason required", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.ResetMfa(context.Background(), ResetMfaRequestObject{
+			Id:   userID(1).String(),
+			Body: &ResetMfaJSONRequestBody{},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ResetMfa400JSONResponse{Error: stringPtr("Missing reset reason")}, resp)
+	})
+
+	t.Run("Not enrolled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ResetMFA", mock.Anything, userID(1), mock.Anything).Return(ownErrors.ErrNotFound)
+
+		resp, err := handler.ResetMfa(context.Background(), ResetMfaRequestObject{
+			Id:   userID(1).String(),
+			Body: &ResetMfaJSONRequestBody{Reason: "Lost phone"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ResetMfa404JSONResponse{Error: stringPtr("User not found or MFA not enabled")}, resp)
+	})
+}
+
+func TestUserHandler_VerifyMfa(t *testing.T) {
+	john := &User{Id: userID(1), Email: types.Email("john@example.com")}
+
+	newChallenge := func(t *testing.T) (string, []byte) {
+		challenge, hash, err := newOpaqueToken()
+		require.NoError(t, err)
+		return challenge, hash
+	}
+
+	t.Run("Valid code opens a session", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		challenge, hash := newChallenge(t)
+		step := mfa.Step(time.Now())
+
+		mockRepo.On("GetMFAChallenge", mock.Anything, hash).
+			Return(&MFAChallenge{UserID: userID(1), ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		mockRepo.On("GetMFA", mock.Anything, userID(1)).Return(&MFA{Secret: testSecret}, nil)
+		mockRepo.On("UseTOTPStep", mock.Anything, userID(1), step).Return(nil)
+		mockRepo.On("DeleteMFAChallenge", mock.Anything, hash).Return(nil)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil)
+
+		code := mfa.Code(testSecret, step)
+		resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+			Body: &VerifyMfaJSONRequestBody{MfaToken: challenge, Code: &code},
+		})
+
+		assert.NoError(t, err)
+		require.IsType(t, VerifyMfa200JSONResponse{}, resp)
+		session := resp.(VerifyMfa200JSONResponse)
+		assert.Equal(t, *john, session.User)
+		claims, err := handler.keys.Verify(session.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, userID(1).String(), claims.Subject)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Recovery code opens a session", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		challenge, hash := newChallenge(t)
+
+		mockRepo.On("GetMFAChallenge", mock.Anything, hash).
+			Return(&MFAChallenge{UserID: userID(1), ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		mockRepo.On("UseRecoveryCode", mock.Anything, userID(1), mfa.HashRecoveryCode("7kq2m9xd4hpt")).Return(nil)
+		mockRepo.On("DeleteMFAChallenge", mock.Anything, hash).Return(nil)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil)
+
+		resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+			Body: &VerifyMfaJSONRequestBody{MfaToken: challenge, RecoveryCode: stringPtr("7KQ2-M9XD-4HPT")},
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, VerifyMfa200JSONResponse{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Replayed code counts as failure", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		challenge, hash := newChallenge(t)
+		step := mfa.Step(time.Now())
+
+		mockRepo.On("GetMFAChallenge", mock.Anything, hash).
+			Return(&MFAChallenge{UserID: userID(1), ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		mockRepo.On("GetMFA", mock.Anything, userID(1)).Return(&MFA{Secret: testSecret}, nil)
+		mockRepo.On("UseTOTPStep", mock.Anything, userID(1), step).Return(ownErrors.ErrInvalidToken)
+		mockRepo.On("RecordMFAChallengeFailure", mock.Anything, hash).Return(nil)
+
+		code := mfa.Code(testSecret, step)
+		resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+			Body: &VerifyMfaJSONRequestBody{MfaToken: challenge, Code: &code},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, VerifyMfa401JSONResponse{Error: stringPtr("Invalid code")}, resp)
+		mockRepo.AssertNotCalled(t, "DeleteMFAChallenge", mock.Anything, mock.Anything)
+	})
+
+	challengeTests := []struct {
+		name      string
+		challenge *MFAChallenge
+		mockError error
+	}{
+		{
+			name:      "Unknown challenge",
+			mockError: ownErrors.ErrInvalidToken,
+		},
+		{
+			name:      "Expired challenge",
+			challenge: &MFAChallenge{UserID: userID(1), ExpiresAt: time.Now().Add(-time.Second)},
+		},
+		{
+			name:      "Attempts exhausted",
+			challenge: &MFAChallenge{UserID: userID(1), Attempts: 3, ExpiresAt: time.Now().Add(time.Minute)},
+		},
+	}
+
+	for _, tc := range challengeTests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			challenge, hash := newChallenge(t)
+			mockRepo.On("GetMFAChallenge", mock.Anything, hash).Return(tc.challenge, tc.mockError)
+
+			resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+				Body: &VerifyMfaJSONRequestBody{MfaToken: challenge, Code: stringPtr("123456")},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, VerifyMfa401JSONResponse{Error: stringPtr("Invalid or expired MFA challenge")}, resp)
+			mockRepo.AssertNotCalled(t, "GetMFA", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Code and recovery code are exclusive", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+			Body: &VerifyMfaJSONRequestBody{MfaToken: "x", Code: stringPtr("123456"), RecoveryCode: stringPtr("7kq2-m9xd-4hpt")},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, VerifyMfa400JSONResponse{Error: stringPtr("Either a code or a recovery code is required")}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		challenge, hash := newChallenge(t)
+		mockRepo.On("GetMFAChallenge", mock.Anything, hash).Return(nil, errors.New("connection refused"))
+
+		resp, err := handler.VerifyMfa(context.Background(), VerifyMfaRequestObject{
+			Body: &VerifyMfaJSONRequestBody{MfaToken: challenge, RecoveryCode: stringPtr(strings.Repeat("a", 12))},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, VerifyMfa500JSONResponse{Error: stringPtr("Internal server error")}, resp)
+	})
+}
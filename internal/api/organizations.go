@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go-users/internal/ownErrors"
+)
+
+// orgSlug matches the slugs of organizations, which must be DNS labels to be usable as subdomains.
+var orgSlug = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ListOrganizations returns all organizations
+func (h *UserHandler) ListOrganizations(ctx context.Context, _ ListOrganizationsRequestObject) (ListOrganizationsResponseObject, error) {
+	orgs, err := h.repo.ListOrganizations(ctx)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListOrganizations500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListOrganizations200JSONResponse{Organizations: orgs}, nil
+}
+
+// CreateOrganization creates an organization
+func (h *UserHandler) CreateOrganization(ctx context.Context, request CreateOrganizationRequestObject) (CreateOrganizationResponseObject, error) {
+	if request.Body == nil || strings.TrimSpace(request.Body.Name) == "" {
+		errorMsg := "Missing organization name"
+		return CreateOrganization400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	o := *request.Body
+	o.Name = strings.TrimSpace(o.Name)
+	o.Slug = strings.ToLower(strings.TrimSpace(o.Slug))
+	if !orgSlug.MatchString(o.Slug) {
+		errorMsg := "Invalid organization slug"
+		return CreateOrganization400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	org, err := h.repo.CreateOrganization(ctx, &o)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrOrganizationAlreadyExists) {
+			errorMsg := "Organization already exists"
+			return CreateOrganization409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return CreateOrganization500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return CreateOrganization201JSONResponse(*org), nil
+}
+
+// GetOrganization returns an organization
+func (h *UserHandler) GetOrganization(ctx context.Context, request GetOrganizationRequestObject) (GetOrganizationResponseObject, error) {
+	org, err := h.repo.GetOrganization(ctx, request.OrgId)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Organization not found"
+			return GetOrganization404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetOrganization500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetOrganization200JSONResponse(*org), nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+func TestUserHandler_CreateOrganization(t *testing.T) {
+	t.Run("Organization created", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		org := &Organization{Id: userID(5), Slug: "acme", Name: "Acme"}
+		mockRepo.On("CreateOrganization", mock.Anything, &OrganizationRequest{Slug: "acme", Name: "Acme"}).Return(org, nil)
+
+		resp, err := handler.CreateOrganization(context.Background(), CreateOrganizationRequestObject{
+			Body: &OrganizationRequest{Slug: " Acme ", Name: " Acme "},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, CreateOrganization201JSONResponse(*org), resp)
+	})
+
+	t.Run("Slug already taken", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("CreateOrganization", mock.Anything, mock.Anything).Return(nil, ownErrors.ErrOrganizationAlreadyExists)
+
+		resp, err := handler.CreateOrganization(context.Background(), CreateOrganizationRequestObject{
+			Body: &OrganizationRequest{Slug: "acme", Name: "Acme"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, CreateOrganization409JSONResponse{}, resp)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		for _, body := range []*OrganizationRequest{
+			nil,
+			{Slug: "acme", Name: " "},
+			{Slug: "", Name: "Acme"},
+			{Slug: "acme.corp", Name: "Acme"},
+			{Slug: "-acme", Name: "Acme"},
+		} {
+			resp, err := handler.CreateOrganization(context.Background(), CreateOrganizationRequestObject{Body: body})
+			require.NoError(t, err)
+			assert.IsType(t, CreateOrganization400JSONResponse{}, resp)
+		}
+	})
+}
+
+func TestUserHandler_GetOrganization(t *testing.T) {
+	t.Run("Organization found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		org := &Organization{Id: userID(5), Slug: "acme", Name: "Acme"}
+		mockRepo.On("GetOrganization", mock.Anything, userID(5)).Return(org, nil)
+
+		resp, err := handler.GetOrganization(context.Background(), GetOrganizationRequestObject{OrgId: userID(5)})
+		require.NoError(t, err)
+		assert.Equal(t, GetOrganization200JSONResponse(*org), resp)
+	})
+
+	t.Run("Organization not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetOrganization", mock.Anything, userID(5)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.GetOrganization(context.Background(), GetOrganizationRequestObject{OrgId: userID(5)})
+		require.NoError(t, err)
+		assert.IsType(t, GetOrganization404JSONResponse{}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetOrganization", mock.Anything, userID(5)).Return(nil, errors.New("db error"))
+
+		resp, err := handler.GetOrganization(context.Background(), GetOrganizationRequestObject{OrgId: userID(5)})
+		require.NoError(t, err)
+		assert.IsType(t, GetOrganization500JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_OpenSessionWithOrganization(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	handler := testAuthHandler(t, mockRepo)
+	mockRepo.On("CreateRefreshToken", mock.Anything, userID(1), mock.Anything, mock.Anything).Return(nil)
+
+	ctx := tenant.WithOrg(context.Background(), userID(5))
+	session, err := handler.openSession(ctx, &User{Id: userID(1)})
+	require.NoError(t, err)
+
+	claims, err := handler.keys.Verify(session.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID(5).String(), claims.Org)
+}
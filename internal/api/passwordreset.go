@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"go-users/internal/mail"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// PasswordReset represents a requested password reset. Requests for unknown addresses are recorded as well, as they
+// count towards the rate limits.
+type PasswordReset struct {
+	Email     string
+	ClientIP  string
+	TokenHash []byte
+	ExpiresAt time.Time
+}
+
+// PasswordResetUsage represents the password reset requests of an email address and of a client IP since the start
+// of the rate limit window, together with the time of the first request of each.
+type PasswordResetUsage struct {
+	EmailRequests     int
+	FirstEmailRequest *time.Time
+	IPRequests        int
+	FirstIPRequest    *time.Time
+}
+
+// RequestPasswordReset mails a password reset link to the user with the given email address. The response does not
+// tell whether such a user exists.
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, request RequestPasswordResetRequestObject) (RequestPasswordResetResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return RequestPasswordReset400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	internalError := func(err error) (RequestPasswordResetResponseObject, error) {
+		h.logger.Error("Failed to request password reset", "error", err)
+		errorMsg := "Internal server error"
+		return RequestPasswordReset500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	address := string(request.Body.Email)
+	clientIP := router.ClientIPFromContext(ctx)
+	now := time.Now()
+
+	usage, err := h.repo.PasswordResetUsage(ctx, address, clientIP, now.Add(-h.resetWindow))
+	if err != nil {
+		return internalError(err)
+	}
+	if retryAfter := h.passwordResetRetryAfter(usage, now); retryAfter > 0 {
+		errorMsg := "Too many password reset requests"
+		return RequestPasswordReset429JSONResponse{
+			Body:    Error{Error: &errorMsg},
+			Headers: RequestPasswordReset429ResponseHeaders{RetryAfter: int(math.Ceil(retryAfter.Seconds()))},
+		}, nil
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return internalError(err)
+	}
+	link, err := tokenLink(h.resetURL, token)
+	if err != nil {
+		return internalError(err)
+	}
+
+	reset := &PasswordReset{Email: address, ClientIP: clientIP, TokenHash: tokenHash, ExpiresAt: now.Add(h.resetTTL).UTC()}
+	user, err := h.repo.CreatePasswordReset(ctx, reset)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			return RequestPasswordReset202Response{}, nil
+		}
+		return internalError(err)
+	}
+
+	// The mail is sent in the background, so the response time does not tell whether the address is known.
+	h.sendMailInBackground(ctx, &mail.Message{
+		To:      string(user.Email),
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nsomeone requested to reset the password of your account. To choose a new "+
+			"password, open this link:\n\n%s\n\nThe link expires on %s. If you did not request this, you can ignore "+
+			"this mail; your password stays unchanged.\n",
+			user.FirstName, link, reset.ExpiresAt.Format(time.RFC1123)),
+	})
+
+	return RequestPasswordReset202Response{}, nil
+}
+
+// ConfirmPasswordReset sets a new password with the token of a password reset mail and ends all sessions of the user
+func (h *UserHandler) ConfirmPasswordReset(ctx context.Context, request ConfirmPasswordResetRequestObject) (ConfirmPasswordResetResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return ConfirmPasswordReset400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	invalidToken := func() (ConfirmPasswordResetResponseObject, error) {
+		errorMsg := "Invalid or expired token"
+		return ConfirmPasswordReset400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	internalError := func() (ConfirmPasswordResetResponseObject, error) {
+		errorMsg := "Internal server error"
+		return ConfirmPasswordReset500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	tokenHash, ok := hashOpaqueToken(request.Body.Token)
+	if !ok {
+		return invalidToken()
+	}
+
+	user, err := h.repo.GetPasswordResetUser(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			return invalidToken()
+		}
+		return internalError()
+	}
+
+	if violations := h.policy.Validate(request.Body.Password, string(user.Email)); len(violations) > 0 {
+		errorMsg := "Password does not satisfy the policy"
+		return ConfirmPasswordReset400JSONResponse{
+			Error:   &errorMsg,
+			Details: &violations,
+		}, nil
+	}
+
+	hash, err := h.hasher.Hash(request.Body.Password)
+	if err == nil {
+		err = h.repo.ResetPassword(ctx, tokenHash, hash)
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			return invalidToken()
+		}
+		return internalError()
+	}
+
+	return ConfirmPasswordReset204Response{}, nil
+}
+
+// passwordResetRetryAfter returns the time until another password reset may be requested, or zero if the request is
+// within the limits.
+func (h *UserHandler) passwordResetRetryAfter(u *PasswordResetUsage, now time.Time) time.Duration {
+	limited := false
+	var retryAfter time.Duration
+	wait := func(requests, limit int, first *time.Time) {
+		if requests < limit {
+			return
+		}
+		limited = true
+		if first != nil {
+			retryAfter = max(retryAfter, first.Add(h.resetWindow).Sub(now))
+		}
+	}
+	wait(u.EmailRequests, h.resetMaxPerEmail, u.FirstEmailRequest)
+	wait(u.IPRequests, h.resetMaxPerIP, u.FirstIPRequest)
+
+	if limited {
+		return max(retryAfter, time.Second)
+	}
+	return 0
+}
+
+// sendMailInBackground sends a mail without waiting for it; failures are only logged.
+func (h *UserHandler) sendMailInBackground(ctx context.Context, m *mail.Message) {
+	ctx = context.WithoutCancel(ctx)
+	h.background.Add(1)
+	go func() {
+		defer h.background.Done()
+		if err := h.mailer.Send(ctx, m); err != nil {
+			h.logger.Error("Failed to send mail", "error", err, "subject", m.Subject)
+		}
+	}()
+}
+
+// tokenLink returns the page at base with the token as token query parameter.
+func tokenLink(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid link %q: %w", base, err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_RequestPasswordReset(t *testing.T) {
+	john := &User{Id: userID(1), FirstName: "John", Email: types.Email("john@example.com")}
+	ctx := router.WithClientIP(context.Background(), "192.0.2.1")
+	request := RequestPasswordResetRequestObject{Body: &PasswordResetRequest{Email: "John@example.com"}}
+
+	t.Run("Mail sent to known address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		var stored *PasswordReset
+		mockRepo.On("PasswordResetUsage", ctx, "John@example.com", "192.0.2.1", mock.Anything).
+			Return(&PasswordResetUsage{EmailRequests: 2, IPRequests: 5}, nil)
+		mockRepo.On("CreatePasswordReset", ctx, mock.MatchedBy(func(r *PasswordReset) bool {
+			stored = r
+			return r.Email == "John@example.com" && r.ClientIP == "192.0.2.1" && r.ExpiresAt.After(time.Now())
+		})).Return(john, nil)
+
+		resp, err := handler.RequestPasswordReset(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, RequestPasswordReset202Response{}, resp)
+		handler.background.Wait()
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "john@example.com", messages[0].To)
+		assert.Equal(t, "Reset your password", messages[0].Subject)
+
+		// Only the hash of the mailed token is stored.
+		raw, err := base64.RawURLEncoding.DecodeString(mailedToken(t, messages[0]))
+		require.NoError(t, err)
+		hash := sha256.Sum256(raw)
+		assert.Equal(t, hash[:], stored.TokenHash)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		mockRepo.On("PasswordResetUsage", ctx, "John@example.com", "192.0.2.1", mock.Anything).
+			Return(&PasswordResetUsage{}, nil)
+		mockRepo.On("CreatePasswordReset", ctx, mock.Anything).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.RequestPasswordReset(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, RequestPasswordReset202Response{}, resp)
+		handler.background.Wait()
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("Rate limited", func(t *testing.T) {
+		now := time.Now()
+		tests := []struct {
+			name     string
+			usage    *PasswordResetUsage
+			expected float64
+		}{
+			{
+				name:     "Per email",
+				usage:    &PasswordResetUsage{EmailRequests: 3, FirstEmailRequest: timePtr(now.Add(-50 * time.Minute)), IPRequests: 3},
+				expected: 600,
+			},
+			{
+				name:     "Per IP",
+				usage:    &PasswordResetUsage{IPRequests: 20, FirstIPRequest: timePtr(now.Add(-59 * time.Minute))},
+				expected: 60,
+			},
+			{
+				name: "Both",
+				usage: &PasswordResetUsage{EmailRequests: 4, FirstEmailRequest: timePtr(now.Add(-30 * time.Minute)),
+					IPRequests: 21, FirstIPRequest: timePtr(now.Add(-50 * time.Minute))},
+				expected: 1800,
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				handler := testAuthHandler(t, mockRepo)
+				withMailbox(t, handler)
+				mockRepo.On("PasswordResetUsage", ctx, "John@example.com", "192.0.2.1", mock.Anything).Return(tc.usage, nil)
+
+				resp, err := handler.RequestPasswordReset(ctx, request)
+				require.NoError(t, err)
+				require.IsType(t, RequestPasswordReset429JSONResponse{}, resp)
+				assert.InDelta(t, tc.expected, resp.(RequestPasswordReset429JSONResponse).Headers.RetryAfter, 2)
+				mockRepo.AssertNotCalled(t, "CreatePasswordReset", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		mockRepo.On("PasswordResetUsage", ctx, "John@example.com", "192.0.2.1", mock.Anything).
+			Return(nil, errors.New("db down"))
+
+		resp, err := handler.RequestPasswordReset(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, RequestPasswordReset500JSONResponse{}, resp)
+	})
+
+	t.Run("Missing body", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+
+		resp, err := handler.RequestPasswordReset(ctx, RequestPasswordResetRequestObject{})
+		require.NoError(t, err)
+		assert.IsType(t, RequestPasswordReset400JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_ConfirmPasswordReset(t *testing.T) {
+	john := &User{Id: userID(1), FirstName: "John", Email: types.Email("john@example.com")}
+	token, tokenHash, err := newOpaqueToken()
+	require.NoError(t, err)
+
+	t.Run("Password reset", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetPasswordResetUser", mock.Anything, tokenHash).Return(john, nil)
+		mockRepo.On("ResetPassword", mock.Anything, tokenHash, mock.MatchedBy(func(hash string) bool {
+			match, _, err := handler.hasher.Verify("Correct-Horse-42", hash)
+			return err == nil && match
+		})).Return(nil)
+
+		resp, err := handler.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequestObject{
+			Body: &PasswordResetConfirmRequest{Token: token, Password: "Correct-Horse-42"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ConfirmPasswordReset204Response{}, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Password policy violated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetPasswordResetUser", mock.Anything, tokenHash).Return(john, nil)
+
+		resp, err := handler.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequestObject{
+			Body: &PasswordResetConfirmRequest{Token: token, Password: "short"},
+		})
+		require.NoError(t, err)
+		require.IsType(t, ConfirmPasswordReset400JSONResponse{}, resp)
+		assert.NotEmpty(t, *resp.(ConfirmPasswordReset400JSONResponse).Details)
+		mockRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid or used token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetPasswordResetUser", mock.Anything, tokenHash).Return(nil, ownErrors.ErrInvalidToken)
+
+		for _, token := range []string{token, "malformed"} {
+			resp, err := handler.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequestObject{
+				Body: &PasswordResetConfirmRequest{Token: token, Password: "Correct-Horse-42"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "Invalid or expired token", *resp.(ConfirmPasswordReset400JSONResponse).Error)
+		}
+	})
+
+	t.Run("Token used concurrently", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetPasswordResetUser", mock.Anything, tokenHash).Return(john, nil)
+		mockRepo.On("ResetPassword", mock.Anything, tokenHash, mock.Anything).Return(ownErrors.ErrInvalidToken)
+
+		resp, err := handler.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequestObject{
+			Body: &PasswordResetConfirmRequest{Token: token, Password: "Correct-Horse-42"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, ConfirmPasswordReset400JSONResponse{}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetPasswordResetUser", mock.Anything, tokenHash).Return(nil, errors.New("db down"))
+
+		resp, err := handler.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequestObject{
+			Body: &PasswordResetConfirmRequest{Token: token, Password: "Correct-Horse-42"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, ConfirmPasswordReset500JSONResponse{}, resp)
+	})
+}
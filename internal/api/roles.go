@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"go-users/internal/ownErrors"
+)
+
+// ListRoles returns all roles
+func (h *UserHandler) ListRoles(ctx context.Context, _ ListRolesRequestObject) (ListRolesResponseObject, error) {
+	roles, err := h.repo.ListRoles(ctx)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListRoles500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListRoles200JSONResponse{Roles: roles}, nil
+}
+
+// GetUserRoles returns the roles assigned to a user
+func (h *UserHandler) GetUserRoles(ctx context.Context, request GetUserRolesRequestObject) (GetUserRolesResponseObject, error) {
+	var roles []Role
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		roles, err = h.repo.GetUserRoles(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return GetUserRoles400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return GetUserRoles404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return GetUserRoles500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return GetUserRoles200JSONResponse{Roles: roles}, nil
+}
+
+// AssignUserRole assigns a role to a user
+func (h *UserHandler) AssignUserRole(ctx context.Context, request AssignUserRoleRequestObject) (AssignUserRoleResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.AssignRole(ctx, id, request.Role)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return AssignUserRole400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User or role not found"
+			return AssignUserRole404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return AssignUserRole500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return AssignUserRole204Response{}, nil
+}
+
+// RevokeUserRole removes a role from a user
+func (h *UserHandler) RevokeUserRole(ctx context.Context, request RevokeUserRoleRequestObject) (RevokeUserRoleResponseObject, error) {
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		err = h.repo.RevokeRole(ctx, id, request.Role)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return RevokeUserRole400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "Role not assigned"
+			return RevokeUserRole404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return RevokeUserRole500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return RevokeUserRole204Response{}, nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestUserHandler_ListRoles(t *testing.T) {
+	roles := []Role{
+		{Name: "admin", Description: "Full access", Permissions: []string{"users:read", "users:write"}},
+		{Name: "support", Description: "Support agents", Permissions: []string{"users:read"}},
+	}
+
+	t.Run("Roles returned", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListRoles", mock.Anything).Return(roles, nil)
+
+		resp, err := handler.ListRoles(context.Background(), ListRolesRequestObject{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ListRoles200JSONResponse{Roles: roles}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("ListRoles", mock.Anything).Return(nil, errors.New("connection refused"))
+
+		resp, err := handler.ListRoles(context.Background(), ListRolesRequestObject{})
+
+		assert.NoError(t, err)
+		assert.IsType(t, ListRoles500JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_GetUserRoles(t *testing.T) {
+	t.Run("Roles returned", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		roles := []Role{{Name: "support", Description: "Support agents", Permissions: []string{"users:read"}}}
+		mockRepo.On("GetUserRoles", mock.Anything, userID(1)).Return(roles, nil)
+
+		resp, err := handler.GetUserRoles(context.Background(), GetUserRolesRequestObject{Id: userID(1).String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, GetUserRoles200JSONResponse{Roles: roles}, resp)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+		mockRepo.On("GetUserRoles", mock.Anything, userID(2)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.GetUserRoles(context.Background(), GetUserRolesRequestObject{Id: userID(2).String()})
+
+		assert.NoError(t, err)
+		assert.IsType(t, GetUserRoles404JSONResponse{}, resp)
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := &UserHandler{repo: mockRepo}
+
+		resp, err := handler.GetUserRoles(context.Background(), GetUserRolesRequestObject{Id: "42"})
+
+		assert.NoError(t, err)
+		assert.IsType(t, GetUserRoles400JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "GetUserRoles", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_AssignUserRole(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedType interface{}
+	}{
+		{name: "Role assigned", expectedType: AssignUserRole204Response{}},
+		{name: "Unknown user or role", mockError: ownErrors.ErrNotFound, expectedType: AssignUserRole404JSONResponse{}},
+		{name: "Database error", mockError: errors.New("connection refused"), expectedType: AssignUserRole500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			mockRepo.On("AssignRole", mock.Anything, userID(1), "support").Return(tc.mockError)
+
+			resp, err := handler.AssignUserRole(context.Background(), AssignUserRoleRequestObject{
+				Id:   userID(1).String(),
+				Role: "support",
+			})
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_RevokeUserRole(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedType interface{}
+	}{
+		{name: "Role revoked", expectedType: RevokeUserRole204Response{}},
+		{name: "Role not assigned", mockError: ownErrors.ErrNotFound, expectedType: RevokeUserRole404JSONResponse{}},
+		{name: "Database error", mockError: errors.New("connection refused"), expectedType: RevokeUserRole500JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+			mockRepo.On("RevokeRole", mock.Anything, userID(1), "support").Return(tc.mockError)
+
+			resp, err := handler.RevokeUserRole(context.Background(), RevokeUserRoleRequestObject{
+				Id:   userID(1).String(),
+				Role: "support",
+			})
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
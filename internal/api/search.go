@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultSearchLimit is the number of search results returned when no limit is requested.
+	defaultSearchLimit = 20
+	// maxSearchLimit is the maximum number of search results returned by a single request.
+	maxSearchLimit = 100
+	// maxSearchQueryLength is the maximum length of the search text.
+	maxSearchQueryLength = 255
+)
+
+// SearchUsers returns users matching the search text, most relevant first
+func (h *UserHandler) SearchUsers(ctx context.Context, request SearchUsersRequestObject) (SearchUsersResponseObject, error) {
+	q := strings.TrimSpace(request.Params.Q)
+	if q == "" || len(q) > maxSearchQueryLength {
+		errorMsg := fmt.Sprintf("Search text must contain between 1 and %d characters", maxSearchQueryLength)
+		return SearchUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	limit := defaultSearchLimit
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		errorMsg := fmt.Sprintf("Limit must be between 1 and %d", maxSearchLimit)
+		return SearchUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	results, err := h.repo.SearchUsers(ctx, q, limit)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return SearchUsers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return SearchUsers200JSONResponse{
+		Results: results,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserHandler_SearchUsers(t *testing.T) {
+	fixedTime := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	results := []SearchResult{
+		{
+			User:       User{Id: userID(1), FirstName: "John", LastName: "Doe", Email: "john@example.com", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			Score:      1.2,
+			Highlights: SearchHighlights{Name: stringPtr("<mark>John</mark> Doe")},
+		},
+	}
+	limit := func(v int) *int { return &v }
+
+	testCases := []struct {
+		name           string
+		params         SearchUsersParams
+		expectedQuery  string
+		expectedLimit  int
+		mockResults    []SearchResult
+		mockError      error
+		expectedOutput SearchUsersResponseObject
+	}{
+		{
+			name:           "Default limit",
+			params:         SearchUsersParams{Q: "  john "},
+			expectedQuery:  "john",
+			expectedLimit:  20,
+			mockResults:    results,
+			expectedOutput: SearchUsers200JSONResponse{Results: results},
+		},
+		{
+			name:           "Explicit limit",
+			params:         SearchUsersParams{Q: "jo", Limit: limit(5)},
+			expectedQuery:  "jo",
+			expectedLimit:  5,
+			mockResults:    []SearchResult{},
+			expectedOutput: SearchUsers200JSONResponse{Results: []SearchResult{}},
+		},
+		{
+			name:           "Blank query",
+			params:         SearchUsersParams{Q: "   "},
+			expectedOutput: SearchUsers400JSONResponse{Error: stringPtr("Search text must contain between 1 and 255 characters")},
+		},
+		{
+			name:           "Query too long",
+			params:         SearchUsersParams{Q: strings.Repeat("a", 256)},
+			expectedOutput: SearchUsers400JSONResponse{Error: stringPtr("Search text must contain between 1 and 255 characters")},
+		},
+		{
+			name:           "Limit out of range",
+			params:         SearchUsersParams{Q: "jo", Limit: limit(101)},
+			expectedOutput: SearchUsers400JSONResponse{Error: stringPtr("Limit must be between 1 and 100")},
+		},
+		{
+			name:           "Repository error",
+			params:         SearchUsersParams{Q: "jo"},
+			expectedQuery:  "jo",
+			expectedLimit:  20,
+			mockError:      errors.New("database connection error"),
+			expectedOutput: SearchUsers500JSONResponse{Error: stringPtr("Internal server error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := &UserHandler{repo: mockRepo}
+
+			if tc.expectedQuery != "" {
+				mockRepo.On("SearchUsers", mock.Anything, tc.expectedQuery, tc.expectedLimit).Return(tc.mockResults, tc.mockError)
+			}
+
+			resp, err := handler.SearchUsers(context.Background(), SearchUsersRequestObject{Params: tc.params})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, resp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-users/internal/router"
+)
+
+// authorize enforces the security requirements declared in the OpenAPI specification. The generated wrapper stores
+// the schemes accepted by an operation in the request context: operations without schemes are public, all others
+// require a principal authenticated with one of the accepted schemes. Personal access tokens of users are API keys.
+func authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, bearer := r.Context().Value(BearerAuthScopes).([]string)
+		_, apiKey := r.Context().Value(ApiKeyAuthScopes).([]string)
+		if !bearer && !apiKey {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, ok := router.PrincipalFromContext(r.Context())
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		switch {
+		case principal.KeyID == "" && bearer, principal.KeyID != "" && apiKey:
+			next.ServeHTTP(w, r)
+		default:
+			writeError(w, http.StatusForbidden, "Operation not allowed with these credentials")
+		}
+	})
+}
+
+// writeError responds with an Error body outside the strict handler.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Error{Error: &message})
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/lifecycle"
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// StatusChange represents a change of the status of a user, recorded with its reason and actor.
+type StatusChange struct {
+	From   UserStatus
+	To     UserStatus
+	Reason string
+	// Until schedules the reactivation of a suspended user.
+	Until     *time.Time
+	ActorKind string
+	ActorID   string
+}
+
+// UserFilter restricts the users returned by ListUsers.
+type UserFilter struct {
+	// Status only returns users with this status, if set.
+	Status *UserStatus
+	// Attributes only returns users whose attributes contain these.
+	Attributes Attributes
+}
+
+// ListUsers returns a page of users ordered by ID, optionally filtered by status and attributes
+func (h *UserHandler) ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error) {
+	limit := defaultListLimit
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+	if limit < 1 || limit > maxListLimit {
+		errorMsg := fmt.Sprintf("Limit must be between 1 and %d", maxListLimit)
+		return ListUsers400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	filter := &UserFilter{Status: request.Params.Status}
+	if request.Params.Attribute != nil {
+		attrs, err := parseAttributeFilter(*request.Params.Attribute)
+		if err != nil {
+			errorMsg := "Invalid attribute filter"
+			details := []string{err.Error()}
+			return ListUsers400JSONResponse{
+				Error:   &errorMsg,
+				Details: &details,
+			}, nil
+		}
+		filter.Attributes = attrs
+	}
+
+	after := uuid.Nil
+	if request.Params.After != nil {
+		after = *request.Params.After
+	}
+
+	// One more user than requested tells whether another page follows.
+	users, err := h.repo.ListUsers(ctx, after, limit+1, filter)
+	if err != nil {
+		errorMsg := "Internal server error"
+		return ListUsers500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	response := ListUsers200JSONResponse{Users: users}
+	if len(users) > limit {
+		response.Users = users[:limit]
+		response.NextAfter = &users[limit-1].Id
+	}
+
+	return response, nil
+}
+
+// SuspendUser suspends an active user, optionally until a scheduled reactivation
+func (h *UserHandler) SuspendUser(ctx context.Context, request SuspendUserRequestObject) (SuspendUserResponseObject, error) {
+	if request.Body == nil || strings.TrimSpace(request.Body.Reason) == "" {
+		errorMsg := "Missing suspension reason"
+		return SuspendUser400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+	if request.Body.Until != nil && !request.Body.Until.After(time.Now()) {
+		errorMsg := "Suspension end must be in the future"
+		return SuspendUser400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	user, err := h.changeUserStatus(ctx, request.Id, UserStatusSuspended, request.Body.Reason, request.Body.Until)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return SuspendUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return SuspendUser404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrInvalidTransition) {
+			errorMsg := "Only active users can be suspended"
+			return SuspendUser409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return SuspendUser500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return SuspendUser200JSONResponse(*user), nil
+}
+
+// ReactivateUser reactivates a suspended or deactivated user
+func (h *UserHandler) ReactivateUser(ctx context.Context, request ReactivateUserRequestObject) (ReactivateUserResponseObject, error) {
+	var reason string
+	if request.Body != nil && request.Body.Reason != nil {
+		reason = *request.Body.Reason
+	}
+
+	user, err := h.changeUserStatus(ctx, request.Id, UserStatusActive, reason, nil)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ReactivateUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ReactivateUser404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrInvalidTransition) {
+			errorMsg := "Only suspended or deactivated users can be reactivated"
+			return ReactivateUser409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrUserErased) {
+			errorMsg := "Erased users cannot be reactivated"
+			return ReactivateUser409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ReactivateUser500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ReactivateUser200JSONResponse(*user), nil
+}
+
+// DeactivateUser deactivates a user without deleting them
+func (h *UserHandler) DeactivateUser(ctx context.Context, request DeactivateUserRequestObject) (DeactivateUserResponseObject, error) {
+	var reason string
+	if request.Body != nil && request.Body.Reason != nil {
+		reason = *request.Body.Reason
+	}
+
+	user, err := h.changeUserStatus(ctx, request.Id, UserStatusDeactivated, reason, nil)
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return DeactivateUser400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return DeactivateUser404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrInvalidTransition) {
+			errorMsg := "User is already deactivated"
+			return DeactivateUser409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return DeactivateUser500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return DeactivateUser200JSONResponse(*user), nil
+}
+
+// ListUserStatusEvents returns the status changes of a user
+func (h *UserHandler) ListUserStatusEvents(ctx context.Context, request ListUserStatusEventsRequestObject) (ListUserStatusEventsResponseObject, error) {
+	var events []UserStatusEvent
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		events, err = h.repo.ListUserStatusEvents(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return ListUserStatusEvents400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return ListUserStatusEvents404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return ListUserStatusEvents500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return ListUserStatusEvents200JSONResponse{Events: events}, nil
+}
+
+// changeUserStatus moves a user to another status if the lifecycle allows it and records the change with the
+// principal of ctx as actor.
+func (h *UserHandler) changeUserStatus(ctx context.Context, rawID UserID, to UserStatus, reason string, until *time.Time) (*User, error) {
+	id, err := h.resolveUserID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.repo.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lifecycle.Transition(lifecycle.Status(user.Status), lifecycle.Status(to)); err != nil {
+		return nil, err
+	}
+	// Erased users stay deactivated: without their personal data they could never be told apart again.
+	if user.ErasedAt != nil {
+		return nil, ownErrors.ErrUserErased
+	}
+
+	return h.repo.ChangeUserStatus(ctx, id, newStatusChange(ctx, user.Status, to, strings.TrimSpace(reason), until))
+}
+
+// newStatusChange returns a status change attributed to the principal of the request.
+func newStatusChange(ctx context.Context, from, to UserStatus, reason string, until *time.Time) *StatusChange {
+	c := &StatusChange{From: from, To: to, Reason: reason, Until: until}
+	if principal, ok := router.PrincipalFromContext(ctx); ok {
+		c.ActorKind = string(principal.Kind)
+		c.ActorID = principal.ID
+	}
+	return c
+}
+
+// userIsActive reports whether a user may use their tokens. Unknown users, e.g. deleted ones, may not.
+func (h *UserHandler) userIsActive(ctx context.Context, id openapi_types.UUID) (bool, error) {
+	status, err := h.repo.GetUserStatus(ctx, id)
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return lifecycle.CanLogIn(lifecycle.Status(status)), nil
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+func TestUserHandler_ListUsers(t *testing.T) {
+	users := []User{{Id: userID(1)}, {Id: userID(2)}, {Id: userID(3)}}
+
+	t.Run("Next page follows", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		suspended := UserStatusSuspended
+		limit := 2
+		mockRepo.On("ListUsers", mock.Anything, userID(9), 3, &UserFilter{Status: &suspended}).Return(users, nil)
+
+		after := userID(9)
+		resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{
+			Params: ListUsersParams{Status: &suspended, After: &after, Limit: &limit},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ListUsers200JSONResponse{Users: users[:2], NextAfter: &users[1].Id}, resp)
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListUsers", mock.Anything, uuid.Nil, defaultListLimit+1, &UserFilter{}).Return(users, nil)
+
+		resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{})
+		require.NoError(t, err)
+		assert.Equal(t, ListUsers200JSONResponse{Users: users}, resp)
+	})
+
+	t.Run("Limit out of range", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+		limit := maxListLimit + 1
+
+		resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{Params: ListUsersParams{Limit: &limit}})
+		require.NoError(t, err)
+		assert.IsType(t, ListUsers400JSONResponse{}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListUsers", mock.Anything, uuid.Nil, defaultListLimit+1, &UserFilter{}).Return(nil, errors.New("db down"))
+
+		resp, err := handler.ListUsers(context.Background(), ListUsersRequestObject{})
+		require.NoError(t, err)
+		assert.IsType(t, ListUsers500JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_SuspendUser(t *testing.T) {
+	ctx := router.WithPrincipal(context.Background(), &router.Principal{Kind: router.PrincipalUser, ID: userID(9).String()})
+	until := time.Now().Add(24 * time.Hour)
+	request := SuspendUserRequestObject{Id: userID(1).String(), Body: &UserSuspendRequest{Reason: " Abuse ", Until: &until}}
+
+	t.Run("User suspended", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		suspended := &User{Id: userID(1), Status: UserStatusSuspended}
+		mockRepo.On("GetUser", ctx, userID(1)).Return(&User{Id: userID(1), Status: UserStatusActive}, nil)
+		mockRepo.On("ChangeUserStatus", ctx, userID(1), &StatusChange{
+			From: UserStatusActive, To: UserStatusSuspended, Reason: "Abuse", Until: &until,
+			ActorKind: "user", ActorID: userID(9).String(),
+		}).Return(suspended, nil)
+
+		resp, err := handler.SuspendUser(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, SuspendUser200JSONResponse(*suspended), resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Transition not allowed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", ctx, userID(1)).Return(&User{Id: userID(1), Status: UserStatusDeactivated}, nil)
+
+		resp, err := handler.SuspendUser(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, SuspendUser409JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "ChangeUserStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Concurrent change", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", ctx, userID(1)).Return(&User{Id: userID(1), Status: UserStatusActive}, nil)
+		mockRepo.On("ChangeUserStatus", ctx, userID(1), mock.Anything).Return(nil, ownErrors.ErrInvalidTransition)
+
+		resp, err := handler.SuspendUser(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, SuspendUser409JSONResponse{}, resp)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", ctx, userID(1)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.SuspendUser(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, SuspendUser404JSONResponse{}, resp)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+		past := time.Now().Add(-time.Hour)
+
+		for _, body := range []*UserSuspendRequest{nil, {Reason: " "}, {Reason: "Abuse", Until: &past}} {
+			resp, err := handler.SuspendUser(ctx, SuspendUserRequestObject{Id: userID(1).String(), Body: body})
+			require.NoError(t, err)
+			assert.IsType(t, SuspendUser400JSONResponse{}, resp)
+		}
+	})
+}
+
+func TestUserHandler_ReactivateUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   UserStatus
+		erasedAt *time.Time
+		expected ReactivateUserResponseObject
+	}{
+		{name: "Suspended user", status: UserStatusSuspended, expected: ReactivateUser200JSONResponse{}},
+		{name: "Deactivated user", status: UserStatusDeactivated, expected: ReactivateUser200JSONResponse{}},
+		{name: "Active user", status: UserStatusActive, expected: ReactivateUser409JSONResponse{}},
+		{name: "Erased user", status: UserStatusDeactivated, erasedAt: &time.Time{}, expected: ReactivateUser409JSONResponse{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			handler := testAuthHandler(t, mockRepo)
+			mockRepo.On("GetUser", mock.Anything, userID(1)).Return(&User{Id: userID(1), Status: tc.status, ErasedAt: tc.erasedAt}, nil)
+			mockRepo.On("ChangeUserStatus", mock.Anything, userID(1), mock.MatchedBy(func(c *StatusChange) bool {
+				return c.From == tc.status && c.To == UserStatusActive && c.Until == nil
+			})).Return(&User{Id: userID(1), Status: UserStatusActive}, nil)
+
+			resp, err := handler.ReactivateUser(context.Background(), ReactivateUserRequestObject{Id: userID(1).String()})
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestUserHandler_DeactivateUser(t *testing.T) {
+	t.Run("User deactivated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		reason := "Left the company"
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(&User{Id: userID(1), Status: UserStatusSuspended}, nil)
+		mockRepo.On("ChangeUserStatus", mock.Anything, userID(1), mock.MatchedBy(func(c *StatusChange) bool {
+			return c.To == UserStatusDeactivated && c.Reason == reason
+		})).Return(&User{Id: userID(1), Status: UserStatusDeactivated}, nil)
+
+		resp, err := handler.DeactivateUser(context.Background(), DeactivateUserRequestObject{
+			Id: userID(1).String(), Body: &UserStatusRequest{Reason: &reason},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, DeactivateUser200JSONResponse{}, resp)
+	})
+
+	t.Run("Already deactivated", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(&User{Id: userID(1), Status: UserStatusDeactivated}, nil)
+
+		resp, err := handler.DeactivateUser(context.Background(), DeactivateUserRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.IsType(t, DeactivateUser409JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_ListUserStatusEvents(t *testing.T) {
+	t.Run("Events listed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		events := []UserStatusEvent{{FromStatus: UserStatusActive, ToStatus: UserStatusSuspended, ActorKind: "user"}}
+		mockRepo.On("ListUserStatusEvents", mock.Anything, userID(1)).Return(events, nil)
+
+		resp, err := handler.ListUserStatusEvents(context.Background(), ListUserStatusEventsRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.Equal(t, ListUserStatusEvents200JSONResponse{Events: events}, resp)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mockRepo.On("ListUserStatusEvents", mock.Anything, userID(1)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.ListUserStatusEvents(context.Background(), ListUserStatusEventsRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.IsType(t, ListUserStatusEvents404JSONResponse{}, resp)
+	})
+}
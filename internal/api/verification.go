@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/mail"
+	"go-users/internal/ownErrors"
+	"go-users/internal/verification"
+)
+
+// errEmailVerified is returned when a verification mail is requested for a verified address without pending change.
+var errEmailVerified = errors.New("email already verified")
+
+// EmailVerification represents a sent verification mail.
+type EmailVerification struct {
+	ID        openapi_types.UUID
+	UserID    openapi_types.UUID
+	Email     string
+	ExpiresAt time.Time
+}
+
+// SendEmailVerification sends a verification mail to the pending or unverified email address of a user
+func (h *UserHandler) SendEmailVerification(ctx context.Context, request SendEmailVerificationRequestObject) (SendEmailVerificationResponseObject, error) {
+	var user *User
+	var retryAfter time.Duration
+	id, err := h.resolveUserID(ctx, request.Id)
+	if err == nil {
+		user, err = h.repo.GetUser(ctx, id)
+	}
+	if err == nil {
+		retryAfter, err = h.sendEmailVerification(ctx, user)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidUserID) {
+			errorMsg := "Invalid user ID"
+			return SendEmailVerification400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrNotFound) {
+			errorMsg := "User not found"
+			return SendEmailVerification404JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, errEmailVerified) {
+			errorMsg := "Email address already verified"
+			return SendEmailVerification409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		h.logger.Error("Failed to send email verification", "error", err, "user_id", id)
+		errorMsg := "Internal server error"
+		return SendEmailVerification500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	if retryAfter > 0 {
+		errorMsg := "Verification mail sent too recently"
+		return SendEmailVerification429JSONResponse{
+			Body:    Error{Error: &errorMsg},
+			Headers: SendEmailVerification429ResponseHeaders{RetryAfter: int(math.Ceil(retryAfter.Seconds()))},
+		}, nil
+	}
+
+	return SendEmailVerification204Response{}, nil
+}
+
+// VerifyEmail confirms the email address of a verification mail
+func (h *UserHandler) VerifyEmail(ctx context.Context, request VerifyEmailRequestObject) (VerifyEmailResponseObject, error) {
+	if request.Body == nil {
+		errorMsg := "Missing request body"
+		return VerifyEmail400JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	claims, err := h.verifier.Verify(request.Body.Token)
+	var user *User
+	if err == nil {
+		user, err = h.repo.ConfirmEmail(ctx, claims.ID, claims.Email)
+	}
+	if err != nil {
+		if errors.Is(err, ownErrors.ErrInvalidToken) {
+			errorMsg := "Invalid or expired token"
+			return VerifyEmail400JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+		if errors.Is(err, ownErrors.ErrUserAlreadyExists) {
+			errorMsg := "User already exists"
+			return VerifyEmail409JSONResponse{
+				Error: &errorMsg,
+			}, nil
+		}
+
+		errorMsg := "Internal server error"
+		return VerifyEmail500JSONResponse{
+			Error: &errorMsg,
+		}, nil
+	}
+
+	return VerifyEmail200JSONResponse(*user), nil
+}
+
+// notifyEmailVerification sends a verification mail after a user was created or changed their address. Failures and
+// throttled mails are only logged; the user can request another mail.
+func (h *UserHandler) notifyEmailVerification(ctx context.Context, user *User) {
+	retryAfter, err := h.sendEmailVerification(ctx, user)
+	switch {
+	case errors.Is(err, errEmailVerified):
+	case err != nil:
+		h.logger.Error("Failed to send email verification", "error", err, "user_id", user.Id)
+	case retryAfter > 0:
+		h.logger.Info("Email verification throttled", "user_id", user.Id, "retry_after", retryAfter)
+	}
+}
+
+// sendEmailVerification mails a signed verification link to the pending address of a user, or to the current one if
+// it is not verified. Returns the time to wait instead if the last mail was sent less than the resend interval ago.
+func (h *UserHandler) sendEmailVerification(ctx context.Context, user *User) (time.Duration, error) {
+	address := string(user.Email)
+	switch {
+	case user.PendingEmail != nil:
+		address = string(*user.PendingEmail)
+	case user.EmailVerifiedAt != nil:
+		return 0, errEmailVerified
+	}
+
+	now := time.Now()
+	sentAt, err := h.repo.LastEmailVerificationSentAt(ctx, user.Id)
+	if err != nil {
+		return 0, err
+	}
+	if sentAt != nil {
+		if wait := sentAt.Add(h.verificationResend).Sub(now); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate verification id: %w", err)
+	}
+	v := &EmailVerification{ID: id, UserID: user.Id, Email: address, ExpiresAt: now.Add(h.verificationTTL).UTC()}
+
+	token, err := h.verifier.Sign(&verification.Claims{ID: v.ID, UserID: v.UserID, Email: v.Email, ExpiresAt: v.ExpiresAt})
+	if err != nil {
+		return 0, err
+	}
+	link, err := tokenLink(h.verificationURL, token)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = h.repo.CreateEmailVerification(ctx, v); err != nil {
+		return 0, err
+	}
+
+	return 0, h.mailer.Send(ctx, &mail.Message{
+		To:      address,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hello %s,\n\nplease confirm your email address by opening this link:\n\n%s\n\n"+
+			"The link expires on %s. If you did not expect this mail, you can ignore it.\n",
+			user.FirstName, link, v.ExpiresAt.Format(time.RFC1123)),
+	})
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mailer "go-users/internal/mail"
+	"go-users/internal/ownErrors"
+	"go-users/internal/verification"
+)
+
+// withMailbox configures email verification, password resets and invitations on a handler and returns the mailbox
+// receiving its mails.
+func withMailbox(t *testing.T, h *UserHandler) *mailer.Mailbox {
+	verifier, err := verification.NewSigner(strings.Repeat("s", 32))
+	require.NoError(t, err)
+
+	mailbox := mailer.NewMailbox(t.TempDir(), &mail.Address{Name: "go-users", Address: "no-reply@example.com"})
+	h.mailer = mailbox
+	h.verifier = verifier
+	h.verificationURL = "https://example.com/verify-email?lang=en"
+	h.verificationTTL = time.Hour
+	h.verificationResend = time.Minute
+	h.resetURL = "https://example.com/reset-password"
+	h.resetTTL = 15 * time.Minute
+	h.resetWindow = time.Hour
+	h.resetMaxPerEmail = 3
+	h.resetMaxPerIP = 20
+	h.invitationURL = "https://example.com/accept-invitation"
+	h.invitationTTL = 7 * 24 * time.Hour
+	if h.logger == nil {
+		h.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return mailbox
+}
+
+// mailedToken returns the token of the link in a mail.
+func mailedToken(t *testing.T, m mailer.Message) string {
+	for _, line := range strings.Split(m.Body, "\n") {
+		if strings.HasPrefix(line, "https://") {
+			link, err := url.Parse(line)
+			require.NoError(t, err)
+			return link.Query().Get("token")
+		}
+	}
+	t.Fatal("mail without link")
+	return ""
+}
+
+func TestUserHandler_SendEmailVerification(t *testing.T) {
+	verifiedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	pending := types.Email("john.new@example.com")
+
+	t.Run("Mail sent to unverified address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		john := &User{Id: userID(1), FirstName: "John", Email: "john@example.com"}
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("LastEmailVerificationSentAt", mock.Anything, userID(1)).Return(nil, nil)
+		mockRepo.On("CreateEmailVerification", mock.Anything, mock.MatchedBy(func(v *EmailVerification) bool {
+			return v.UserID == userID(1) && v.Email == "john@example.com" && v.ExpiresAt.After(time.Now())
+		})).Return(nil)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.Equal(t, SendEmailVerification204Response{}, resp)
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "john@example.com", messages[0].To)
+		assert.Equal(t, "Confirm your email address", messages[0].Subject)
+		assert.Contains(t, messages[0].Body, "https://example.com/verify-email?lang=en&token=")
+
+		claims, err := handler.verifier.Verify(mailedToken(t, messages[0]))
+		require.NoError(t, err)
+		assert.Equal(t, userID(1), claims.UserID)
+		assert.Equal(t, "john@example.com", claims.Email)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Mail sent to pending address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		john := &User{Id: userID(1), FirstName: "John", Email: "john@example.com", EmailVerifiedAt: &verifiedAt, PendingEmail: &pending}
+		sentAt := time.Now().Add(-time.Hour)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("LastEmailVerificationSentAt", mock.Anything, userID(1)).Return(&sentAt, nil)
+		mockRepo.On("CreateEmailVerification", mock.Anything, mock.MatchedBy(func(v *EmailVerification) bool {
+			return v.Email == string(pending)
+		})).Return(nil)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.Equal(t, SendEmailVerification204Response{}, resp)
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, string(pending), messages[0].To)
+	})
+
+	t.Run("Verified address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		john := &User{Id: userID(1), Email: "john@example.com", EmailVerifiedAt: &verifiedAt}
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.IsType(t, SendEmailVerification409JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "CreateEmailVerification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Resend throttled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		mailbox := withMailbox(t, handler)
+		john := &User{Id: userID(1), Email: "john@example.com"}
+		sentAt := time.Now().Add(-20 * time.Second)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(john, nil)
+		mockRepo.On("LastEmailVerificationSentAt", mock.Anything, userID(1)).Return(&sentAt, nil)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		require.IsType(t, SendEmailVerification429JSONResponse{}, resp)
+		retryAfter := resp.(SendEmailVerification429JSONResponse).Headers.RetryAfter
+		assert.InDelta(t, 40, retryAfter, 1)
+
+		messages, err := mailbox.Messages()
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+		mockRepo.AssertNotCalled(t, "CreateEmailVerification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		mockRepo.On("GetUser", mock.Anything, userID(1)).Return(nil, ownErrors.ErrNotFound)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: userID(1).String()})
+		require.NoError(t, err)
+		assert.IsType(t, SendEmailVerification404JSONResponse{}, resp)
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		handler := testAuthHandler(t, new(MockUserRepository))
+		withMailbox(t, handler)
+
+		resp, err := handler.SendEmailVerification(context.Background(), SendEmailVerificationRequestObject{Id: "not-a-uuid"})
+		require.NoError(t, err)
+		assert.IsType(t, SendEmailVerification400JSONResponse{}, resp)
+	})
+}
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+	sign := func(t *testing.T, h *UserHandler, claims *verification.Claims) string {
+		token, err := h.verifier.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("Address confirmed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		verifiedAt := time.Now()
+		john := &User{Id: userID(1), Email: "john.new@example.com", EmailVerifiedAt: &verifiedAt}
+		token := sign(t, handler, &verification.Claims{ID: userID(9), UserID: userID(1), Email: "john.new@example.com", ExpiresAt: time.Now().Add(time.Hour)})
+		mockRepo.On("ConfirmEmail", mock.Anything, userID(9), "john.new@example.com").Return(john, nil)
+
+		resp, err := handler.VerifyEmail(context.Background(), VerifyEmailRequestObject{Body: &EmailVerifyRequest{Token: token}})
+		require.NoError(t, err)
+		assert.Equal(t, VerifyEmail200JSONResponse(*john), resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		token := sign(t, handler, &verification.Claims{ID: userID(9), UserID: userID(1), Email: "john@example.com", ExpiresAt: time.Now().Add(-time.Minute)})
+
+		resp, err := handler.VerifyEmail(context.Background(), VerifyEmailRequestObject{Body: &EmailVerifyRequest{Token: token}})
+		require.NoError(t, err)
+		assert.IsType(t, VerifyEmail400JSONResponse{}, resp)
+		mockRepo.AssertNotCalled(t, "ConfirmEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Token already used", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		token := sign(t, handler, &verification.Claims{ID: userID(9), UserID: userID(1), Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour)})
+		mockRepo.On("ConfirmEmail", mock.Anything, userID(9), "john@example.com").Return(nil, ownErrors.ErrInvalidToken)
+
+		resp, err := handler.VerifyEmail(context.Background(), VerifyEmailRequestObject{Body: &EmailVerifyRequest{Token: token}})
+		require.NoError(t, err)
+		assert.IsType(t, VerifyEmail400JSONResponse{}, resp)
+	})
+
+	t.Run("Address taken meanwhile", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		token := sign(t, handler, &verification.Claims{ID: userID(9), UserID: userID(1), Email: "jane@example.com", ExpiresAt: time.Now().Add(time.Hour)})
+		mockRepo.On("ConfirmEmail", mock.Anything, userID(9), "jane@example.com").Return(nil, ownErrors.ErrUserAlreadyExists)
+
+		resp, err := handler.VerifyEmail(context.Background(), VerifyEmailRequestObject{Body: &EmailVerifyRequest{Token: token}})
+		require.NoError(t, err)
+		assert.IsType(t, VerifyEmail409JSONResponse{}, resp)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		handler := testAuthHandler(t, mockRepo)
+		withMailbox(t, handler)
+		token := sign(t, handler, &verification.Claims{ID: userID(9), UserID: userID(1), Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour)})
+		mockRepo.On("ConfirmEmail", mock.Anything, userID(9), "john@example.com").Return(nil, errors.New("db down"))
+
+		resp, err := handler.VerifyEmail(context.Background(), VerifyEmailRequestObject{Body: &EmailVerifyRequest{Token: token}})
+		require.NoError(t, err)
+		assert.IsType(t, VerifyEmail500JSONResponse{}, resp)
+	})
+}
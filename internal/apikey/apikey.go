@@ -0,0 +1,140 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+const (
+	// keyTag starts every managed key, which tells them apart from static keys and makes leaked keys easy to detect.
+	keyTag = "gu_"
+	// prefixLength is the number of random bytes of the visible prefix.
+	prefixLength = 6
+	// secretLength is the number of random bytes of the secret.
+	secretLength = 32
+)
+
+// Key represents what the verifier needs to know about a stored key.
+type Key struct {
+	ID uuid.UUID
+	// OwnerID is the user a personal access token belongs to; nil for service keys.
+	OwnerID *uuid.UUID
+	// OrgID is the organization the key was created in, which is the owner's for personal access tokens.
+	OrgID      *uuid.UUID
+	Name       string
+	SecretHash []byte
+	Scopes     []string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+}
+
+// Store defines the persistence operations the verifier needs.
+type Store interface {
+	// GetAPIKeyByPrefix returns the key with the given visible prefix or ErrNotFound.
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*Key, error)
+	// TouchAPIKey records that a key was used. Implementations may skip updates of recently used keys.
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+}
+
+// Generate returns a new key of the form gu_<prefix>_<secret> together with its visible prefix and the hash under
+// which it is stored.
+func Generate() (key, prefix string, hash []byte, err error) {
+	raw := make([]byte, prefixLength+secretLength)
+	if _, err = rand.Read(raw); err != nil {
+		return "", "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	prefix = keyTag + hex.EncodeToString(raw[:prefixLength])
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(raw[prefixLength:])
+
+	return key, prefix, Hash(key), nil
+}
+
+// Hash returns the stored hash of a key.
+func Hash(key string) []byte {
+	hash := sha256.Sum256([]byte(key))
+	return hash[:]
+}
+
+// Prefix returns the visible prefix of a managed key, or false if the key does not have the form of one.
+func Prefix(key string) (string, bool) {
+	n := len(keyTag) + 2*prefixLength
+	if !strings.HasPrefix(key, keyTag) || len(key) <= n+1 || key[n] != '_' {
+		return "", false
+	}
+	return key[:n], true
+}
+
+// Verifier accepts managed keys from the store and falls back to static keys for everything else.
+type Verifier struct {
+	store  Store
+	static router.APIKeyVerifier
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// _ ensures that Verifier implements router.APIKeyVerifier at compile time.
+var _ router.APIKeyVerifier = (*Verifier)(nil)
+
+// NewVerifier creates a verifier for the keys of the store and the given static keys.
+func NewVerifier(store Store, static router.APIKeyVerifier, logger *slog.Logger) *Verifier {
+	return &Verifier{store: store, static: static, logger: logger, now: time.Now}
+}
+
+// VerifyAPIKey resolves a key to its principal. Personal access tokens authenticate their owner, service keys a
+// service named after the key ID; both are restricted to the scopes and the organization of the key.
+func (v *Verifier) VerifyAPIKey(ctx context.Context, key string) (*router.Principal, error) {
+	prefix, ok := Prefix(key)
+	if !ok {
+		return v.static.VerifyAPIKey(ctx, key)
+	}
+
+	stored, err := v.store.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		return v.static.VerifyAPIKey(ctx, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	now := v.now()
+	switch {
+	case subtle.ConstantTimeCompare(Hash(key), stored.SecretHash) != 1:
+		return nil, router.ErrInvalidCredentials
+	case stored.RevokedAt != nil, stored.ExpiresAt != nil && !stored.ExpiresAt.After(now):
+		return nil, router.ErrInvalidCredentials
+	}
+
+	if err = v.store.TouchAPIKey(ctx, stored.ID); err != nil {
+		v.logger.Error("Failed to record API key usage", "error", err, "key_id", stored.ID)
+	}
+
+	scopes := stored.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	principal := &router.Principal{Kind: router.PrincipalService, ID: stored.ID.String(), KeyID: stored.ID.String(), Scopes: scopes}
+	if stored.OwnerID != nil {
+		principal.Kind = router.PrincipalUser
+		principal.ID = stored.OwnerID.String()
+	}
+	if stored.OrgID != nil {
+		principal.Org = stored.OrgID.String()
+	}
+
+	return principal, nil
+}
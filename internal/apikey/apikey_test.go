@@ -0,0 +1,192 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/router"
+)
+
+// memoryStore is an in-memory Store.
+type memoryStore struct {
+	keys    map[string]*Key
+	touched []uuid.UUID
+	err     error
+}
+
+func (s *memoryStore) GetAPIKeyByPrefix(_ context.Context, prefix string) (*Key, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	key, ok := s.keys[prefix]
+	if !ok {
+		return nil, ownErrors.ErrNotFound
+	}
+	return key, nil
+}
+
+func (s *memoryStore) TouchAPIKey(_ context.Context, id uuid.UUID) error {
+	s.touched = append(s.touched, id)
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.Len(t, prefix, len(keyTag)+2*prefixLength)
+	assert.Equal(t, Hash(key), hash)
+
+	parsed, ok := Prefix(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+
+	other, _, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestPrefix_Malformed(t *testing.T) {
+	for _, key := range []string{"", "0123456789abcdef0123456789abcdef", "gu_short", "gu_0123456789ab", "gu_0123456789ab-secret"} {
+		_, ok := Prefix(key)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestVerifier_VerifyAPIKey(t *testing.T) {
+	static, err := router.NewStaticAPIKeys([]string{"ci:0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	owner := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-000000000001")
+	org := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-00000000a001")
+
+	newKey := func(store *memoryStore, modify func(*Key)) string {
+		secret, prefix, hash, err := Generate()
+		require.NoError(t, err)
+		key := &Key{ID: uuid.New(), Name: "test", SecretHash: hash, Scopes: []string{"users:read"}}
+		modify(key)
+		store.keys[prefix] = key
+		return secret
+	}
+
+	tests := []struct {
+		name              string
+		key               func(store *memoryStore) string
+		storeErr          error
+		expectedPrincipal func(store *memoryStore) *router.Principal
+		expectedErr       error
+	}{
+		{
+			name: "Static key",
+			key:  func(*memoryStore) string { return "0123456789abcdef0123456789abcdef" },
+			expectedPrincipal: func(*memoryStore) *router.Principal {
+				return &router.Principal{Kind: router.PrincipalService, ID: "ci", KeyID: "ci"}
+			},
+		},
+		{
+			name: "Personal access token",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(k *Key) { k.OwnerID, k.OrgID = &owner, &org })
+			},
+			expectedPrincipal: func(store *memoryStore) *router.Principal {
+				id := store.touched[0].String()
+				return &router.Principal{Kind: router.PrincipalUser, ID: owner.String(), KeyID: id, Scopes: []string{"users:read"},
+					Org: org.String()}
+			},
+		},
+		{
+			name: "Service key of an organization",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(k *Key) { k.OrgID = &org })
+			},
+			expectedPrincipal: func(store *memoryStore) *router.Principal {
+				id := store.touched[0].String()
+				return &router.Principal{Kind: router.PrincipalService, ID: id, KeyID: id, Scopes: []string{"users:read"},
+					Org: org.String()}
+			},
+		},
+		{
+			name: "Service key without scopes",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(k *Key) { k.Scopes = nil })
+			},
+			expectedPrincipal: func(store *memoryStore) *router.Principal {
+				id := store.touched[0].String()
+				return &router.Principal{Kind: router.PrincipalService, ID: id, KeyID: id, Scopes: []string{}}
+			},
+		},
+		{
+			name: "Wrong secret",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(*Key) {}) + "x"
+			},
+			expectedErr: router.ErrInvalidCredentials,
+		},
+		{
+			name: "Expired key",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(k *Key) { k.ExpiresAt = &past })
+			},
+			expectedErr: router.ErrInvalidCredentials,
+		},
+		{
+			name: "Revoked key",
+			key: func(store *memoryStore) string {
+				return newKey(store, func(k *Key) { k.RevokedAt = &past })
+			},
+			expectedErr: router.ErrInvalidCredentials,
+		},
+		{
+			name: "Unknown managed key",
+			key: func(*memoryStore) string {
+				secret, _, _, err := Generate()
+				require.NoError(t, err)
+				return secret
+			},
+			expectedErr: router.ErrInvalidCredentials,
+		},
+		{
+			name:     "Store error",
+			key:      func(*memoryStore) string { return "gu_0123456789ab_secret" },
+			storeErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &memoryStore{keys: make(map[string]*Key)}
+			verifier := NewVerifier(store, static, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			verifier.now = func() time.Time { return now }
+
+			key := tc.key(store)
+			store.err = tc.storeErr
+
+			principal, err := verifier.VerifyAPIKey(context.Background(), key)
+
+			switch {
+			case tc.expectedPrincipal != nil:
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedPrincipal(store), principal)
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, store.touched)
+			default:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, router.ErrInvalidCredentials)
+			}
+		})
+	}
+}
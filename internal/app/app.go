@@ -12,25 +12,43 @@ import (
 	"time"
 
 	"go-users/internal/api"
+	"go-users/internal/blob"
 	"go-users/internal/config"
 	"go-users/internal/database"
+	"go-users/internal/jobs"
+	"go-users/internal/lifecycle"
+	"go-users/internal/pii"
+	"go-users/internal/retention"
+	"go-users/internal/token"
 )
 
 // The App represents the core application structure including configuration, logging, database, and the HTTP server.
 type App struct {
-	db     database.DB
-	cfg    *config.Config
-	logger *slog.Logger
-	server *http.Server
+	db          database.DB
+	cfg         *config.Config
+	logger      *slog.Logger
+	server      *http.Server
+	blobs       blob.Store
+	jobs        *jobs.Pool
+	keys        *token.Keyring
+	piiKeys     *pii.Keyring
+	reactivator *lifecycle.Reactivator
+	retention   *retention.Scheduler
 }
 
 // New initializes and returns a new App instance configured with the provided config and logger. Returns an error if setup fails.
 func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
-	db, err := database.New(context.Background(), cfg.Database)
+	db, err := database.New(context.Background(), cfg.Database, cfg.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
+	if bypassed, err := db.RowSecurityBypassed(context.Background()); err != nil {
+		logger.Warn("Failed to check row security", "error", err)
+	} else if bypassed {
+		logger.Warn("The database role bypasses row-level security, organizations are not isolated")
+	}
+
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.HTTP.Host, cfg.HTTP.Port),
 		ReadTimeout:  time.Duration(cfg.HTTP.ReadTimeout) * time.Second,
@@ -38,11 +56,59 @@ func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
 		IdleTimeout:  time.Duration(cfg.HTTP.IdleTimeout) * time.Second,
 	}
 
+	// Workers run the asynchronous jobs, such as user imports and exports, queued in the database.
+	pool := jobs.NewPool(db, logger, cfg.Jobs)
+	jobs.RegisterUserHandlers(pool, db)
+
+	// Access tokens are signed with keys stored in the database and rotated on a schedule shared by all instances.
+	keys, err := token.NewKeyring(db, logger, cfg.JWT, cfg.SigningKeys)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize signing keys: %w", err)
+	}
+
+	// Personal data stays unencrypted without a key-encryption key.
+	piiKeys, err := pii.NewKeyring(db, logger, cfg.PII)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize personal data keys: %w", err)
+	}
+	if piiKeys != nil {
+		db.UseKeyring(piiKeys)
+	}
+
+	// Suspended users are reactivated once their suspension ends.
+	if cfg.Lifecycle.ReactivationInterval <= 0 {
+		db.Close()
+		return nil, fmt.Errorf("user reactivation interval must be positive")
+	}
+	reactivator := lifecycle.NewReactivator(db, logger, time.Duration(cfg.Lifecycle.ReactivationInterval)*time.Second)
+
+	// Avatars are uploaded to the blob store by the handler and deleted from it by retention.
+	blobs, err := blob.New(cfg.Blob)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create blob store: %w", err)
+	}
+
+	// Retention purges expired users and audit entries, with the avatars of purged users.
+	scheduler, err := retention.NewScheduler(db, blobs, logger, cfg.Retention)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize retention: %w", err)
+	}
+
 	return &App{
-		cfg:    cfg,
-		logger: logger,
-		db:     db,
-		server: server,
+		cfg:         cfg,
+		logger:      logger,
+		db:          db,
+		server:      server,
+		blobs:       blobs,
+		jobs:        pool,
+		keys:        keys,
+		piiKeys:     piiKeys,
+		reactivator: reactivator,
+		retention:   scheduler,
 	}, nil
 }
 
@@ -50,12 +116,34 @@ func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
 func (a *App) Run() error {
 	serverErrors := make(chan error, 1)
 
-	handler, err := api.NewHandler(a.cfg.OpenAPI, a.logger, a.db)
+	if a.piiKeys != nil {
+		if err := a.piiKeys.Start(context.Background()); err != nil {
+			a.db.Close()
+			return fmt.Errorf("failed to load personal data keys: %w", err)
+		}
+	}
+
+	if err := a.keys.Start(context.Background()); err != nil {
+		a.shutdownPIIKeys()
+		a.db.Close()
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	handler, err := api.NewHandler(a.cfg, a.logger, a.db, a.keys, a.blobs)
 	if err != nil {
+		a.keys.Shutdown()
+		a.shutdownPIIKeys()
+		a.db.Close()
 		return fmt.Errorf("failed to create handler: %w", err)
 	}
 	a.server.Handler = handler
 
+	a.jobs.Start()
+	a.reactivator.Start(context.Background())
+	a.retention.Start()
+	// The subsystems are shut down however the server stops, also when it failed.
+	defer a.shutdown()
+
 	go func() {
 		a.logger.Info("Starting server",
 			"addr", a.server.Addr,
@@ -83,8 +171,30 @@ func (a *App) Run() error {
 		a.logger.Error("Server forced to shutdown", "error", err)
 	}
 
+	return nil
+}
+
+// shutdown drains the job workers, stops the background tasks and closes the database connection.
+func (a *App) shutdown() {
+	jobsCtx, jobsCancel := context.WithTimeout(context.Background(), time.Duration(a.cfg.Jobs.ShutdownTimeout)*time.Second)
+	defer jobsCancel()
+
+	if err := a.jobs.Shutdown(jobsCtx); err != nil {
+		a.logger.Error("Job workers forced to shutdown", "error", err)
+	}
+
+	a.retention.Shutdown()
+	a.reactivator.Shutdown()
+	a.keys.Shutdown()
+	a.shutdownPIIKeys()
 	a.db.Close()
 
 	a.logger.Info("Server exiting")
-	return nil
+}
+
+// shutdownPIIKeys stops the personal data key rotation if personal data is encrypted.
+func (a *App) shutdownPIIKeys() {
+	if a.piiKeys != nil {
+		a.piiKeys.Shutdown()
+	}
 }
@@ -0,0 +1,113 @@
+package attributes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"go-users/internal/ownErrors"
+)
+
+// Schema describes the custom attributes users of an organization may have. Schemas are written in the JSON Schema
+// dialect of OpenAPI 3.0 schema objects and must describe an object.
+type Schema struct {
+	schema *openapi3.Schema
+}
+
+// InvalidError reports the attributes violating a schema, one problem per violation.
+type InvalidError struct {
+	Problems []string
+}
+
+func (e *InvalidError) Error() string {
+	return fmt.Sprintf("%v: %s", ownErrors.ErrInvalidAttributes, strings.Join(e.Problems, "; "))
+}
+
+func (e *InvalidError) Unwrap() error {
+	return ownErrors.ErrInvalidAttributes
+}
+
+// Compile parses a schema. Returns ErrInvalidAttributeSchema if it is malformed or does not describe an object.
+// The $schema and $id keywords of JSON Schema documents are ignored.
+func Compile(raw []byte) (*Schema, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrInvalidAttributeSchema, err)
+	}
+	delete(doc, "$schema")
+	delete(doc, "$id")
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrInvalidAttributeSchema, err)
+	}
+
+	var schema openapi3.Schema
+	if err = json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrInvalidAttributeSchema, err)
+	}
+	if err = schema.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrInvalidAttributeSchema, err)
+	}
+	if !schema.Type.Is(openapi3.TypeObject) {
+		return nil, fmt.Errorf("%w: type must be object", ownErrors.ErrInvalidAttributeSchema)
+	}
+
+	return &Schema{schema: &schema}, nil
+}
+
+// Validate returns the problems of the attributes, or nothing if they satisfy the schema. Without a schema only empty
+// attributes are valid.
+func (s *Schema) Validate(attrs map[string]interface{}) []string {
+	if s == nil {
+		if len(attrs) > 0 {
+			return []string{"no attribute schema is registered"}
+		}
+		return nil
+	}
+	if attrs == nil {
+		attrs = map[string]interface{}{}
+	}
+
+	err := s.schema.VisitJSON(attrs, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		multi = openapi3.MultiError{err}
+	}
+
+	problems := make([]string, 0, len(multi))
+	for _, e := range multi {
+		problems = append(problems, problem(e))
+	}
+	return problems
+}
+
+// problem formats a violation as the path of the attribute followed by the reason.
+func problem(err error) string {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return err.Error()
+	}
+
+	path := schemaErr.JSONPointer()
+	if len(path) == 0 {
+		return schemaErr.Reason
+	}
+	return strings.Join(path, ".") + ": " + schemaErr.Reason
+}
+
+// Check validates the attributes against the schema and returns an InvalidError listing the problems, if any.
+func (s *Schema) Check(attrs map[string]interface{}) error {
+	if problems := s.Validate(attrs); len(problems) > 0 {
+		return &InvalidError{Problems: problems}
+	}
+	return nil
+}
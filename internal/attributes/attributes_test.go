@@ -0,0 +1,77 @@
+package attributes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+const employeeSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"department": {"type": "string", "enum": ["sales", "engineering"]},
+		"employee_id": {"type": "integer", "minimum": 1},
+		"locale": {"type": "string", "pattern": "^[a-z]{2}(-[A-Z]{2})?$"}
+	},
+	"required": ["department"]
+}`
+
+func TestCompile(t *testing.T) {
+	_, err := Compile([]byte(employeeSchema))
+	require.NoError(t, err)
+
+	for _, raw := range []string{
+		`not json`,
+		`{"type": "string"}`,
+		`{"type": "objekt"}`,
+		`{"type": "object", "properties": {"code": {"type": "string", "pattern": "("}}}`,
+		`{"type": "object", "unknown": true}`,
+	} {
+		_, err = Compile([]byte(raw))
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidAttributeSchema, raw)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := Compile([]byte(employeeSchema))
+	require.NoError(t, err)
+
+	assert.Empty(t, schema.Validate(map[string]interface{}{"department": "sales", "employee_id": float64(42)}))
+
+	problems := schema.Validate(map[string]interface{}{
+		"department":  "marketing",
+		"employee_id": float64(0),
+		"team":        "platform",
+	})
+	assert.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "department: ")
+
+	assert.Equal(t, []string{`department: property "department" is missing`}, schema.Validate(nil))
+}
+
+func TestSchema_ValidateWithoutSchema(t *testing.T) {
+	var schema *Schema
+
+	assert.Empty(t, schema.Validate(nil))
+	assert.Empty(t, schema.Validate(map[string]interface{}{}))
+	assert.Equal(t, []string{"no attribute schema is registered"}, schema.Validate(map[string]interface{}{"team": "x"}))
+}
+
+func TestSchema_Check(t *testing.T) {
+	schema, err := Compile([]byte(employeeSchema))
+	require.NoError(t, err)
+
+	err = schema.Check(map[string]interface{}{"department": 1})
+	assert.ErrorIs(t, err, ownErrors.ErrInvalidAttributes)
+
+	var invalid *InvalidError
+	require.ErrorAs(t, err, &invalid)
+	assert.Len(t, invalid.Problems, 1)
+
+	assert.NoError(t, schema.Check(map[string]interface{}{"department": "sales"}))
+}
@@ -0,0 +1,196 @@
+package avatar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+
+	"go-users/internal/ownErrors"
+)
+
+// Image formats accepted for upload.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+const (
+	// DefaultSize is the variant served when no size is requested.
+	DefaultSize = 128
+	// jpegQuality is the quality of JPEG variants.
+	jpegQuality = 85
+	// versionLength is the number of hex digits of the upload hash used as version.
+	versionLength = 16
+)
+
+// Sizes lists the edge lengths in pixels of the square variants generated for every avatar.
+var Sizes = []int{64, 128, 512}
+
+// Limits restricts the uploads accepted by Process.
+type Limits struct {
+	// MaxSize is the maximum size of an upload in bytes.
+	MaxSize int64
+	// MaxPixels is the maximum number of pixels of an upload, checked before it is decoded.
+	MaxPixels int
+}
+
+// Variant represents an encoded avatar in one of the Sizes.
+type Variant struct {
+	Size        int
+	Data        []byte
+	ContentType string
+}
+
+// Avatar represents a processed upload.
+type Avatar struct {
+	// Version identifies the upload. It is derived from its content, so URLs containing it can be cached forever.
+	Version  string
+	Variants []Variant
+}
+
+// Key returns the blob key of a variant.
+func Key(userID, version string, size int) string {
+	return "avatars/" + userID + "/" + version + "/" + strconv.Itoa(size)
+}
+
+// ValidSize reports whether size is one of the Sizes.
+func ValidSize(size int) bool {
+	for _, s := range Sizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
+
+// Process validates an uploaded image and renders it in all Sizes. The image is turned upright according to its EXIF
+// orientation and cropped to a centered square. Variants are encoded from the pixels alone, which strips EXIF and other
+// metadata: opaque images as JPEG, images with transparency as PNG.
+//
+// Returns ErrImageTooLarge if the upload exceeds the limits and ErrUnsupportedImage if it is not a valid JPEG, PNG or
+// WebP image.
+func Process(data []byte, limits Limits) (*Avatar, error) {
+	if int64(len(data)) > limits.MaxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ownErrors.ErrImageTooLarge, limits.MaxSize)
+	}
+
+	format := DetectFormat(data)
+	if format == "" {
+		return nil, ownErrors.ErrUnsupportedImage
+	}
+
+	cfg, err := decodeConfig(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrUnsupportedImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ownErrors.ErrUnsupportedImage)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > int64(limits.MaxPixels) {
+		return nil, fmt.Errorf("%w: more than %d pixels", ownErrors.ErrImageTooLarge, limits.MaxPixels)
+	}
+
+	img, err := decode(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrUnsupportedImage, err)
+	}
+
+	orientation := 1
+	if format == FormatJPEG {
+		orientation = exifOrientation(data)
+	}
+
+	encode, contentType := encodePNG, "image/png"
+	if isOpaque(img) {
+		encode, contentType = encodeJPEG, "image/jpeg"
+	}
+
+	// Cropping to the centered square commutes with the orientation transforms, so the source is cropped and scaled
+	// first and only the small variants are turned.
+	crop := centerSquare(img.Bounds())
+	sum := sha256.Sum256(data)
+	avatar := &Avatar{Version: hex.EncodeToString(sum[:])[:versionLength]}
+	for _, size := range Sizes {
+		scaled := image.NewRGBA(image.Rect(0, 0, size, size))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, crop, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if err = encode(&buf, orient(scaled, orientation)); err != nil {
+			return nil, fmt.Errorf("failed to encode avatar: %w", err)
+		}
+		avatar.Variants = append(avatar.Variants, Variant{Size: size, Data: buf.Bytes(), ContentType: contentType})
+	}
+
+	return avatar, nil
+}
+
+// DetectFormat returns the format of an image by its magic bytes, or an empty string if it is none of the accepted
+// formats. The declared content type of an upload is not trusted.
+func DetectFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(data, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return FormatPNG
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return FormatWebP
+	default:
+		return ""
+	}
+}
+
+func decodeConfig(format string, r io.Reader) (image.Config, error) {
+	switch format {
+	case FormatJPEG:
+		return jpeg.DecodeConfig(r)
+	case FormatPNG:
+		return png.DecodeConfig(r)
+	default:
+		return webp.DecodeConfig(r)
+	}
+}
+
+func decode(format string, r io.Reader) (image.Image, error) {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Decode(r)
+	case FormatPNG:
+		return png.Decode(r)
+	default:
+		return webp.Decode(r)
+	}
+}
+
+func encodeJPEG(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
+
+func encodePNG(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
+
+// isOpaque reports whether an image has no transparent pixels.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// centerSquare returns the largest square centered in r.
+func centerSquare(r image.Rectangle) image.Rectangle {
+	side := min(r.Dx(), r.Dy())
+	x := r.Min.X + (r.Dx()-side)/2
+	y := r.Min.Y + (r.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
@@ -0,0 +1,166 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+var limits = Limits{MaxSize: 1 << 20, MaxPixels: 1 << 20}
+
+// halves returns an image with a red left and a blue right half.
+func halves(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an EXIF segment with the given orientation after the start of a JPEG image.
+func withOrientation(t *testing.T, data []byte, orientation uint16) []byte {
+	t.Helper()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	require.Equal(t, []byte{0xFF, 0xD8}, data[:2])
+	return append(append([]byte{0xFF, 0xD8}, app1...), data[2:]...)
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+func TestProcess_Variants(t *testing.T) {
+	data := encodeTestJPEG(t, halves(300, 200))
+
+	avatar, err := Process(data, limits)
+
+	require.NoError(t, err)
+	assert.Len(t, avatar.Version, versionLength)
+	require.Len(t, avatar.Variants, len(Sizes))
+	for i, v := range avatar.Variants {
+		assert.Equal(t, Sizes[i], v.Size)
+		assert.Equal(t, "image/jpeg", v.ContentType)
+
+		img, err := jpeg.Decode(bytes.NewReader(v.Data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, v.Size, v.Size), img.Bounds())
+	}
+
+	again, err := Process(data, limits)
+	require.NoError(t, err)
+	assert.Equal(t, avatar.Version, again.Version, "the version depends only on the upload")
+}
+
+func TestProcess_Orientation(t *testing.T) {
+	data := withOrientation(t, encodeTestJPEG(t, halves(200, 200)), 6)
+	require.Equal(t, 6, exifOrientation(data))
+
+	avatar, err := Process(data, limits)
+	require.NoError(t, err)
+
+	v := avatar.Variants[0]
+	assert.NotContains(t, string(v.Data), "Exif", "metadata is stripped")
+	img, err := jpeg.Decode(bytes.NewReader(v.Data))
+	require.NoError(t, err)
+
+	// Turned clockwise, the red left half ends up on top.
+	r, _, b, _ := img.At(v.Size/2, 4).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = img.At(v.Size/2, v.Size-5).RGBA()
+	assert.Greater(t, b, r)
+}
+
+func TestProcess_Transparency(t *testing.T) {
+	img := halves(100, 100)
+	img.SetNRGBA(50, 50, color.NRGBA{})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	avatar, err := Process(buf.Bytes(), limits)
+
+	require.NoError(t, err)
+	for _, v := range avatar.Variants {
+		assert.Equal(t, "image/png", v.ContentType)
+		_, err = png.Decode(bytes.NewReader(v.Data))
+		assert.NoError(t, err)
+	}
+}
+
+func TestProcess_Rejected(t *testing.T) {
+	small := encodeTestJPEG(t, halves(20, 20))
+
+	_, err := Process(small, Limits{MaxSize: 10, MaxPixels: 1 << 20})
+	assert.ErrorIs(t, err, ownErrors.ErrImageTooLarge)
+
+	_, err = Process(small, Limits{MaxSize: 1 << 20, MaxPixels: 399})
+	assert.ErrorIs(t, err, ownErrors.ErrImageTooLarge)
+
+	_, err = Process([]byte("GIF89a\x01\x00\x01\x00"), limits)
+	assert.ErrorIs(t, err, ownErrors.ErrUnsupportedImage)
+
+	_, err = Process(small[:len(small)/2], limits)
+	assert.ErrorIs(t, err, ownErrors.ErrUnsupportedImage)
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, FormatJPEG, DetectFormat([]byte{0xFF, 0xD8, 0xFF, 0xE0}))
+	assert.Equal(t, FormatPNG, DetectFormat([]byte("\x89PNG\r\n\x1a\n....")))
+	assert.Equal(t, FormatWebP, DetectFormat([]byte("RIFF\x24\x00\x00\x00WEBPVP8 ")))
+	assert.Empty(t, DetectFormat([]byte("RIFF\x24\x00\x00\x00WAVEfmt ")))
+	assert.Empty(t, DetectFormat([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")))
+}
+
+func TestOrient(t *testing.T) {
+	// 2x2 image with distinct pixels a b / c d.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	a, b, c, d := color.RGBA{R: 1, A: 255}, color.RGBA{R: 2, A: 255}, color.RGBA{R: 3, A: 255}, color.RGBA{R: 4, A: 255}
+	img.SetRGBA(0, 0, a)
+	img.SetRGBA(1, 0, b)
+	img.SetRGBA(0, 1, c)
+	img.SetRGBA(1, 1, d)
+
+	expected := map[int][4]color.RGBA{
+		1: {a, b, c, d},
+		2: {b, a, d, c},
+		3: {d, c, b, a},
+		4: {c, d, a, b},
+		5: {a, c, b, d},
+		6: {c, a, d, b},
+		7: {d, b, c, a},
+		8: {b, d, a, c},
+	}
+	for orientation, pixels := range expected {
+		o := orient(img, orientation)
+		assert.Equal(t, pixels, [4]color.RGBA{o.RGBAAt(0, 0), o.RGBAAt(1, 0), o.RGBAAt(0, 1), o.RGBAAt(1, 1)}, orientation)
+	}
+}
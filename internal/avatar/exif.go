@@ -0,0 +1,118 @@
+package avatar
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+// exifOrientationTag is the EXIF tag holding the orientation of the camera.
+const exifOrientationTag = 0x0112
+
+// exifOrientation returns the EXIF orientation (1-8) of a JPEG image, or 1 if it has none. Only the APP1 segments
+// before the image data are searched; malformed metadata is ignored.
+func exifOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		// Start of scan and end of image: no more metadata follows.
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			if o := tiffOrientation(segment[6:]); o != 0 {
+				return o
+			}
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation returns the orientation stored in the first IFD of a TIFF header, or 0 if there is none.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		// Orientation is a single SHORT (type 3) stored in the first bytes of the value field.
+		if order.Uint16(tiff[entry:]) == exifOrientationTag && order.Uint16(tiff[entry+2:]) == 3 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+
+	return 0
+}
+
+// orient applies an EXIF orientation to a square image, so that it displays upright without the metadata.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	n := img.Bounds().Dx()
+	last := n - 1
+	dst := image.NewRGBA(img.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			// The source pixel shown at (x, y) once the image is upright.
+			sx, sy := x, y
+			switch orientation {
+			case 2: // Mirrored horizontally.
+				sx = last - x
+			case 3: // Rotated by 180°.
+				sx, sy = last-x, last-y
+			case 4: // Mirrored vertically.
+				sy = last - y
+			case 5: // Mirrored along the main diagonal.
+				sx, sy = y, x
+			case 6: // Needs a clockwise rotation by 90°.
+				sx, sy = y, last-x
+			case 7: // Mirrored along the anti-diagonal.
+				sx, sy = last-y, last-x
+			case 8: // Needs a counterclockwise rotation by 90°.
+				sx, sy = last-y, x
+			}
+			dst.SetRGBA(x, y, img.RGBAAt(sx, sy))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,70 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go-users/internal/config"
+)
+
+// Drivers selectable with BLOB_DRIVER.
+const (
+	DriverFile = "file"
+	DriverS3   = "s3"
+)
+
+// keyPattern restricts keys to slash-separated names that are safe as file paths and in URLs.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// Object represents a stored blob.
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Store stores blobs under slash-separated keys such as "avatars/<user>/<version>/64".
+type Store interface {
+	// Put stores data under key, replacing an existing blob.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the blob stored under key. Returns ErrNotFound if there is none.
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the store selected by the configuration.
+func New(cfg config.Blob) (Store, error) {
+	switch cfg.Driver {
+	case DriverFile:
+		return NewFileStore(cfg.Dir), nil
+	case DriverS3:
+		store, err := NewS3Store(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", cfg.Driver)
+	}
+}
+
+// checkKey rejects keys that could escape the store, such as absolute paths or ".." segments.
+func checkKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
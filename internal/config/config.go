@@ -27,6 +27,9 @@ type HTTP struct {
 	ReadTimeout  int    `env:"HTTP_READ_TIMEOUT" env-default:"5"`
 	WriteTimeout int    `env:"HTTP_WRITE_TIMEOUT" env-default:"10"`
 	IdleTimeout  int    `env:"HTTP_IDLE_TIMEOUT" env-default:"120"`
+	// TrustedProxies lists the IP addresses or CIDR prefixes of the reverse proxies whose forwarding headers name the
+	// client address.
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES" env-separator:","`
 }
 
 // Log represents logging configuration including log level, format, and output destination.
@@ -40,6 +43,8 @@ type Log struct {
 type OpenAPI struct {
 	SpecPath  string `env:"OPENAPI_SPEC_PATH" env-default:"openapi/openapi.yaml"`
 	APIPrefix string `env:"OPENAPI_API_PREFIX" env-default:"/api/v1"`
+	// LegacyIDs additionally accepts the former integer user IDs wherever a user ID is expected.
+	LegacyIDs bool `env:"OPENAPI_LEGACY_IDS" env-default:"false"`
 }
 
 // Database represents the configuration for a database connection, including host, port, credentials, and settings.
@@ -53,13 +58,197 @@ type Database struct {
 	MaxConnections int    `env:"DB_MAX_CONNECTIONS" env-default:"10"`
 }
 
-// Config represents the configuration structure for the application, including settings for App, HTTP, Log, OpenAPI, and Database.
+// Email represents the rules used to derive the canonical form of user email addresses.
+type Email struct {
+	FoldGmail bool `env:"EMAIL_FOLD_GMAIL" env-default:"false"`
+}
+
+// EmailVerification represents the configuration of email address verification. Tokens are signed with Secret and
+// expire after TTL seconds; a user gets at most one mail per ResendInterval seconds. The token is appended to URL as
+// token query parameter. Without a secret a random one is used, which does not survive restarts.
+type EmailVerification struct {
+	Secret         string `env:"EMAIL_VERIFICATION_SECRET"`
+	TTL            int    `env:"EMAIL_VERIFICATION_TTL" env-default:"86400"`
+	ResendInterval int    `env:"EMAIL_VERIFICATION_RESEND_INTERVAL" env-default:"60"`
+	URL            string `env:"EMAIL_VERIFICATION_URL" env-default:"http://localhost:8080/verify-email"`
+}
+
+// Mail represents the configuration of outgoing mail. The driver is smtp or file; the file driver writes every mail
+// to Dir instead of delivering it, which is meant for development and tests.
+type Mail struct {
+	Driver       string `env:"MAIL_DRIVER" env-default:"file"`
+	From         string `env:"MAIL_FROM" env-default:"go-users <no-reply@localhost>"`
+	Dir          string `env:"MAIL_DIR" env-default:"mail"`
+	SMTPHost     string `env:"MAIL_SMTP_HOST" env-default:"localhost"`
+	SMTPPort     int    `env:"MAIL_SMTP_PORT" env-default:"587"`
+	SMTPUsername string `env:"MAIL_SMTP_USERNAME"`
+	SMTPPassword string `env:"MAIL_SMTP_PASSWORD"`
+}
+
+// Password represents the Argon2id hashing parameters, the password policy and the account lockout rules.
+// Memory is in KiB, cooldowns are in seconds.
+type Password struct {
+	Memory              uint32 `env:"PASSWORD_ARGON2_MEMORY" env-default:"65536"`
+	Iterations          uint32 `env:"PASSWORD_ARGON2_ITERATIONS" env-default:"3"`
+	Parallelism         uint8  `env:"PASSWORD_ARGON2_PARALLELISM" env-default:"2"`
+	MinLength           int    `env:"PASSWORD_MIN_LENGTH" env-default:"12"`
+	MaxLength           int    `env:"PASSWORD_MAX_LENGTH" env-default:"128"`
+	MinClasses          int    `env:"PASSWORD_MIN_CLASSES" env-default:"3"`
+	LockoutThreshold    int    `env:"PASSWORD_LOCKOUT_THRESHOLD" env-default:"5"`
+	LockoutBaseCooldown int    `env:"PASSWORD_LOCKOUT_BASE_COOLDOWN" env-default:"30"`
+	LockoutMaxCooldown  int    `env:"PASSWORD_LOCKOUT_MAX_COOLDOWN" env-default:"3600"`
+}
+
+// PasswordReset represents the configuration of password resets by mail. Tokens expire after TTL seconds and are
+// appended to URL as token query parameter. At most MaxPerEmail requests per email address and MaxPerIP requests per
+// client IP are accepted within Window seconds.
+type PasswordReset struct {
+	TTL         int    `env:"PASSWORD_RESET_TTL" env-default:"900"`
+	URL         string `env:"PASSWORD_RESET_URL" env-default:"http://localhost:8080/reset-password"`
+	Window      int    `env:"PASSWORD_RESET_WINDOW" env-default:"3600"`
+	MaxPerEmail int    `env:"PASSWORD_RESET_MAX_PER_EMAIL" env-default:"3"`
+	MaxPerIP    int    `env:"PASSWORD_RESET_MAX_PER_IP" env-default:"20"`
+}
+
+// Invitation represents the configuration of user invitations. Invitation tokens expire after TTL seconds and are
+// appended to URL as token query parameter.
+type Invitation struct {
+	TTL int    `env:"INVITATION_TTL" env-default:"604800"`
+	URL string `env:"INVITATION_URL" env-default:"http://localhost:8080/accept-invitation"`
+}
+
+// Blob represents the configuration of the blob storage holding uploaded files. The driver is file or s3; the file
+// driver stores blobs below Dir, the s3 driver in Bucket of an S3-compatible service such as AWS S3 or MinIO, addressed
+// in path style below Endpoint.
+type Blob struct {
+	Driver      string `env:"BLOB_DRIVER" env-default:"file"`
+	Dir         string `env:"BLOB_DIR" env-default:"blobs"`
+	S3Endpoint  string `env:"BLOB_S3_ENDPOINT" env-default:"https://s3.amazonaws.com"`
+	S3Region    string `env:"BLOB_S3_REGION" env-default:"us-east-1"`
+	S3Bucket    string `env:"BLOB_S3_BUCKET"`
+	S3AccessKey string `env:"BLOB_S3_ACCESS_KEY"`
+	S3SecretKey string `env:"BLOB_S3_SECRET_KEY"`
+}
+
+// Avatar represents the configuration of avatar uploads. Uploads larger than MaxSize bytes or MaxPixels pixels are
+// rejected. URL is the public address of the avatar endpoint, below which the avatar URLs of users are formed.
+type Avatar struct {
+	MaxSize   int    `env:"AVATAR_MAX_SIZE" env-default:"5242880"`
+	MaxPixels int    `env:"AVATAR_MAX_PIXELS" env-default:"25000000"`
+	URL       string `env:"AVATAR_URL" env-default:"http://localhost:8080/api/v1/avatars"`
+}
+
+// Lifecycle represents the configuration of the user status lifecycle. Suspensions that have ended are checked for
+// every ReactivationInterval seconds.
+type Lifecycle struct {
+	ReactivationInterval int `env:"USER_REACTIVATION_INTERVAL" env-default:"60"`
+}
+
+// Retention represents the configuration of data retention. Deactivated users are purged DeactivatedUserDays after
+// their deactivation, pending users who never verified their address PendingUserDays after their creation, and audit
+// log entries after AuditLogDays; 0 keeps them forever. The rules are applied every Interval seconds. With DryRun
+// nothing is deleted and the rows that would be are only reported.
+type Retention struct {
+	DeactivatedUserDays int  `env:"RETENTION_DEACTIVATED_USER_DAYS" env-default:"0"`
+	PendingUserDays     int  `env:"RETENTION_PENDING_USER_DAYS" env-default:"0"`
+	AuditLogDays        int  `env:"RETENTION_AUDIT_LOG_DAYS" env-default:"0"`
+	Interval            int  `env:"RETENTION_INTERVAL" env-default:"3600"`
+	DryRun              bool `env:"RETENTION_DRY_RUN" env-default:"false"`
+}
+
+// Tenancy represents the configuration of organizations. Requests to a subdomain of BaseDomain, e.g. acme.users.example.com
+// for users.example.com, belong to the organization with that slug; an empty BaseDomain disables subdomains.
+type Tenancy struct {
+	BaseDomain string `env:"TENANT_BASE_DOMAIN"`
+}
+
+// Session represents the configuration of login sessions. The TTL is the lifetime of a refresh token in seconds;
+// every refresh issues a new refresh token with a fresh lifetime.
+type Session struct {
+	TTL int `env:"SESSION_TTL" env-default:"86400"`
+}
+
+// JWT represents the configuration of signed access tokens. The algorithm is EdDSA or RS256, the TTL is in seconds.
+type JWT struct {
+	Algorithm string `env:"JWT_ALGORITHM" env-default:"EdDSA"`
+	Issuer    string `env:"JWT_ISSUER" env-default:"go-users"`
+	Audience  string `env:"JWT_AUDIENCE" env-default:"go-users"`
+	AccessTTL int    `env:"JWT_ACCESS_TTL" env-default:"900"`
+}
+
+// SigningKeys represents the rotation schedule of the access token signing keys. Durations are in seconds.
+// A new key is published Overlap seconds before it replaces the current one, which stays published for Overlap
+// seconds after its replacement, so Overlap must cover the access token TTL and be shorter than the interval.
+type SigningKeys struct {
+	RotationInterval int `env:"SIGNING_KEYS_ROTATION_INTERVAL" env-default:"604800"`
+	Overlap          int `env:"SIGNING_KEYS_OVERLAP" env-default:"86400"`
+	CheckInterval    int `env:"SIGNING_KEYS_CHECK_INTERVAL" env-default:"60"`
+}
+
+// PII represents the encryption of personal data at rest. KEK is the base64-encoded 32-byte key-encryption key that
+// wraps the data keys, read from KEKFile if that is set; without one personal data is stored unencrypted.
+// PreviousKEKs lists replaced key-encryption keys, whose data keys are rewrapped with KEK. A new data key is created
+// every RotationInterval seconds; users encrypted with an older one are re-encrypted in batches of ReencryptBatch,
+// checked for every CheckInterval seconds.
+type PII struct {
+	KEK              string   `env:"PII_KEK"`
+	KEKFile          string   `env:"PII_KEK_FILE"`
+	PreviousKEKs     []string `env:"PII_PREVIOUS_KEKS" env-separator:","`
+	RotationInterval int      `env:"PII_KEY_ROTATION_INTERVAL" env-default:"7776000"`
+	CheckInterval    int      `env:"PII_KEY_CHECK_INTERVAL" env-default:"300"`
+	ReencryptBatch   int      `env:"PII_REENCRYPT_BATCH" env-default:"500"`
+}
+
+// Auth represents the configuration of request authentication. APIKeys lists the static API keys of services as
+// name:secret pairs.
+type Auth struct {
+	APIKeys []string `env:"AUTH_API_KEYS" env-separator:","`
+}
+
+// MFA represents the configuration of multi-factor authentication. The issuer is shown in authenticator apps; the
+// challenge TTL is the time in seconds to complete a login with the second factor.
+type MFA struct {
+	Issuer           string `env:"MFA_ISSUER" env-default:"go-users"`
+	ChallengeTTL     int    `env:"MFA_CHALLENGE_TTL" env-default:"300"`
+	ChallengeRetries int    `env:"MFA_CHALLENGE_RETRIES" env-default:"5"`
+	RecoveryCodes    int    `env:"MFA_RECOVERY_CODES" env-default:"10"`
+}
+
+// Jobs represents the configuration of the background job worker pool. Durations are in seconds. Artifacts that were
+// not downloaded are deleted ArtifactTTL after their job finished.
+type Jobs struct {
+	Workers         int `env:"JOBS_WORKERS" env-default:"4"`
+	PollInterval    int `env:"JOBS_POLL_INTERVAL" env-default:"1"`
+	StaleTimeout    int `env:"JOBS_STALE_TIMEOUT" env-default:"300"`
+	ShutdownTimeout int `env:"JOBS_SHUTDOWN_TIMEOUT" env-default:"30"`
+	ArtifactTTL     int `env:"JOBS_ARTIFACT_TTL" env-default:"3600"`
+}
+
+// Config represents the configuration structure for the application, including settings for App, HTTP, Log, OpenAPI, Database, Email, EmailVerification, Mail, Blob, Avatar, Password, PasswordReset, Invitation, Lifecycle, Retention, Tenancy, Session, JWT, SigningKeys, PII, Auth, MFA, and Jobs.
 type Config struct {
-	App      App
-	HTTP     HTTP
-	Log      Log
-	OpenAPI  OpenAPI
-	Database Database
+	App               App
+	HTTP              HTTP
+	Log               Log
+	OpenAPI           OpenAPI
+	Database          Database
+	Email             Email
+	EmailVerification EmailVerification
+	Mail              Mail
+	Blob              Blob
+	Avatar            Avatar
+	Password          Password
+	PasswordReset     PasswordReset
+	Invitation        Invitation
+	Lifecycle         Lifecycle
+	Retention         Retention
+	Tenancy           Tenancy
+	Session           Session
+	JWT               JWT
+	SigningKeys       SigningKeys
+	PII               PII
+	Auth              Auth
+	MFA               MFA
+	Jobs              Jobs
 }
 
 // New initializes a new Config object by reading environment variables and applying default settings and flags.
@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/apikey"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// apiKeyColumns selects an API key from k joined with its owner u.
+const apiKeyColumns = "k.id, k.name, k.prefix, k.scopes, u.uid, k.created_at, k.expires_at, k.last_used_at"
+
+// CreateAPIKey stores a key for the given owner, or for a service if owner is nil, in the organization of ctx. Returns
+// ErrNotFound if the owner does not exist.
+func (db *db) CreateAPIKey(ctx context.Context, owner *openapi_types.UUID, k *api.APIKeyRequest, prefix string, secretHash []byte) (*api.APIKey, error) {
+	ownerID, err := db.apiKeyOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	query := `WITH k AS (
+		INSERT INTO api_keys (user_id, name, prefix, secret_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING *
+	)
+	SELECT ` + apiKeyColumns + ` FROM k LEFT JOIN users u ON u.id = k.user_id`
+
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, query, ownerID, k.Name, prefix, secretHash, scopes, k.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys returns the keys of the given owner, or of services if owner is nil, that have not been revoked.
+// Returns ErrNotFound if the owner does not exist.
+func (db *db) ListAPIKeys(ctx context.Context, owner *openapi_types.UUID) ([]api.APIKey, error) {
+	ownerID, err := db.apiKeyOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + apiKeyColumns + `
+	FROM api_keys k
+	LEFT JOIN users u ON u.id = k.user_id
+	WHERE k.user_id IS NOT DISTINCT FROM $1 AND k.revoked_at IS NULL
+	ORDER BY k.created_at`
+
+	rows, err := db.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]api.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key of the given owner, or of a service if owner is nil. Returns ErrNotFound if there is no
+// such key or it has already been revoked.
+func (db *db) RevokeAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID) error {
+	ownerID, err := db.apiKeyOwner(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id IS NOT DISTINCT FROM $2 AND revoked_at IS NULL`
+
+	tag, err := db.pool.Exec(ctx, query, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// RotateAPIKey replaces the prefix and secret of a key of the given owner, or of a service if owner is nil. Returns
+// ErrNotFound if there is no such key or it has been revoked.
+func (db *db) RotateAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID, prefix string, secretHash []byte) (*api.APIKey, error) {
+	ownerID, err := db.apiKeyOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `WITH k AS (
+		UPDATE api_keys SET prefix = $3, secret_hash = $4, rotated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id IS NOT DISTINCT FROM $2 AND revoked_at IS NULL
+		RETURNING *
+	)
+	SELECT ` + apiKeyColumns + ` FROM k LEFT JOIN users u ON u.id = k.user_id`
+
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, query, id, ownerID, prefix, secretHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeyByPrefix returns the key with the given prefix across all organizations, since the organization of a
+// request is only known once its key is. Returns ErrNotFound if there is no such key.
+func (db *db) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*apikey.Key, error) {
+	query := `SELECT k.id, u.uid, k.org_id, k.name, k.secret_hash, k.scopes, k.expires_at, k.revoked_at
+	FROM api_keys k
+	LEFT JOIN users u ON u.id = k.user_id
+	WHERE k.prefix = $1`
+
+	var key apikey.Key
+	err := db.pool.QueryRow(tenant.WithAllOrgs(ctx), query, prefix).Scan(
+		&key.ID,
+		&key.OwnerID,
+		&key.OrgID,
+		&key.Name,
+		&key.SecretHash,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// TouchAPIKey records the use of a key in any organization. Keys used within the last minute are not updated again.
+func (db *db) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')`
+
+	if _, err := db.pool.Exec(tenant.WithAllOrgs(ctx), query, id); err != nil {
+		return fmt.Errorf("failed to update API key usage: %w", err)
+	}
+
+	return nil
+}
+
+// apiKeyOwner returns the internal ID of the owner of personal access tokens, or nil for services.
+func (db *db) apiKeyOwner(ctx context.Context, owner *openapi_types.UUID) (*int64, error) {
+	if owner == nil {
+		return nil, nil
+	}
+
+	var id int64
+	if err := db.pool.QueryRow(ctx, "SELECT id FROM users WHERE uid = $1", *owner).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &id, nil
+}
+
+// scanAPIKey scans a row selected with apiKeyColumns; rows of pgx.Rows are scanned the same way.
+func scanAPIKey(row pgx.Row) (*api.APIKey, error) {
+	var key api.APIKey
+	if err := row.Scan(
+		&key.Id,
+		&key.Name,
+		&key.Prefix,
+		&key.Scopes,
+		&key.UserId,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Run("Service key without scopes", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		createdAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*openapi_types.UUID) = testUID(9)
+				*args.Get(1).(*string) = "ci"
+				*args.Get(2).(*string) = "gu_0123456789ab"
+				*args.Get(3).(*[]string) = []string{}
+				*args.Get(5).(*time.Time) = createdAt
+			}).
+			Return(nil)
+		mp.On("QueryRow", context.Background(), mock.Anything,
+			[]any{(*int64)(nil), "ci", "gu_0123456789ab", []byte("hash"), []string{}, (*time.Time)(nil)},
+		).Return(mr)
+
+		key, err := db.CreateAPIKey(context.Background(), nil, &api.APIKeyRequest{Name: "ci"}, "gu_0123456789ab", []byte("hash"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &api.APIKey{Id: testUID(9), Name: "ci", Prefix: "gu_0123456789ab", Scopes: []string{}, CreatedAt: createdAt}, key)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("Unknown owner", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		owner := testUID(2)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), "SELECT id FROM users WHERE uid = $1", []any{owner}).Return(mr)
+
+		key, err := db.CreateAPIKey(context.Background(), &owner, &api.APIKeyRequest{Name: "ci"}, "gu_0123456789ab", []byte("hash"))
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		assert.Nil(t, key)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	owner := testUID(1)
+	ownerID := int64(7)
+
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*int64) = ownerID
+	}).Return(nil)
+	mp.On("QueryRow", context.Background(), "SELECT id FROM users WHERE uid = $1", []any{owner}).Return(mr)
+	mp.On("Exec", context.Background(), mock.Anything, []any{testUID(9), &ownerID}).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	err := db.RevokeAPIKey(context.Background(), &owner, testUID(9))
+
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	mp.AssertExpectations(t)
+}
+
+func TestGetAPIKeyByPrefix(t *testing.T) {
+	t.Run("Personal access token", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		owner := testUID(1)
+		org := testUID(5)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*uuid.UUID) = testUID(9)
+				*args.Get(1).(**uuid.UUID) = &owner
+				*args.Get(2).(**uuid.UUID) = &org
+				*args.Get(4).(*[]byte) = []byte("hash")
+			}).
+			Return(nil)
+		mp.On("QueryRow", mock.MatchedBy(tenant.AllOrgs), mock.Anything, []any{"gu_0123456789ab"}).Return(mr)
+
+		key, err := db.GetAPIKeyByPrefix(context.Background(), "gu_0123456789ab")
+
+		assert.NoError(t, err)
+		assert.Equal(t, testUID(9), key.ID)
+		assert.Equal(t, &owner, key.OwnerID)
+		assert.Equal(t, &org, key.OrgID)
+		assert.Equal(t, []byte("hash"), key.SecretHash)
+	})
+
+	t.Run("Unknown prefix", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything).
+			Return(sql.ErrNoRows)
+		mp.On("QueryRow", mock.MatchedBy(tenant.AllOrgs), mock.Anything, []any{"gu_0123456789ab"}).Return(mr)
+
+		key, err := db.GetAPIKeyByPrefix(context.Background(), "gu_0123456789ab")
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		assert.Nil(t, key)
+	})
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-users/internal/api"
+	"go-users/internal/attributes"
+	"go-users/internal/ownErrors"
+)
+
+// GetAttributeSchema returns the attribute schema of the organization. Returns ErrNotFound if none is registered.
+func (db *db) GetAttributeSchema(ctx context.Context) (*api.AttributeSchema, error) {
+	var s api.AttributeSchema
+	err := db.pool.QueryRow(ctx, "SELECT schema, updated_at FROM attribute_schemas").Scan(&s.Schema, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	return &s, nil
+}
+
+// PutAttributeSchema registers or replaces the attribute schema of the organization. Returns ErrInvalidAttributeSchema
+// if the schema does not compile. The attributes of existing users are not validated again.
+func (db *db) PutAttributeSchema(ctx context.Context, schema map[string]interface{}) (*api.AttributeSchema, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ownErrors.ErrInvalidAttributeSchema, err)
+	}
+	if _, err = attributes.Compile(raw); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO attribute_schemas (schema) VALUES ($1)
+		ON CONFLICT (org_id) DO UPDATE SET schema = EXCLUDED.schema
+		RETURNING schema, updated_at`
+
+	var s api.AttributeSchema
+	if err = db.pool.QueryRow(ctx, query, raw).Scan(&s.Schema, &s.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to put attribute schema: %w", err)
+	}
+
+	return &s, nil
+}
+
+// DeleteAttributeSchema removes the attribute schema of the organization. Returns ErrNotFound if none is registered.
+func (db *db) DeleteAttributeSchema(ctx context.Context) error {
+	tag, err := db.pool.Exec(ctx, "DELETE FROM attribute_schemas")
+	if err != nil {
+		return fmt.Errorf("failed to delete attribute schema: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// checkAttributes validates attributes against the attribute schema of the organization, read with the given
+// querier. Returns an *attributes.InvalidError if they do not satisfy it.
+func (db *db) checkAttributes(ctx context.Context, q querier, attrs api.Attributes) error {
+	var raw []byte
+	err := q.QueryRow(ctx, "SELECT schema FROM attribute_schemas").Scan(&raw)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	var schema *attributes.Schema
+	if err == nil {
+		if schema, err = attributes.Compile(raw); err != nil {
+			return fmt.Errorf("failed to compile attribute schema: %w", err)
+		}
+	}
+
+	return schema.Check(attrs)
+}
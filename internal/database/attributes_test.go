@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/attributes"
+	"go-users/internal/ownErrors"
+)
+
+const departmentSchema = `{"type": "object", "additionalProperties": false,
+	"properties": {"department": {"type": "string"}}, "required": ["department"]}`
+
+func TestCreateUser_Attributes(t *testing.T) {
+	t.Run("Attributes violate schema", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).
+			Return(attributeSchemaRow([]byte(departmentSchema)))
+
+		_, err := db.CreateUser(context.Background(), &api.UserRequest{
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
+			Attributes: &api.Attributes{"department": 7},
+		})
+
+		var invalid *attributes.InvalidError
+		require.ErrorAs(t, err, &invalid)
+		assert.Len(t, invalid.Problems, 1)
+		mp.AssertNotCalled(t, "QueryRow", context.Background(), insertUserQuery, mock.Anything)
+	})
+
+	t.Run("Attributes without schema", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+
+		_, err := db.CreateUser(context.Background(), &api.UserRequest{
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
+			Attributes: &api.Attributes{"department": "sales"},
+		})
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidAttributes)
+	})
+
+	t.Run("Attributes stored", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		attrs := api.Attributes{"department": "sales"}
+		mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).
+			Return(attributeSchemaRow([]byte(departmentSchema)))
+		mp.On("QueryRow", context.Background(), insertUserQuery,
+			withoutProfile("John", "Doe", "john@example.com", "john@example.com", attrs)).
+			Return(scannedUserRow(testUID(1), "john@example.com"))
+
+		user, err := db.CreateUser(context.Background(), &api.UserRequest{
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
+			Attributes: &attrs,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		mp.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser_Attributes(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("QueryRow", context.Background(), emailTakenQuery, mock.Anything).Return(emailTakenRow(false))
+	mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).
+		Return(attributeSchemaRow([]byte(departmentSchema)))
+
+	_, err := db.UpdateUser(context.Background(), &api.UserRequest{
+		FirstName:  "John",
+		LastName:   "Doe",
+		Email:      "john@example.com",
+		Attributes: &api.Attributes{},
+	}, testUID(1))
+
+	assert.ErrorIs(t, err, ownErrors.ErrInvalidAttributes)
+	mp.AssertNotCalled(t, "QueryRow", context.Background(), updateUserQuery, mock.Anything)
+}
+
+func TestListUsers_Filter(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	status := api.UserStatusActive
+	attrs := api.Attributes{"department": "sales"}
+	statusArg := "active"
+	mp.On("Query", context.Background(), mock.AnythingOfType("string"),
+		[]any{testUID(0), 10, &statusArg, &attrs}).Return(&fakeRows{}, nil)
+
+	users, err := db.ListUsers(context.Background(), testUID(0), 10, &api.UserFilter{Status: &status, Attributes: attrs})
+
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	mp.AssertExpectations(t)
+}
+
+func TestPutAttributeSchema(t *testing.T) {
+	t.Run("Invalid schema", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		_, err := db.PutAttributeSchema(context.Background(), map[string]interface{}{"type": "string"})
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidAttributeSchema)
+		mp.AssertNotCalled(t, "QueryRow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Schema stored", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything).Return(nil)
+		mp.On("QueryRow", context.Background(), mock.AnythingOfType("string"),
+			[]any{[]byte(`{"type":"object"}`)}).Return(mr)
+
+		_, err := db.PutAttributeSchema(context.Background(), map[string]interface{}{"type": "object"})
+
+		require.NoError(t, err)
+		mp.AssertExpectations(t)
+	})
+}
+
+func TestGetAttributeSchema_NotFound(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+	mp.On("QueryRow", context.Background(), mock.AnythingOfType("string"), mock.Anything).Return(mr)
+
+	_, err := db.GetAttributeSchema(context.Background())
+
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+}
+
+func TestDeleteAttributeSchema_NotFound(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), "DELETE FROM attribute_schemas", mock.Anything).
+		Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	err := db.DeleteAttributeSchema(context.Background())
+
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// SetAvatar records the uploaded avatar of a user and points avatar_url to it, or removes both if version is nil.
+// Returns the updated user and the version of the avatar it replaced, whose blobs the caller deletes. Returns
+// ErrNotFound if there is no such user.
+func (db *db) SetAvatar(ctx context.Context, id openapi_types.UUID, version, url *string) (*api.User, *string, error) {
+	query := `UPDATE users SET avatar_version = $2, avatar_url = $3
+		FROM (SELECT id, avatar_version FROM users WHERE uid = $1 FOR UPDATE) AS previous
+		WHERE users.id = previous.id
+		RETURNING previous.avatar_version, ` + userColumns
+
+	var (
+		previous *string
+		user     storedUser
+	)
+	err := db.pool.QueryRow(ctx, query, id, version, url).Scan(append([]any{&previous}, userFields(&user)...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ownErrors.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to set avatar: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, nil, err
+	}
+
+	return &user.User, previous, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestSetAvatar(t *testing.T) {
+	version, url := "0123456789abcdef", "http://localhost:8080/api/v1/avatars/u/0123456789abcdef"
+
+	t.Run("Replaced avatar returned", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1), &version, &url}).Return(mr)
+		mr.On("Scan", append([]any{mock.Anything}, userScanArgs()...)...).Run(func(args mock.Arguments) {
+			old := "fedcba9876543210"
+			*args.Get(0).(**string) = &old
+			*args.Get(1).(*openapi_types.UUID) = testUID(1)
+		}).Return(nil)
+
+		user, previous, err := db.SetAvatar(context.Background(), testUID(1), &version, &url)
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		require.NotNil(t, previous)
+		assert.Equal(t, "fedcba9876543210", *previous)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+
+		mp.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(mr)
+		mr.On("Scan", append([]any{mock.Anything}, userScanArgs()...)...).Return(sql.ErrNoRows)
+
+		_, _, err := db.SetAvatar(context.Background(), testUID(1), nil, nil)
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+)
+
+// RunBatch executes user operations in order inside a single transaction and returns one result per executed operation
+// together with whether the transaction was committed.
+//
+// Without continueOnError the first failing operation stops the batch and rolls back the transaction; the returned
+// results then end with the failed operation. With continueOnError every operation runs in its own savepoint, so a
+// failure only rolls back that operation and the transaction is committed at the end.
+func (db *db) RunBatch(ctx context.Context, ops []api.BatchOperation, continueOnError bool) ([]api.BatchOperationResult, bool, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]api.BatchOperationResult, 0, len(ops))
+	for i := range ops {
+		if !continueOnError {
+			result := db.runBatchOperation(ctx, tx, &ops[i])
+			results = append(results, result)
+			if result.Err != nil {
+				return results, false, nil
+			}
+			continue
+		}
+
+		result, err := db.runBatchOperationInSavepoint(ctx, tx, &ops[i])
+		if err != nil {
+			return nil, false, err
+		}
+		results = append(results, result)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, true, nil
+}
+
+// runBatchOperationInSavepoint executes an operation in a savepoint which is rolled back if the operation fails.
+// The returned error reports failures of the savepoint handling itself, which abort the whole batch.
+func (db *db) runBatchOperationInSavepoint(ctx context.Context, tx pgx.Tx, op *api.BatchOperation) (api.BatchOperationResult, error) {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return api.BatchOperationResult{}, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	result := db.runBatchOperation(ctx, sp, op)
+	if result.Err != nil {
+		if err = sp.Rollback(ctx); err != nil {
+			return api.BatchOperationResult{}, fmt.Errorf("failed to roll back savepoint: %w", err)
+		}
+		return result, nil
+	}
+
+	if err = sp.Commit(ctx); err != nil {
+		return api.BatchOperationResult{}, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return result, nil
+}
+
+// runBatchOperation executes a single, already validated operation using the given querier. The user ID of update and
+// delete operations must be a UUID; legacy IDs are resolved by the caller.
+func (db *db) runBatchOperation(ctx context.Context, q querier, op *api.BatchOperation) api.BatchOperationResult {
+	var result api.BatchOperationResult
+
+	var id openapi_types.UUID
+	if op.Id != nil {
+		parsed, err := uuid.Parse(*op.Id)
+		if err != nil {
+			result.Err = fmt.Errorf("invalid user id %q: %w", *op.Id, err)
+			return result
+		}
+		id = parsed
+	}
+
+	switch op.Method {
+	case api.BatchOperationMethodCreate:
+		result.User, result.Err = db.createUser(ctx, q, op.User)
+	case api.BatchOperationMethodUpdate:
+		result.User, result.Err = db.updateUser(ctx, q, op.User, id)
+	case api.BatchOperationMethodDelete:
+		result.Err = deleteUser(ctx, q, id)
+	default:
+		result.Err = fmt.Errorf("unsupported batch method %q", op.Method)
+	}
+
+	return result
+}
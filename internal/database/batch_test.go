@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// MockTx is a mock pgx.Tx. Begin returns the savepoint registered with On("Begin").
+type MockTx struct {
+	mock.Mock
+}
+
+func (m *MockTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(pgx.Tx), args.Error(1)
+}
+
+func (m *MockTx) Commit(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockTx) Rollback(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockTx) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	panic("implement me")
+}
+
+func (m *MockTx) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults {
+	panic("implement me")
+}
+
+func (m *MockTx) LargeObjects() pgx.LargeObjects {
+	panic("implement me")
+}
+
+func (m *MockTx) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
+	panic("implement me")
+}
+
+func (m *MockTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	argsMock := m.Called(ctx, sql, args)
+	return argsMock.Get(0).(pgconn.CommandTag), argsMock.Error(1)
+}
+
+func (m *MockTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	argsMock := m.Called(ctx, sql, args)
+	if rows := argsMock.Get(0); rows != nil {
+		return rows.(pgx.Rows), argsMock.Error(1)
+	}
+	return nil, argsMock.Error(1)
+}
+
+func (m *MockTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	argsMock := m.Called(ctx, sql, args)
+	return argsMock.Get(0).(pgx.Row)
+}
+
+func (m *MockTx) Conn() *pgx.Conn {
+	return nil
+}
+
+const (
+	insertUserQuery = `INSERT INTO users (first_name, last_name, email, email_canonical, attributes,
+			phone, locale, time_zone, display_name, avatar_url, pii_key_id, uid)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), $11, $12)
+		RETURNING ` + userColumns
+	deleteUserQuery      = "DELETE FROM users WHERE uid = $1 AND NOT legal_hold"
+	userExistsQuery      = "SELECT EXISTS (SELECT 1 FROM users WHERE uid = $1)"
+	attributeSchemaQuery = "SELECT schema FROM attribute_schemas"
+)
+
+// attributeSchemaRow returns the row of the attribute schema lookup; a nil schema means none is registered.
+func attributeSchemaRow(schema []byte) *MockRow {
+	mr := new(MockRow)
+	call := mr.On("Scan", mock.Anything)
+	if schema == nil {
+		call.Return(sql.ErrNoRows)
+		return mr
+	}
+	call.Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]byte) = schema
+	}).Return(nil)
+	return mr
+}
+
+// noProfile is the argument of an unset profile field.
+var noProfile = (*string)(nil)
+
+// noPIIKey is the data key ID of users written without encryption.
+var noPIIKey = (*int32)(nil)
+
+// insertUserArgs matches the arguments of insertUserQuery, followed by the public ID generated for the user.
+func insertUserArgs(args ...any) any {
+	return mock.MatchedBy(func(got []any) bool {
+		if len(got) != len(args)+1 {
+			return false
+		}
+		_, ok := got[len(args)].(openapi_types.UUID)
+		return ok && assert.ObjectsAreEqual(args, got[:len(args)])
+	})
+}
+
+// withoutProfile matches the arguments of insertUserQuery for an unencrypted user without profile fields.
+func withoutProfile(args ...any) any {
+	return insertUserArgs(append(args, noProfile, noProfile, noProfile, noProfile, noProfile, noPIIKey)...)
+}
+
+func scannedUserRow(id openapi_types.UUID, email string) *MockRow {
+	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	mr := new(MockRow)
+	mr.On("Scan", userScanArgs()...).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = id
+			*args.Get(1).(*string) = "John"
+			*args.Get(2).(*string) = "Doe"
+			*args.Get(3).(*openapi_types.Email) = openapi_types.Email(email)
+			*args.Get(4).(*time.Time) = fixedTime
+			*args.Get(5).(*time.Time) = fixedTime
+		}).Return(nil)
+	return mr
+}
+
+func TestRunBatch_Atomic(t *testing.T) {
+	id := testUID(3).String()
+	ops := []api.BatchOperation{
+		{Method: api.BatchOperationMethodCreate, User: &api.UserRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com"}},
+		{Method: api.BatchOperationMethodDelete, Id: &id},
+		{Method: api.BatchOperationMethodDelete, Id: &id},
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+		tx.On("QueryRow", context.Background(), insertUserQuery, mock.Anything).Return(scannedUserRow(testUID(1), "john@example.com"))
+		tx.On("Exec", context.Background(), deleteUserQuery, []any{testUID(3)}).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		results, committed, err := db.RunBatch(context.Background(), ops, false)
+
+		assert.NoError(t, err)
+		assert.True(t, committed)
+		assert.Len(t, results, 3)
+		assert.Equal(t, testUID(1), results[0].User.Id)
+		assert.NoError(t, results[1].Err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Rollback on first failure", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+		tx.On("QueryRow", context.Background(), insertUserQuery, mock.Anything).Return(scannedUserRow(testUID(1), "john@example.com"))
+		tx.On("Exec", context.Background(), deleteUserQuery, []any{testUID(3)}).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+		tx.On("QueryRow", context.Background(), userExistsQuery, []any{testUID(3)}).Return(boolRow(false))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		results, committed, err := db.RunBatch(context.Background(), ops, false)
+
+		assert.NoError(t, err)
+		assert.False(t, committed)
+		assert.Len(t, results, 2)
+		assert.ErrorIs(t, results[1].Err, ownErrors.ErrNotFound)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestRunBatch_ContinueOnError(t *testing.T) {
+	id := testUID(3).String()
+	ops := []api.BatchOperation{
+		{Method: api.BatchOperationMethodDelete, Id: &id},
+		{Method: api.BatchOperationMethodCreate, User: &api.UserRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com"}},
+	}
+
+	mp := new(MockPool)
+	tx := new(MockTx)
+	failed := new(MockTx)
+	succeeded := new(MockTx)
+	db := &db{pool: mp}
+
+	mp.On("Begin", context.Background()).Return(tx, nil)
+	tx.On("Begin", context.Background()).Return(failed, nil).Once()
+	tx.On("Begin", context.Background()).Return(succeeded, nil).Once()
+	failed.On("Exec", context.Background(), deleteUserQuery, []any{testUID(3)}).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+	failed.On("QueryRow", context.Background(), userExistsQuery, []any{testUID(3)}).Return(boolRow(false))
+	failed.On("Rollback", context.Background()).Return(nil)
+	succeeded.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+	succeeded.On("QueryRow", context.Background(), insertUserQuery, mock.Anything).Return(scannedUserRow(testUID(1), "john@example.com"))
+	succeeded.On("Commit", context.Background()).Return(nil)
+	tx.On("Commit", context.Background()).Return(nil)
+	tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+	results, committed, err := db.RunBatch(context.Background(), ops, true)
+
+	assert.NoError(t, err)
+	assert.True(t, committed)
+	assert.Len(t, results, 2)
+	assert.ErrorIs(t, results[0].Err, ownErrors.ErrNotFound)
+	assert.NoError(t, results[1].Err)
+	failed.AssertExpectations(t)
+	succeeded.AssertExpectations(t)
+	tx.AssertExpectations(t)
+}
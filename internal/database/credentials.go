@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/ownErrors"
+)
+
+// SetPassword stores the password hash of a user, replacing an existing one, and clears a login lockout. The refresh
+// tokens and pending MFA challenges of the user are revoked, so sessions opened with the old password end.
+// Returns ErrNotFound if the user does not exist.
+func (db *db) SetPassword(ctx context.Context, id openapi_types.UUID, passwordHash string) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO user_credentials (user_id, password_hash)
+		SELECT id, $2 FROM users WHERE uid = $1
+		ON CONFLICT (user_id) DO UPDATE SET
+			password_hash = EXCLUDED.password_hash,
+			failed_attempts = 0,
+			locked_until = NULL,
+			password_changed_at = CURRENT_TIMESTAMP
+		RETURNING user_id`
+
+	var userID int64
+	if err := tx.QueryRow(ctx, query, id, passwordHash).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ownErrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	query = "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL"
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM mfa_challenges WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("failed to delete MFA challenges: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// credentialsQuery selects a user with their password hash, lockout and MFA state; a condition on u completes it.
+const credentialsQuery = `SELECT u.uid, u.first_name, u.last_name, u.email, u.created_at, u.updated_at, u.email_verified_at,
+		u.pending_email, u.status, u.status_reason, u.suspended_until, c.password_hash, c.failed_attempts, c.locked_until,
+		u.pii_key_id IS NOT NULL, m.enabled_at IS NOT NULL,
+		EXISTS (SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE ur.user_id = u.id AND r.mfa_required)
+	FROM users u
+	JOIN user_credentials c ON c.user_id = u.id
+	LEFT JOIN user_mfa m ON m.user_id = u.id
+	WHERE `
+
+// GetCredentialsByEmail retrieves the user with the given email address, compared in canonical form, together with
+// their password hash, lockout and MFA state. Returns ErrNotFound if there is no such user or the user has no password.
+func (db *db) GetCredentialsByEmail(ctx context.Context, address string) (*api.Credentials, error) {
+	canonical, err := email.Canonicalize(address, db.emailOptions)
+	if err != nil {
+		return nil, ownErrors.ErrNotFound
+	}
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.getCredentials(ctx, "u.email_canonical = $1", lookup)
+}
+
+// GetCredentials retrieves the user with the given ID together with their password hash, lockout and MFA state.
+// Returns ErrNotFound if there is no such user or the user has no password.
+func (db *db) GetCredentials(ctx context.Context, id openapi_types.UUID) (*api.Credentials, error) {
+	return db.getCredentials(ctx, "u.uid = $1", id)
+}
+
+// getCredentials retrieves the credentials of the user matching condition, which takes arg as its parameter.
+func (db *db) getCredentials(ctx context.Context, condition string, arg any) (*api.Credentials, error) {
+	var (
+		creds api.Credentials
+		user  storedUser
+	)
+	err := db.pool.QueryRow(ctx, credentialsQuery+condition, arg).Scan(
+		&user.Id,
+		&user.FirstName,
+		&user.LastName,
+		&user.Email,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.PendingEmail,
+		&user.Status,
+		&user.StatusReason,
+		&user.SuspendedUntil,
+		&creds.PasswordHash,
+		&creds.FailedAttempts,
+		&creds.LockedUntil,
+		&user.sealed,
+		&creds.MFAEnabled,
+		&creds.MFARequired,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+	creds.User = user.User
+
+	return &creds, nil
+}
+
+// RecordLoginFailure increments the number of consecutive failed logins of a user and returns the new count.
+func (db *db) RecordLoginFailure(ctx context.Context, id openapi_types.UUID) (int, error) {
+	query := `UPDATE user_credentials SET failed_attempts = failed_attempts + 1
+		WHERE user_id = (SELECT id FROM users WHERE uid = $1)
+		RETURNING failed_attempts`
+
+	var failures int
+	if err := db.pool.QueryRow(ctx, query, id).Scan(&failures); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ownErrors.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+// LockCredentials rejects logins of a user until the given time.
+func (db *db) LockCredentials(ctx context.Context, id openapi_types.UUID, until time.Time) error {
+	query := "UPDATE user_credentials SET locked_until = $2 WHERE user_id = (SELECT id FROM users WHERE uid = $1)"
+
+	if _, err := db.pool.Exec(ctx, query, id, until); err != nil {
+		return fmt.Errorf("failed to lock credentials: %w", err)
+	}
+
+	return nil
+}
+
+// RecordLoginSuccess resets the failed login counter of a user. A non-empty rehash replaces the stored password hash,
+// which upgrades hashes created with outdated parameters.
+func (db *db) RecordLoginSuccess(ctx context.Context, id openapi_types.UUID, rehash string) error {
+	query := `UPDATE user_credentials SET
+			failed_attempts = 0,
+			locked_until = NULL,
+			last_login_at = CURRENT_TIMESTAMP,
+			password_hash = COALESCE(NULLIF($2, ''), password_hash)
+		WHERE user_id = (SELECT id FROM users WHERE uid = $1)`
+
+	if _, err := db.pool.Exec(ctx, query, id, rehash); err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+
+	return nil
+}
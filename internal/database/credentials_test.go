@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestSetPassword(t *testing.T) {
+	t.Run("Stored and sessions revoked", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+		}).Return(nil)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1), "hash"}).Return(mr)
+		tx.On("Exec", context.Background(),
+			"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL",
+			[]any{int64(7)}).Return(pgconn.NewCommandTag("UPDATE 2"), nil)
+		tx.On("Exec", context.Background(), "DELETE FROM mfa_challenges WHERE user_id = $1", []any{int64(7)}).
+			Return(pgconn.NewCommandTag("DELETE 0"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		err := db.SetPassword(context.Background(), testUID(1), "hash")
+
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(2), "hash"}).Return(mr)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.SetPassword(context.Background(), testUID(2), "hash")
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+func TestGetCredentialsByEmail(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		lockedUntil := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(3).(*openapi_types.Email) = "john@example.com"
+				*args.Get(11).(*string) = "hash"
+				*args.Get(12).(*int) = 2
+				*args.Get(13).(**time.Time) = &lockedUntil
+				*args.Get(15).(*bool) = true
+			}).
+			Return(nil)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{"john@example.com"}).Return(mr)
+
+		creds, err := db.GetCredentialsByEmail(context.Background(), " John@Example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hash", creds.PasswordHash)
+		assert.Equal(t, 2, creds.FailedAttempts)
+		assert.Equal(t, &lockedUntil, creds.LockedUntil)
+		assert.True(t, creds.MFAEnabled)
+		assert.False(t, creds.MFARequired)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("No password", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything).
+			Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{"john@example.com"}).Return(mr)
+
+		creds, err := db.GetCredentialsByEmail(context.Background(), "john@example.com")
+
+		assert.Nil(t, creds)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		db := &db{pool: new(MockPool)}
+
+		creds, err := db.GetCredentialsByEmail(context.Background(), "not-an-email")
+
+		assert.Nil(t, creds)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestGetCredentials(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = testUID(1)
+			*args.Get(11).(*string) = "hash"
+		}).
+		Return(nil)
+	mp.On("QueryRow", context.Background(), mock.MatchedBy(func(q string) bool {
+		return strings.HasSuffix(q, "WHERE u.uid = $1")
+	}), []any{testUID(1)}).Return(mr)
+
+	creds, err := db.GetCredentials(context.Background(), testUID(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, testUID(1), creds.User.Id)
+	assert.Equal(t, "hash", creds.PasswordHash)
+	mp.AssertExpectations(t)
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(0).(*int) = 3 }).
+		Return(nil)
+	mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(mr)
+
+	failures, err := db.RecordLoginFailure(context.Background(), testUID(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, failures)
+	mp.AssertExpectations(t)
+}
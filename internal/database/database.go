@@ -5,38 +5,151 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 
 	"go-users/internal/api"
+	"go-users/internal/apikey"
 	"go-users/internal/config"
+	"go-users/internal/email"
+	"go-users/internal/jobs"
 	"go-users/internal/ownErrors"
+	"go-users/internal/pii"
+	"go-users/internal/profile"
+	"go-users/internal/retention"
+	"go-users/internal/token"
 )
 
 // ConnPool represents a connection pool abstraction for executing queries and managing database connections.
 type ConnPool interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 	Close()
 }
 
+// querier represents the query methods shared by the connection pool and transactions.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
 // db represents a database connection abstraction with a connection pool for executing queries and managing transactions.
 type db struct {
-	pool ConnPool
+	pool         ConnPool
+	emailOptions email.Options
+	pii          *pii.Keyring
 }
 
-// DB defines an interface for interacting with the database, including user management and resource cleanup.
+// DB defines an interface for interacting with the database, including organizations, user management, credentials, tokens, password resets, invitations, roles, API keys, MFA, email verification, attribute schemas, avatars, personal data keys, data retention, legal holds, background jobs and resource cleanup.
 type DB interface {
+	jobs.Store
+	token.Store
+	apikey.Store
+	pii.Store
+	retention.Store
+	UseKeyring(k *pii.Keyring)
 	CreateUser(ctx context.Context, user *api.UserRequest) (*api.User, error)
-	GetUser(ctx context.Context, id uint) (*api.User, error)
-	UpdateUser(ctx context.Context, u *api.UserRequest, id uint) (*api.User, error)
+	GetUser(ctx context.Context, id openapi_types.UUID) (*api.User, error)
+	UpdateUser(ctx context.Context, u *api.UserRequest, id openapi_types.UUID) (*api.User, error)
+	GetUsersByIDs(ctx context.Context, ids []openapi_types.UUID) ([]api.User, error)
+	ResolveLegacyUserIDs(ctx context.Context, ids []uint) (map[uint]openapi_types.UUID, error)
+	GetUserByEmail(ctx context.Context, email string) (*api.User, error)
+	SearchUsers(ctx context.Context, q string, limit int) ([]api.SearchResult, error)
+	RunBatch(ctx context.Context, ops []api.BatchOperation, continueOnError bool) ([]api.BatchOperationResult, bool, error)
+	ListUsers(ctx context.Context, after openapi_types.UUID, limit int, filter *api.UserFilter) ([]api.User, error)
+	GetUserStatus(ctx context.Context, id openapi_types.UUID) (api.UserStatus, error)
+	ChangeUserStatus(ctx context.Context, id openapi_types.UUID, c *api.StatusChange) (*api.User, error)
+	ListUserStatusEvents(ctx context.Context, id openapi_types.UUID) ([]api.UserStatusEvent, error)
+	ReactivateDueUsers(ctx context.Context, now time.Time) (int, error)
+	CountUsers(ctx context.Context) (int, error)
+	ReindexUsers(ctx context.Context, after openapi_types.UUID, limit int) (*jobs.Reindex, error)
+	SetPassword(ctx context.Context, id openapi_types.UUID, passwordHash string) error
+	GetCredentialsByEmail(ctx context.Context, address string) (*api.Credentials, error)
+	GetCredentials(ctx context.Context, id openapi_types.UUID) (*api.Credentials, error)
+	RecordLoginFailure(ctx context.Context, id openapi_types.UUID) (int, error)
+	LockCredentials(ctx context.Context, id openapi_types.UUID, until time.Time) error
+	RecordLoginSuccess(ctx context.Context, id openapi_types.UUID, rehash string) error
+	PasswordResetUsage(ctx context.Context, email, clientIP string, since time.Time) (*api.PasswordResetUsage, error)
+	CreatePasswordReset(ctx context.Context, r *api.PasswordReset) (*api.User, error)
+	GetPasswordResetUser(ctx context.Context, tokenHash []byte) (*api.User, error)
+	ResetPassword(ctx context.Context, tokenHash []byte, passwordHash string) error
+	CreateInvitation(ctx context.Context, inv *api.NewInvitation) (*api.Invitation, error)
+	ListInvitations(ctx context.Context, status *api.InvitationStatus) ([]api.Invitation, error)
+	GetInvitation(ctx context.Context, id openapi_types.UUID) (*api.Invitation, error)
+	GetInvitationByToken(ctx context.Context, tokenHash []byte) (*api.Invitation, error)
+	RevokeInvitation(ctx context.Context, id openapi_types.UUID, e *api.InvitationEvent) error
+	ResendInvitation(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time, e *api.InvitationEvent) (*api.Invitation, error)
+	AcceptInvitation(ctx context.Context, tokenHash []byte, a *api.InvitationAcceptance) (*api.User, error)
+	GetAttributeSchema(ctx context.Context) (*api.AttributeSchema, error)
+	PutAttributeSchema(ctx context.Context, schema map[string]interface{}) (*api.AttributeSchema, error)
+	DeleteAttributeSchema(ctx context.Context) error
+	SetAvatar(ctx context.Context, id openapi_types.UUID, version, url *string) (*api.User, *string, error)
+	ExportUserData(ctx context.Context, id openapi_types.UUID) (*api.UserDataExport, error)
+	EraseUser(ctx context.Context, id openapi_types.UUID, e *api.Erasure) (*api.UserErasure, *string, error)
+	ListLegalHolds(ctx context.Context, id openapi_types.UUID) ([]api.LegalHold, error)
+	PlaceLegalHold(ctx context.Context, id openapi_types.UUID, c *api.LegalHoldChange) (*api.LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, id, holdID openapi_types.UUID, c *api.LegalHoldChange) (*api.LegalHold, error)
+	CreateRefreshToken(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error
+	RotateRefreshToken(ctx context.Context, tokenHash, newHash []byte, expiresAt time.Time) (openapi_types.UUID, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash []byte) error
+	ListRoles(ctx context.Context) ([]api.Role, error)
+	GetUserRoles(ctx context.Context, id openapi_types.UUID) ([]api.Role, error)
+	AssignRole(ctx context.Context, id openapi_types.UUID, role string) error
+	RevokeRole(ctx context.Context, id openapi_types.UUID, role string) error
+	GetUserPermissions(ctx context.Context, id openapi_types.UUID) ([]string, error)
+	ListGroups(ctx context.Context) ([]api.Group, error)
+	CreateGroup(ctx context.Context, g *api.GroupRequest) (*api.Group, error)
+	GetGroup(ctx context.Context, id openapi_types.UUID) (*api.Group, error)
+	UpdateGroup(ctx context.Context, id openapi_types.UUID, g *api.GroupRequest) (*api.Group, error)
+	DeleteGroup(ctx context.Context, id openapi_types.UUID) error
+	ListGroupMembers(ctx context.Context, id openapi_types.UUID, transitive bool) (*api.GroupMembers, error)
+	ListUserGroups(ctx context.Context, id openapi_types.UUID, transitive bool) ([]api.Group, error)
+	IsGroupMember(ctx context.Context, groupID, id openapi_types.UUID) (*api.GroupMembership, error)
+	AddGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error
+	RemoveGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error
+	AddSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error
+	RemoveSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error
+	LogPolicyDecision(ctx context.Context, d *api.PolicyDecision) error
+	CreateAPIKey(ctx context.Context, owner *openapi_types.UUID, k *api.APIKeyRequest, prefix string, secretHash []byte) (*api.APIKey, error)
+	ListAPIKeys(ctx context.Context, owner *openapi_types.UUID) ([]api.APIKey, error)
+	RevokeAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID) error
+	RotateAPIKey(ctx context.Context, owner *openapi_types.UUID, id openapi_types.UUID, prefix string, secretHash []byte) (*api.APIKey, error)
+	GetMFA(ctx context.Context, id openapi_types.UUID) (*api.MFA, error)
+	StartTOTPEnrollment(ctx context.Context, id openapi_types.UUID, secret []byte) error
+	EnableMFA(ctx context.Context, id openapi_types.UUID, step int64, recoveryHashes [][]byte, e *api.MFAEvent) error
+	ResetMFA(ctx context.Context, id openapi_types.UUID, e *api.MFAEvent) error
+	UseTOTPStep(ctx context.Context, id openapi_types.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, id openapi_types.UUID, codeHash []byte) error
+	CreateMFAChallenge(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error
+	GetMFAChallenge(ctx context.Context, tokenHash []byte) (*api.MFAChallenge, error)
+	RecordMFAChallengeFailure(ctx context.Context, tokenHash []byte) error
+	DeleteMFAChallenge(ctx context.Context, tokenHash []byte) error
+	CreateEmailVerification(ctx context.Context, v *api.EmailVerification) error
+	LastEmailVerificationSentAt(ctx context.Context, id openapi_types.UUID) (*time.Time, error)
+	ConfirmEmail(ctx context.Context, id openapi_types.UUID, address string) (*api.User, error)
+	CreateJob(ctx context.Context, jobType string, payload map[string]interface{}) (*api.Job, error)
+	GetJob(ctx context.Context, id uint) (*api.Job, error)
+	CancelJob(ctx context.Context, id uint) (*api.Job, error)
+	GetJobArtifact(ctx context.Context, id uint) (*api.JobArtifact, error)
+	ResolveOrg(ctx context.Context, ref string) (openapi_types.UUID, error)
+	ListOrganizations(ctx context.Context) ([]api.Organization, error)
+	CreateOrganization(ctx context.Context, o *api.OrganizationRequest) (*api.Organization, error)
+	GetOrganization(ctx context.Context, id openapi_types.UUID) (*api.Organization, error)
+	RowSecurityBypassed(ctx context.Context) (bool, error)
 	Close()
 }
 
-// New initializes a new database connection pool using the provided context and configuration. Returns a DB instance or an error.
-func New(ctx context.Context, cfg config.Database) (DB, error) {
+// New initializes a new database connection pool using the provided context and configuration. Email addresses are
+// canonicalized according to emailCfg. Returns a DB instance or an error.
+func New(ctx context.Context, cfg config.Database, emailCfg config.Email) (DB, error) {
 	connString := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
@@ -51,7 +164,10 @@ func New(ctx context.Context, cfg config.Database) (DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &db{pool: pool}, nil
+	return &db{
+		pool:         &tenantPool{ConnPool: pool},
+		emailOptions: email.Options{FoldGmail: emailCfg.FoldGmail},
+	}, nil
 }
 
 // Close releases all resources associated with the database connection pool if it is initialized.
@@ -63,21 +179,67 @@ func (db *db) Close() {
 
 // CreateUser creates a new user in the database
 func (db *db) CreateUser(ctx context.Context, u *api.UserRequest) (*api.User, error) {
-	query := "INSERT INTO users (first_name, last_name, email) VALUES ($1, $2, $3) RETURNING id, first_name, last_name, email, created_at, updated_at"
+	return db.createUser(ctx, db.pool, u)
+}
 
-	var user api.User
-	err := db.pool.QueryRow(ctx, query,
-		u.FirstName,
-		u.LastName,
-		u.Email,
-	).Scan(
-		&user.Id,
-		&user.FirstName,
-		&user.LastName,
-		&user.Email,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+// createUser inserts a user using the given querier, which may be the pool or a transaction.
+func (db *db) createUser(ctx context.Context, q querier, u *api.UserRequest) (*api.User, error) {
+	canonical, err := email.Canonicalize(string(u.Email), db.emailOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := profile.Normalize(userProfile(u))
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := api.Attributes{}
+	if u.Attributes != nil {
+		attrs = *u.Attributes
+	}
+	if err = db.checkAttributes(ctx, q, attrs); err != nil {
+		return nil, err
+	}
+
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+	// The public ID is chosen here, as the personal data is sealed for it.
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user id: %w", err)
+	}
+	s, err := db.sealer(uid)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{
+		s.seal("first_name", u.FirstName),
+		s.seal("last_name", u.LastName),
+		s.seal("email", string(u.Email)),
+		lookup,
+		attrs,
+		s.sealOptional("phone", p.Phone),
+		p.Locale,
+		p.TimeZone,
+		s.sealOptional("display_name", p.DisplayName),
+		p.AvatarURL,
+		s.keyID(),
+		uid,
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	query := `INSERT INTO users (first_name, last_name, email, email_canonical, attributes,
+			phone, locale, time_zone, display_name, avatar_url, pii_key_id, uid)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), $11, $12)
+		RETURNING ` + userColumns
+
+	var user storedUser
+	err = q.QueryRow(ctx, query, args...).Scan(userFields(&user)...)
 
 	if err != nil {
 		switch {
@@ -88,7 +250,34 @@ func (db *db) CreateUser(ctx context.Context, u *api.UserRequest) (*api.User, er
 		}
 	}
 
-	return &user, nil
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// userColumns selects a user; scan it with userFields.
+const userColumns = `uid, first_name, last_name, email, created_at, updated_at, email_verified_at, pending_email, status,
+	status_reason, suspended_until, attributes, phone, locale, time_zone, display_name, avatar_url, erased_at,
+	pii_key_id IS NOT NULL`
+
+// userFields returns the scan destinations of userColumns.
+func userFields(u *storedUser) []any {
+	return []any{&u.Id, &u.FirstName, &u.LastName, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.EmailVerifiedAt, &u.PendingEmail,
+		&u.Status, &u.StatusReason, &u.SuspendedUntil, &u.Attributes, &u.Phone, &u.Locale, &u.TimeZone, &u.DisplayName,
+		&u.AvatarUrl, &u.ErasedAt, &u.sealed}
+}
+
+// userProfile returns the profile fields of a user request.
+func userProfile(u *api.UserRequest) profile.Profile {
+	return profile.Profile{
+		Phone:       u.Phone,
+		Locale:      u.Locale,
+		TimeZone:    u.TimeZone,
+		DisplayName: u.DisplayName,
+		AvatarURL:   u.AvatarUrl,
+	}
 }
 
 func isDuplicateKeyError(err error) bool {
@@ -100,19 +289,12 @@ func isDuplicateKeyError(err error) bool {
 	return pgErr.Code == "23505"
 }
 
-// GetUser retrieves a user from the database by their unique ID. Returns the user or an error if not found or on failure.
-func (db *db) GetUser(ctx context.Context, id uint) (*api.User, error) {
-	query := "SELECT id, first_name, last_name, email, created_at, updated_at FROM users WHERE id = $1"
+// GetUser retrieves a user from the database by their public ID. Returns the user or an error if not found or on failure.
+func (db *db) GetUser(ctx context.Context, id openapi_types.UUID) (*api.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE uid = $1"
 
-	var user api.User
-	err := db.pool.QueryRow(ctx, query, id).Scan(
-		&user.Id,
-		&user.FirstName,
-		&user.LastName,
-		&user.Email,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	var user storedUser
+	err := db.pool.QueryRow(ctx, query, id).Scan(userFields(&user)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -121,35 +303,376 @@ func (db *db) GetUser(ctx context.Context, id uint) (*api.User, error) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
 }
 
 // UpdateUser updates an existing user's details in the database and sets the updated timestamp in the User struct.
-// Returns ErrNotFound if the user does not exist or an error if the operation fails.
-func (db *db) UpdateUser(ctx context.Context, u *api.UserRequest, id uint) (*api.User, error) {
-	query := "UPDATE users SET first_name = $1, last_name = $2, email = $3 WHERE id = $4 RETURNING id, first_name, last_name, email, created_at, updated_at"
+// A changed email address is only stored as pending until it is confirmed with ConfirmEmail; entering the current
+// address again discards a pending change. Returns ErrNotFound if the user does not exist, ErrUserAlreadyExists if
+// another user has the address, a *profile.InvalidError if profile fields are invalid, an *attributes.InvalidError if
+// given attributes do not match the attribute schema or an error if the operation fails. Omitted profile fields and
+// attributes are kept; profile fields given as empty strings are cleared.
+func (db *db) UpdateUser(ctx context.Context, u *api.UserRequest, id openapi_types.UUID) (*api.User, error) {
+	return db.updateUser(ctx, db.pool, u, id)
+}
+
+// updateUser updates a user using the given querier, which may be the pool or a transaction.
+func (db *db) updateUser(ctx context.Context, q querier, u *api.UserRequest, id openapi_types.UUID) (*api.User, error) {
+	canonical, err := email.Canonicalize(string(u.Email), db.emailOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := profile.Normalize(userProfile(u))
+	if err != nil {
+		return nil, err
+	}
+
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	// The unique index only protects confirmed addresses, so a pending one is checked here.
+	var taken bool
+	err = q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE email_canonical = $1 AND uid <> $2)", lookup, id).
+		Scan(&taken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if taken {
+		return nil, ownErrors.ErrUserAlreadyExists
+	}
+
+	if u.Attributes != nil {
+		if err = db.checkAttributes(ctx, q, *u.Attributes); err != nil {
+			return nil, err
+		}
+	}
 
-	var user api.User
-	err := db.pool.QueryRow(ctx, query,
+	s, err := db.sealer(id)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{
+		s.seal("first_name", u.FirstName),
+		s.seal("last_name", u.LastName),
+		s.seal("email", string(u.Email)),
+		lookup,
+		id,
+		u.Attributes,
+		s.sealOptional("phone", p.Phone),
+		p.Locale,
+		p.TimeZone,
+		s.sealOptional("display_name", p.DisplayName),
+		p.AvatarURL,
+		s.seal("pending_email", string(u.Email)),
 		u.FirstName,
 		u.LastName,
-		u.Email,
-		id,
-	).Scan(
-		&user.Id,
-		&user.FirstName,
-		&user.LastName,
-		&user.Email,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+		string(u.Email),
+		p.Phone,
+		p.DisplayName,
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	// Omitted profile fields are kept as they are stored, so the row keeps its encryption state: encrypted rows get
+	// sealed values, unencrypted rows plaintext. The current address is only rewritten if it is kept.
+	query := `UPDATE users SET
+		first_name = CASE WHEN pii_key_id IS NULL THEN $13 ELSE $1 END,
+		last_name = CASE WHEN pii_key_id IS NULL THEN $14 ELSE $2 END,
+		email = CASE WHEN email_canonical <> $4 THEN email WHEN pii_key_id IS NULL THEN $15 ELSE $3 END,
+		pending_email = CASE WHEN email_canonical = $4 THEN NULL WHEN pii_key_id IS NULL THEN $15 ELSE $12 END,
+		attributes = COALESCE($6::jsonb, attributes),
+		phone = CASE WHEN $16::text IS NULL THEN phone WHEN pii_key_id IS NULL THEN NULLIF($16, '') ELSE NULLIF($7, '') END,
+		locale = CASE WHEN $8::text IS NULL THEN locale ELSE NULLIF($8, '') END,
+		time_zone = CASE WHEN $9::text IS NULL THEN time_zone ELSE NULLIF($9, '') END,
+		display_name = CASE WHEN $17::text IS NULL THEN display_name
+			WHEN pii_key_id IS NULL THEN NULLIF($17, '') ELSE NULLIF($10, '') END,
+		avatar_url = CASE WHEN $11::text IS NULL THEN avatar_url ELSE NULLIF($11, '') END
+		WHERE uid = $5 RETURNING ` + userColumns
+
+	var user storedUser
+	err = q.QueryRow(ctx, query, args...).Scan(userFields(&user)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ownErrors.ErrNotFound
 		}
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrUserAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	return &user, nil
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// deleteUser deletes a user using the given querier. Returns ErrNotFound if the user does not exist and ErrLegalHold
+// if they are under legal hold.
+func deleteUser(ctx context.Context, q querier, id openapi_types.UUID) error {
+	tag, err := q.Exec(ctx, "DELETE FROM users WHERE uid = $1 AND NOT legal_hold", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// The user was either missing or held when the deletion ran.
+	var exists bool
+	if err = q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE uid = $1)", id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if exists {
+		return ownErrors.ErrLegalHold
+	}
+
+	return ownErrors.ErrNotFound
+}
+
+// GetUsersByIDs retrieves all users whose ID is in ids with a single query. Unknown IDs are silently skipped.
+func (db *db) GetUsersByIDs(ctx context.Context, ids []openapi_types.UUID) ([]api.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE uid = ANY($1) ORDER BY id"
+
+	rows, err := db.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	users, err := db.collectUsers(ctx, rows, len(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// ResolveLegacyUserIDs maps former integer user IDs to the public IDs of the users. Unknown IDs are silently skipped.
+func (db *db) ResolveLegacyUserIDs(ctx context.Context, ids []uint) (map[uint]openapi_types.UUID, error) {
+	params := make([]int64, len(ids))
+	for i, id := range ids {
+		params[i] = int64(id)
+	}
+
+	rows, err := db.pool.Query(ctx, "SELECT id, uid FROM users WHERE id = ANY($1)", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve legacy user ids: %w", err)
+	}
+	defer rows.Close()
+
+	resolved := make(map[uint]openapi_types.UUID, len(ids))
+	for rows.Next() {
+		var id uint
+		var uid openapi_types.UUID
+		if err = rows.Scan(&id, &uid); err != nil {
+			return nil, fmt.Errorf("failed to resolve legacy user ids: %w", err)
+		}
+		resolved[id] = uid
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to resolve legacy user ids: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// GetUserByEmail retrieves a user by their email address, compared in canonical form, so any spelling of the address
+// finds the user. Returns ErrNotFound if no user has this address.
+func (db *db) GetUserByEmail(ctx context.Context, address string) (*api.User, error) {
+	canonical, err := email.Canonicalize(address, db.emailOptions)
+	if err != nil {
+		return nil, ownErrors.ErrNotFound
+	}
+
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + userColumns + " FROM users WHERE email_canonical = $1"
+
+	var user storedUser
+	err = db.pool.QueryRow(ctx, query, lookup).Scan(userFields(&user)...)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// ListUsers returns up to limit users ordered by public ID, starting after the given ID; pass the nil UUID to start
+// from the beginning. It is intended for keyset pagination. If filter is not nil only users with its status and
+// containing its attributes are returned.
+func (db *db) ListUsers(ctx context.Context, after openapi_types.UUID, limit int, filter *api.UserFilter) ([]api.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+		WHERE uid > $1 AND ($3::text IS NULL OR status = $3) AND ($4::jsonb IS NULL OR attributes @> $4)
+		ORDER BY uid LIMIT $2`
+
+	var status *string
+	var attrs *api.Attributes
+	if filter != nil {
+		if filter.Status != nil {
+			s := string(*filter.Status)
+			status = &s
+		}
+		if len(filter.Attributes) > 0 {
+			attrs = &filter.Attributes
+		}
+	}
+
+	rows, err := db.pool.Query(ctx, query, after, limit, status, attrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users, err := db.collectUsers(ctx, rows, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+// collectUsers scans all rows into decrypted users and closes them.
+func (db *db) collectUsers(ctx context.Context, rows pgx.Rows, capacity int) ([]api.User, error) {
+	defer rows.Close()
+
+	var stored []storedUser
+	for rows.Next() {
+		var user storedUser
+		if err := rows.Scan(userFields(&user)...); err != nil {
+			return nil, err
+		}
+		stored = append(stored, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	users := make([]api.User, 0, capacity)
+	for i := range stored {
+		if err := db.openUser(ctx, &stored[i]); err != nil {
+			return nil, err
+		}
+		users = append(users, stored[i].User)
+	}
+
+	return users, nil
+}
+
+// CountUsers returns the total number of users stored in the database.
+func (db *db) CountUsers(ctx context.Context) (int, error) {
+	var count int
+	if err := db.pool.QueryRow(ctx, "SELECT count(*) FROM users").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// ReindexUsers recomputes the canonical addresses of up to limit users ordered by public ID after the given one with
+// the current canonicalization options. Users left without a canonical address by a conflict get one if it is free,
+// and their conflict report is deleted. Erased users and users locked by other transactions are skipped, and
+// updated_at is kept.
+func (db *db) ReindexUsers(ctx context.Context, after openapi_types.UUID, limit int) (*jobs.Reindex, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, setTenantQuery, "app.keep_updated_at", "on"); err != nil {
+		return nil, fmt.Errorf("failed to keep updated_at: %w", err)
+	}
+
+	query := `SELECT id, uid, pii_key_id IS NOT NULL, email, email_canonical FROM users
+		WHERE uid > $1 AND erased_at IS NULL
+		ORDER BY uid
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(ctx, query, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users to reindex: %w", err)
+	}
+	defer rows.Close()
+
+	var users []piiRow
+	for rows.Next() {
+		var r piiRow
+		if err = rows.Scan(&r.id, &r.uid, &r.sealed, &r.email, &r.emailCanonical); err != nil {
+			return nil, fmt.Errorf("failed to scan user to reindex: %w", err)
+		}
+		users = append(users, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to select users to reindex: %w", err)
+	}
+	rows.Close()
+
+	result := &jobs.Reindex{Taken: len(users)}
+	for _, r := range users {
+		result.Last = r.uid
+		address, err := db.openAddress(ctx, "email", r.uid, r.sealed, r.email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt user %s: %w", r.uid, err)
+		}
+		canonical, err := email.Canonicalize(address, db.emailOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to canonicalize the address of user %s: %w", r.uid, err)
+		}
+		lookup, err := db.emailLookup(canonical)
+		if err != nil {
+			return nil, err
+		}
+		if r.emailCanonical != nil && *r.emailCanonical == lookup {
+			continue
+		}
+
+		var taken bool
+		query = "SELECT EXISTS (SELECT 1 FROM users WHERE email_canonical = $1 AND id <> $2)"
+		if err = tx.QueryRow(ctx, query, lookup, r.id).Scan(&taken); err != nil {
+			return nil, fmt.Errorf("failed to check canonical email: %w", err)
+		}
+		if taken {
+			result.Conflicts = append(result.Conflicts, r.uid)
+			continue
+		}
+
+		if _, err = tx.Exec(ctx, "UPDATE users SET email_canonical = $2 WHERE id = $1", r.id, lookup); err != nil {
+			return nil, fmt.Errorf("failed to reindex user %s: %w", r.uid, err)
+		}
+		if r.emailCanonical == nil {
+			if _, err = tx.Exec(ctx, "DELETE FROM email_canonical_conflicts WHERE user_id = $1", r.id); err != nil {
+				return nil, fmt.Errorf("failed to resolve canonical email conflict: %w", err)
+			}
+		}
+		result.Updated++
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
 }
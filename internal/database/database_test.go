@@ -4,14 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/jobs"
 	"go-users/internal/ownErrors"
+	"strings"
 	"testing"
 	"time"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -25,6 +31,27 @@ func (m *MockPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Ro
 	return argsMock.Get(0).(pgx.Row)
 }
 
+func (m *MockPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	argsMock := m.Called(ctx, sql, args)
+	if rows := argsMock.Get(0); rows != nil {
+		return rows.(pgx.Rows), argsMock.Error(1)
+	}
+	return nil, argsMock.Error(1)
+}
+
+func (m *MockPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	argsMock := m.Called(ctx, sql, args)
+	return argsMock.Get(0).(pgconn.CommandTag), argsMock.Error(1)
+}
+
+func (m *MockPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	argsMock := m.Called(ctx)
+	if tx := argsMock.Get(0); tx != nil {
+		return tx.(pgx.Tx), argsMock.Error(1)
+	}
+	return nil, argsMock.Error(1)
+}
+
 func (m *MockPool) Close() {}
 
 type MockRow struct {
@@ -36,6 +63,53 @@ func (m *MockRow) Scan(dest ...any) error {
 	return args.Error(0)
 }
 
+// fakeRows is a pgx.Rows returning pre-built rows. Each row scans itself into the destinations.
+type fakeRows struct {
+	pgx.Rows
+	rows   []func(dest ...any) error
+	cursor int
+	closed bool
+}
+
+// testUID returns a deterministic UUID for the given number.
+func testUID(n int) openapi_types.UUID {
+	return uuid.MustParse(fmt.Sprintf("01927a3e-8f2c-7b3d-9e4f-%012d", n))
+}
+
+// userScanArgs returns matchers for the destinations of a scanned user.
+func userScanArgs() []any {
+	args := make([]any, len(userFields(&storedUser{})))
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func (r *fakeRows) Next() bool {
+	if r.cursor >= len(r.rows) {
+		return false
+	}
+	r.cursor++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return r.rows[r.cursor-1](dest...)
+}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+func (r *fakeRows) Close() {
+	r.closed = true
+}
+
+// TestUserColumns guards the queries built from userColumns: every selected column needs a scan destination.
+func TestUserColumns(t *testing.T) {
+	assert.Len(t, strings.Split(userColumns, ","), len(userFields(&storedUser{})))
+}
+
 func TestCreateUser(t *testing.T) {
 	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
 
@@ -50,28 +124,55 @@ func TestCreateUser(t *testing.T) {
 			name: "Database error",
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Return(errors.New("db error"))
 
-				mp.On("QueryRow", context.Background(),
-					"INSERT INTO users (first_name, last_name, email) VALUES ($1, $2, $3) RETURNING id, first_name, last_name, email, created_at, updated_at",
-					[]any{"John", "Doe", openapi_types.Email("john@example.com")},
-				).Return(mr)
+				mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+				mp.On("QueryRow", context.Background(), insertUserQuery,
+					withoutProfile("John", "Doe", "John@Example.com", "john@example.com", api.Attributes{})).Return(mr)
 			},
 			user: &api.UserRequest{
 				FirstName: "John",
 				LastName:  "Doe",
-				Email:     openapi_types.Email("john@example.com"),
+				Email:     openapi_types.Email("John@Example.com"),
 			},
 			expectedErr: "failed to create user: db error",
 		},
+		{
+			name: "Canonical email taken",
+			prepare: func(mp *MockPool) {
+				mr := new(MockRow)
+				mr.On("Scan", userScanArgs()...).
+					Return(&pgconn.PgError{Code: "23505"})
+
+				mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+				mp.On("QueryRow", context.Background(), insertUserQuery,
+					withoutProfile("John", "Doe", "JOHN@example.com", "john@example.com", api.Attributes{})).Return(mr)
+			},
+			user: &api.UserRequest{
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     openapi_types.Email("JOHN@example.com"),
+			},
+			expectedErr: ownErrors.ErrUserAlreadyExists.Error(),
+		},
+		{
+			name:    "Invalid email",
+			prepare: func(mp *MockPool) {},
+			user: &api.UserRequest{
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     openapi_types.Email("john@exa mple.com"),
+			},
+			expectedErr: ownErrors.ErrInvalidEmail.Error(),
+		},
 		{
 			name: "Success",
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Run(func(args mock.Arguments) {
-						*args.Get(0).(*uint) = 1
+						*args.Get(0).(*openapi_types.UUID) = testUID(1)
 						*args.Get(1).(*string) = "John"
 						*args.Get(2).(*string) = "Doe"
 						*args.Get(3).(*openapi_types.Email) = openapi_types.Email("john@example.com")
@@ -79,18 +180,17 @@ func TestCreateUser(t *testing.T) {
 						*args.Get(5).(*time.Time) = fixedTime
 					}).Return(nil)
 
-				mp.On("QueryRow", context.Background(),
-					"INSERT INTO users (first_name, last_name, email) VALUES ($1, $2, $3) RETURNING id, first_name, last_name, email, created_at, updated_at",
-					[]any{"John", "Doe", openapi_types.Email("john@example.com")},
-				).Return(mr)
+				mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+				mp.On("QueryRow", context.Background(), insertUserQuery,
+					withoutProfile("John", "Doe", "John@Example.com", "john@example.com", api.Attributes{})).Return(mr)
 			},
 			user: &api.UserRequest{
 				FirstName: "John",
 				LastName:  "Doe",
-				Email:     openapi_types.Email("john@example.com"),
+				Email:     openapi_types.Email("John@Example.com"),
 			},
 			expected: &api.User{
-				Id:        1,
+				Id:        testUID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     openapi_types.Email("john@example.com"),
@@ -120,24 +220,65 @@ func TestCreateUser(t *testing.T) {
 	}
 }
 
+func TestCreateUser_Profile(t *testing.T) {
+	phone, locale := "+1 (415) 555-2671", "en_us"
+
+	t.Run("Fields normalized", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		normalizedPhone, normalizedLocale := "+14155552671", "en-US"
+		mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+		mp.On("QueryRow", context.Background(), insertUserQuery, insertUserArgs("John", "Doe", "john@example.com",
+			"john@example.com", api.Attributes{}, &normalizedPhone, &normalizedLocale, noProfile, noProfile, noProfile, noPIIKey)).
+			Return(scannedUserRow(testUID(1), "john@example.com"))
+
+		_, err := db.CreateUser(context.Background(), &api.UserRequest{
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "john@example.com",
+			Phone:     &phone,
+			Locale:    &locale,
+		})
+
+		assert.NoError(t, err)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		timeZone := "Mars/Olympus_Mons"
+
+		_, err := db.CreateUser(context.Background(), &api.UserRequest{
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "john@example.com",
+			TimeZone:  &timeZone,
+		})
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidProfile)
+		mp.AssertNotCalled(t, "QueryRow", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestGetUser(t *testing.T) {
 	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name        string
-		id          uint
+		id          openapi_types.UUID
 		prepare     func(*MockPool)
 		expected    *api.User
 		expectedErr error
 	}{
 		{
 			name: "User found",
-			id:   1,
+			id:   testUID(1),
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Run(func(args mock.Arguments) {
-						*args.Get(0).(*uint) = 1
+						*args.Get(0).(*openapi_types.UUID) = testUID(1)
 						*args.Get(1).(*string) = "John"
 						*args.Get(2).(*string) = "Doe"
 						*args.Get(3).(*openapi_types.Email) = openapi_types.Email("john@example.com")
@@ -146,12 +287,12 @@ func TestGetUser(t *testing.T) {
 					}).Return(nil)
 
 				mp.On("QueryRow", context.Background(),
-					"SELECT id, first_name, last_name, email, created_at, updated_at FROM users WHERE id = $1",
-					[]any{uint(1)},
+					"SELECT "+userColumns+" FROM users WHERE uid = $1",
+					[]any{testUID(1)},
 				).Return(mr)
 			},
 			expected: &api.User{
-				Id:        1,
+				Id:        testUID(1),
 				FirstName: "John",
 				LastName:  "Doe",
 				Email:     openapi_types.Email("john@example.com"),
@@ -161,15 +302,15 @@ func TestGetUser(t *testing.T) {
 		},
 		{
 			name: "User not found",
-			id:   2,
+			id:   testUID(2),
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Return(sql.ErrNoRows)
 
 				mp.On("QueryRow", context.Background(),
-					"SELECT id, first_name, last_name, email, created_at, updated_at FROM users WHERE id = $1",
-					[]any{uint(2)},
+					"SELECT "+userColumns+" FROM users WHERE uid = $1",
+					[]any{testUID(2)},
 				).Return(mr)
 			},
 			expectedErr: ownErrors.ErrNotFound,
@@ -196,12 +337,39 @@ func TestGetUser(t *testing.T) {
 	}
 }
 
+const (
+	emailTakenQuery = "SELECT EXISTS (SELECT 1 FROM users WHERE email_canonical = $1 AND uid <> $2)"
+	updateUserQuery = `UPDATE users SET
+		first_name = CASE WHEN pii_key_id IS NULL THEN $13 ELSE $1 END,
+		last_name = CASE WHEN pii_key_id IS NULL THEN $14 ELSE $2 END,
+		email = CASE WHEN email_canonical <> $4 THEN email WHEN pii_key_id IS NULL THEN $15 ELSE $3 END,
+		pending_email = CASE WHEN email_canonical = $4 THEN NULL WHEN pii_key_id IS NULL THEN $15 ELSE $12 END,
+		attributes = COALESCE($6::jsonb, attributes),
+		phone = CASE WHEN $16::text IS NULL THEN phone WHEN pii_key_id IS NULL THEN NULLIF($16, '') ELSE NULLIF($7, '') END,
+		locale = CASE WHEN $8::text IS NULL THEN locale ELSE NULLIF($8, '') END,
+		time_zone = CASE WHEN $9::text IS NULL THEN time_zone ELSE NULLIF($9, '') END,
+		display_name = CASE WHEN $17::text IS NULL THEN display_name
+			WHEN pii_key_id IS NULL THEN NULLIF($17, '') ELSE NULLIF($10, '') END,
+		avatar_url = CASE WHEN $11::text IS NULL THEN avatar_url ELSE NULLIF($11, '') END
+		WHERE uid = $5 RETURNING ` + userColumns
+)
+
+// emailTakenRow returns the row of the email uniqueness check.
+func emailTakenRow(taken bool) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*bool) = taken
+	}).Return(nil)
+	return mr
+}
+
 func TestUpdateUser(t *testing.T) {
 	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	pending := openapi_types.Email("john.updated@example.com")
 
 	tests := []struct {
 		name        string
-		id          uint
+		id          openapi_types.UUID
 		user        *api.UserRequest
 		prepare     func(*MockPool)
 		expected    *api.User
@@ -209,7 +377,7 @@ func TestUpdateUser(t *testing.T) {
 	}{
 		{
 			name: "Success",
-			id:   1,
+			id:   testUID(1),
 			user: &api.UserRequest{
 				FirstName: "John",
 				LastName:  "Doe Updated",
@@ -217,33 +385,54 @@ func TestUpdateUser(t *testing.T) {
 			},
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Run(func(args mock.Arguments) {
-						*args.Get(0).(*uint) = 1
+						*args.Get(0).(*openapi_types.UUID) = testUID(1)
 						*args.Get(1).(*string) = "John"
 						*args.Get(2).(*string) = "Doe Updated"
-						*args.Get(3).(*openapi_types.Email) = openapi_types.Email("john.updated@example.com")
+						*args.Get(3).(*openapi_types.Email) = openapi_types.Email("john@example.com")
 						*args.Get(4).(*time.Time) = fixedTime
 						*args.Get(5).(*time.Time) = fixedTime.Add(1 * time.Hour)
+						*args.Get(7).(**openapi_types.Email) = &pending
 					}).Return(nil)
 
+				mp.On("QueryRow", context.Background(), emailTakenQuery,
+					[]any{"john.updated@example.com", testUID(1)}).Return(emailTakenRow(false))
 				mp.On("QueryRow", context.Background(),
-					"UPDATE users SET first_name = $1, last_name = $2, email = $3 WHERE id = $4 RETURNING id, first_name, last_name, email, created_at, updated_at",
-					[]any{"John", "Doe Updated", openapi_types.Email("john.updated@example.com"), uint(1)},
+					updateUserQuery,
+					[]any{"John", "Doe Updated", "john.updated@example.com", "john.updated@example.com", testUID(1),
+						(*api.Attributes)(nil), noProfile, noProfile, noProfile, noProfile, noProfile, "john.updated@example.com",
+						"John", "Doe Updated", "john.updated@example.com", noProfile, noProfile},
 				).Return(mr)
 			},
 			expected: &api.User{
-				Id:        1,
+				Id:        testUID(1),
 				FirstName: "John",
 				LastName:  "Doe Updated",
-				Email:     openapi_types.Email("john.updated@example.com"),
+				Email:     openapi_types.Email("john@example.com"),
 				CreatedAt: fixedTime,
 				UpdatedAt: fixedTime.Add(1 * time.Hour),
+
+				PendingEmail: &pending,
+			},
+		},
+		{
+			name: "Email taken",
+			id:   testUID(1),
+			user: &api.UserRequest{
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     openapi_types.Email("Jane@example.com"),
+			},
+			prepare: func(mp *MockPool) {
+				mp.On("QueryRow", context.Background(), emailTakenQuery,
+					[]any{"jane@example.com", testUID(1)}).Return(emailTakenRow(true))
 			},
+			expectedErr: ownErrors.ErrUserAlreadyExists,
 		},
 		{
 			name: "Not found",
-			id:   2,
+			id:   testUID(2),
 			user: &api.UserRequest{
 				FirstName: "NonExistent",
 				LastName:  "User",
@@ -251,12 +440,16 @@ func TestUpdateUser(t *testing.T) {
 			},
 			prepare: func(mp *MockPool) {
 				mr := new(MockRow)
-				mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				mr.On("Scan", userScanArgs()...).
 					Return(sql.ErrNoRows)
 
+				mp.On("QueryRow", context.Background(), emailTakenQuery,
+					[]any{"nope@example.com", testUID(2)}).Return(emailTakenRow(false))
 				mp.On("QueryRow", context.Background(),
-					"UPDATE users SET first_name = $1, last_name = $2, email = $3 WHERE id = $4 RETURNING id, first_name, last_name, email, created_at, updated_at",
-					[]any{"NonExistent", "User", openapi_types.Email("nope@example.com"), uint(2)},
+					updateUserQuery,
+					[]any{"NonExistent", "User", "nope@example.com", "nope@example.com", testUID(2),
+						(*api.Attributes)(nil), noProfile, noProfile, noProfile, noProfile, noProfile, "nope@example.com",
+						"NonExistent", "User", "nope@example.com", noProfile, noProfile},
 				).Return(mr)
 			},
 			expectedErr: ownErrors.ErrNotFound,
@@ -282,3 +475,141 @@ func TestUpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUsersByIDs(t *testing.T) {
+	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	userRow := func(id openapi_types.UUID, email string) func(dest ...any) error {
+		return func(dest ...any) error {
+			*dest[0].(*openapi_types.UUID) = id
+			*dest[1].(*string) = "John"
+			*dest[2].(*string) = "Doe"
+			*dest[3].(*openapi_types.Email) = openapi_types.Email(email)
+			*dest[4].(*time.Time) = fixedTime
+			*dest[5].(*time.Time) = fixedTime
+			return nil
+		}
+	}
+
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	rows := &fakeRows{rows: []func(dest ...any) error{
+		userRow(testUID(1), "john@example.com"),
+		userRow(testUID(3), "jane@example.com"),
+	}}
+
+	mp.On("Query", context.Background(),
+		"SELECT "+userColumns+" FROM users WHERE uid = ANY($1) ORDER BY id",
+		[]any{[]openapi_types.UUID{testUID(1), testUID(2), testUID(3)}},
+	).Return(rows, nil)
+
+	users, err := db.GetUsersByIDs(context.Background(), []openapi_types.UUID{testUID(1), testUID(2), testUID(3)})
+
+	assert.NoError(t, err)
+	assert.True(t, rows.closed)
+	assert.Len(t, users, 2)
+	assert.Equal(t, testUID(1), users[0].Id)
+	assert.Equal(t, openapi_types.Email("jane@example.com"), users[1].Email)
+	mp.AssertExpectations(t)
+}
+
+func TestGetUserByEmail_NotFound(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mr := new(MockRow)
+	mr.On("Scan", userScanArgs()...).
+		Return(sql.ErrNoRows)
+	mp.On("QueryRow", context.Background(),
+		"SELECT "+userColumns+" FROM users WHERE email_canonical = $1",
+		[]any{"nobody@example.com"},
+	).Return(mr)
+
+	user, err := db.GetUserByEmail(context.Background(), "Nobody@Example.COM")
+
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	mp.AssertExpectations(t)
+}
+
+func TestGetUserByEmail_FoldGmail(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp, emailOptions: email.Options{FoldGmail: true}}
+
+	mp.On("QueryRow", context.Background(),
+		"SELECT "+userColumns+" FROM users WHERE email_canonical = $1",
+		[]any{"johndoe@gmail.com"},
+	).Return(scannedUserRow(testUID(1), "john.doe@gmail.com"))
+
+	user, err := db.GetUserByEmail(context.Background(), "John.Doe+news@googlemail.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, openapi_types.Email("john.doe@gmail.com"), user.Email)
+	mp.AssertExpectations(t)
+}
+
+func TestResolveLegacyUserIDs(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	rows := &fakeRows{rows: []func(dest ...any) error{
+		func(dest ...any) error {
+			*dest[0].(*uint) = 7
+			*dest[1].(*openapi_types.UUID) = testUID(7)
+			return nil
+		},
+	}}
+
+	mp.On("Query", context.Background(), "SELECT id, uid FROM users WHERE id = ANY($1)", []any{[]int64{7, 8}}).Return(rows, nil)
+
+	resolved, err := db.ResolveLegacyUserIDs(context.Background(), []uint{7, 8})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint]openapi_types.UUID{7: testUID(7)}, resolved)
+	assert.True(t, rows.closed)
+	mp.AssertExpectations(t)
+}
+
+func TestReindexUsers(t *testing.T) {
+	mp := new(MockPool)
+	tx := new(MockTx)
+	db := &db{pool: mp, emailOptions: email.Options{FoldGmail: true}}
+	user := func(id int64, address string, canonical *string) func(dest ...any) error {
+		return func(dest ...any) error {
+			*dest[0].(*int64) = id
+			*dest[1].(*uuid.UUID) = testUID(int(id))
+			*dest[3].(*string) = address
+			*dest[4].(**string) = canonical
+			return nil
+		}
+	}
+	dotted, plain := "j.doe@gmail.com", "john@example.com"
+	rows := &fakeRows{rows: []func(dest ...any) error{
+		user(1, "J.Doe@gmail.com", &dotted),
+		user(2, "John@Example.com", &plain),
+		user(3, "Jane.Doe@googlemail.com", nil),
+	}}
+	free, taken := new(MockRow), new(MockRow)
+	free.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*bool) = false
+	}).Return(nil)
+	taken.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*bool) = true
+	}).Return(nil)
+
+	mp.On("Begin", context.Background()).Return(tx, nil)
+	tx.On("Exec", context.Background(), setTenantQuery, []any{"app.keep_updated_at", "on"}).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+	tx.On("Query", context.Background(), mock.Anything, []any{uuid.Nil, 10}).Return(rows, nil)
+	tx.On("QueryRow", context.Background(), mock.Anything, []any{"jdoe@gmail.com", int64(1)}).Return(free)
+	tx.On("QueryRow", context.Background(), mock.Anything, []any{"janedoe@gmail.com", int64(3)}).Return(taken)
+	tx.On("Exec", context.Background(), "UPDATE users SET email_canonical = $2 WHERE id = $1", []any{int64(1), "jdoe@gmail.com"}).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	tx.On("Commit", context.Background()).Return(nil)
+	tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+	r, err := db.ReindexUsers(context.Background(), uuid.Nil, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &jobs.Reindex{Taken: 3, Updated: 1, Last: testUID(3), Conflicts: []uuid.UUID{testUID(3)}}, r)
+	assert.True(t, rows.closed)
+	tx.AssertExpectations(t)
+	tx.AssertNumberOfCalls(t, "Exec", 2)
+}
@@ -0,0 +1,444 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/ownErrors"
+)
+
+// userDataQuery collects the data held about user $1 besides the user row and their jobs as a single JSON document,
+// whose keys are those of api.UserDataExport. Secrets such as hashes and TOTP secrets are left out. Running as one
+// statement, all parts are read from the same snapshot. The addresses of invitations and verification mails come with
+// what exportedAddresses needs to decrypt them, and the stored canonical address, which finds the imports of the user.
+const userDataQuery = `SELECT ` + userColumns + `, jsonb_build_object(
+	'credentials', (SELECT jsonb_build_object('password_changed_at', c.password_changed_at,
+			'last_login_at', c.last_login_at, 'failed_attempts', c.failed_attempts, 'locked_until', c.locked_until)
+		FROM user_credentials c WHERE c.user_id = users.id),
+	'sessions', COALESCE((SELECT jsonb_agg(jsonb_build_object('family_id', t.family_id, 'created_at', t.created_at,
+			'expires_at', t.expires_at, 'used_at', t.used_at, 'revoked_at', t.revoked_at) ORDER BY t.id)
+		FROM refresh_tokens t WHERE t.user_id = users.id), '[]'),
+	'roles', COALESCE((SELECT jsonb_agg(r.name ORDER BY r.name)
+		FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE ur.user_id = users.id), '[]'),
+	'groups', COALESCE((SELECT jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name, 'added_at', gu.added_at)
+			ORDER BY g.name)
+		FROM group_users gu JOIN groups g ON g.id = gu.group_id WHERE gu.user_id = users.id), '[]'),
+	'api_keys', COALESCE((SELECT jsonb_agg(jsonb_build_object('id', k.id, 'name', k.name, 'prefix', k.prefix,
+			'scopes', k.scopes, 'created_at', k.created_at, 'expires_at', k.expires_at, 'last_used_at', k.last_used_at,
+			'revoked_at', k.revoked_at) ORDER BY k.created_at)
+		FROM api_keys k WHERE k.user_id = users.id), '[]'),
+	'mfa', (SELECT jsonb_build_object('enabled_at', m.enabled_at, 'created_at', m.created_at)
+		FROM user_mfa m WHERE m.user_id = users.id),
+	'email_verifications', COALESCE((SELECT jsonb_agg(jsonb_build_object('id', v.id, 'email', v.email,
+			'sealed', v.pii_key_id IS NOT NULL, 'created_at', v.created_at, 'used_at', v.used_at) ORDER BY v.created_at)
+		FROM email_verifications v WHERE v.user_id = users.id), '[]'),
+	'invitations', COALESCE((SELECT jsonb_agg(jsonb_build_object('id', i.id, 'email', i.email,
+			'sealed', i.pii_key_id IS NOT NULL, 'invited_by_kind', i.invited_by_kind, 'invited_by_id', i.invited_by_id, 'created_at', i.created_at,
+			'accepted_at', i.accepted_at, 'revoked_at', i.revoked_at) ORDER BY i.created_at)
+		FROM invitations i WHERE i.user_id = users.id), '[]'),
+	'status_events', COALESCE((SELECT jsonb_agg(jsonb_build_object('from_status', e.from_status,
+			'to_status', e.to_status, 'reason', e.reason, 'until', e.until, 'actor_kind', e.actor_kind,
+			'actor_id', e.actor_id, 'created_at', e.created_at) ORDER BY e.id)
+		FROM user_status_events e WHERE e.user_uid = users.uid), '[]'),
+	'mfa_events', COALESCE((SELECT jsonb_agg(jsonb_build_object('event', e.event, 'actor_kind', e.actor_kind,
+			'actor_id', e.actor_id, 'reason', e.reason, 'created_at', e.created_at) ORDER BY e.id)
+		FROM mfa_events e WHERE e.user_uid = users.uid), '[]'),
+	'policy_decisions', COALESCE((SELECT jsonb_agg(jsonb_build_object('operation', d.operation,
+			'principal_kind', d.principal_kind, 'principal_id', d.principal_id, 'resource', d.resource,
+			'allowed', d.allowed, 'reason', d.reason, 'created_at', d.created_at) ORDER BY d.id)
+		FROM policy_decisions d
+		WHERE (d.principal_kind = 'user' AND d.principal_id = users.uid::text) OR d.resource = users.uid::text), '[]'),
+	'erasure', (SELECT jsonb_build_object('user_id', ue.user_uid, 'reason', ue.reason, 'actor_kind', ue.actor_kind,
+			'actor_id', ue.actor_id, 'affected', ue.affected, 'erased_at', ue.erased_at)
+		FROM user_erasures ue WHERE ue.user_uid = users.uid),
+	'email_canonical', users.email_canonical)
+	FROM users WHERE uid = $1`
+
+// ExportUserData returns everything held about a user. Returns ErrNotFound if there is no such user.
+func (db *db) ExportUserData(ctx context.Context, id openapi_types.UUID) (*api.UserDataExport, error) {
+	var (
+		user storedUser
+		data []byte
+	)
+	if err := db.pool.QueryRow(ctx, userDataQuery, id).Scan(append(userFields(&user), &data)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to export user data: %w", err)
+	}
+	if err := db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	var export api.UserDataExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode user data: %w", err)
+	}
+	if err := db.openExportedAddresses(ctx, &export, data); err != nil {
+		return nil, err
+	}
+	jobs, err := db.exportUserJobs(ctx, id, data)
+	if err != nil {
+		return nil, err
+	}
+	export.Jobs = jobs
+	export.User = user.User
+	export.ExportedAt = time.Now().UTC()
+
+	return &export, nil
+}
+
+// exportedAddresses holds, in the order of the export, the IDs of the invitations and verification mails of a user
+// and whether their addresses are encrypted. It is not part of the export.
+type exportedAddresses struct {
+	EmailVerifications []exportedAddress `json:"email_verifications"`
+	Invitations        []exportedAddress `json:"invitations"`
+}
+
+type exportedAddress struct {
+	ID     openapi_types.UUID `json:"id"`
+	Sealed bool               `json:"sealed"`
+}
+
+// openExportedAddresses decrypts the encrypted addresses of the invitations and verification mails of an export
+// decoded from data.
+func (db *db) openExportedAddresses(ctx context.Context, export *api.UserDataExport, data []byte) error {
+	var addresses exportedAddresses
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return fmt.Errorf("failed to decode user data: %w", err)
+	}
+
+	for i := range export.EmailVerifications {
+		a := addresses.EmailVerifications[i]
+		address, err := db.openAddress(ctx, "email_verifications.email", a.ID, a.Sealed, export.EmailVerifications[i].Email)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt email verification: %w", err)
+		}
+		export.EmailVerifications[i].Email = address
+	}
+	for i := range export.Invitations {
+		a := addresses.Invitations[i]
+		address, err := db.openAddress(ctx, "invitations.email", a.ID, a.Sealed, export.Invitations[i].Email)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt invitation: %w", err)
+		}
+		export.Invitations[i].Email = address
+	}
+	return nil
+}
+
+// userJobsQuery selects the bulk jobs that may hold users: imports listing users in their payload and exports whose
+// artifact has not been purged yet.
+const userJobsQuery = `SELECT id, type, created_at, finished_at, payload, artifact FROM jobs
+	WHERE (type = 'users.import' AND jsonb_typeof(payload->'users') = 'array')
+		OR (type = 'users.export' AND artifact IS NOT NULL)
+	ORDER BY id`
+
+// userJob is a bulk job holding a user, with its payload or artifact redacted of the user.
+type userJob struct {
+	api.ExportedJob
+	payload  []byte
+	artifact []byte
+}
+
+// exportUserJobs returns the jobs holding the user of an export decoded from data. Jobs are matched in Go, so they
+// are read after the rest of the export.
+func (db *db) exportUserJobs(ctx context.Context, id openapi_types.UUID, data []byte) ([]api.ExportedJob, error) {
+	var stored struct {
+		EmailCanonical *string `json:"email_canonical"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode user data: %w", err)
+	}
+
+	rows, err := db.pool.Query(ctx, userJobsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	found, err := db.scanUserJobs(rows, id, stored.EmailCanonical)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]api.ExportedJob, len(found))
+	for i := range found {
+		exported[i] = found[i].ExportedJob
+	}
+	return exported, nil
+}
+
+// eraseUserJobs removes a user from the payloads and artifacts of the jobs holding them and returns how many jobs
+// were changed. lookup is the stored canonical address of the user before the erasure.
+func (db *db) eraseUserJobs(ctx context.Context, tx pgx.Tx, id openapi_types.UUID, lookup *string) (int, error) {
+	rows, err := tx.Query(ctx, userJobsQuery+" FOR UPDATE")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	found, err := db.scanUserJobs(rows, id, lookup)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range found {
+		query := "UPDATE jobs SET payload = COALESCE($2, payload), artifact = COALESCE($3, artifact) WHERE id = $1"
+		if _, err = tx.Exec(ctx, query, job.Id, job.payload, job.artifact); err != nil {
+			return 0, fmt.Errorf("failed to erase jobs: %w", err)
+		}
+	}
+	return len(found), nil
+}
+
+// scanUserJobs reads rows selected with userJobsQuery and returns the jobs holding the user: imports listing an
+// address with the canonical form lookup, and exports containing the user's record.
+func (db *db) scanUserJobs(rows pgx.Rows, id openapi_types.UUID, lookup *string) ([]userJob, error) {
+	defer rows.Close()
+
+	found := make([]userJob, 0)
+	for rows.Next() {
+		var (
+			job               userJob
+			payload, artifact []byte
+		)
+		err := rows.Scan(&job.Id, &job.Type, &job.CreatedAt, &job.FinishedAt, &payload, &artifact)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+
+		if artifact != nil {
+			job.Records, job.artifact, err = exportedRecords(artifact, id)
+		} else {
+			job.Records, job.payload, err = db.importedRecords(payload, lookup)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read job %d: %w", job.Id, err)
+		}
+		if len(job.Records) > 0 {
+			found = append(found, job)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read jobs: %w", err)
+	}
+
+	return found, nil
+}
+
+// importedRecords returns the users of an import payload with the canonical address lookup and the payload without
+// them. Entries with invalid addresses are kept, as they cannot be the user's.
+func (db *db) importedRecords(payload []byte, lookup *string) ([]map[string]interface{}, []byte, error) {
+	if lookup == nil {
+		return nil, nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, nil, err
+	}
+	var users []json.RawMessage
+	if err := json.Unmarshal(fields["users"], &users); err != nil {
+		return nil, nil, err
+	}
+
+	var records []map[string]interface{}
+	kept := make([]json.RawMessage, 0, len(users))
+	for _, raw := range users {
+		var record map[string]interface{}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, nil, err
+		}
+		address, _ := record["email"].(string)
+		if matches, err := db.hasLookup(address, *lookup); err != nil {
+			return nil, nil, err
+		} else if matches {
+			records = append(records, record)
+			continue
+		}
+		kept = append(kept, raw)
+	}
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	var err error
+	if fields["users"], err = json.Marshal(kept); err != nil {
+		return nil, nil, err
+	}
+	redacted, err := json.Marshal(fields)
+	return records, redacted, err
+}
+
+// hasLookup reports whether address is stored with the canonical form lookup.
+func (db *db) hasLookup(address, lookup string) (bool, error) {
+	canonical, err := email.Canonicalize(address, db.emailOptions)
+	if err != nil {
+		return false, nil
+	}
+	own, err := db.emailLookup(canonical)
+	return own == lookup, err
+}
+
+// exportedRecords returns the records of a user in a users.export artifact, one user as JSON per line, and the
+// artifact without them.
+func exportedRecords(artifact []byte, id openapi_types.UUID) ([]map[string]interface{}, []byte, error) {
+	var records []map[string]interface{}
+	var kept bytes.Buffer
+	for _, line := range bytes.SplitAfter(artifact, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			kept.Write(line)
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, nil, err
+		}
+		if record["id"] == id.String() {
+			records = append(records, record)
+			continue
+		}
+		kept.Write(line)
+	}
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	return records, kept.Bytes(), nil
+}
+
+// erasureSteps remove the personal data of user $1 from the tables referring to the user, once the user row has been
+// anonymized. Each step is counted under its table in the proof of erasure.
+var erasureSteps = []struct {
+	table string
+	query string
+}{
+	{"user_credentials", "DELETE FROM user_credentials WHERE user_id = $1"},
+	{"refresh_tokens", "DELETE FROM refresh_tokens WHERE user_id = $1"},
+	{"user_mfa", "DELETE FROM user_mfa WHERE user_id = $1"},
+	{"email_verifications", "DELETE FROM email_verifications WHERE user_id = $1"},
+	{"password_resets", "DELETE FROM password_resets WHERE user_id = $1"},
+	{"email_canonical_conflicts", "DELETE FROM email_canonical_conflicts WHERE user_id = $1"},
+	{"api_keys", "DELETE FROM api_keys WHERE user_id = $1"},
+	{"user_roles", "DELETE FROM user_roles WHERE user_id = $1"},
+	{"group_users", "DELETE FROM group_users WHERE user_id = $1"},
+	{"invitations", `UPDATE invitations SET email = (SELECT email FROM users WHERE id = $1), pii_key_id = NULL
+		WHERE user_id = $1`},
+	{"user_status_events", `UPDATE user_status_events SET reason = NULL
+		WHERE user_uid = (SELECT uid FROM users WHERE id = $1) AND reason IS NOT NULL`},
+	{"mfa_events", `UPDATE mfa_events SET reason = NULL
+		WHERE user_uid = (SELECT uid FROM users WHERE id = $1) AND reason IS NOT NULL`},
+}
+
+// erasedEmail returns the address that replaces the email address of an erased user. It is unique, so the user row
+// keeps satisfying the uniqueness of addresses, and uses the reserved .invalid domain, so no mail is ever delivered.
+func erasedEmail(id openapi_types.UUID) string {
+	return "erased-" + id.String() + "@erased.invalid"
+}
+
+// EraseUser irreversibly removes the personal data of a user, keeping the anonymized and deactivated user row so that
+// references to it stay valid, and records the erasure. Returns the proof of erasure and the version of the removed
+// avatar, whose blobs the caller deletes. Returns ErrNotFound if there is no such user, ErrUserErased if the user
+// has already been erased and ErrLegalHold if they are under legal hold.
+func (db *db) EraseUser(ctx context.Context, id openapi_types.UUID, e *api.Erasure) (*api.UserErasure, *string, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		userID         int64
+		status         api.UserStatus
+		emailCanonical *string
+		avatarVersion  *string
+		erasedAt       *time.Time
+		legalHold      bool
+	)
+	query := "SELECT id, status, email_canonical, avatar_version, erased_at, legal_hold FROM users WHERE uid = $1 FOR UPDATE"
+	err = tx.QueryRow(ctx, query, id).Scan(&userID, &status, &emailCanonical, &avatarVersion, &erasedAt, &legalHold)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ownErrors.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to lock user: %w", err)
+	}
+	if erasedAt != nil {
+		return nil, nil, ownErrors.ErrUserErased
+	}
+	if legalHold {
+		return nil, nil, ownErrors.ErrLegalHold
+	}
+
+	// The anonymized values are plaintext, so the row is marked as unencrypted.
+	query = `UPDATE users SET first_name = '', last_name = '', email = $2, email_canonical = $2, pending_email = NULL,
+		email_verified_at = NULL, phone = NULL, locale = NULL, time_zone = NULL, display_name = NULL, avatar_url = NULL,
+		avatar_version = NULL, attributes = '{}', status = $3, status_reason = NULL, suspended_until = NULL,
+		erased_at = CURRENT_TIMESTAMP, pii_key_id = NULL
+		WHERE id = $1`
+	if _, err = tx.Exec(ctx, query, userID, erasedEmail(id), api.UserStatusDeactivated); err != nil {
+		return nil, nil, fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	affected := map[string]int{"users": 1}
+	// Reset requests are only kept by address, so they are found by the former one.
+	if emailCanonical != nil {
+		tag, err := tx.Exec(ctx, "DELETE FROM password_reset_requests WHERE email_canonical = $1", *emailCanonical)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to erase password_reset_requests: %w", err)
+		}
+		if n := int(tag.RowsAffected()); n > 0 {
+			affected["password_reset_requests"] = n
+		}
+	}
+	for _, step := range erasureSteps {
+		tag, err := tx.Exec(ctx, step.query, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to erase %s: %w", step.table, err)
+		}
+		if n := int(tag.RowsAffected()); n > 0 {
+			affected[step.table] = n
+		}
+	}
+	// Imports and exports hold copies of the user's data, which are found by their address and ID.
+	n, err := db.eraseUserJobs(ctx, tx, id, emailCanonical)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n > 0 {
+		affected["jobs"] = n
+	}
+
+	// The deactivation is recorded like any other status change, after the reasons were cleared.
+	if status != api.UserStatusDeactivated {
+		query = `INSERT INTO user_status_events (user_uid, from_status, to_status, reason, actor_kind, actor_id)
+			VALUES ($1, $2, $3, 'Erased', $4, $5)`
+		if _, err = tx.Exec(ctx, query, id, status, api.UserStatusDeactivated, e.ActorKind, e.ActorID); err != nil {
+			return nil, nil, fmt.Errorf("failed to record status change: %w", err)
+		}
+	}
+
+	erasure := &api.UserErasure{UserId: id, ActorKind: e.ActorKind, ActorId: e.ActorID, Affected: affected}
+	if e.Reason != "" {
+		erasure.Reason = &e.Reason
+	}
+	query = `INSERT INTO user_erasures (user_uid, reason, actor_kind, actor_id, affected)
+		VALUES ($1, $2, $3, $4, $5) RETURNING erased_at`
+	err = tx.QueryRow(ctx, query, id, erasure.Reason, e.ActorKind, e.ActorID, affected).Scan(&erasure.ErasedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to record erasure: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return erasure, avatarVersion, nil
+}
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// jobRow returns a row selected with userJobsQuery.
+func jobRow(id uint, jobType string, payload, artifact []byte) func(dest ...any) error {
+	return func(dest ...any) error {
+		*dest[0].(*uint) = id
+		*dest[1].(*string) = jobType
+		*dest[4].(*[]byte) = payload
+		*dest[5].(*[]byte) = artifact
+		return nil
+	}
+}
+
+func TestExportUserData(t *testing.T) {
+	t.Run("Sections decoded", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+
+		mp.On("QueryRow", context.Background(), userDataQuery, []any{testUID(1)}).Return(mr)
+		mr.On("Scan", append(userScanArgs(), mock.Anything)...).Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = testUID(1)
+			*args.Get(len(args) - 1).(*[]byte) = []byte(`{"credentials": null, "sessions": [], "roles": ["admin"],
+				"groups": [], "api_keys": [], "mfa": null, "email_verifications": [], "invitations": [],
+				"status_events": [{"from_status": "active", "to_status": "suspended", "reason": "Abuse",
+					"actor_kind": "user", "actor_id": "admin", "created_at": "2024-05-01T12:00:00Z"}],
+				"mfa_events": [], "policy_decisions": [], "erasure": null, "email_canonical": "jane@example.com"}`)
+		}).Return(nil)
+		mp.On("Query", context.Background(), userJobsQuery, mock.Anything).Return(&fakeRows{rows: []func(dest ...any) error{
+			jobRow(3, "users.import", []byte(`{"users": [{"email": "Jane@Example.com", "first_name": "Jane"},
+				{"email": "john@example.com", "first_name": "John"}]}`), nil),
+			jobRow(4, "users.export", nil, []byte(`{"id": "`+testUID(2).String()+`"}`+"\n")),
+			jobRow(5, "users.export", nil, []byte(`{"id": "`+testUID(1).String()+`", "first_name": "Jane"}`+"\n")),
+		}}, nil)
+
+		export, err := db.ExportUserData(context.Background(), testUID(1))
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), export.User.Id)
+		assert.Equal(t, []string{"admin"}, export.Roles)
+		require.Len(t, export.StatusEvents, 1)
+		assert.Equal(t, api.UserStatusSuspended, export.StatusEvents[0].ToStatus)
+		assert.Nil(t, export.Erasure)
+		assert.False(t, export.ExportedAt.IsZero())
+		require.Len(t, export.Jobs, 2)
+		assert.Equal(t, uint(3), export.Jobs[0].Id)
+		assert.Equal(t, []map[string]interface{}{{"email": "Jane@Example.com", "first_name": "Jane"}}, export.Jobs[0].Records)
+		assert.Equal(t, uint(5), export.Jobs[1].Id)
+		assert.Equal(t, "Jane", export.Jobs[1].Records[0]["first_name"])
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+
+		mp.On("QueryRow", context.Background(), userDataQuery, []any{testUID(1)}).Return(mr)
+		mr.On("Scan", append(userScanArgs(), mock.Anything)...).Return(sql.ErrNoRows)
+
+		_, err := db.ExportUserData(context.Background(), testUID(1))
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestEraseUser(t *testing.T) {
+	erasure := &api.Erasure{Reason: "Ticket 42", ActorKind: "user", ActorID: "admin"}
+	erasedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lockedRow := func(status api.UserStatus, erased *time.Time, held bool) *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			email, version := "jane@example.com", "0123456789abcdef"
+			*args.Get(0).(*int64) = 7
+			*args.Get(1).(*api.UserStatus) = status
+			*args.Get(2).(**string) = &email
+			*args.Get(3).(**string) = &version
+			*args.Get(4).(**time.Time) = erased
+			*args.Get(5).(*bool) = held
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Personal data removed and erasure recorded", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		recorded := new(MockRow)
+		recorded.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*time.Time) = erasedAt
+		}).Return(nil)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(lockedRow(api.UserStatusActive, nil, false))
+		tx.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(recorded)
+		tx.On("Query", context.Background(), userJobsQuery+" FOR UPDATE", mock.Anything).Return(&fakeRows{rows: []func(dest ...any) error{
+			jobRow(3, "users.import", []byte(`{"users": [{"email": "jane@example.com"}, {"email": "john@example.com"}]}`), nil),
+			jobRow(4, "users.export", nil, []byte(`{"id": "`+testUID(2).String()+`"}`+"\n")),
+		}}, nil)
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		proof, version, err := db.EraseUser(context.Background(), testUID(1), erasure)
+
+		require.NoError(t, err)
+		require.NotNil(t, version)
+		assert.Equal(t, "0123456789abcdef", *version)
+		assert.Equal(t, testUID(1), proof.UserId)
+		assert.Equal(t, "Ticket 42", *proof.Reason)
+		assert.Equal(t, erasedAt, proof.ErasedAt)
+		assert.Equal(t, 1, proof.Affected["users"])
+		assert.Equal(t, 1, proof.Affected["refresh_tokens"])
+		assert.Equal(t, 1, proof.Affected["password_reset_requests"])
+		assert.Equal(t, 1, proof.Affected["jobs"])
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{uint(3), []byte(`{"users":[{"email":"john@example.com"}]}`), []byte(nil)})
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{int64(7), "erased-" + testUID(1).String() + "@erased.invalid", api.UserStatusDeactivated})
+		tx.AssertCalled(t, "Exec", context.Background(), "DELETE FROM password_reset_requests WHERE email_canonical = $1",
+			[]any{"jane@example.com"})
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{testUID(1), api.UserStatusActive, api.UserStatusDeactivated, "user", "admin"})
+		tx.AssertNumberOfCalls(t, "Exec", len(erasureSteps)+4)
+		tx.AssertCalled(t, "Commit", context.Background())
+	})
+
+	t.Run("Already deactivated user gets no status event", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		recorded := new(MockRow)
+		recorded.On("Scan", mock.Anything).Return(nil)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(lockedRow(api.UserStatusDeactivated, nil, false))
+		tx.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(recorded)
+		tx.On("Query", context.Background(), mock.Anything, mock.Anything).Return(&fakeRows{}, nil)
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		proof, _, err := db.EraseUser(context.Background(), testUID(1), &api.Erasure{ActorKind: "user", ActorID: "admin"})
+
+		require.NoError(t, err)
+		assert.Nil(t, proof.Reason)
+		assert.Equal(t, map[string]int{"users": 1}, proof.Affected)
+		tx.AssertNumberOfCalls(t, "Exec", len(erasureSteps)+2)
+	})
+
+	t.Run("User already erased", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(lockedRow(api.UserStatusDeactivated, &erasedAt, false))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, _, err := db.EraseUser(context.Background(), testUID(1), erasure)
+
+		assert.ErrorIs(t, err, ownErrors.ErrUserErased)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("User under legal hold", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(lockedRow(api.UserStatusActive, nil, true))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, _, err := db.EraseUser(context.Background(), testUID(1), erasure)
+
+		assert.ErrorIs(t, err, ownErrors.ErrLegalHold)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(mr)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, _, err := db.EraseUser(context.Background(), testUID(1), erasure)
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,340 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+const groupColumns = "id, name, description, created_at, updated_at"
+
+// groupScope selects the group $1 into scope, and with $2 also every group nested in it at any depth. UNION drops
+// groups already visited, so the recursion ends even on a cyclic graph.
+const groupScope = `WITH RECURSIVE scope (id) AS (
+		SELECT $1::uuid
+		UNION
+		SELECT gs.child_id FROM group_subgroups gs JOIN scope s ON gs.parent_id = s.id WHERE $2
+	)`
+
+// ListGroups returns all groups ordered by name.
+func (db *db) ListGroups(ctx context.Context) ([]api.Group, error) {
+	rows, err := db.pool.Query(ctx, "SELECT "+groupColumns+" FROM groups ORDER BY lower(name)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return scanGroups(rows)
+}
+
+// CreateGroup creates a group. Returns ErrGroupAlreadyExists if the name is taken.
+func (db *db) CreateGroup(ctx context.Context, g *api.GroupRequest) (*api.Group, error) {
+	query := `INSERT INTO groups (name, description) VALUES ($1, COALESCE($2, ''))
+		RETURNING ` + groupColumns
+
+	group, err := scanGroup(db.pool.QueryRow(ctx, query, g.Name, g.Description))
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrGroupAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return group, nil
+}
+
+// GetGroup returns a group. Returns ErrNotFound if there is no such group.
+func (db *db) GetGroup(ctx context.Context, id openapi_types.UUID) (*api.Group, error) {
+	group, err := scanGroup(db.pool.QueryRow(ctx, "SELECT "+groupColumns+" FROM groups WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return group, nil
+}
+
+// UpdateGroup renames a group and changes its description unless none is given. Returns ErrNotFound if there is no
+// such group and ErrGroupAlreadyExists if the name is taken.
+func (db *db) UpdateGroup(ctx context.Context, id openapi_types.UUID, g *api.GroupRequest) (*api.Group, error) {
+	query := `UPDATE groups SET name = $2, description = COALESCE($3, description)
+		WHERE id = $1
+		RETURNING ` + groupColumns
+
+	group, err := scanGroup(db.pool.QueryRow(ctx, query, id, g.Name, g.Description))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrGroupAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group with its memberships and nesting. Returns ErrNotFound if there is no such group.
+func (db *db) DeleteGroup(ctx context.Context, id openapi_types.UUID) error {
+	tag, err := db.pool.Exec(ctx, "DELETE FROM groups WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListGroupMembers returns the users and subgroups of a group, transitively those of nested groups as well. Returns
+// ErrNotFound if there is no such group.
+func (db *db) ListGroupMembers(ctx context.Context, id openapi_types.UUID, transitive bool) (*api.GroupMembers, error) {
+	if _, err := db.GetGroup(ctx, id); err != nil {
+		return nil, err
+	}
+
+	query := groupScope + `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE id IN (SELECT gu.user_id FROM group_users gu JOIN scope s ON s.id = gu.group_id)
+	ORDER BY uid`
+
+	rows, err := db.pool.Query(ctx, query, id, transitive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+	defer rows.Close()
+
+	var stored []storedUser
+	for rows.Next() {
+		var user storedUser
+		if err = rows.Scan(userFields(&user)...); err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		stored = append(stored, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read group members: %w", err)
+	}
+	rows.Close()
+
+	members := &api.GroupMembers{Users: make([]api.User, 0, len(stored))}
+	for i := range stored {
+		if err = db.openUser(ctx, &stored[i]); err != nil {
+			return nil, err
+		}
+		members.Users = append(members.Users, stored[i].User)
+	}
+
+	query = groupScope + `
+	SELECT ` + groupColumns + `
+	FROM groups
+	WHERE id IN (SELECT gs.child_id FROM group_subgroups gs JOIN scope s ON s.id = gs.parent_id)
+	ORDER BY lower(name)`
+
+	rows, err = db.pool.Query(ctx, query, id, transitive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subgroups: %w", err)
+	}
+	if members.Groups, err = scanGroups(rows); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// ListUserGroups returns the groups a user is a direct member of, transitively the groups containing them as well,
+// ordered by name. Returns ErrNotFound if there is no such user.
+func (db *db) ListUserGroups(ctx context.Context, id openapi_types.UUID, transitive bool) ([]api.Group, error) {
+	var userID int64
+	if err := db.pool.QueryRow(ctx, "SELECT id FROM users WHERE uid = $1", id).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	query := `WITH RECURSIVE scope (id) AS (
+		SELECT group_id FROM group_users WHERE user_id = $1
+		UNION
+		SELECT gs.parent_id FROM group_subgroups gs JOIN scope s ON gs.child_id = s.id WHERE $2
+	)
+	SELECT ` + groupColumns + `
+	FROM groups
+	WHERE id IN (SELECT id FROM scope)
+	ORDER BY lower(name)`
+
+	rows, err := db.pool.Query(ctx, query, userID, transitive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	return scanGroups(rows)
+}
+
+// IsGroupMember reports whether a user is a member of a group, directly or through nested groups. Returns
+// ErrNotFound if the group or the user does not exist.
+func (db *db) IsGroupMember(ctx context.Context, groupID, id openapi_types.UUID) (*api.GroupMembership, error) {
+	query := groupScope + `
+	SELECT
+		EXISTS (SELECT 1 FROM groups WHERE id = $1),
+		u.id IS NOT NULL,
+		EXISTS (SELECT 1 FROM group_users gu JOIN scope s ON s.id = gu.group_id WHERE gu.user_id = u.id),
+		EXISTS (SELECT 1 FROM group_users WHERE group_id = $1 AND user_id = u.id)
+	FROM (SELECT 1) AS one
+	LEFT JOIN users u ON u.uid = $3`
+
+	var (
+		groupExists, userExists bool
+		membership              api.GroupMembership
+	)
+	err := db.pool.QueryRow(ctx, query, groupID, true, id).
+		Scan(&groupExists, &userExists, &membership.Member, &membership.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !groupExists || !userExists {
+		return nil, ownErrors.ErrNotFound
+	}
+
+	return &membership, nil
+}
+
+// AddGroupUser makes a user a direct member of a group. Adding a member twice is not an error. Returns ErrNotFound
+// if the group or the user does not exist.
+func (db *db) AddGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error {
+	// The no-op update makes an existing membership count as an affected row.
+	query := `INSERT INTO group_users (group_id, user_id)
+		SELECT g.id, u.id FROM groups g, users u WHERE g.id = $1 AND u.uid = $2
+		ON CONFLICT (group_id, user_id) DO UPDATE SET added_at = group_users.added_at`
+
+	tag, err := db.pool.Exec(ctx, query, groupID, id)
+	if err != nil {
+		return fmt.Errorf("failed to add group member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveGroupUser removes a direct member from a group. Returns ErrNotFound if the user is not a direct member.
+func (db *db) RemoveGroupUser(ctx context.Context, groupID, id openapi_types.UUID) error {
+	query := `DELETE FROM group_users gu
+		USING users u
+		WHERE gu.user_id = u.id AND gu.group_id = $1 AND u.uid = $2`
+
+	tag, err := db.pool.Exec(ctx, query, groupID, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// AddSubgroup nests a group in another one. Nesting a group twice is not an error. Returns ErrNotFound if either
+// group does not exist and ErrGroupCycle if the parent is the child or already nested in it.
+func (db *db) AddSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error {
+	if parentID == childID {
+		return ownErrors.ErrGroupCycle
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Concurrent nestings could each pass the cycle check and create a cycle together, so they are serialized.
+	// Reads of the graph are not blocked by this lock.
+	if _, err = tx.Exec(ctx, "LOCK TABLE group_subgroups IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("failed to lock group nesting: %w", err)
+	}
+
+	var found int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM groups WHERE id = $1 OR id = $2", parentID, childID).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to get groups: %w", err)
+	}
+	if found != 2 {
+		return ownErrors.ErrNotFound
+	}
+
+	query := groupScope + `
+	SELECT EXISTS (SELECT 1 FROM scope WHERE id = $3)`
+
+	var cycle bool
+	if err = tx.QueryRow(ctx, query, childID, true, parentID).Scan(&cycle); err != nil {
+		return fmt.Errorf("failed to check group nesting: %w", err)
+	}
+	if cycle {
+		return ownErrors.ErrGroupCycle
+	}
+
+	query = `INSERT INTO group_subgroups (parent_id, child_id) VALUES ($1, $2)
+		ON CONFLICT (parent_id, child_id) DO NOTHING`
+	if _, err = tx.Exec(ctx, query, parentID, childID); err != nil {
+		return fmt.Errorf("failed to nest group: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSubgroup unnests a group. Returns ErrNotFound if it is not a direct subgroup of the parent.
+func (db *db) RemoveSubgroup(ctx context.Context, parentID, childID openapi_types.UUID) error {
+	tag, err := db.pool.Exec(ctx, "DELETE FROM group_subgroups WHERE parent_id = $1 AND child_id = $2", parentID, childID)
+	if err != nil {
+		return fmt.Errorf("failed to unnest group: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// scanGroup reads a row selected with groupColumns.
+func scanGroup(row pgx.Row) (*api.Group, error) {
+	var g api.Group
+	if err := row.Scan(&g.Id, &g.Name, &g.Description, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// scanGroups reads and closes rows selected with groupColumns.
+func scanGroups(rows pgx.Rows) ([]api.Group, error) {
+	defer rows.Close()
+
+	groups := make([]api.Group, 0)
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, *group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read groups: %w", err)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// groupRow returns a row of a group with the given ID and name.
+func groupRow(id openapi_types.UUID, name string) func(dest ...any) error {
+	return func(dest ...any) error {
+		*dest[0].(*openapi_types.UUID) = id
+		*dest[1].(*string) = name
+		return nil
+	}
+}
+
+func TestCreateGroup(t *testing.T) {
+	t.Run("Name already taken", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&pgconn.PgError{Code: "23505"})
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{"Platform", (*string)(nil)}).Return(mr)
+
+		group, err := db.CreateGroup(context.Background(), &api.GroupRequest{Name: "Platform"})
+
+		assert.Nil(t, group)
+		assert.ErrorIs(t, err, ownErrors.ErrGroupAlreadyExists)
+	})
+}
+
+func TestListGroupMembers(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	found := new(MockRow)
+	found.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = testUID(1)
+		}).Return(nil)
+	mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(found)
+
+	users := &fakeRows{rows: []func(dest ...any) error{
+		func(dest ...any) error {
+			*dest[0].(*openapi_types.UUID) = testUID(7)
+			return nil
+		},
+	}}
+	subgroups := &fakeRows{rows: []func(dest ...any) error{groupRow(testUID(2), "Backend")}}
+	mp.On("Query", context.Background(), mock.MatchedBy(func(q string) bool {
+		return q == groupScope+`
+	SELECT `+userColumns+`
+	FROM users
+	WHERE id IN (SELECT gu.user_id FROM group_users gu JOIN scope s ON s.id = gu.group_id)
+	ORDER BY uid`
+	}), []any{testUID(1), true}).Return(users, nil)
+	mp.On("Query", context.Background(), mock.Anything, []any{testUID(1), true}).Return(subgroups, nil)
+
+	members, err := db.ListGroupMembers(context.Background(), testUID(1), true)
+
+	require.NoError(t, err)
+	require.Len(t, members.Users, 1)
+	assert.Equal(t, testUID(7), members.Users[0].Id)
+	assert.Equal(t, []api.Group{{Id: testUID(2), Name: "Backend"}}, members.Groups)
+	assert.True(t, users.closed)
+	assert.True(t, subgroups.closed)
+}
+
+func TestIsGroupMember(t *testing.T) {
+	tests := []struct {
+		name                    string
+		groupExists, userExists bool
+		member, direct          bool
+		err                     error
+	}{
+		{name: "Direct member", groupExists: true, userExists: true, member: true, direct: true},
+		{name: "Member through a subgroup", groupExists: true, userExists: true, member: true},
+		{name: "Not a member", groupExists: true, userExists: true},
+		{name: "Unknown group", userExists: true, err: ownErrors.ErrNotFound},
+		{name: "Unknown user", groupExists: true, err: ownErrors.ErrNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+
+			mr := new(MockRow)
+			mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				*args.Get(0).(*bool) = tc.groupExists
+				*args.Get(1).(*bool) = tc.userExists
+				*args.Get(2).(*bool) = tc.member
+				*args.Get(3).(*bool) = tc.direct
+			}).Return(nil)
+			mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1), true, testUID(7)}).Return(mr)
+
+			membership, err := db.IsGroupMember(context.Background(), testUID(1), testUID(7))
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &api.GroupMembership{Member: tc.member, Direct: tc.direct}, membership)
+		})
+	}
+}
+
+func TestAddSubgroup(t *testing.T) {
+	lockQuery := "LOCK TABLE group_subgroups IN SHARE ROW EXCLUSIVE MODE"
+	countQuery := "SELECT COUNT(*) FROM groups WHERE id = $1 OR id = $2"
+
+	countRow := func(n int) *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int) = n
+		}).Return(nil)
+		return mr
+	}
+	cycleRow := func(cycle bool) *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*bool) = cycle
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Group nested", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), lockQuery, []any(nil)).Return(pgconn.NewCommandTag("LOCK TABLE"), nil)
+		tx.On("QueryRow", context.Background(), countQuery, []any{testUID(1), testUID(2)}).Return(countRow(2))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(2), true, testUID(1)}).Return(cycleRow(false))
+		tx.On("Exec", context.Background(), mock.Anything, []any{testUID(1), testUID(2)}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		require.NoError(t, db.AddSubgroup(context.Background(), testUID(1), testUID(2)))
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Cycle rejected", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), lockQuery, []any(nil)).Return(pgconn.NewCommandTag("LOCK TABLE"), nil)
+		tx.On("QueryRow", context.Background(), countQuery, []any{testUID(1), testUID(2)}).Return(countRow(2))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(2), true, testUID(1)}).Return(cycleRow(true))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.AddSubgroup(context.Background(), testUID(1), testUID(2))
+
+		assert.ErrorIs(t, err, ownErrors.ErrGroupCycle)
+		tx.AssertNumberOfCalls(t, "Exec", 1)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Unknown group", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), lockQuery, []any(nil)).Return(pgconn.NewCommandTag("LOCK TABLE"), nil)
+		tx.On("QueryRow", context.Background(), countQuery, []any{testUID(1), testUID(2)}).Return(countRow(1))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		assert.ErrorIs(t, db.AddSubgroup(context.Background(), testUID(1), testUID(2)), ownErrors.ErrNotFound)
+	})
+
+	t.Run("Group nested in itself", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		assert.ErrorIs(t, db.AddSubgroup(context.Background(), testUID(1), testUID(1)), ownErrors.ErrGroupCycle)
+		mp.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+}
+
+func TestRemoveGroupUser(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), testUID(7)}).
+		Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	assert.ErrorIs(t, db.RemoveGroupUser(context.Background(), testUID(1), testUID(7)), ownErrors.ErrNotFound)
+}
@@ -0,0 +1,418 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/ownErrors"
+)
+
+// invitationStatus derives the status of an invitation; pending invitations past their expiry are expired.
+const invitationStatus = `CASE
+		WHEN i.accepted_at IS NOT NULL THEN 'accepted'
+		WHEN i.revoked_at IS NOT NULL THEN 'revoked'
+		WHEN i.expires_at <= CURRENT_TIMESTAMP THEN 'expired'
+		ELSE 'pending'
+	END`
+
+// invitationColumns selects an invitation joined with its user; scan it with scanInvitation.
+const invitationColumns = "i.id, i.email, u.uid, " + invitationStatus + `, i.invited_by_kind, i.invited_by_id,
+	i.created_at, i.sent_at, i.expires_at, i.accepted_at, i.revoked_at, i.pii_key_id IS NOT NULL`
+
+// CreateInvitation creates a pending user for the invited email address together with the invitation and its
+// created event. Returns ErrUserAlreadyExists if a user with the address exists.
+func (db *db) CreateInvitation(ctx context.Context, inv *api.NewInvitation) (*api.Invitation, error) {
+	canonical, err := email.Canonicalize(inv.Email, db.emailOptions)
+	if err != nil {
+		return nil, err
+	}
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user id: %w", err)
+	}
+	s, err := db.sealer(uid)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{s.seal("first_name", ""), s.seal("last_name", ""), s.seal("email", inv.Email), lookup, s.keyID(), uid}
+	if s.err != nil {
+		return nil, s.err
+	}
+	// The address of the invitation is sealed for the invitation, whose ID is chosen here for that.
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation id: %w", err)
+	}
+	is, err := db.sealer(id)
+	if err != nil {
+		return nil, err
+	}
+	address := is.seal("invitations.email", inv.Email)
+	if is.err != nil {
+		return nil, is.err
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO users (first_name, last_name, email, email_canonical, pii_key_id, uid, status)
+		VALUES ($1, $2, $3, $4, $5, $6, 'pending') RETURNING id`
+
+	var userID int64
+	if err = tx.QueryRow(ctx, query, args...).Scan(&userID); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create pending user: %w", err)
+	}
+
+	query = `INSERT INTO invitations (id, user_id, email, pii_key_id, token_hash, invited_by_kind, invited_by_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = tx.Exec(ctx, query, id, userID, address, is.keyID(), inv.TokenHash, inv.InvitedByKind, inv.InvitedByID, inv.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	e := &api.InvitationEvent{Event: api.InvitationEventEventCreated, ActorKind: inv.InvitedByKind, ActorId: inv.InvitedByID}
+	if err = recordInvitationEvent(ctx, tx, id, e); err != nil {
+		return nil, err
+	}
+
+	invitation, err := db.getInvitation(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations returns all invitations, newest first, optionally only those with the given status.
+func (db *db) ListInvitations(ctx context.Context, status *api.InvitationStatus) ([]api.Invitation, error) {
+	query := `SELECT ` + invitationColumns + `
+	FROM invitations i
+	LEFT JOIN users u ON u.id = i.user_id
+	WHERE $1::text IS NULL OR ` + invitationStatus + ` = $1
+	ORDER BY i.created_at DESC, i.id DESC`
+
+	var filter *string
+	if status != nil {
+		s := string(*status)
+		filter = &s
+	}
+
+	rows, err := db.pool.Query(ctx, query, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := make([]api.Invitation, 0)
+	for rows.Next() {
+		invitation, err := db.scanInvitation(ctx, rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, *invitation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// GetInvitation returns an invitation with its events, oldest first. Returns ErrNotFound if there is no such
+// invitation.
+func (db *db) GetInvitation(ctx context.Context, id openapi_types.UUID) (*api.Invitation, error) {
+	invitation, err := db.getInvitation(ctx, db.pool, id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT event, actor_kind, actor_id, created_at FROM invitation_events
+		WHERE invitation_id = $1 ORDER BY created_at, id`
+
+	rows, err := db.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitation events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]api.InvitationEvent, 0)
+	for rows.Next() {
+		var e api.InvitationEvent
+		if err = rows.Scan(&e.Event, &e.ActorKind, &e.ActorId, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read invitation events: %w", err)
+	}
+
+	invitation.Events = &events
+	return invitation, nil
+}
+
+// GetInvitationByToken returns the invitation of a token. Returns ErrInvalidToken if the token is unknown or its
+// invitation is not pending.
+func (db *db) GetInvitationByToken(ctx context.Context, tokenHash []byte) (*api.Invitation, error) {
+	query := `SELECT ` + invitationColumns + `
+	FROM invitations i
+	LEFT JOIN users u ON u.id = i.user_id
+	WHERE i.token_hash = $1`
+
+	invitation, err := db.scanInvitation(ctx, db.pool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation.Status != api.InvitationStatusPending {
+		return nil, ownErrors.ErrInvalidToken
+	}
+
+	return invitation, nil
+}
+
+// RevokeInvitation revokes an invitation and deletes its pending user, unless they are under legal hold. Returns
+// ErrNotFound if there is no such invitation and ErrInvitationClosed if it has already been accepted or revoked.
+func (db *db) RevokeInvitation(ctx context.Context, id openapi_types.UUID, e *api.InvitationEvent) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	userID, err := lockOpenInvitation(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE invitations SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM users WHERE id = $1 AND status = 'pending' AND NOT legal_hold", userID); err != nil {
+		return fmt.Errorf("failed to delete pending user: %w", err)
+	}
+
+	if err = recordInvitationEvent(ctx, tx, id, e); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ResendInvitation replaces the token and expiry of an invitation, including an expired one. Returns ErrNotFound if
+// there is no such invitation and ErrInvitationClosed if it has already been accepted or revoked.
+func (db *db) ResendInvitation(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time, e *api.InvitationEvent) (*api.Invitation, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = lockOpenInvitation(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE invitations SET token_hash = $2, expires_at = $3, sent_at = CURRENT_TIMESTAMP WHERE id = $1"
+	if _, err = tx.Exec(ctx, query, id, tokenHash, expiresAt); err != nil {
+		return nil, fmt.Errorf("failed to renew invitation: %w", err)
+	}
+
+	if err = recordInvitationEvent(ctx, tx, id, e); err != nil {
+		return nil, err
+	}
+
+	invitation, err := db.getInvitation(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation accepts the invitation of a token and activates its pending user with the given names and
+// password. The email address counts as verified, since the token was mailed to it. Returns ErrInvalidToken if the
+// token is unknown or its invitation is not pending.
+func (db *db) AcceptInvitation(ctx context.Context, tokenHash []byte, a *api.InvitationAcceptance) (*api.User, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE invitations SET accepted_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id, user_id`
+
+	var (
+		id     openapi_types.UUID
+		userID int64
+	)
+	if err = tx.QueryRow(ctx, query, tokenHash).Scan(&id, &userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	// Only the names are written, so they follow how the row is stored.
+	var (
+		uid    uuid.UUID
+		sealed bool
+	)
+	query = "SELECT uid, pii_key_id IS NOT NULL FROM users WHERE id = $1 FOR UPDATE"
+	if err = tx.QueryRow(ctx, query, userID).Scan(&uid, &sealed); err != nil {
+		return nil, fmt.Errorf("failed to lock user: %w", err)
+	}
+	s, err := db.rowSealer(uid, sealed)
+	if err != nil {
+		return nil, err
+	}
+	firstName, lastName := s.seal("first_name", a.FirstName), s.seal("last_name", a.LastName)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	query = `UPDATE users SET first_name = $2, last_name = $3, status = 'active', email_verified_at = CURRENT_TIMESTAMP
+		WHERE id = $1 RETURNING ` + userColumns
+
+	var user storedUser
+	if err = tx.QueryRow(ctx, query, userID, firstName, lastName).Scan(userFields(&user)...); err != nil {
+		return nil, fmt.Errorf("failed to activate user: %w", err)
+	}
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	query = `INSERT INTO user_credentials (user_id, password_hash) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET password_hash = EXCLUDED.password_hash, password_changed_at = CURRENT_TIMESTAMP`
+	if _, err = tx.Exec(ctx, query, userID, a.PasswordHash); err != nil {
+		return nil, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	e := &api.InvitationEvent{Event: api.InvitationEventEventAccepted, ActorKind: "user", ActorId: user.Id.String()}
+	if err = recordInvitationEvent(ctx, tx, id, e); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &user.User, nil
+}
+
+// getInvitation returns an invitation without its events using the given querier. Returns ErrNotFound if there is no
+// such invitation.
+func (db *db) getInvitation(ctx context.Context, q querier, id openapi_types.UUID) (*api.Invitation, error) {
+	query := `SELECT ` + invitationColumns + `
+	FROM invitations i
+	LEFT JOIN users u ON u.id = i.user_id
+	WHERE i.id = $1`
+
+	invitation, err := db.scanInvitation(ctx, q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// lockOpenInvitation locks an invitation for the rest of the transaction and returns the ID of its pending user.
+// Returns ErrNotFound if there is no such invitation and ErrInvitationClosed if it has been accepted or revoked.
+func lockOpenInvitation(ctx context.Context, tx pgx.Tx, id openapi_types.UUID) (*int64, error) {
+	query := "SELECT user_id, accepted_at IS NOT NULL OR revoked_at IS NOT NULL FROM invitations WHERE id = $1 FOR UPDATE"
+
+	var (
+		userID *int64
+		closed bool
+	)
+	if err := tx.QueryRow(ctx, query, id).Scan(&userID, &closed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock invitation: %w", err)
+	}
+	if closed {
+		return nil, ownErrors.ErrInvitationClosed
+	}
+
+	return userID, nil
+}
+
+// recordInvitationEvent records an event of an invitation.
+func recordInvitationEvent(ctx context.Context, q querier, id openapi_types.UUID, e *api.InvitationEvent) error {
+	query := "INSERT INTO invitation_events (invitation_id, event, actor_kind, actor_id) VALUES ($1, $2, $3, $4)"
+	if _, err := q.Exec(ctx, query, id, e.Event, e.ActorKind, e.ActorId); err != nil {
+		return fmt.Errorf("failed to record invitation event: %w", err)
+	}
+	return nil
+}
+
+// scanInvitation scans a row of invitationColumns and decrypts its address if it is encrypted.
+func (db *db) scanInvitation(ctx context.Context, row pgx.Row) (*api.Invitation, error) {
+	var (
+		invitation api.Invitation
+		sealed     bool
+	)
+	if err := row.Scan(
+		&invitation.Id,
+		&invitation.Email,
+		&invitation.UserId,
+		&invitation.Status,
+		&invitation.InvitedByKind,
+		&invitation.InvitedById,
+		&invitation.CreatedAt,
+		&invitation.SentAt,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+		&sealed,
+	); err != nil {
+		return nil, err
+	}
+
+	address, err := db.openAddress(ctx, "invitations.email", invitation.Id, sealed, string(invitation.Email))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt invitation: %w", err)
+	}
+	invitation.Email = openapi_types.Email(address)
+	return &invitation, nil
+}
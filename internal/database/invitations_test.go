@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// invitationScanArgs returns matchers for the scan destinations of invitationColumns.
+func invitationScanArgs() []any {
+	args := make([]any, 12)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func TestCreateInvitation(t *testing.T) {
+	inv := &api.NewInvitation{Email: "John@example.com", TokenHash: []byte("hash"), InvitedByKind: "user", InvitedByID: "admin"}
+
+	t.Run("Address already taken", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		taken := new(MockRow)
+		taken.On("Scan", mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, insertUserArgs("", "", "John@example.com", "john@example.com", noPIIKey)).Return(taken)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		invitation, err := db.CreateInvitation(context.Background(), inv)
+
+		assert.Nil(t, invitation)
+		assert.ErrorIs(t, err, ownErrors.ErrUserAlreadyExists)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		db := &db{pool: new(MockPool)}
+
+		_, err := db.CreateInvitation(context.Background(), &api.NewInvitation{Email: "john"})
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidEmail)
+	})
+}
+
+func TestGetInvitationByToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		status api.InvitationStatus
+		err    error
+	}{
+		{name: "Pending", status: api.InvitationStatusPending},
+		{name: "Expired", status: api.InvitationStatusExpired, err: ownErrors.ErrInvalidToken},
+		{name: "Accepted", status: api.InvitationStatusAccepted, err: ownErrors.ErrInvalidToken},
+		{name: "Unknown", err: ownErrors.ErrInvalidToken},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+
+			mr := new(MockRow)
+			if tc.status == "" {
+				mr.On("Scan", invitationScanArgs()...).Return(sql.ErrNoRows)
+			} else {
+				mr.On("Scan", invitationScanArgs()...).Run(func(args mock.Arguments) {
+					*args.Get(3).(*api.InvitationStatus) = tc.status
+				}).Return(nil)
+			}
+			mp.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("hash")}).Return(mr)
+
+			invitation, err := db.GetInvitationByToken(context.Background(), []byte("hash"))
+
+			if tc.err != nil {
+				assert.Nil(t, invitation)
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, api.InvitationStatusPending, invitation.Status)
+		})
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	lockQuery := "SELECT user_id, accepted_at IS NOT NULL OR revoked_at IS NOT NULL FROM invitations WHERE id = $1 FOR UPDATE"
+	e := &api.InvitationEvent{Event: api.InvitationEventEventRevoked, ActorKind: "user", ActorId: "admin"}
+
+	lockedRow := func(userID int64, closed bool) *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(**int64) = &userID
+			*args.Get(1).(*bool) = closed
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Pending user deleted", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(lockedRow(7, false))
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		err := db.RevokeInvitation(context.Background(), testUID(1), e)
+
+		require.NoError(t, err)
+		userID := int64(7)
+		tx.AssertCalled(t, "Exec", context.Background(), "DELETE FROM users WHERE id = $1 AND status = 'pending' AND NOT legal_hold", []any{&userID})
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{testUID(1), api.InvitationEventEventRevoked, "user", "admin"})
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Already accepted", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(lockedRow(7, true))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.RevokeInvitation(context.Background(), testUID(1), e)
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvitationClosed)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		missing := new(MockRow)
+		missing.On("Scan", mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(missing)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.RevokeInvitation(context.Background(), testUID(1), e)
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	acceptance := &api.InvitationAcceptance{FirstName: "John", LastName: "Doe", PasswordHash: "argon2"}
+
+	t.Run("User activated", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		accepted := new(MockRow)
+		accepted.On("Scan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = testUID(9)
+			*args.Get(1).(*int64) = 7
+		}).Return(nil)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("hash")}).Return(accepted)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7)}).Return(plaintextUserRow())
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7), "John", "Doe"}).
+			Return(scannedUserRow(testUID(1), "john@example.com"))
+		tx.On("Exec", context.Background(), mock.Anything, []any{int64(7), "argon2"}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		user, err := db.AcceptInvitation(context.Background(), []byte("hash"), acceptance)
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{testUID(9), api.InvitationEventEventAccepted, "user", testUID(1).String()})
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		missing := new(MockRow)
+		missing.On("Scan", mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("hash")}).Return(missing)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.AcceptInvitation(context.Background(), []byte("hash"), acceptance)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Activation failure rolls back", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		accepted := new(MockRow)
+		accepted.On("Scan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*int64) = 7
+		}).Return(nil)
+		failed := new(MockRow)
+		failed.On("Scan", userScanArgs()...).Return(errors.New("db down"))
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("hash")}).Return(accepted)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7)}).Return(plaintextUserRow())
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7), "John", "Doe"}).Return(failed)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.AcceptInvitation(context.Background(), []byte("hash"), acceptance)
+
+		assert.ErrorContains(t, err, "failed to activate user")
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+// plaintextUserRow returns the locked row of a user whose personal data is not encrypted.
+func plaintextUserRow() *MockRow {
+	row := new(MockRow)
+	row.On("Scan", mock.Anything, mock.Anything).Return(nil)
+	return row
+}
@@ -0,0 +1,274 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"go-users/internal/api"
+	"go-users/internal/jobs"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// jobColumns lists the columns needed to build an api.Job, in the order expected by scanJob.
+const jobColumns = "id, type, status, progress_done, progress_total, result, error, cancel_requested, artifact IS NOT NULL, created_at, started_at, finished_at"
+
+// scanJob scans a row selected with jobColumns into an api.Job.
+func scanJob(row pgx.Row) (*api.Job, error) {
+	var job api.Job
+	err := row.Scan(
+		&job.Id,
+		&job.Type,
+		&job.Status,
+		&job.Progress.Done,
+		&job.Progress.Total,
+		&job.Result,
+		&job.Error,
+		&job.CancelRequested,
+		&job.HasArtifact,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// CreateJob inserts a new queued job of the given type.
+func (db *db) CreateJob(ctx context.Context, jobType string, payload map[string]interface{}) (*api.Job, error) {
+	query := "INSERT INTO jobs (type, payload) VALUES ($1, $2) RETURNING " + jobColumns
+
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+
+	job, err := scanJob(db.pool.QueryRow(ctx, query, jobType, payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetJob retrieves a job by its ID. Returns ErrNotFound if the job does not exist.
+func (db *db) GetJob(ctx context.Context, id uint) (*api.Job, error) {
+	query := "SELECT " + jobColumns + " FROM jobs WHERE id = $1"
+
+	job, err := scanJob(db.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+// CancelJob cancels a queued job immediately and flags a running job for cancellation by its worker.
+// Returns ErrNotFound if the job does not exist and ErrJobFinished if it has already finished.
+func (db *db) CancelJob(ctx context.Context, id uint) (*api.Job, error) {
+	query := `UPDATE jobs SET
+		cancel_requested = TRUE,
+		status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+		payload = CASE WHEN status = 'queued' THEN '{}' ELSE payload END,
+		finished_at = CASE WHEN status = 'queued' THEN CURRENT_TIMESTAMP ELSE finished_at END
+	WHERE id = $1 AND status IN ('queued', 'running')
+	RETURNING ` + jobColumns
+
+	job, err := scanJob(db.pool.QueryRow(ctx, query, id))
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	if _, err = db.GetJob(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return nil, ownErrors.ErrJobFinished
+}
+
+// GetJobArtifact retrieves the artifact produced by a job and deletes it, so it can be downloaded once. Returns
+// ErrNotFound if the job or its artifact does not exist.
+func (db *db) GetJobArtifact(ctx context.Context, id uint) (*api.JobArtifact, error) {
+	query := `WITH downloaded AS (
+		SELECT id, artifact_name, artifact_content_type, artifact FROM jobs
+		WHERE id = $1 AND artifact IS NOT NULL
+		FOR UPDATE
+	)
+	UPDATE jobs SET artifact = NULL, artifact_name = NULL, artifact_content_type = NULL
+	FROM downloaded WHERE jobs.id = downloaded.id
+	RETURNING downloaded.artifact_name, downloaded.artifact_content_type, downloaded.artifact`
+
+	var artifact api.JobArtifact
+	err := db.pool.QueryRow(ctx, query, id).Scan(
+		&artifact.Name,
+		&artifact.ContentType,
+		&artifact.Data,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job artifact: %w", err)
+	}
+
+	return &artifact, nil
+}
+
+// ClaimJob atomically marks the oldest queued job of any organization, or a running job whose worker stopped sending
+// heartbeats for longer than staleAfter, as running and returns it. Concurrent workers never claim the same job thanks
+// to SKIP LOCKED. Returns ErrNotFound if there is nothing to do.
+func (db *db) ClaimJob(ctx context.Context, staleAfter time.Duration) (*jobs.Job, error) {
+	query := `UPDATE jobs SET
+		status = 'running',
+		attempts = attempts + 1,
+		started_at = CURRENT_TIMESTAMP,
+		heartbeat_at = CURRENT_TIMESTAMP
+	WHERE id = (
+		SELECT id FROM jobs
+		WHERE status = 'queued'
+			OR (status = 'running' AND heartbeat_at < CURRENT_TIMESTAMP - make_interval(secs => $1))
+		ORDER BY id
+		FOR UPDATE SKIP LOCKED
+		LIMIT 1
+	)
+	RETURNING id, org_id, type, payload, attempts`
+
+	var job jobs.Job
+	err := db.pool.QueryRow(tenant.WithAllOrgs(ctx), query, staleAfter.Seconds()).Scan(
+		&job.ID,
+		&job.Org,
+		&job.Type,
+		&job.Payload,
+		&job.Attempts,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// UpdateJobProgress stores the progress of a running job and reports whether its cancellation was requested.
+func (db *db) UpdateJobProgress(ctx context.Context, id uint, done, total int) (bool, error) {
+	query := "UPDATE jobs SET progress_done = $1, progress_total = $2, heartbeat_at = CURRENT_TIMESTAMP WHERE id = $3 RETURNING cancel_requested"
+
+	var cancelRequested bool
+	if err := db.pool.QueryRow(ctx, query, done, total, id).Scan(&cancelRequested); err != nil {
+		return false, fmt.Errorf("failed to update job progress: %w", err)
+	}
+
+	return cancelRequested, nil
+}
+
+// HeartbeatJob marks a running job as alive and reports whether its cancellation was requested.
+func (db *db) HeartbeatJob(ctx context.Context, id uint) (bool, error) {
+	query := "UPDATE jobs SET heartbeat_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING cancel_requested"
+
+	var cancelRequested bool
+	if err := db.pool.QueryRow(ctx, query, id).Scan(&cancelRequested); err != nil {
+		return false, fmt.Errorf("failed to send job heartbeat: %w", err)
+	}
+
+	return cancelRequested, nil
+}
+
+// CompleteJob marks a job as succeeded and stores its result summary and artifact. The payload is cleared, as it may
+// hold the users of an import.
+func (db *db) CompleteJob(ctx context.Context, id uint, result *jobs.Result) error {
+	query := `UPDATE jobs SET
+		status = 'succeeded',
+		payload = '{}',
+		result = $1,
+		artifact = $2,
+		artifact_name = $3,
+		artifact_content_type = $4,
+		finished_at = CURRENT_TIMESTAMP
+	WHERE id = $5`
+
+	var summary []byte
+	var data []byte
+	var name, contentType *string
+	if result != nil {
+		if result.Summary != nil {
+			var err error
+			if summary, err = json.Marshal(result.Summary); err != nil {
+				return fmt.Errorf("failed to encode job result: %w", err)
+			}
+		}
+		if result.Artifact != nil {
+			data = result.Artifact.Data
+			name = &result.Artifact.Name
+			contentType = &result.Artifact.ContentType
+		}
+	}
+
+	if _, err := db.pool.Exec(ctx, query, summary, data, name, contentType, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+
+	return nil
+}
+
+// FailJob marks a job as failed with the given reason and clears its payload.
+func (db *db) FailJob(ctx context.Context, id uint, reason string) error {
+	query := "UPDATE jobs SET status = 'failed', payload = '{}', error = $1, finished_at = CURRENT_TIMESTAMP WHERE id = $2"
+
+	if _, err := db.pool.Exec(ctx, query, reason, id); err != nil {
+		return fmt.Errorf("failed to mark job as failed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkJobCancelled marks a running job as cancelled after its worker stopped processing it and clears its payload.
+func (db *db) MarkJobCancelled(ctx context.Context, id uint) error {
+	query := "UPDATE jobs SET status = 'cancelled', payload = '{}', finished_at = CURRENT_TIMESTAMP WHERE id = $1"
+
+	if _, err := db.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark job as cancelled: %w", err)
+	}
+
+	return nil
+}
+
+// RequeueJob puts a running job back into the queue, e.g. when it was interrupted by a shutdown.
+func (db *db) RequeueJob(ctx context.Context, id uint) error {
+	query := "UPDATE jobs SET status = 'queued', heartbeat_at = NULL WHERE id = $1 AND status = 'running'"
+
+	if _, err := db.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to requeue job: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeJobArtifacts deletes the artifacts of the jobs of every organization that finished before the cutoff and
+// returns how many were deleted.
+func (db *db) PurgeJobArtifacts(ctx context.Context, before time.Time) (int, error) {
+	query := `UPDATE jobs SET artifact = NULL, artifact_name = NULL, artifact_content_type = NULL
+	WHERE artifact IS NOT NULL AND finished_at < $1`
+
+	tag, err := db.pool.Exec(tenant.WithAllOrgs(ctx), query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge job artifacts: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/jobs"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+func TestClaimJob(t *testing.T) {
+	tests := []struct {
+		name        string
+		scanErr     error
+		expected    *jobs.Job
+		expectedErr error
+	}{
+		{
+			name: "Job claimed",
+			expected: &jobs.Job{
+				ID:       3,
+				Org:      testUID(5),
+				Type:     "users.export",
+				Payload:  json.RawMessage(`{}`),
+				Attempts: 1,
+			},
+		},
+		{
+			name:        "Queue empty",
+			scanErr:     sql.ErrNoRows,
+			expectedErr: ownErrors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+
+			mr := new(MockRow)
+			call := mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			if tt.scanErr != nil {
+				call.Return(tt.scanErr)
+			} else {
+				call.Run(func(args mock.Arguments) {
+					*args.Get(0).(*uint) = tt.expected.ID
+					*args.Get(1).(*uuid.UUID) = tt.expected.Org
+					*args.Get(2).(*string) = tt.expected.Type
+					*args.Get(3).(*json.RawMessage) = tt.expected.Payload
+					*args.Get(4).(*int) = tt.expected.Attempts
+				}).Return(nil)
+			}
+			mp.On("QueryRow", mock.MatchedBy(tenant.AllOrgs), mock.MatchedBy(func(query string) bool {
+				return assert.Contains(t, query, "FOR UPDATE SKIP LOCKED")
+			}), []any{float64(300)}).Return(mr)
+
+			job, err := db.ClaimJob(context.Background(), 300*time.Second)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, job)
+			}
+			mp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCancelJob_Finished(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	updateRow := new(MockRow)
+	updateRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+	mp.On("QueryRow", context.Background(), mock.MatchedBy(func(query string) bool {
+		return len(query) > 6 && query[:6] == "UPDATE"
+	}), []any{uint(5)}).Return(updateRow)
+
+	selectRow := new(MockRow)
+	selectRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mp.On("QueryRow", context.Background(), "SELECT "+jobColumns+" FROM jobs WHERE id = $1", []any{uint(5)}).Return(selectRow)
+
+	job, err := db.CancelJob(context.Background(), 5)
+
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, ownErrors.ErrJobFinished)
+	mp.AssertExpectations(t)
+}
+
+func TestGetJobArtifact(t *testing.T) {
+	t.Run("Artifact deleted once read", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*string) = "users.ndjson"
+			*args.Get(1).(*string) = "application/x-ndjson"
+			*args.Get(2).(*[]byte) = []byte("data")
+		}).Return(nil)
+		mp.On("QueryRow", context.Background(), mock.MatchedBy(func(query string) bool {
+			return assert.Contains(t, query, "SET artifact = NULL")
+		}), []any{uint(9)}).Return(mr)
+
+		artifact, err := db.GetJobArtifact(context.Background(), 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "users.ndjson", artifact.Name)
+		assert.Equal(t, []byte("data"), artifact.Data)
+	})
+
+	t.Run("Artifact already downloaded", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		db := &db{pool: mp}
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{uint(9)}).Return(mr)
+
+		artifact, err := db.GetJobArtifact(context.Background(), 9)
+
+		assert.Nil(t, artifact)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestPurgeJobArtifacts(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	before := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mp.On("Exec", mock.MatchedBy(tenant.AllOrgs), mock.Anything, []any{before}).
+		Return(pgconn.NewCommandTag("UPDATE 2"), nil)
+
+	n, err := db.PurgeJobArtifacts(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	mp.AssertExpectations(t)
+}
+
+func TestCompleteJob(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mp.On("Exec", context.Background(), mock.Anything, []any{
+		[]byte(`{"exported":2}`),
+		[]byte("data"),
+		stringPointer("users.ndjson"),
+		stringPointer("application/x-ndjson"),
+		uint(9),
+	}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	err := db.CompleteJob(context.Background(), 9, &jobs.Result{
+		Summary: map[string]interface{}{"exported": 2},
+		Artifact: &jobs.Artifact{
+			Name:        "users.ndjson",
+			ContentType: "application/x-ndjson",
+			Data:        []byte("data"),
+		},
+	})
+
+	assert.NoError(t, err)
+	mp.AssertExpectations(t)
+}
+
+func TestFailJob_Error(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mp.On("Exec", context.Background(), mock.Anything, []any{"boom", uint(9)}).
+		Return(pgconn.CommandTag{}, errors.New("db error"))
+
+	err := db.FailJob(context.Background(), 9, "boom")
+
+	assert.ErrorContains(t, err, "failed to mark job as failed: db error")
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// legalHoldColumns selects a legal hold; scan it with legalHoldFields.
+const legalHoldColumns = `id, user_uid, reason, case_reference, placed_by_kind, placed_by_id, placed_at, released_by_kind,
+	released_by_id, release_reason, released_at`
+
+// legalHoldFields returns the scan destinations of legalHoldColumns.
+func legalHoldFields(h *api.LegalHold) []any {
+	return []any{&h.Id, &h.UserId, &h.Reason, &h.CaseReference, &h.PlacedByKind, &h.PlacedById, &h.PlacedAt,
+		&h.ReleasedByKind, &h.ReleasedById, &h.ReleaseReason, &h.ReleasedAt}
+}
+
+// ListLegalHolds returns the legal holds of a user, active and released, oldest first. Returns ErrNotFound if there
+// is no such user.
+func (db *db) ListLegalHolds(ctx context.Context, id openapi_types.UUID) ([]api.LegalHold, error) {
+	if _, err := db.GetUserStatus(ctx, id); err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + legalHoldColumns + " FROM legal_holds WHERE user_uid = $1 ORDER BY placed_at, id"
+
+	rows, err := db.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := make([]api.LegalHold, 0)
+	for rows.Next() {
+		var h api.LegalHold
+		if err = rows.Scan(legalHoldFields(&h)...); err != nil {
+			return nil, fmt.Errorf("failed to scan legal hold: %w", err)
+		}
+		holds = append(holds, h)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read legal holds: %w", err)
+	}
+
+	return holds, nil
+}
+
+// PlaceLegalHold places a legal hold on a user and records it. Returns ErrNotFound if there is no such user.
+func (db *db) PlaceLegalHold(ctx context.Context, id openapi_types.UUID, c *api.LegalHoldChange) (*api.LegalHold, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "UPDATE users SET legal_hold = TRUE WHERE uid = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ownErrors.ErrNotFound
+	}
+
+	query := `INSERT INTO legal_holds (user_uid, reason, case_reference, placed_by_kind, placed_by_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + legalHoldColumns
+
+	var hold api.LegalHold
+	err = tx.QueryRow(ctx, query, id, c.Reason, c.CaseReference, c.ActorKind, c.ActorID).Scan(legalHoldFields(&hold)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record legal hold: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &hold, nil
+}
+
+// ReleaseLegalHold releases a legal hold of a user and records who released it and why. The user is released once
+// none of their holds is active. Returns ErrNotFound if there is no such user or hold and ErrLegalHoldReleased if the
+// hold has already been released.
+func (db *db) ReleaseLegalHold(ctx context.Context, id, holdID openapi_types.UUID, c *api.LegalHoldChange) (*api.LegalHold, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The user is locked first, so that concurrent releases see each other's holds when clearing the flag.
+	var userID int64
+	if err = tx.QueryRow(ctx, "SELECT id FROM users WHERE uid = $1 FOR UPDATE", id).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	query := `UPDATE legal_holds SET released_by_kind = $3, released_by_id = $4, release_reason = NULLIF($5, ''),
+		released_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_uid = $2 AND released_at IS NULL
+		RETURNING ` + legalHoldColumns
+
+	var hold api.LegalHold
+	err = tx.QueryRow(ctx, query, holdID, id, c.ActorKind, c.ActorID, c.Reason).Scan(legalHoldFields(&hold)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		query = "SELECT EXISTS (SELECT 1 FROM legal_holds WHERE id = $1 AND user_uid = $2)"
+		if err = tx.QueryRow(ctx, query, holdID, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to get legal hold: %w", err)
+		}
+		if exists {
+			return nil, ownErrors.ErrLegalHoldReleased
+		}
+		return nil, ownErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to release legal hold: %w", err)
+	}
+
+	query = `UPDATE users SET legal_hold = EXISTS (
+			SELECT 1 FROM legal_holds h WHERE h.user_uid = users.uid AND h.released_at IS NULL)
+		WHERE id = $1`
+	if _, err = tx.Exec(ctx, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to release user: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &hold, nil
+}
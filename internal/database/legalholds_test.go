@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// legalHoldRow returns a row holding a legal hold of the user with the given ID.
+func legalHoldRow(id, userUID uuid.UUID) *MockRow {
+	args := make([]any, len(legalHoldFields(&api.LegalHold{})))
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	mr := new(MockRow)
+	mr.On("Scan", args...).Run(func(args mock.Arguments) {
+		*args.Get(0).(*uuid.UUID) = id
+		*args.Get(1).(*uuid.UUID) = userUID
+	}).Return(nil)
+	return mr
+}
+
+func TestPlaceLegalHold(t *testing.T) {
+	change := &api.LegalHoldChange{Reason: "Litigation", CaseReference: "CASE-1", ActorKind: "user", ActorID: "counsel"}
+
+	t.Run("User held and hold recorded", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		holdID := uuid.New()
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), "UPDATE users SET legal_hold = TRUE WHERE uid = $1", []any{testUID(1)}).
+			Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1), "Litigation", "CASE-1", "user", "counsel"}).
+			Return(legalHoldRow(holdID, testUID(1)))
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		hold, err := db.PlaceLegalHold(context.Background(), testUID(1), change)
+
+		require.NoError(t, err)
+		assert.Equal(t, holdID, hold.Id)
+		assert.Equal(t, testUID(1), hold.UserId)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), mock.Anything, []any{testUID(1)}).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.PlaceLegalHold(context.Background(), testUID(1), change)
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+func TestReleaseLegalHold(t *testing.T) {
+	change := &api.LegalHoldChange{Reason: "Case closed", ActorKind: "service", ActorID: "legal"}
+	holdID := uuid.New()
+	lockQuery := "SELECT id FROM users WHERE uid = $1 FOR UPDATE"
+	releaseQuery := mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE legal_holds") })
+	lockedRow := func() *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Hold released and flag recomputed", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(lockedRow())
+		tx.On("QueryRow", context.Background(), releaseQuery, []any{holdID, testUID(1), "service", "legal", "Case closed"}).
+			Return(legalHoldRow(holdID, testUID(1)))
+		tx.On("Exec", context.Background(), mock.Anything, []any{int64(7)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		hold, err := db.ReleaseLegalHold(context.Background(), testUID(1), holdID, change)
+
+		require.NoError(t, err)
+		assert.Equal(t, holdID, hold.Id)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Hold already released", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		released := new(MockRow)
+		released.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(lockedRow())
+		tx.On("QueryRow", context.Background(), releaseQuery, mock.Anything).Return(released)
+		tx.On("QueryRow", context.Background(), "SELECT EXISTS (SELECT 1 FROM legal_holds WHERE id = $1 AND user_uid = $2)",
+			[]any{holdID, testUID(1)}).Return(boolRow(true))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.ReleaseLegalHold(context.Background(), testUID(1), holdID, change)
+
+		assert.ErrorIs(t, err, ownErrors.ErrLegalHoldReleased)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		missing := new(MockRow)
+		missing.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), lockQuery, []any{testUID(1)}).Return(missing)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.ReleaseLegalHold(context.Background(), testUID(1), holdID, change)
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestDeleteUser_LegalHold(t *testing.T) {
+	tx := new(MockTx)
+	tx.On("Exec", context.Background(), deleteUserQuery, []any{testUID(1)}).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+	tx.On("QueryRow", context.Background(), userExistsQuery, []any{testUID(1)}).Return(boolRow(true))
+
+	err := deleteUser(context.Background(), tx, testUID(1))
+
+	assert.ErrorIs(t, err, ownErrors.ErrLegalHold)
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// GetMFA returns the MFA state of a user. Users who never enrolled have no secret. Returns ErrNotFound if the user
+// does not exist.
+func (db *db) GetMFA(ctx context.Context, id openapi_types.UUID) (*api.MFA, error) {
+	query := `SELECT m.totp_secret, m.enabled_at,
+		(SELECT count(*) FROM mfa_recovery_codes rc WHERE rc.user_id = u.id AND rc.used_at IS NULL),
+		EXISTS (SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE ur.user_id = u.id AND r.mfa_required)
+	FROM users u
+	LEFT JOIN user_mfa m ON m.user_id = u.id
+	WHERE u.uid = $1`
+
+	var state api.MFA
+	err := db.pool.QueryRow(ctx, query, id).Scan(&state.Secret, &state.EnabledAt, &state.RecoveryCodesRemaining,
+		&state.Required)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get MFA: %w", err)
+	}
+
+	return &state, nil
+}
+
+// StartTOTPEnrollment stores the secret of a pending enrollment, replacing a previous pending one. Returns
+// ErrNotFound if the user does not exist and ErrMFAEnabled if the user already enabled MFA.
+func (db *db) StartTOTPEnrollment(ctx context.Context, id openapi_types.UUID, secret []byte) error {
+	var userID int64
+	if err := db.pool.QueryRow(ctx, "SELECT id FROM users WHERE uid = $1", id).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ownErrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	query := `INSERT INTO user_mfa (user_id, totp_secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET totp_secret = EXCLUDED.totp_secret, created_at = CURRENT_TIMESTAMP
+		WHERE user_mfa.enabled_at IS NULL`
+
+	tag, err := db.pool.Exec(ctx, query, userID, secret)
+	if err != nil {
+		return fmt.Errorf("failed to start TOTP enrollment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrMFAEnabled
+	}
+
+	return nil
+}
+
+// EnableMFA confirms a pending enrollment with the step of its first code, stores the recovery code hashes and
+// records the event in the audit log. Returns ErrMFAEnabled if there is no pending enrollment.
+func (db *db) EnableMFA(ctx context.Context, id openapi_types.UUID, step int64, recoveryHashes [][]byte, e *api.MFAEvent) error {
+	// Pending enrollments never have recovery codes, so the new ones need not replace any.
+	query := `WITH m AS (
+		UPDATE user_mfa SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2
+		WHERE user_id = (SELECT id FROM users WHERE uid = $1) AND enabled_at IS NULL
+		RETURNING user_id
+	), codes AS (
+		INSERT INTO mfa_recovery_codes (user_id, code_hash)
+		SELECT m.user_id, h FROM m, unnest($3::bytea[]) AS h
+	)
+	INSERT INTO mfa_events (user_uid, event, actor_kind, actor_id, reason)
+	SELECT $1, $4, $5, $6, NULLIF($7, '') FROM m`
+
+	tag, err := db.pool.Exec(ctx, query, id, step, recoveryHashes, e.Event, e.ActorKind, e.ActorID, e.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to enable MFA: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrMFAEnabled
+	}
+
+	return nil
+}
+
+// ResetMFA removes the secret, recovery codes and pending challenges of a user and records the event in the audit
+// log. Returns ErrNotFound if the user does not exist or never enrolled.
+func (db *db) ResetMFA(ctx context.Context, id openapi_types.UUID, e *api.MFAEvent) error {
+	// Recovery codes and challenges are removed by ON DELETE CASCADE.
+	query := `WITH m AS (
+		DELETE FROM user_mfa WHERE user_id = (SELECT id FROM users WHERE uid = $1)
+		RETURNING user_id
+	)
+	INSERT INTO mfa_events (user_uid, event, actor_kind, actor_id, reason)
+	SELECT $1, $2, $3, $4, NULLIF($5, '') FROM m`
+
+	tag, err := db.pool.Exec(ctx, query, id, e.Event, e.ActorKind, e.ActorID, e.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to reset MFA: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the time step of an accepted code. Returns ErrInvalidToken if MFA is not enabled or the step
+// is not newer than the last used one, which rejects replayed codes.
+func (db *db) UseTOTPStep(ctx context.Context, id openapi_types.UUID, step int64) error {
+	query := `UPDATE user_mfa SET last_used_step = $2
+		WHERE user_id = (SELECT id FROM users WHERE uid = $1) AND enabled_at IS NOT NULL
+			AND (last_used_step IS NULL OR last_used_step < $2)`
+
+	tag, err := db.pool.Exec(ctx, query, id, step)
+	if err != nil {
+		return fmt.Errorf("failed to record TOTP step: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks a recovery code of a user as used and records the event in the audit log. Returns
+// ErrInvalidToken if the user has no such unused code.
+func (db *db) UseRecoveryCode(ctx context.Context, id openapi_types.UUID, codeHash []byte) error {
+	query := `WITH rc AS (
+		UPDATE mfa_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = (SELECT id FROM users WHERE uid = $1) AND code_hash = $2 AND used_at IS NULL
+		RETURNING id
+	)
+	INSERT INTO mfa_events (user_uid, event, actor_kind, actor_id)
+	SELECT $1, $3, 'user', $1::text FROM rc`
+
+	tag, err := db.pool.Exec(ctx, query, id, codeHash, api.MFAEventRecoveryCodeUsed)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// CreateMFAChallenge stores the hash of a challenge of a login awaiting its second factor. Returns ErrNotFound if
+// the user has not enabled MFA.
+func (db *db) CreateMFAChallenge(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error {
+	query := `INSERT INTO mfa_challenges (user_id, token_hash, expires_at)
+		SELECT m.user_id, $2, $3 FROM user_mfa m JOIN users u ON u.id = m.user_id
+		WHERE u.uid = $1 AND m.enabled_at IS NOT NULL`
+
+	tag, err := db.pool.Exec(ctx, query, id, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create MFA challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetMFAChallenge returns the challenge with the given hash. Returns ErrInvalidToken if there is no such challenge.
+func (db *db) GetMFAChallenge(ctx context.Context, tokenHash []byte) (*api.MFAChallenge, error) {
+	query := `SELECT u.uid, c.attempts, c.expires_at
+	FROM mfa_challenges c
+	JOIN users u ON u.id = c.user_id
+	WHERE c.token_hash = $1`
+
+	var challenge api.MFAChallenge
+	err := db.pool.QueryRow(ctx, query, tokenHash).Scan(&challenge.UserID, &challenge.Attempts, &challenge.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get MFA challenge: %w", err)
+	}
+
+	return &challenge, nil
+}
+
+// RecordMFAChallengeFailure counts a wrong code entered for a challenge.
+func (db *db) RecordMFAChallengeFailure(ctx context.Context, tokenHash []byte) error {
+	query := "UPDATE mfa_challenges SET attempts = attempts + 1 WHERE token_hash = $1"
+
+	if _, err := db.pool.Exec(ctx, query, tokenHash); err != nil {
+		return fmt.Errorf("failed to record MFA challenge failure: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMFAChallenge removes a completed challenge. Returns ErrInvalidToken if it was already removed.
+func (db *db) DeleteMFAChallenge(ctx context.Context, tokenHash []byte) error {
+	tag, err := db.pool.Exec(ctx, "DELETE FROM mfa_challenges WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete MFA challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrInvalidToken
+	}
+
+	return nil
+}
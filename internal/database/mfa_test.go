@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+func TestGetMFA(t *testing.T) {
+	t.Run("Enabled", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		enabledAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*[]byte) = []byte("secret")
+				*args.Get(1).(**time.Time) = &enabledAt
+				*args.Get(2).(*int) = 8
+				*args.Get(3).(*bool) = true
+			}).
+			Return(nil)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(mr)
+
+		state, err := db.GetMFA(context.Background(), testUID(1))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &api.MFA{Secret: []byte("secret"), EnabledAt: &enabledAt, RecoveryCodesRemaining: 8, Required: true}, state)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(2)}).Return(mr)
+
+		_, err := db.GetMFA(context.Background(), testUID(2))
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+func TestStartTOTPEnrollment(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected error
+	}{
+		{name: "Pending enrollment stored", tag: "INSERT 0 1"},
+		{name: "Already enabled", tag: "INSERT 0 0", expected: ownErrors.ErrMFAEnabled},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+
+			mr := new(MockRow)
+			mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+				*args.Get(0).(*int64) = 7
+			}).Return(nil)
+			mp.On("QueryRow", context.Background(), "SELECT id FROM users WHERE uid = $1", []any{testUID(1)}).Return(mr)
+			mp.On("Exec", context.Background(), mock.Anything, []any{int64(7), []byte("secret")}).
+				Return(pgconn.NewCommandTag(tc.tag), nil)
+
+			err := db.StartTOTPEnrollment(context.Background(), testUID(1), []byte("secret"))
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	event := &api.MFAEvent{Event: api.MFAEventEnrolled, ActorKind: "user", ActorID: testUID(1).String()}
+	hashes := [][]byte{[]byte("a"), []byte("b")}
+
+	t.Run("Enabled", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything,
+			[]any{testUID(1), int64(42), hashes, "enrolled", "user", testUID(1).String(), ""}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+		assert.NoError(t, db.EnableMFA(context.Background(), testUID(1), 42, hashes, event))
+	})
+
+	t.Run("No pending enrollment", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil)
+
+		assert.ErrorIs(t, db.EnableMFA(context.Background(), testUID(1), 42, hashes, event), ownErrors.ErrMFAEnabled)
+	})
+}
+
+func TestResetMFA(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), "reset", "user", testUID(9).String(), "Lost phone"}).
+		Return(pgconn.NewCommandTag("INSERT 0 0"), nil)
+
+	err := db.ResetMFA(context.Background(), testUID(1),
+		&api.MFAEvent{Event: api.MFAEventReset, ActorKind: "user", ActorID: testUID(9).String(), Reason: "Lost phone"})
+
+	assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+}
+
+func TestUseTOTPStep(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), int64(42)}).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	assert.ErrorIs(t, db.UseTOTPStep(context.Background(), testUID(1), 42), ownErrors.ErrInvalidToken)
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected error
+	}{
+		{name: "Unused code", tag: "INSERT 0 1"},
+		{name: "Unknown or used code", tag: "INSERT 0 0", expected: ownErrors.ErrInvalidToken},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+			mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), []byte("hash"), "recovery_code_used"}).
+				Return(pgconn.NewCommandTag(tc.tag), nil)
+
+			err := db.UseRecoveryCode(context.Background(), testUID(1), []byte("hash"))
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestGetMFAChallenge(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+	mp.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("hash")}).Return(mr)
+
+	_, err := db.GetMFAChallenge(context.Background(), []byte("hash"))
+
+	assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+}
+
+func TestDeleteMFAChallenge(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), "DELETE FROM mfa_challenges WHERE token_hash = $1", []any{[]byte("hash")}).
+		Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	assert.ErrorIs(t, db.DeleteMFAChallenge(context.Background(), []byte("hash")), ownErrors.ErrInvalidToken)
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+const organizationColumns = "id, slug, name, created_at"
+
+// ResolveOrg returns the ID of the organization with the given ID or slug. Returns ErrNotFound if there is no such
+// organization.
+func (db *db) ResolveOrg(ctx context.Context, ref string) (openapi_types.UUID, error) {
+	query := "SELECT id FROM organizations WHERE slug = $1"
+	arg := any(strings.ToLower(ref))
+	if id, err := uuid.Parse(ref); err == nil {
+		query = "SELECT id FROM organizations WHERE id = $1"
+		arg = id
+	}
+
+	var id openapi_types.UUID
+	if err := db.pool.QueryRow(ctx, query, arg).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ownErrors.ErrNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListOrganizations returns all organizations ordered by slug.
+func (db *db) ListOrganizations(ctx context.Context) ([]api.Organization, error) {
+	rows, err := db.pool.Query(ctx, "SELECT "+organizationColumns+" FROM organizations ORDER BY slug")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := make([]api.Organization, 0)
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, *org)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// CreateOrganization creates an organization. Returns ErrOrganizationAlreadyExists if the slug is taken.
+func (db *db) CreateOrganization(ctx context.Context, o *api.OrganizationRequest) (*api.Organization, error) {
+	query := "INSERT INTO organizations (slug, name) VALUES ($1, $2) RETURNING " + organizationColumns
+
+	org, err := scanOrganization(db.pool.QueryRow(ctx, query, o.Slug, o.Name))
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrOrganizationAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// GetOrganization returns an organization. Returns ErrNotFound if there is no such organization.
+func (db *db) GetOrganization(ctx context.Context, id openapi_types.UUID) (*api.Organization, error) {
+	org, err := scanOrganization(db.pool.QueryRow(ctx, "SELECT "+organizationColumns+" FROM organizations WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// scanOrganization reads a row selected with organizationColumns.
+func scanOrganization(row pgx.Row) (*api.Organization, error) {
+	var org api.Organization
+	if err := row.Scan(&org.Id, &org.Slug, &org.Name, &org.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
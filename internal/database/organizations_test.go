@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+func TestResolveOrg(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         string
+		query       string
+		arg         any
+		scanErr     error
+		expectedErr error
+	}{
+		{
+			name:  "By slug",
+			ref:   "Acme",
+			query: "SELECT id FROM organizations WHERE slug = $1",
+			arg:   "acme",
+		},
+		{
+			name:  "By ID",
+			ref:   testUID(5).String(),
+			query: "SELECT id FROM organizations WHERE id = $1",
+			arg:   testUID(5),
+		},
+		{
+			name:        "Unknown",
+			ref:         "nobody",
+			query:       "SELECT id FROM organizations WHERE slug = $1",
+			arg:         "nobody",
+			scanErr:     sql.ErrNoRows,
+			expectedErr: ownErrors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := new(MockPool)
+			db := &db{pool: mp}
+
+			mr := new(MockRow)
+			call := mr.On("Scan", mock.Anything)
+			if tt.scanErr != nil {
+				call.Return(tt.scanErr)
+			} else {
+				call.Run(func(args mock.Arguments) {
+					*args.Get(0).(*uuid.UUID) = testUID(5)
+				}).Return(nil)
+			}
+			mp.On("QueryRow", context.Background(), tt.query, []any{tt.arg}).Return(mr)
+
+			id, err := db.ResolveOrg(context.Background(), tt.ref)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testUID(5), id)
+			}
+			mp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateOrganization(t *testing.T) {
+	t.Run("Created", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*uuid.UUID) = testUID(5)
+				*args.Get(1).(*string) = "acme"
+				*args.Get(2).(*string) = "Acme"
+				*args.Get(3).(*time.Time) = createdAt
+			}).Return(nil)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{"acme", "Acme"}).Return(mr)
+
+		org, err := db.CreateOrganization(context.Background(), &api.OrganizationRequest{Slug: "acme", Name: "Acme"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &api.Organization{Id: testUID(5), Slug: "acme", Name: "Acme", CreatedAt: createdAt}, org)
+	})
+
+	t.Run("Slug taken", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{"acme", "Acme"}).Return(mr)
+
+		org, err := db.CreateOrganization(context.Background(), &api.OrganizationRequest{Slug: "acme", Name: "Acme"})
+
+		assert.ErrorIs(t, err, ownErrors.ErrOrganizationAlreadyExists)
+		assert.Nil(t, org)
+	})
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/ownErrors"
+)
+
+// PasswordResetUsage counts the password reset requests of an email address, compared in canonical form, and of a
+// client IP since the given time.
+func (db *db) PasswordResetUsage(ctx context.Context, address, clientIP string, since time.Time) (*api.PasswordResetUsage, error) {
+	// Invalid addresses cannot have requests, so only the client IP is counted for them.
+	canonical, _ := email.Canonicalize(address, db.emailOptions)
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT
+		count(*) FILTER (WHERE email_canonical = $1), min(created_at) FILTER (WHERE email_canonical = $1),
+		count(*) FILTER (WHERE client_ip = $2), min(created_at) FILTER (WHERE client_ip = $2)
+	FROM password_reset_requests
+	WHERE created_at > $3 AND (email_canonical = $1 OR client_ip = $2)`
+
+	var usage api.PasswordResetUsage
+	err = db.pool.QueryRow(ctx, query, lookup, clientIP, since).Scan(
+		&usage.EmailRequests,
+		&usage.FirstEmailRequest,
+		&usage.IPRequests,
+		&usage.FirstIPRequest,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count password reset requests: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// CreatePasswordReset records a password reset request and stores its token for the user with the email address,
+// compared in canonical form. Returns the user, or ErrNotFound if there is no such user; the request is recorded
+// either way.
+func (db *db) CreatePasswordReset(ctx context.Context, r *api.PasswordReset) (*api.User, error) {
+	canonical, err := email.Canonicalize(r.Email, db.emailOptions)
+	if err != nil {
+		return nil, ownErrors.ErrNotFound
+	}
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := "INSERT INTO password_reset_requests (email_canonical, client_ip) VALUES ($1, $2)"
+	if _, err = tx.Exec(ctx, query, lookup, r.ClientIP); err != nil {
+		return nil, fmt.Errorf("failed to record password reset request: %w", err)
+	}
+
+	var user storedUser
+	query = "SELECT " + userColumns + " FROM users WHERE email_canonical = $1"
+	err = tx.QueryRow(ctx, query, lookup).Scan(userFields(&user)...)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		if err = tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil, ownErrors.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	query = "INSERT INTO password_resets (user_id, token_hash, expires_at) SELECT id, $2, $3 FROM users WHERE uid = $1"
+	if _, err = tx.Exec(ctx, query, user.Id, r.TokenHash, r.ExpiresAt); err != nil {
+		return nil, fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// GetPasswordResetUser returns the user of a password reset token. Returns ErrInvalidToken if the token is unknown,
+// used or expired.
+func (db *db) GetPasswordResetUser(ctx context.Context, tokenHash []byte) (*api.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+	WHERE id = (SELECT pr.user_id FROM password_resets pr
+		WHERE pr.token_hash = $1 AND pr.used_at IS NULL AND pr.expires_at > CURRENT_TIMESTAMP)`
+
+	var user storedUser
+	if err := db.pool.QueryRow(ctx, query, tokenHash).Scan(userFields(&user)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get password reset: %w", err)
+	}
+
+	if err := db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// ResetPassword uses up a password reset token and replaces the password of its user. All other reset tokens of the
+// user are invalidated, a login lockout and pending MFA challenges are cleared and all refresh tokens are revoked.
+// Returns ErrInvalidToken if the token is unknown, used or expired.
+func (db *db) ResetPassword(ctx context.Context, tokenHash []byte, passwordHash string) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE password_resets SET used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id`
+
+	var userID int64
+	if err = tx.QueryRow(ctx, query, tokenHash).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ownErrors.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to use password reset: %w", err)
+	}
+
+	query = "UPDATE password_resets SET used_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND used_at IS NULL"
+	if _, err = tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password resets: %w", err)
+	}
+
+	query = `INSERT INTO user_credentials (user_id, password_hash) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			password_hash = EXCLUDED.password_hash,
+			failed_attempts = 0,
+			locked_until = NULL,
+			password_changed_at = CURRENT_TIMESTAMP`
+	if _, err = tx.Exec(ctx, query, userID, passwordHash); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	query = "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL"
+	if _, err = tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM mfa_challenges WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("failed to delete MFA challenges: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+func TestPasswordResetUsage(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	since := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	first := since.Add(time.Minute)
+
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*int) = 2
+		*args.Get(1).(**time.Time) = &first
+		*args.Get(2).(*int) = 7
+		*args.Get(3).(**time.Time) = &since
+	}).Return(nil)
+	mp.On("QueryRow", context.Background(), mock.Anything, []any{"john@example.com", "192.0.2.1", since}).Return(mr)
+
+	usage, err := db.PasswordResetUsage(context.Background(), " John@Example.com", "192.0.2.1", since)
+
+	require.NoError(t, err)
+	assert.Equal(t, &api.PasswordResetUsage{EmailRequests: 2, FirstEmailRequest: &first, IPRequests: 7, FirstIPRequest: &since}, usage)
+	mp.AssertExpectations(t)
+}
+
+func TestCreatePasswordReset(t *testing.T) {
+	reset := &api.PasswordReset{Email: "John@example.com", ClientIP: "192.0.2.1", TokenHash: []byte("hash"),
+		ExpiresAt: time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)}
+	recordQuery := "INSERT INTO password_reset_requests (email_canonical, client_ip) VALUES ($1, $2)"
+	userQuery := "SELECT " + userColumns + " FROM users WHERE email_canonical = $1"
+
+	t.Run("Known address", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), recordQuery, []any{"john@example.com", "192.0.2.1"}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("QueryRow", context.Background(), userQuery, []any{"john@example.com"}).
+			Return(scannedUserRow(testUID(1), "John@example.com"))
+		tx.On("Exec", context.Background(), mock.Anything, []any{testUID(1), []byte("hash"), reset.ExpiresAt}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		user, err := db.CreatePasswordReset(context.Background(), reset)
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Unknown address is recorded", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		noUser := new(MockRow)
+		noUser.On("Scan", userScanArgs()...).Return(sql.ErrNoRows)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("Exec", context.Background(), recordQuery, []any{"john@example.com", "192.0.2.1"}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("QueryRow", context.Background(), userQuery, []any{"john@example.com"}).Return(noUser)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		user, err := db.CreatePasswordReset(context.Background(), reset)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestGetPasswordResetUser(t *testing.T) {
+	query := `SELECT ` + userColumns + ` FROM users
+	WHERE id = (SELECT pr.user_id FROM password_resets pr
+		WHERE pr.token_hash = $1 AND pr.used_at IS NULL AND pr.expires_at > CURRENT_TIMESTAMP)`
+
+	t.Run("Valid token", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("QueryRow", context.Background(), query, []any{[]byte("hash")}).
+			Return(scannedUserRow(testUID(1), "john@example.com"))
+
+		user, err := db.GetPasswordResetUser(context.Background(), []byte("hash"))
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		assert.Equal(t, "John", user.FirstName)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", userScanArgs()...).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), query, []any{[]byte("hash")}).Return(mr)
+
+		user, err := db.GetPasswordResetUser(context.Background(), []byte("hash"))
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	useQuery := `UPDATE password_resets SET used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id`
+	revokeQuery := "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL"
+
+	t.Run("Password replaced and sessions revoked", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		used := new(MockRow)
+		used.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+		}).Return(nil)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), useQuery, []any{[]byte("hash")}).Return(used)
+		tx.On("Exec", context.Background(), mock.Anything, []any{int64(7)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Exec", context.Background(), mock.Anything, []any{int64(7), "argon2"}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		err := db.ResetPassword(context.Background(), []byte("hash"), "argon2")
+
+		require.NoError(t, err)
+		tx.AssertCalled(t, "Exec", context.Background(), revokeQuery, []any{int64(7)})
+		tx.AssertCalled(t, "Exec", context.Background(), "DELETE FROM mfa_challenges WHERE user_id = $1", []any{int64(7)})
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Used or expired token", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		used := new(MockRow)
+		used.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), useQuery, []any{[]byte("hash")}).Return(used)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.ResetPassword(context.Background(), []byte("hash"), "argon2")
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Revocation failure rolls back", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		used := new(MockRow)
+		used.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+		}).Return(nil)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), useQuery, []any{[]byte("hash")}).Return(used)
+		tx.On("Exec", context.Background(), revokeQuery, []any{int64(7)}).Return(pgconn.CommandTag{}, errors.New("db down"))
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		err := db.ResetPassword(context.Background(), []byte("hash"), "argon2")
+
+		assert.ErrorContains(t, err, "failed to revoke refresh tokens")
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
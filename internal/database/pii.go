@@ -0,0 +1,389 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/pii"
+	"go-users/internal/tenant"
+)
+
+// UseKeyring encrypts the names, email addresses, phone numbers and display names of users and the addresses of their
+// invitations and verification mails with the given keyring from now on and decrypts them transparently. It must be
+// called before the database is used.
+func (db *db) UseKeyring(k *pii.Keyring) {
+	db.pii = k
+}
+
+// ListPIIKeys returns all personal data keys.
+func (db *db) ListPIIKeys(ctx context.Context) ([]pii.Key, error) {
+	rows, err := db.pool.Query(ctx, "SELECT id, purpose, wrapped_key, kek_id, created_at FROM pii_keys ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal data keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]pii.Key, 0)
+	for rows.Next() {
+		var k pii.Key
+		if err = rows.Scan(&k.ID, &k.Purpose, &k.Wrapped, &k.KEKID, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan personal data key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list personal data keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreatePIIKey stores a personal data key. An index key is not stored if one already exists.
+func (db *db) CreatePIIKey(ctx context.Context, key *pii.Key) error {
+	query := `INSERT INTO pii_keys (purpose, wrapped_key, kek_id) VALUES ($1, $2, $3)
+		ON CONFLICT (purpose) WHERE purpose = 'index' DO NOTHING`
+
+	if _, err := db.pool.Exec(ctx, query, key.Purpose, key.Wrapped, key.KEKID); err != nil {
+		return fmt.Errorf("failed to create personal data key: %w", err)
+	}
+
+	return nil
+}
+
+// RewrapPIIKey replaces the wrapped form of a personal data key after the key-encryption key changed.
+func (db *db) RewrapPIIKey(ctx context.Context, id int32, wrapped []byte, kekID string) error {
+	if _, err := db.pool.Exec(ctx, "UPDATE pii_keys SET wrapped_key = $2, kek_id = $3 WHERE id = $1", id, wrapped, kekID); err != nil {
+		return fmt.Errorf("failed to rewrap personal data key: %w", err)
+	}
+
+	return nil
+}
+
+// errNoKeyring is returned for users whose personal data is encrypted while no key-encryption key is configured.
+var errNoKeyring = errors.New("personal data is encrypted but no key-encryption key is configured")
+
+// ReencryptUsers encrypts up to limit users of all organizations ordered by public ID after the given one with the
+// current data key. Only unencrypted users are taken if unencrypted is set, otherwise also those encrypted with an
+// older data key, together with their invitations and verification mails. Unencrypted users get the blind index of
+// their canonical address. Users whose personal data cannot be decrypted are skipped and reported, users locked by
+// other transactions are skipped, and updated_at is kept.
+func (db *db) ReencryptUsers(ctx context.Context, after uuid.UUID, limit int, unencrypted bool) (*pii.Reencryption, error) {
+	if db.pii == nil {
+		return &pii.Reencryption{}, nil
+	}
+	key, err := db.pii.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tenant.WithAllOrgs(ctx)
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, setTenantQuery, "app.keep_updated_at", "on"); err != nil {
+		return nil, fmt.Errorf("failed to keep updated_at: %w", err)
+	}
+
+	query := `SELECT id, uid, pii_key_id IS NOT NULL, first_name, last_name, email, pending_email, email_canonical,
+			phone, display_name
+		FROM users
+		WHERE (pii_key_id IS NULL OR (NOT $1 AND pii_key_id <> $2)) AND uid > $3
+		ORDER BY uid
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(ctx, query, unencrypted, key.ID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users to encrypt: %w", err)
+	}
+	defer rows.Close()
+
+	var users []piiRow
+	for rows.Next() {
+		var r piiRow
+		err = rows.Scan(&r.id, &r.uid, &r.sealed, &r.firstName, &r.lastName, &r.email, &r.pendingEmail, &r.emailCanonical,
+			&r.phone, &r.displayName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user to encrypt: %w", err)
+		}
+		users = append(users, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to select users to encrypt: %w", err)
+	}
+	rows.Close()
+
+	result := &pii.Reencryption{Taken: len(users)}
+	query = `UPDATE users SET first_name = $2, last_name = $3, email = $4, pending_email = $5, email_canonical = $6,
+		phone = $7, display_name = $8, pii_key_id = $9
+		WHERE id = $1`
+	for _, r := range users {
+		result.Last = r.uid
+		err = db.openPIIRow(ctx, &r)
+		if err == nil {
+			err = db.reencryptAddresses(ctx, tx, key, r.id)
+		}
+		if err != nil {
+			if errors.Is(err, pii.ErrInvalidCiphertext) {
+				result.Failed = append(result.Failed, r.uid)
+				continue
+			}
+			return nil, fmt.Errorf("failed to decrypt user %s: %w", r.uid, err)
+		}
+		if r.emailCanonical != nil && !r.sealed {
+			index, err := db.pii.Index(*r.emailCanonical)
+			if err != nil {
+				return nil, err
+			}
+			r.emailCanonical = &index
+		}
+
+		s := &piiSealer{key: key, row: r.uid}
+		args := []any{r.id, s.seal("first_name", r.firstName), s.seal("last_name", r.lastName), s.seal("email", r.email),
+			s.sealOptional("pending_email", r.pendingEmail), r.emailCanonical, s.sealOptional("phone", r.phone),
+			s.sealOptional("display_name", r.displayName), key.ID}
+		if s.err != nil {
+			return nil, s.err
+		}
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to encrypt user %s: %w", r.uid, err)
+		}
+		result.Encrypted++
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// addressTables are the tables keeping the addresses that mails to a user were sent to, with the column name their
+// ciphertexts are bound to.
+var addressTables = []struct {
+	table  string
+	column string
+}{
+	{"invitations", "invitations.email"},
+	{"email_verifications", "email_verifications.email"},
+}
+
+// reencryptAddresses encrypts the invitations and verification mails of the user with the given internal ID with the
+// key unless they already are.
+func (db *db) reencryptAddresses(ctx context.Context, tx pgx.Tx, key *pii.DataKey, userID int64) error {
+	for _, t := range addressTables {
+		query := `SELECT id, pii_key_id IS NOT NULL, email FROM ` + t.table + `
+			WHERE user_id = $1 AND (pii_key_id IS NULL OR pii_key_id <> $2)
+			FOR UPDATE`
+		rows, err := tx.Query(ctx, query, userID, key.ID)
+		if err != nil {
+			return fmt.Errorf("failed to select %s to encrypt: %w", t.table, err)
+		}
+		var addresses []sealedAddress
+		for rows.Next() {
+			var a sealedAddress
+			if err = rows.Scan(&a.id, &a.sealed, &a.email); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan %s to encrypt: %w", t.table, err)
+			}
+			addresses = append(addresses, a)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("failed to select %s to encrypt: %w", t.table, err)
+		}
+
+		for _, a := range addresses {
+			address, err := db.openAddress(ctx, t.column, a.id, a.sealed, a.email)
+			if err != nil {
+				return err
+			}
+			s := &piiSealer{key: key, row: a.id}
+			sealed := s.seal(t.column, address)
+			if s.err != nil {
+				return s.err
+			}
+			query = "UPDATE " + t.table + " SET email = $2, pii_key_id = $3 WHERE id = $1"
+			if _, err = tx.Exec(ctx, query, a.id, sealed, key.ID); err != nil {
+				return fmt.Errorf("failed to encrypt %s: %w", t.table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sealedAddress is an address of an invitation or verification mail as stored; sealed tells that it is encrypted.
+type sealedAddress struct {
+	id     uuid.UUID
+	sealed bool
+	email  string
+}
+
+// openAddress decrypts an address of an invitation or verification mail if it is encrypted.
+func (db *db) openAddress(ctx context.Context, column string, id uuid.UUID, sealed bool, value string) (string, error) {
+	if !sealed {
+		return value, nil
+	}
+	if db.pii == nil {
+		return "", errNoKeyring
+	}
+	return db.pii.Open(ctx, column, id, value)
+}
+
+// piiRow holds the personal data of a user as stored; sealed tells that it is encrypted.
+type piiRow struct {
+	id             int64
+	uid            uuid.UUID
+	sealed         bool
+	firstName      string
+	lastName       string
+	email          string
+	pendingEmail   *string
+	emailCanonical *string
+	phone          *string
+	displayName    *string
+}
+
+// openPIIRow decrypts the personal data of a user if it is encrypted.
+func (db *db) openPIIRow(ctx context.Context, r *piiRow) error {
+	if !r.sealed {
+		return nil
+	}
+	if db.pii == nil {
+		return errNoKeyring
+	}
+
+	var err error
+	if r.firstName, err = db.pii.Open(ctx, "first_name", r.uid, r.firstName); err != nil {
+		return err
+	}
+	if r.lastName, err = db.pii.Open(ctx, "last_name", r.uid, r.lastName); err != nil {
+		return err
+	}
+	if r.email, err = db.pii.Open(ctx, "email", r.uid, r.email); err != nil {
+		return err
+	}
+	if r.pendingEmail, err = db.openOptional(ctx, "pending_email", r.uid, r.pendingEmail); err != nil {
+		return err
+	}
+	if r.phone, err = db.openOptional(ctx, "phone", r.uid, r.phone); err != nil {
+		return err
+	}
+	r.displayName, err = db.openOptional(ctx, "display_name", r.uid, r.displayName)
+	return err
+}
+
+// openOptional decrypts the value of a nullable column of the user with the given public ID.
+func (db *db) openOptional(ctx context.Context, column string, row uuid.UUID, value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	opened, err := db.pii.Open(ctx, column, row, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &opened, nil
+}
+
+// storedUser is a user as scanned with userFields; sealed tells that their personal data is encrypted.
+type storedUser struct {
+	api.User
+	sealed bool
+}
+
+// openUser decrypts the personal data of a scanned user if it is encrypted.
+func (db *db) openUser(ctx context.Context, u *storedUser) error {
+	r := piiRow{uid: u.Id, sealed: u.sealed, firstName: u.FirstName, lastName: u.LastName, email: string(u.Email),
+		pendingEmail: (*string)(u.PendingEmail), phone: u.Phone, displayName: u.DisplayName}
+	if err := db.openPIIRow(ctx, &r); err != nil {
+		return fmt.Errorf("failed to decrypt user: %w", err)
+	}
+
+	u.FirstName, u.LastName, u.Email = r.firstName, r.lastName, openapi_types.Email(r.email)
+	u.PendingEmail = (*openapi_types.Email)(r.pendingEmail)
+	u.Phone, u.DisplayName = r.phone, r.displayName
+	return nil
+}
+
+// emailLookup returns the value of email_canonical for a canonical address: the address itself, or its blind index
+// if personal data is encrypted.
+func (db *db) emailLookup(canonical string) (string, error) {
+	if db.pii == nil {
+		return canonical, nil
+	}
+	return db.pii.Index(canonical)
+}
+
+// piiSealer seals the personal data of a row written by one statement with a single data key, whose ID is stored
+// with the row. Without encryption values are written as they are. The first error is kept in err, so that the
+// arguments of a statement can be built before checking it.
+type piiSealer struct {
+	key *pii.DataKey
+	row uuid.UUID
+	err error
+}
+
+// sealer returns the sealer of a statement writing all personal data of the user with the given public ID.
+func (db *db) sealer(row uuid.UUID) (*piiSealer, error) {
+	if db.pii == nil {
+		return &piiSealer{}, nil
+	}
+
+	key, err := db.pii.Current()
+	if err != nil {
+		return nil, err
+	}
+	return &piiSealer{key: key, row: row}, nil
+}
+
+// rowSealer returns the sealer of a statement writing only some personal data of the user with the given public ID.
+// The values follow how the row is stored: sealed if it is encrypted, plaintext otherwise.
+func (db *db) rowSealer(row uuid.UUID, sealed bool) (*piiSealer, error) {
+	if !sealed {
+		return &piiSealer{}, nil
+	}
+	if db.pii == nil {
+		return nil, errNoKeyring
+	}
+	return db.sealer(row)
+}
+
+// seal returns the value to store in a column.
+func (s *piiSealer) seal(column, value string) string {
+	if s.key == nil || s.err != nil {
+		return value
+	}
+
+	sealed, err := s.key.Seal(column, s.row, value)
+	if err != nil {
+		s.err = fmt.Errorf("failed to encrypt %s: %w", column, err)
+	}
+	return sealed
+}
+
+// sealOptional returns the value to store in a nullable column. Empty values are kept, as they clear the column.
+func (s *piiSealer) sealOptional(column string, value *string) *string {
+	if value == nil || *value == "" {
+		return value
+	}
+	sealed := s.seal(column, *value)
+	return &sealed
+}
+
+// keyID returns the data key ID stored with rows whose personal data was all written by the sealer, or nil without
+// encryption.
+func (s *piiSealer) keyID() *int32 {
+	if s.key == nil {
+		return nil
+	}
+	return &s.key.ID
+}
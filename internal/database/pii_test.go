@@ -0,0 +1,479 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/config"
+	"go-users/internal/pii"
+)
+
+// memoryPIIKeys is an in-memory store of personal data keys.
+type memoryPIIKeys struct {
+	keys []pii.Key
+}
+
+func (s *memoryPIIKeys) ListPIIKeys(context.Context) ([]pii.Key, error) {
+	return s.keys, nil
+}
+
+func (s *memoryPIIKeys) CreatePIIKey(_ context.Context, key *pii.Key) error {
+	stored := *key
+	stored.ID = int32(len(s.keys) + 1)
+	stored.CreatedAt = time.Now()
+	s.keys = append(s.keys, stored)
+	return nil
+}
+
+func (s *memoryPIIKeys) RewrapPIIKey(context.Context, int32, []byte, string) error {
+	return nil
+}
+
+func (s *memoryPIIKeys) ReencryptUsers(context.Context, uuid.UUID, int, bool) (*pii.Reencryption, error) {
+	return &pii.Reencryption{}, nil
+}
+
+// testPIIKeyring returns a keyring with loaded keys.
+func testPIIKeyring(t *testing.T) *pii.Keyring {
+	kek := make([]byte, 32)
+	_, err := rand.Read(kek)
+	require.NoError(t, err)
+
+	k, err := pii.NewKeyring(&memoryPIIKeys{}, slog.New(slog.NewTextHandler(io.Discard, nil)), config.PII{
+		KEK:              base64.StdEncoding.EncodeToString(kek),
+		RotationInterval: 86400,
+		CheckInterval:    60,
+		ReencryptBatch:   10,
+	})
+	require.NoError(t, err)
+	require.NoError(t, k.Refresh(context.Background()))
+	return k
+}
+
+// sealedUserRow returns a scanned encrypted user whose names and address are the given stored values.
+func sealedUserRow(id openapi_types.UUID, firstName, lastName, email string) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", userScanArgs()...).Run(func(args mock.Arguments) {
+		*args.Get(0).(*openapi_types.UUID) = id
+		*args.Get(1).(*string) = firstName
+		*args.Get(2).(*string) = lastName
+		*args.Get(3).(*openapi_types.Email) = openapi_types.Email(email)
+		*args.Get(len(args) - 1).(*bool) = true
+	}).Return(nil)
+	return mr
+}
+
+func TestCreateUser_Encrypted(t *testing.T) {
+	keys := testPIIKeyring(t)
+	mp := new(MockPool)
+	db := &db{pool: mp, pii: keys}
+	index, err := keys.Index("john@example.com")
+	require.NoError(t, err)
+
+	var stored []any
+	mp.On("QueryRow", context.Background(), attributeSchemaQuery, mock.Anything).Return(attributeSchemaRow(nil))
+	mp.On("QueryRow", context.Background(), insertUserQuery, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(2).([]any)
+	}).Return(sealedUserRowFunc(func() *MockRow {
+		return sealedUserRow(stored[11].(uuid.UUID), stored[0].(string), stored[1].(string), stored[2].(string))
+	}))
+
+	phone, displayName := "+49 30 123456", "Johnny"
+	user, err := db.CreateUser(context.Background(), &api.UserRequest{FirstName: "John", LastName: "Doe", Email: "John@Example.com",
+		Phone: &phone, DisplayName: &displayName})
+
+	require.NoError(t, err)
+	for i, column := range []string{"first_name", "last_name", "email"} {
+		assert.True(t, strings.HasPrefix(stored[i].(string), "pii:"), "%s is encrypted", column)
+	}
+	sealedPhone, err := keys.Open(context.Background(), "phone", stored[11].(uuid.UUID), *stored[5].(*string))
+	require.NoError(t, err)
+	assert.Equal(t, "+4930123456", sealedPhone, "the phone number is normalized before it is encrypted")
+	sealedName, err := keys.Open(context.Background(), "display_name", stored[11].(uuid.UUID), *stored[8].(*string))
+	require.NoError(t, err)
+	assert.Equal(t, displayName, sealedName)
+	assert.Equal(t, index, stored[3], "the canonical address is stored as blind index")
+	current, err := keys.Current()
+	require.NoError(t, err)
+	assert.Equal(t, &current.ID, stored[10])
+	assert.Equal(t, "John", user.FirstName)
+	assert.Equal(t, "Doe", user.LastName)
+	assert.Equal(t, openapi_types.Email("John@Example.com"), user.Email)
+}
+
+// sealedUserRowFunc is a pgx.Row built when it is scanned, so that it can return the values a statement stored.
+type sealedUserRowFunc func() *MockRow
+
+func (f sealedUserRowFunc) Scan(dest ...any) error {
+	return f().Scan(dest...)
+}
+
+func TestGetUserByEmail_Encrypted(t *testing.T) {
+	keys := testPIIKeyring(t)
+	mp := new(MockPool)
+	db := &db{pool: mp, pii: keys}
+	index, err := keys.Index("john@example.com")
+	require.NoError(t, err)
+	key, err := keys.Current()
+	require.NoError(t, err)
+	firstName, err := key.Seal("first_name", testUID(1), "John")
+	require.NoError(t, err)
+	lastName, err := key.Seal("last_name", testUID(1), "Doe")
+	require.NoError(t, err)
+	email, err := key.Seal("email", testUID(1), "John@Example.com")
+	require.NoError(t, err)
+
+	mp.On("QueryRow", context.Background(), "SELECT "+userColumns+" FROM users WHERE email_canonical = $1", []any{index}).
+		Return(sealedUserRow(testUID(1), firstName, lastName, email))
+
+	user, err := db.GetUserByEmail(context.Background(), "JOHN@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, openapi_types.Email("John@Example.com"), user.Email)
+	assert.Equal(t, "John", user.FirstName)
+	mp.AssertExpectations(t)
+}
+
+func TestOpenUser(t *testing.T) {
+	keys := testPIIKeyring(t)
+	key, err := keys.Current()
+	require.NoError(t, err)
+	email, err := key.Seal("email", testUID(1), "john@example.com")
+	require.NoError(t, err)
+
+	t.Run("Unencrypted values are not opened", func(t *testing.T) {
+		db := &db{pii: keys}
+		u := &storedUser{User: api.User{Id: testUID(2), FirstName: "pii:AAAA", Email: "pii@example.com"}}
+
+		require.NoError(t, db.openUser(context.Background(), u))
+		assert.Equal(t, "pii:AAAA", u.FirstName, "the encryption of a row is told by its data key, not its values")
+	})
+
+	t.Run("Ciphertext of another user", func(t *testing.T) {
+		db := &db{pii: keys}
+		u := &storedUser{User: api.User{Id: testUID(2), Email: openapi_types.Email(email)}, sealed: true}
+
+		assert.ErrorIs(t, db.openUser(context.Background(), u), pii.ErrInvalidCiphertext)
+	})
+
+	t.Run("Encrypted without keyring", func(t *testing.T) {
+		db := &db{}
+		u := &storedUser{User: api.User{Id: testUID(1), Email: openapi_types.Email(email)}, sealed: true}
+
+		assert.ErrorIs(t, db.openUser(context.Background(), u), errNoKeyring)
+	})
+
+	t.Run("Profile fields opened", func(t *testing.T) {
+		db := &db{pii: keys}
+		empty := func(column string) string {
+			v, err := key.Seal(column, testUID(1), "")
+			require.NoError(t, err)
+			return v
+		}
+		phone, err := key.Seal("phone", testUID(1), "+4930123456")
+		require.NoError(t, err)
+		u := &storedUser{User: api.User{Id: testUID(1), FirstName: empty("first_name"), LastName: empty("last_name"),
+			Email: openapi_types.Email(email), Phone: &phone}, sealed: true}
+
+		require.NoError(t, db.openUser(context.Background(), u))
+		assert.Equal(t, "+4930123456", *u.Phone)
+		assert.Nil(t, u.DisplayName)
+	})
+}
+
+func TestGetInvitation_Encrypted(t *testing.T) {
+	keys := testPIIKeyring(t)
+	key, err := keys.Current()
+	require.NoError(t, err)
+	address, err := key.Seal("invitations.email", testUID(5), "John@Example.com")
+	require.NoError(t, err)
+
+	invitationRow := func(id openapi_types.UUID) *MockRow {
+		mr := new(MockRow)
+		mr.On("Scan", invitationScanArgs()...).Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = id
+			*args.Get(1).(*openapi_types.Email) = openapi_types.Email(address)
+			*args.Get(11).(*bool) = true
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Address decrypted", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp, pii: keys}
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(5)}).Return(invitationRow(testUID(5)))
+		mp.On("Query", context.Background(), mock.Anything, []any{testUID(5)}).Return(&fakeRows{}, nil)
+
+		invitation, err := db.GetInvitation(context.Background(), testUID(5))
+
+		require.NoError(t, err)
+		assert.Equal(t, openapi_types.Email("John@Example.com"), invitation.Email)
+	})
+
+	t.Run("Address of another invitation", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp, pii: keys}
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(6)}).Return(invitationRow(testUID(6)))
+
+		_, err := db.GetInvitation(context.Background(), testUID(6))
+
+		assert.ErrorIs(t, err, pii.ErrInvalidCiphertext)
+	})
+}
+
+func TestCreateEmailVerification_Encrypted(t *testing.T) {
+	keys := testPIIKeyring(t)
+	mp := new(MockPool)
+	db := &db{pool: mp, pii: keys}
+	v := &api.EmailVerification{ID: testUID(9), UserID: testUID(1), Email: "john@example.com", ExpiresAt: time.Now()}
+
+	var stored []any
+	mp.On("Exec", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(2).([]any)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	require.NoError(t, db.CreateEmailVerification(context.Background(), v))
+
+	address, err := keys.Open(context.Background(), "email_verifications.email", testUID(9), stored[2].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", address)
+	current, err := keys.Current()
+	require.NoError(t, err)
+	assert.Equal(t, &current.ID, stored[3])
+}
+
+func TestExportUserData_Encrypted(t *testing.T) {
+	keys := testPIIKeyring(t)
+	key, err := keys.Current()
+	require.NoError(t, err)
+	sent, err := key.Seal("email_verifications.email", testUID(9), "john@example.com")
+	require.NoError(t, err)
+	invited, err := key.Seal("invitations.email", testUID(5), "John@Example.com")
+	require.NoError(t, err)
+	index, err := keys.Index("john@example.com")
+	require.NoError(t, err)
+
+	mp := new(MockPool)
+	mr := new(MockRow)
+	db := &db{pool: mp, pii: keys}
+	mp.On("QueryRow", context.Background(), userDataQuery, []any{testUID(1)}).Return(mr)
+	mr.On("Scan", append(userScanArgs(), mock.Anything)...).Run(func(args mock.Arguments) {
+		*args.Get(0).(*openapi_types.UUID) = testUID(1)
+		*args.Get(len(args) - 1).(*[]byte) = []byte(`{"sessions": [], "roles": [], "groups": [], "api_keys": [],
+			"email_verifications": [{"id": "` + testUID(9).String() + `", "email": "` + sent + `", "sealed": true,
+				"created_at": "2024-05-01T12:00:00Z"}, {"id": "` + testUID(10).String() + `", "email": "old@example.com",
+				"sealed": false, "created_at": "2024-04-01T12:00:00Z"}],
+			"invitations": [{"id": "` + testUID(5).String() + `", "email": "` + invited + `", "sealed": true,
+				"invited_by_kind": "user", "invited_by_id": "admin", "created_at": "2024-04-01T12:00:00Z"}],
+			"status_events": [], "mfa_events": [], "policy_decisions": [], "email_canonical": "` + index + `"}`)
+	}).Return(nil)
+	mp.On("Query", context.Background(), userJobsQuery, mock.Anything).Return(&fakeRows{rows: []func(dest ...any) error{
+		jobRow(3, "users.import", []byte(`{"users": [{"email": "John@Example.com"}, {"email": "jane@example.com"}]}`), nil),
+	}}, nil)
+
+	export, err := db.ExportUserData(context.Background(), testUID(1))
+
+	require.NoError(t, err)
+	require.Len(t, export.EmailVerifications, 2)
+	assert.Equal(t, "john@example.com", export.EmailVerifications[0].Email)
+	assert.Equal(t, "old@example.com", export.EmailVerifications[1].Email)
+	require.Len(t, export.Invitations, 1)
+	assert.Equal(t, "John@Example.com", export.Invitations[0].Email)
+	require.Len(t, export.Jobs, 1)
+	assert.Equal(t, []map[string]interface{}{{"email": "John@Example.com"}}, export.Jobs[0].Records)
+}
+
+func TestReencryptUsers(t *testing.T) {
+	ctx := context.Background()
+	keys := testPIIKeyring(t)
+	current, err := keys.Current()
+	require.NoError(t, err)
+	index, err := keys.Index("john@example.com")
+	require.NoError(t, err)
+	updateQuery := `UPDATE users SET first_name = $2, last_name = $3, email = $4, pending_email = $5, email_canonical = $6,
+		phone = $7, display_name = $8, pii_key_id = $9
+		WHERE id = $1`
+	noAddresses := func(tx *MockTx, userID int64) {
+		tx.On("Query", mock.Anything, mock.Anything, []any{userID, current.ID}).Return(&fakeRows{}, nil)
+	}
+
+	t.Run("Unencrypted user encrypted", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp, pii: keys}
+		canonical, phone := "john@example.com", "+4930123456"
+		rows := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*int64) = 7
+				*dest[1].(*uuid.UUID) = testUID(7)
+				*dest[3].(*string) = "John"
+				*dest[4].(*string) = "Doe"
+				*dest[5].(*string) = "John@Example.com"
+				*dest[7].(**string) = &canonical
+				*dest[8].(**string) = &phone
+				return nil
+			},
+		}}
+		invitations := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*uuid.UUID) = testUID(70)
+				*dest[2].(*string) = "John@Example.com"
+				return nil
+			},
+		}}
+
+		var stored, invitation []any
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("Exec", mock.Anything, setTenantQuery, []any{"app.keep_updated_at", "on"}).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+		tx.On("Query", mock.Anything, mock.Anything, []any{true, current.ID, uuid.Nil, 10}).Return(rows, nil)
+		tx.On("Query", mock.Anything, mock.MatchedBy(func(q string) bool {
+			return strings.Contains(q, "FROM invitations")
+		}), []any{int64(7), current.ID}).Return(invitations, nil)
+		noAddresses(tx, 7)
+		tx.On("Exec", mock.Anything, "UPDATE invitations SET email = $2, pii_key_id = $3 WHERE id = $1", mock.Anything).
+			Run(func(args mock.Arguments) {
+				invitation = args.Get(2).([]any)
+			}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Exec", mock.Anything, updateQuery, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]any)
+		}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		r, err := db.ReencryptUsers(ctx, uuid.Nil, 10, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, &pii.Reencryption{Taken: 1, Encrypted: 1, Last: testUID(7)}, r)
+		assert.True(t, rows.closed)
+		require.Len(t, stored, 9)
+		assert.Equal(t, int64(7), stored[0])
+		email, err := keys.Open(ctx, "email", testUID(7), stored[3].(string))
+		require.NoError(t, err)
+		assert.Equal(t, "John@Example.com", email)
+		assert.Equal(t, (*string)(nil), stored[4])
+		assert.Equal(t, &index, stored[5])
+		sealedPhone, err := keys.Open(ctx, "phone", testUID(7), *stored[6].(*string))
+		require.NoError(t, err)
+		assert.Equal(t, phone, sealedPhone)
+		assert.Equal(t, (*string)(nil), stored[7])
+		assert.Equal(t, current.ID, stored[8])
+		require.Len(t, invitation, 3)
+		address, err := keys.Open(ctx, "invitations.email", testUID(70), invitation[1].(string))
+		require.NoError(t, err)
+		assert.Equal(t, "John@Example.com", address)
+		assert.Equal(t, current.ID, invitation[2])
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Encrypted user resealed", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp, pii: keys}
+		sealed := func(column, value string) string {
+			v, err := current.Seal(column, testUID(8), value)
+			require.NoError(t, err)
+			return v
+		}
+		rows := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*int64) = 8
+				*dest[1].(*uuid.UUID) = testUID(8)
+				*dest[2].(*bool) = true
+				*dest[3].(*string) = sealed("first_name", "John")
+				*dest[4].(*string) = sealed("last_name", "Doe")
+				*dest[5].(*string) = sealed("email", "john@example.com")
+				*dest[7].(**string) = &index
+				return nil
+			},
+		}}
+
+		var stored []any
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("Exec", mock.Anything, setTenantQuery, mock.Anything).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+		tx.On("Query", mock.Anything, mock.Anything, []any{false, current.ID, testUID(1), 10}).Return(rows, nil)
+		noAddresses(tx, 8)
+		tx.On("Exec", mock.Anything, updateQuery, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]any)
+		}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		r, err := db.ReencryptUsers(ctx, testUID(1), 10, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, r.Encrypted)
+		name, err := keys.Open(ctx, "first_name", testUID(8), stored[1].(string))
+		require.NoError(t, err)
+		assert.Equal(t, "John", name)
+		assert.Equal(t, &index, stored[5], "the blind index is kept")
+	})
+
+	t.Run("Undecryptable user skipped and reported", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp, pii: keys}
+		rows := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*int64) = 9
+				*dest[1].(*uuid.UUID) = testUID(9)
+				*dest[2].(*bool) = true
+				*dest[3].(*string) = "pii:corrupt"
+				return nil
+			},
+			func(dest ...any) error {
+				*dest[0].(*int64) = 10
+				*dest[1].(*uuid.UUID) = testUID(10)
+				*dest[3].(*string) = "pii:plaintext name"
+				return nil
+			},
+		}}
+
+		var stored []any
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("Exec", mock.Anything, setTenantQuery, mock.Anything).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+		noAddresses(tx, 10)
+		tx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(rows, nil)
+		tx.On("Exec", mock.Anything, updateQuery, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]any)
+		}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		r, err := db.ReencryptUsers(ctx, uuid.Nil, 10, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, &pii.Reencryption{Taken: 2, Encrypted: 1, Last: testUID(10), Failed: []uuid.UUID{testUID(9)}}, r)
+		require.Len(t, stored, 9)
+		assert.Equal(t, int64(10), stored[0])
+		name, err := keys.Open(ctx, "first_name", testUID(10), stored[1].(string))
+		require.NoError(t, err)
+		assert.Equal(t, "pii:plaintext name", name, "unencrypted values looking like ciphertexts are sealed as they are")
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Nothing to do without encryption", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		r, err := db.ReencryptUsers(ctx, uuid.Nil, 10, true)
+
+		require.NoError(t, err)
+		assert.Zero(t, r.Encrypted)
+		mp.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+}
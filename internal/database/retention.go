@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"go-users/internal/retention"
+	"go-users/internal/tenant"
+)
+
+// retentionPurge selects the rows of a table purged by a retention rule; $1 is the cutoff.
+type retentionPurge struct {
+	table     string
+	condition string
+}
+
+// retentionPurges lists the purges of each retention rule. Users are deleted with their dependent rows; their audit
+// entries are kept until the audit logs are trimmed. Users under legal hold and their audit entries are kept.
+var retentionPurges = map[string][]retentionPurge{
+	retention.DeactivatedUsers: {
+		{"users", `status = 'deactivated' AND NOT legal_hold AND COALESCE(
+			(SELECT max(e.created_at) FROM user_status_events e WHERE e.user_uid = users.uid AND e.to_status = 'deactivated'),
+			updated_at) < $1`},
+	},
+	retention.PendingUsers: {
+		{"users", `status = 'pending' AND NOT legal_hold AND email_verified_at IS NULL AND created_at < $1
+			AND NOT EXISTS (SELECT 1 FROM invitations i WHERE i.user_id = users.id
+				AND i.accepted_at IS NULL AND i.revoked_at IS NULL AND i.expires_at > CURRENT_TIMESTAMP)`},
+	},
+	retention.AuditLogs: {
+		{"policy_decisions", `created_at < $1 AND NOT EXISTS (SELECT 1 FROM users u WHERE u.legal_hold
+			AND (u.uid::text = policy_decisions.resource
+				OR (policy_decisions.principal_kind = 'user' AND u.uid::text = policy_decisions.principal_id)))`},
+		{"user_status_events", `created_at < $1 AND NOT EXISTS (SELECT 1 FROM users u WHERE u.legal_hold
+			AND u.uid = user_status_events.user_uid)`},
+		{"mfa_events", `created_at < $1 AND NOT EXISTS (SELECT 1 FROM users u WHERE u.legal_hold
+			AND u.uid = mfa_events.user_uid)`},
+		{"invitation_events", `created_at < $1 AND NOT EXISTS (SELECT 1 FROM invitations i
+			JOIN users u ON u.id = i.user_id WHERE u.legal_hold AND i.id = invitation_events.invitation_id)`},
+	},
+}
+
+// ApplyRetention deletes the rows of a retention rule in all organizations older than the cutoff and returns how many
+// were deleted per table together with the avatars of deleted users; in a dry run they are only counted. The rule is
+// applied under a transaction-level advisory lock, so that instances do not apply it concurrently. Returns
+// retention.ErrLocked if another instance holds it.
+func (db *db) ApplyRetention(ctx context.Context, rule string, cutoff time.Time, dryRun bool) (map[string]int, []retention.Avatar, error) {
+	purges, ok := retentionPurges[rule]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown retention rule %s", rule)
+	}
+
+	ctx = tenant.WithAllOrgs(ctx)
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", "retention:"+rule).Scan(&locked); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock retention rule: %w", err)
+	}
+	if !locked {
+		return nil, nil, retention.ErrLocked
+	}
+
+	rows := make(map[string]int, len(purges))
+	var avatars []retention.Avatar
+	for _, p := range purges {
+		if dryRun {
+			var count int
+			if err = tx.QueryRow(ctx, "SELECT count(*) FROM "+p.table+" WHERE "+p.condition, cutoff).Scan(&count); err != nil {
+				return nil, nil, fmt.Errorf("failed to count expired %s: %w", p.table, err)
+			}
+			rows[p.table] += count
+			continue
+		}
+
+		if p.table == "users" {
+			count, purged, err := purgeUsers(ctx, tx, p.condition, cutoff)
+			if err != nil {
+				return nil, nil, err
+			}
+			rows[p.table] += count
+			avatars = append(avatars, purged...)
+			continue
+		}
+
+		tag, err := tx.Exec(ctx, "DELETE FROM "+p.table+" WHERE "+p.condition, cutoff)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to purge expired %s: %w", p.table, err)
+		}
+		rows[p.table] += int(tag.RowsAffected())
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rows, avatars, nil
+}
+
+// purgeUsers deletes the users matching a retention condition and returns how many were deleted and their avatars.
+func purgeUsers(ctx context.Context, tx pgx.Tx, condition string, cutoff time.Time) (int, []retention.Avatar, error) {
+	rows, err := tx.Query(ctx, "DELETE FROM users WHERE "+condition+" RETURNING uid, avatar_version", cutoff)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to purge expired users: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	var avatars []retention.Avatar
+	for rows.Next() {
+		var (
+			a       retention.Avatar
+			version *string
+		)
+		if err = rows.Scan(&a.User, &version); err != nil {
+			return 0, nil, fmt.Errorf("failed to scan purged user: %w", err)
+		}
+		count++
+		if version != nil {
+			a.Version = *version
+			avatars = append(avatars, a)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("failed to purge expired users: %w", err)
+	}
+
+	return count, avatars, nil
+}
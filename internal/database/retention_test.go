@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/retention"
+)
+
+// boolRow returns a row with a single boolean, such as the result of an advisory lock attempt.
+func boolRow(b bool) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*bool) = b
+	}).Return(nil)
+	return mr
+}
+
+func TestApplyRetention(t *testing.T) {
+	cutoff := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	lockQuery := "SELECT pg_try_advisory_xact_lock(hashtext($1))"
+
+	t.Run("Audit logs trimmed", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("QueryRow", mock.Anything, lockQuery, []any{"retention:audit_logs"}).Return(boolRow(true))
+		tx.On("Exec", mock.Anything, mock.MatchedBy(func(q string) bool {
+			return strings.HasPrefix(q, "DELETE FROM policy_decisions WHERE created_at < $1")
+		}), []any{cutoff}).Return(pgconn.NewCommandTag("DELETE 5"), nil).Once()
+		tx.On("Exec", mock.Anything, mock.Anything, []any{cutoff}).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		rows, avatars, err := db.ApplyRetention(context.Background(), retention.AuditLogs, cutoff, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"policy_decisions": 5, "user_status_events": 0, "mfa_events": 0, "invitation_events": 0}, rows)
+		assert.Empty(t, avatars)
+		tx.AssertNumberOfCalls(t, "Exec", 4)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Dry run counts", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		count := new(MockRow)
+		count.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int) = 3
+		}).Return(nil)
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("QueryRow", mock.Anything, lockQuery, []any{"retention:pending_users"}).Return(boolRow(true))
+		tx.On("QueryRow", mock.Anything, mock.MatchedBy(func(q string) bool {
+			return strings.HasPrefix(q, "SELECT count(*) FROM users WHERE status = 'pending' AND NOT legal_hold")
+		}), []any{cutoff}).Return(count)
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		rows, _, err := db.ApplyRetention(context.Background(), retention.PendingUsers, cutoff, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"users": 3}, rows)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Purged users return their avatars", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		version := "0123456789abcdef"
+		purged := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*uuid.UUID) = testUID(1)
+				*dest[1].(**string) = &version
+				return nil
+			},
+			func(dest ...any) error {
+				*dest[0].(*uuid.UUID) = testUID(2)
+				return nil
+			},
+		}}
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("QueryRow", mock.Anything, lockQuery, []any{"retention:deactivated_users"}).Return(boolRow(true))
+		tx.On("Query", mock.Anything, mock.MatchedBy(func(q string) bool {
+			return strings.HasPrefix(q, "DELETE FROM users WHERE status = 'deactivated'") &&
+				strings.HasSuffix(q, "RETURNING uid, avatar_version")
+		}), []any{cutoff}).Return(purged, nil)
+		tx.On("Commit", mock.Anything).Return(nil)
+		tx.On("Rollback", mock.Anything).Return(pgx.ErrTxClosed)
+
+		rows, avatars, err := db.ApplyRetention(context.Background(), retention.DeactivatedUsers, cutoff, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"users": 2}, rows)
+		assert.Equal(t, []retention.Avatar{{User: testUID(1), Version: version}}, avatars)
+		assert.True(t, purged.closed)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Applied by another instance", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mp.On("Begin", mock.Anything).Return(tx, nil)
+		tx.On("QueryRow", mock.Anything, lockQuery, []any{"retention:deactivated_users"}).Return(boolRow(false))
+		tx.On("Rollback", mock.Anything).Return(nil)
+
+		rows, _, err := db.ApplyRetention(context.Background(), retention.DeactivatedUsers, cutoff, false)
+
+		assert.Nil(t, rows)
+		assert.ErrorIs(t, err, retention.ErrLocked)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown rule", func(t *testing.T) {
+		db := &db{pool: new(MockPool)}
+
+		_, _, err := db.ApplyRetention(context.Background(), "sessions", cutoff, false)
+
+		assert.ErrorContains(t, err, "unknown retention rule")
+	})
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// roleColumns selects a role together with its permissions; queries using it must group by r.id.
+const roleColumns = `r.name, r.description, r.mfa_required,
+	COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')`
+
+// ListRoles returns all roles ordered by name.
+func (db *db) ListRoles(ctx context.Context) ([]api.Role, error) {
+	query := `SELECT ` + roleColumns + `
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role_id = r.id
+	GROUP BY r.id
+	ORDER BY r.name`
+
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	return scanRoles(rows)
+}
+
+// GetUserRoles returns the roles assigned to a user ordered by name. Returns ErrNotFound if the user does not exist.
+func (db *db) GetUserRoles(ctx context.Context, id openapi_types.UUID) ([]api.Role, error) {
+	var userID int64
+	if err := db.pool.QueryRow(ctx, "SELECT id FROM users WHERE uid = $1", id).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	query := `SELECT ` + roleColumns + `
+	FROM user_roles ur
+	JOIN roles r ON r.id = ur.role_id
+	LEFT JOIN role_permissions rp ON rp.role_id = r.id
+	WHERE ur.user_id = $1
+	GROUP BY r.id
+	ORDER BY r.name`
+
+	rows, err := db.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return scanRoles(rows)
+}
+
+// scanRoles reads and closes rows selected with roleColumns.
+func scanRoles(rows pgx.Rows) ([]api.Role, error) {
+	defer rows.Close()
+
+	roles := make([]api.Role, 0)
+	for rows.Next() {
+		var role api.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.MfaRequired, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// AssignRole assigns a role to a user. Assigning a role twice is not an error. Returns ErrNotFound if the user or
+// the role does not exist.
+func (db *db) AssignRole(ctx context.Context, id openapi_types.UUID, role string) error {
+	// The no-op update makes an existing assignment count as an affected row.
+	query := `INSERT INTO user_roles (user_id, role_id)
+		SELECT u.id, r.id FROM users u, roles r WHERE u.uid = $1 AND r.name = $2
+		ON CONFLICT (user_id, role_id) DO UPDATE SET assigned_at = user_roles.assigned_at`
+
+	tag, err := db.pool.Exec(ctx, query, id, role)
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeRole removes a role from a user. Returns ErrNotFound if the user does not have the role.
+func (db *db) RevokeRole(ctx context.Context, id openapi_types.UUID, role string) error {
+	query := `DELETE FROM user_roles ur
+		USING users u, roles r
+		WHERE ur.user_id = u.id AND ur.role_id = r.id AND u.uid = $1 AND r.name = $2`
+
+	tag, err := db.pool.Exec(ctx, query, id, role)
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUserPermissions returns the permissions granted to a user by their roles. Roles requiring MFA grant nothing
+// until the user has enabled MFA.
+func (db *db) GetUserPermissions(ctx context.Context, id openapi_types.UUID) ([]string, error) {
+	query := `SELECT DISTINCT rp.permission
+	FROM users u
+	JOIN user_roles ur ON ur.user_id = u.id
+	JOIN roles r ON r.id = ur.role_id
+	JOIN role_permissions rp ON rp.role_id = r.id
+	LEFT JOIN user_mfa m ON m.user_id = u.id
+	WHERE u.uid = $1 AND (NOT r.mfa_required OR m.enabled_at IS NOT NULL)
+	ORDER BY rp.permission`
+
+	rows, err := db.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user permissions: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := make([]string, 0)
+	for rows.Next() {
+		var permission string
+		if err = rows.Scan(&permission); err != nil {
+			return nil, fmt.Errorf("failed to scan permission: %w", err)
+		}
+		permissions = append(permissions, permission)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// LogPolicyDecision appends a decision of the policy engine to the decision log.
+func (db *db) LogPolicyDecision(ctx context.Context, d *api.PolicyDecision) error {
+	query := `INSERT INTO policy_decisions
+		(operation, principal_kind, principal_id, key_id, resource, allowed, reason, request_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, ''))`
+
+	_, err := db.pool.Exec(ctx, query, d.Operation, d.PrincipalKind, d.PrincipalID, d.KeyID, d.Resource, d.Allowed,
+		d.Reason, d.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to log policy decision: %w", err)
+	}
+
+	return nil
+}
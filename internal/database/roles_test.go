@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+func TestGetUserRoles(t *testing.T) {
+	t.Run("Roles of the user", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+		}).Return(nil)
+		mp.On("QueryRow", context.Background(), "SELECT id FROM users WHERE uid = $1", []any{testUID(1)}).Return(mr)
+
+		rows := &fakeRows{rows: []func(dest ...any) error{
+			func(dest ...any) error {
+				*dest[0].(*string) = "support"
+				*dest[1].(*string) = "Support agents"
+				*dest[2].(*bool) = false
+				*dest[3].(*[]string) = []string{"roles:read", "users:read"}
+				return nil
+			},
+		}}
+		mp.On("Query", context.Background(), mock.Anything, []any{int64(7)}).Return(rows, nil)
+
+		roles, err := db.GetUserRoles(context.Background(), testUID(1))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []api.Role{
+			{Name: "support", Description: "Support agents", Permissions: []string{"roles:read", "users:read"}},
+		}, roles)
+		assert.True(t, rows.closed)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), "SELECT id FROM users WHERE uid = $1", []any{testUID(2)}).Return(mr)
+
+		roles, err := db.GetUserRoles(context.Background(), testUID(2))
+
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+		assert.Nil(t, roles)
+		mp.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAssignRole(t *testing.T) {
+	t.Run("Assigned", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), "support"}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+		assert.NoError(t, db.AssignRole(context.Background(), testUID(1), "support"))
+	})
+
+	t.Run("Unknown user or role", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), "owner"}).
+			Return(pgconn.NewCommandTag("INSERT 0 0"), nil)
+
+		assert.ErrorIs(t, db.AssignRole(context.Background(), testUID(1), "owner"), ownErrors.ErrNotFound)
+	})
+}
+
+func TestRevokeRole(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), mock.Anything, []any{testUID(1), "support"}).
+		Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	assert.ErrorIs(t, db.RevokeRole(context.Background(), testUID(1), "support"), ownErrors.ErrNotFound)
+}
+
+func TestLogPolicyDecision(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", context.Background(), mock.Anything,
+		[]any{"putUser", "user", testUID(1).String(), "", testUID(2).String(), false, "missing permission users:write", "req-1"},
+	).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	err := db.LogPolicyDecision(context.Background(), &api.PolicyDecision{
+		Operation:     "putUser",
+		PrincipalKind: "user",
+		PrincipalID:   testUID(1).String(),
+		Resource:      testUID(2).String(),
+		Reason:        "missing permission users:write",
+		RequestID:     "req-1",
+	})
+
+	assert.NoError(t, err)
+	mp.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+	"go-users/internal/search"
+)
+
+// SearchUsers finds users whose names or email match every word of q as a prefix (full-text search) or resemble q
+// (trigram similarity), ordered by relevance. Snippets are built with search.Highlight, which escapes the fields, so
+// markup stored in names cannot reach clients of the snippets. It relies on the indexes created by the user search migration, which
+// only cover unencrypted users. If personal data is encrypted, only a user whose email address equals q is found.
+func (db *db) SearchUsers(ctx context.Context, q string, limit int) ([]api.SearchResult, error) {
+	if db.pii != nil {
+		return db.searchUsersByEmail(ctx, q)
+	}
+
+	terms := search.Terms(q)
+	if len(terms) == 0 {
+		return []api.SearchResult{}, nil
+	}
+
+	query := `SELECT ` + userColumns + `,
+		ts_rank(search_vector, query) + similarity(first_name || ' ' || last_name, $2) + similarity(email, $2) AS score
+	FROM users, to_tsquery('simple', $1) AS query
+	WHERE pii_key_id IS NULL AND (search_vector @@ query OR (first_name || ' ' || last_name) % $2 OR email % $2)
+	ORDER BY score DESC, uid
+	LIMIT $3`
+
+	rows, err := db.pool.Query(ctx, query, search.TSQuery(terms), strings.Join(terms, " "), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]api.SearchResult, 0, limit)
+	for rows.Next() {
+		var result api.SearchResult
+		var user storedUser
+		if err = rows.Scan(append(userFields(&user), &result.Score)...); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if err = db.openUser(ctx, &user); err != nil {
+			return nil, err
+		}
+		result.User = user.User
+
+		if snippet, ok := search.Highlight(user.FirstName+" "+user.LastName, terms); ok {
+			result.Highlights.Name = &snippet
+		}
+		if snippet, ok := search.Highlight(string(user.Email), terms); ok {
+			result.Highlights.Email = &snippet
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return results, nil
+}
+
+// searchUsersByEmail finds the user with the email address q, compared in canonical form through its blind index.
+// Encrypted names and addresses cannot be matched partially.
+func (db *db) searchUsersByEmail(ctx context.Context, q string) ([]api.SearchResult, error) {
+	user, err := db.GetUserByEmail(ctx, strings.TrimSpace(q))
+	if errors.Is(err, ownErrors.ErrNotFound) {
+		return []api.SearchResult{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return []api.SearchResult{{User: *user, Score: 1}}, nil
+}
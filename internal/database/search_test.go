@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchUsers(t *testing.T) {
+	fixedTime := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	rows := &fakeRows{rows: []func(dest ...any) error{
+		func(dest ...any) error {
+			*dest[0].(*openapi_types.UUID) = testUID(1)
+			*dest[1].(*string) = "<b>John</b>"
+			*dest[2].(*string) = "Doe"
+			*dest[3].(*openapi_types.Email) = openapi_types.Email("john@example.com")
+			*dest[4].(*time.Time) = fixedTime
+			*dest[5].(*time.Time) = fixedTime
+			n := len(userFields(&storedUser{}))
+			*dest[n].(*float64) = 1.25
+			return nil
+		},
+	}}
+
+	mp.On("Query", context.Background(), mock.AnythingOfType("string"),
+		[]any{"jo:* & do:*", "jo do", 10},
+	).Return(rows, nil)
+
+	results, err := db.SearchUsers(context.Background(), "Jo, Do!", 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 1.25, results[0].Score)
+	assert.Equal(t, "&lt;b&gt;<mark>John</mark>&lt;/b&gt; <mark>Doe</mark>", *results[0].Highlights.Name)
+	assert.Equal(t, "<mark>john</mark>@example.com", *results[0].Highlights.Email)
+	mp.AssertExpectations(t)
+}
+
+func TestSearchUsers_NoTerms(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+
+	results, err := db.SearchUsers(context.Background(), "@@", 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	mp.AssertNotCalled(t, "Query")
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-users/internal/tenant"
+)
+
+// setTenantQuery sets a setting read by the row-level security policies for the rest of the transaction, like
+// SET LOCAL but with the value as parameter.
+const setTenantQuery = "SELECT set_config($1, $2, true)"
+
+// tenantPool scopes every statement to the organization of its context. Statements outside a transaction run in one
+// that first sets app.current_org, or app.all_orgs for system tasks; transactions get the setting when they begin.
+// Statements without an organization in their context run unscoped and see no tenant rows.
+type tenantPool struct {
+	ConnPool
+}
+
+// tenantSetting returns the setting and value that scope a transaction to the organization of ctx, if any.
+func tenantSetting(ctx context.Context) (string, string, bool) {
+	if tenant.AllOrgs(ctx) {
+		return "app.all_orgs", "on", true
+	}
+	if org, ok := tenant.FromContext(ctx); ok {
+		return "app.current_org", org.String(), true
+	}
+	return "", "", false
+}
+
+// begin starts a transaction scoped to the organization of ctx.
+func (p *tenantPool) begin(ctx context.Context, name, value string) (pgx.Tx, error) {
+	tx, err := p.ConnPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, setTenantQuery, name, value); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to set organization: %w", err)
+	}
+
+	return tx, nil
+}
+
+// Begin starts a transaction scoped to the organization of ctx.
+func (p *tenantPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	name, value, ok := tenantSetting(ctx)
+	if !ok {
+		return p.ConnPool.Begin(ctx)
+	}
+	return p.begin(ctx, name, value)
+}
+
+// Exec runs a statement in its own transaction scoped to the organization of ctx.
+func (p *tenantPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	name, value, ok := tenantSetting(ctx)
+	if !ok {
+		return p.ConnPool.Exec(ctx, sql, args...)
+	}
+
+	tx, err := p.begin(ctx, name, value)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return tag, err
+	}
+
+	return tag, tx.Commit(ctx)
+}
+
+// QueryRow runs a query in its own transaction scoped to the organization of ctx, which ends when the row is scanned.
+func (p *tenantPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	name, value, ok := tenantSetting(ctx)
+	if !ok {
+		return p.ConnPool.QueryRow(ctx, sql, args...)
+	}
+
+	tx, err := p.begin(ctx, name, value)
+	if err != nil {
+		return errRow{err: err}
+	}
+
+	return &txRow{ctx: ctx, tx: tx, row: tx.QueryRow(ctx, sql, args...)}
+}
+
+// Query runs a query in its own transaction scoped to the organization of ctx, which ends when the rows are closed.
+func (p *tenantPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	name, value, ok := tenantSetting(ctx)
+	if !ok {
+		return p.ConnPool.Query(ctx, sql, args...)
+	}
+
+	tx, err := p.begin(ctx, name, value)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+
+	return &txRows{Rows: rows, ctx: ctx, tx: tx}, nil
+}
+
+// errRow is a pgx.Row failing with the error that prevented the query.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
+
+// txRow is a pgx.Row that ends its transaction when scanned. Statements with RETURNING are only committed if the
+// row was scanned successfully.
+type txRow struct {
+	ctx context.Context
+	tx  pgx.Tx
+	row pgx.Row
+}
+
+func (r *txRow) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != nil {
+		_ = r.tx.Rollback(r.ctx)
+		return err
+	}
+	return r.tx.Commit(r.ctx)
+}
+
+// txRows is a pgx.Rows that ends its transaction when closed. A failed commit is reported by Err.
+type txRows struct {
+	pgx.Rows
+	ctx       context.Context
+	tx        pgx.Tx
+	commitErr error
+	done      bool
+}
+
+func (r *txRows) Close() {
+	r.Rows.Close()
+	if r.done {
+		return
+	}
+	r.done = true
+
+	if r.Rows.Err() != nil {
+		_ = r.tx.Rollback(r.ctx)
+		return
+	}
+	r.commitErr = r.tx.Commit(r.ctx)
+}
+
+func (r *txRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.Close()
+	return false
+}
+
+func (r *txRows) Err() error {
+	if err := r.Rows.Err(); err != nil {
+		return err
+	}
+	return r.commitErr
+}
+
+// rowSecurityBypassedQuery reports whether the current role ignores the row-level security policies.
+const rowSecurityBypassedQuery = "SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user"
+
+// RowSecurityBypassed reports whether the database role ignores row-level security, in which case organizations are
+// not isolated from each other.
+func (db *db) RowSecurityBypassed(ctx context.Context) (bool, error) {
+	var bypassed bool
+	if err := db.pool.QueryRow(ctx, rowSecurityBypassedQuery).Scan(&bypassed); err != nil {
+		return false, fmt.Errorf("failed to check row security: %w", err)
+	}
+	return bypassed, nil
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/tenant"
+)
+
+func TestTenantPool_QueryRow(t *testing.T) {
+	ctx := tenant.WithOrg(context.Background(), testUID(5))
+
+	t.Run("Scoped to the organization", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		mp.On("Begin", ctx).Return(tx, nil)
+		tx.On("Exec", ctx, setTenantQuery, []any{"app.current_org", testUID(5).String()}).
+			Return(pgconn.NewCommandTag("SELECT 1"), nil)
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(nil)
+		tx.On("QueryRow", ctx, "SELECT 1", []any(nil)).Return(mr)
+		tx.On("Commit", ctx).Return(nil)
+
+		var one int
+		err := (&tenantPool{ConnPool: mp}).QueryRow(ctx, "SELECT 1").Scan(&one)
+
+		require.NoError(t, err)
+		tx.AssertExpectations(t)
+		tx.AssertNotCalled(t, "Rollback", mock.Anything)
+	})
+
+	t.Run("Failed scan rolls back", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		mp.On("Begin", ctx).Return(tx, nil)
+		tx.On("Exec", ctx, setTenantQuery, mock.Anything).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(errors.New("db error"))
+		tx.On("QueryRow", ctx, "SELECT 1", []any(nil)).Return(mr)
+		tx.On("Rollback", ctx).Return(nil)
+
+		var one int
+		err := (&tenantPool{ConnPool: mp}).QueryRow(ctx, "SELECT 1").Scan(&one)
+
+		assert.EqualError(t, err, "db error")
+		tx.AssertExpectations(t)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Failed begin", func(t *testing.T) {
+		mp := new(MockPool)
+		mp.On("Begin", ctx).Return(nil, errors.New("pool closed"))
+
+		var one int
+		err := (&tenantPool{ConnPool: mp}).QueryRow(ctx, "SELECT 1").Scan(&one)
+
+		assert.EqualError(t, err, "pool closed")
+	})
+
+	t.Run("Without organization", func(t *testing.T) {
+		mp := new(MockPool)
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(nil)
+		mp.On("QueryRow", context.Background(), "SELECT 1", []any(nil)).Return(mr)
+
+		var one int
+		err := (&tenantPool{ConnPool: mp}).QueryRow(context.Background(), "SELECT 1").Scan(&one)
+
+		require.NoError(t, err)
+		mp.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+}
+
+func TestTenantPool_Exec(t *testing.T) {
+	ctx := tenant.WithAllOrgs(context.Background())
+
+	mp := new(MockPool)
+	tx := new(MockTx)
+	mp.On("Begin", ctx).Return(tx, nil)
+	tx.On("Exec", ctx, setTenantQuery, []any{"app.all_orgs", "on"}).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+	tx.On("Exec", ctx, "DELETE FROM jobs", []any(nil)).Return(pgconn.NewCommandTag("DELETE 2"), nil)
+	tx.On("Commit", ctx).Return(nil)
+	tx.On("Rollback", ctx).Return(nil)
+
+	tag, err := (&tenantPool{ConnPool: mp}).Exec(ctx, "DELETE FROM jobs")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), tag.RowsAffected())
+	tx.AssertExpectations(t)
+}
+
+func TestTenantPool_Query(t *testing.T) {
+	ctx := tenant.WithOrg(context.Background(), testUID(5))
+
+	mp := new(MockPool)
+	tx := new(MockTx)
+	mp.On("Begin", ctx).Return(tx, nil)
+	tx.On("Exec", ctx, setTenantQuery, mock.Anything).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+	rows := &fakeRows{rows: []func(dest ...any) error{
+		func(dest ...any) error { return nil },
+	}}
+	tx.On("Query", ctx, "SELECT id FROM groups", []any(nil)).Return(rows, nil)
+	tx.On("Commit", ctx).Return(nil).Once()
+
+	result, err := (&tenantPool{ConnPool: mp}).Query(ctx, "SELECT id FROM groups")
+	require.NoError(t, err)
+
+	for result.Next() {
+		require.NoError(t, result.Scan())
+	}
+	result.Close()
+
+	require.NoError(t, result.Err())
+	assert.True(t, rows.closed)
+	tx.AssertExpectations(t)
+}
+
+func TestTenantPool_Begin(t *testing.T) {
+	ctx := tenant.WithOrg(context.Background(), testUID(5))
+
+	mp := new(MockPool)
+	tx := new(MockTx)
+	mp.On("Begin", ctx).Return(tx, nil)
+	tx.On("Exec", ctx, setTenantQuery, []any{"app.current_org", testUID(5).String()}).
+		Return(pgconn.CommandTag{}, errors.New("db error"))
+	tx.On("Rollback", ctx).Return(nil)
+
+	_, err := (&tenantPool{ConnPool: mp}).Begin(ctx)
+
+	assert.ErrorContains(t, err, "failed to set organization")
+	tx.AssertExpectations(t)
+}
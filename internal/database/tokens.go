@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/token"
+)
+
+// ListSigningKeys returns the access token signing keys that have not expired at the given time.
+func (db *db) ListSigningKeys(ctx context.Context, now time.Time) ([]token.Key, error) {
+	query := `SELECT kid, algorithm, private_key, not_before, expires_at FROM signing_keys
+		WHERE expires_at > $1 ORDER BY not_before`
+
+	rows, err := db.pool.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]token.Key, 0)
+	for rows.Next() {
+		var k token.Key
+		if err = rows.Scan(&k.ID, &k.Algorithm, &k.PrivateKey, &k.NotBefore, &k.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateSigningKey stores a signing key unless a key becoming valid at the same time already exists.
+func (db *db) CreateSigningKey(ctx context.Context, key *token.Key) error {
+	query := `INSERT INTO signing_keys (kid, algorithm, private_key, not_before, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (not_before) DO NOTHING`
+
+	if _, err := db.pool.Exec(ctx, query, key.ID, key.Algorithm, key.PrivateKey, key.NotBefore, key.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return nil
+}
+
+// CreateRefreshToken stores the hash of the first refresh token of a new session of a user.
+func (db *db) CreateRefreshToken(ctx context.Context, id openapi_types.UUID, tokenHash []byte, expiresAt time.Time) error {
+	query := "INSERT INTO refresh_tokens (user_id, token_hash, expires_at) SELECT id, $2, $3 FROM users WHERE uid = $1"
+
+	tag, err := db.pool.Exec(ctx, query, id, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// RotateRefreshToken marks a refresh token as used and stores its successor in the same session, returning the ID of
+// the user. Returns ErrInvalidToken if the token is unknown, expired or revoked or its user is not active. A token that
+// was already used revokes its session, as it has most likely been stolen.
+func (db *db) RotateRefreshToken(ctx context.Context, tokenHash, newHash []byte, expiresAt time.Time) (openapi_types.UUID, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT rt.id, rt.user_id, rt.family_id, u.uid, rt.expires_at <= CURRENT_TIMESTAMP, rt.used_at IS NOT NULL, rt.revoked_at IS NOT NULL,
+		u.status <> 'active'
+	FROM refresh_tokens rt
+	JOIN users u ON u.id = rt.user_id
+	WHERE rt.token_hash = $1
+	FOR UPDATE OF rt`
+
+	var (
+		tokenID                int64
+		userID                 int64
+		familyID, uid          openapi_types.UUID
+		expired, used, revoked bool
+		inactive               bool
+	)
+	err = tx.QueryRow(ctx, query, tokenHash).Scan(&tokenID, &userID, &familyID, &uid, &expired, &used, &revoked, &inactive)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ownErrors.ErrInvalidToken
+		}
+		return uuid.Nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	switch {
+	case revoked, expired, inactive:
+		return uuid.Nil, ownErrors.ErrInvalidToken
+	case used:
+		if err = revokeRefreshTokenFamily(ctx, tx, familyID); err != nil {
+			return uuid.Nil, err
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return uuid.Nil, fmt.Errorf("%w: refresh token reused", ownErrors.ErrInvalidToken)
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP WHERE id = $1", tokenID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	query = "INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)"
+	if _, err = tx.Exec(ctx, query, userID, familyID, newHash, expiresAt); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uid, nil
+}
+
+// RevokeRefreshToken revokes the session of a refresh token. Returns ErrNotFound if the token is unknown.
+func (db *db) RevokeRefreshToken(ctx context.Context, tokenHash []byte) error {
+	var familyID openapi_types.UUID
+	err := db.pool.QueryRow(ctx, "SELECT family_id FROM refresh_tokens WHERE token_hash = $1", tokenHash).Scan(&familyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ownErrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return revokeRefreshTokenFamily(ctx, db.pool, familyID)
+}
+
+// revokeRefreshTokenFamily revokes all refresh tokens of a session.
+func revokeRefreshTokenFamily(ctx context.Context, q querier, familyID openapi_types.UUID) error {
+	query := "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL"
+
+	if _, err := q.Exec(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/token"
+)
+
+const revokeFamilyQuery = "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL"
+
+// refreshTokenRow returns a row of the refresh token lookup with the given state.
+func refreshTokenRow(familyID openapi_types.UUID, expired, used, revoked, inactive bool) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 5
+			*args.Get(1).(*int64) = 1
+			*args.Get(2).(*openapi_types.UUID) = familyID
+			*args.Get(3).(*openapi_types.UUID) = testUID(1)
+			*args.Get(4).(*bool) = expired
+			*args.Get(5).(*bool) = used
+			*args.Get(6).(*bool) = revoked
+			*args.Get(7).(*bool) = inactive
+		}).Return(nil)
+	return mr
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	familyID := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-0000000000ff")
+	expiresAt := time.Date(2024, time.March, 21, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Successor stored in the same family", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("old")}).Return(refreshTokenRow(familyID, false, false, false, false))
+		tx.On("Exec", context.Background(), "UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP WHERE id = $1", []any{int64(5)}).
+			Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Exec", context.Background(), mock.Anything, []any{int64(1), familyID, []byte("new"), expiresAt}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		uid, err := db.RotateRefreshToken(context.Background(), []byte("old"), []byte("new"), expiresAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testUID(1), uid)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Reuse revokes the family", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("old")}).Return(refreshTokenRow(familyID, false, true, false, false))
+		tx.On("Exec", context.Background(), revokeFamilyQuery, []any{familyID}).Return(pgconn.NewCommandTag("UPDATE 2"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		_, err := db.RotateRefreshToken(context.Background(), []byte("old"), []byte("new"), expiresAt)
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Revoked token", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("old")}).Return(refreshTokenRow(familyID, false, false, true, false))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.RotateRefreshToken(context.Background(), []byte("old"), []byte("new"), expiresAt)
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("User not active", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{[]byte("old")}).Return(refreshTokenRow(familyID, false, false, false, true))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		_, err := db.RotateRefreshToken(context.Background(), []byte("old"), []byte("new"), expiresAt)
+
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+func TestCreateSigningKey(t *testing.T) {
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	notBefore := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	key := &token.Key{ID: "kid", Algorithm: token.EdDSA, PrivateKey: []byte("der"), NotBefore: notBefore, ExpiresAt: notBefore.Add(time.Hour)}
+
+	mp.On("Exec", context.Background(), mock.Anything, []any{"kid", token.EdDSA, []byte("der"), notBefore, notBefore.Add(time.Hour)}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	err := db.CreateSigningKey(context.Background(), key)
+
+	assert.NoError(t, err)
+	mp.AssertExpectations(t)
+}
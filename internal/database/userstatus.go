@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// GetUserStatus returns the status of a user. Returns ErrNotFound if there is no such user.
+func (db *db) GetUserStatus(ctx context.Context, id openapi_types.UUID) (api.UserStatus, error) {
+	var status api.UserStatus
+	if err := db.pool.QueryRow(ctx, "SELECT status FROM users WHERE uid = $1", id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ownErrors.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get user status: %w", err)
+	}
+
+	return status, nil
+}
+
+// ChangeUserStatus moves a user from c.From to c.To and records the change. Users leaving the active status lose
+// their sessions: refresh tokens are revoked and pending MFA challenges deleted. Returns ErrInvalidTransition if the
+// status of the user is no longer c.From, e.g. after a concurrent change.
+func (db *db) ChangeUserStatus(ctx context.Context, id openapi_types.UUID, c *api.StatusChange) (*api.User, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET status = $3, status_reason = NULLIF($4, ''), suspended_until = $5
+		WHERE uid = $1 AND status = $2
+		RETURNING id, ` + userColumns
+
+	var (
+		userID int64
+		user   storedUser
+	)
+	err = tx.QueryRow(ctx, query, id, c.From, c.To, c.Reason, c.Until).Scan(append([]any{&userID}, userFields(&user)...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user is no longer %s", ownErrors.ErrInvalidTransition, c.From)
+		}
+		return nil, fmt.Errorf("failed to change user status: %w", err)
+	}
+
+	if c.To != api.UserStatusActive {
+		query = "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL"
+		if _, err = tx.Exec(ctx, query, userID); err != nil {
+			return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+
+		if _, err = tx.Exec(ctx, "DELETE FROM mfa_challenges WHERE user_id = $1", userID); err != nil {
+			return nil, fmt.Errorf("failed to delete MFA challenges: %w", err)
+		}
+	}
+
+	query = `INSERT INTO user_status_events (user_uid, from_status, to_status, reason, until, actor_kind, actor_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)`
+	if _, err = tx.Exec(ctx, query, id, c.From, c.To, c.Reason, c.Until, c.ActorKind, c.ActorID); err != nil {
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// ListUserStatusEvents returns the status changes of a user, oldest first. Returns ErrNotFound if there is no such
+// user.
+func (db *db) ListUserStatusEvents(ctx context.Context, id openapi_types.UUID) ([]api.UserStatusEvent, error) {
+	if _, err := db.GetUserStatus(ctx, id); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT from_status, to_status, reason, until, actor_kind, actor_id, created_at
+	FROM user_status_events
+	WHERE user_uid = $1
+	ORDER BY created_at, id`
+
+	rows, err := db.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]api.UserStatusEvent, 0)
+	for rows.Next() {
+		var e api.UserStatusEvent
+		if err = rows.Scan(&e.FromStatus, &e.ToStatus, &e.Reason, &e.Until, &e.ActorKind, &e.ActorId, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ReactivateDueUsers reactivates the suspended users of all organizations whose suspension ended at the given time and
+// records the changes with the system as actor. Returns how many users were reactivated.
+func (db *db) ReactivateDueUsers(ctx context.Context, now time.Time) (int, error) {
+	query := `WITH due AS (
+		UPDATE users SET status = 'active', status_reason = NULL, suspended_until = NULL
+		WHERE status = 'suspended' AND suspended_until <= $1
+		RETURNING uid, org_id
+	)
+	INSERT INTO user_status_events (user_uid, org_id, from_status, to_status, reason, actor_kind, actor_id)
+	SELECT uid, org_id, 'suspended', 'active', 'suspension ended', 'system', 'reactivator' FROM due`
+
+	tag, err := db.pool.Exec(tenant.WithAllOrgs(ctx), query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reactivate users: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+func TestChangeUserStatus(t *testing.T) {
+	until := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suspension := &api.StatusChange{From: api.UserStatusActive, To: api.UserStatusSuspended, Reason: "Abuse", Until: &until,
+		ActorKind: "user", ActorID: "admin"}
+	changedRow := func(err error) *MockRow {
+		mr := new(MockRow)
+		call := mr.On("Scan", append([]any{mock.Anything}, userScanArgs()...)...)
+		if err != nil {
+			call.Return(err)
+			return mr
+		}
+		call.Run(func(args mock.Arguments) {
+			*args.Get(0).(*int64) = 7
+			*args.Get(1).(*openapi_types.UUID) = testUID(1)
+		}).Return(nil)
+		return mr
+	}
+
+	t.Run("Suspension revokes sessions", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything,
+			[]any{testUID(1), api.UserStatusActive, api.UserStatusSuspended, "Abuse", &until}).Return(changedRow(nil))
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		user, err := db.ChangeUserStatus(context.Background(), testUID(1), suspension)
+
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		tx.AssertCalled(t, "Exec", context.Background(),
+			"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL", []any{int64(7)})
+		tx.AssertCalled(t, "Exec", context.Background(), "DELETE FROM mfa_challenges WHERE user_id = $1", []any{int64(7)})
+		tx.AssertCalled(t, "Exec", context.Background(), mock.Anything,
+			[]any{testUID(1), api.UserStatusActive, api.UserStatusSuspended, "Abuse", &until, "user", "admin"})
+		tx.AssertNumberOfCalls(t, "Exec", 3)
+	})
+
+	t.Run("Reactivation keeps sessions", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		reactivation := &api.StatusChange{From: api.UserStatusSuspended, To: api.UserStatusActive, ActorKind: "user", ActorID: "admin"}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(changedRow(nil))
+		tx.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		_, err := db.ChangeUserStatus(context.Background(), testUID(1), reactivation)
+
+		require.NoError(t, err)
+		tx.AssertNumberOfCalls(t, "Exec", 1)
+	})
+
+	t.Run("Status changed concurrently", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(changedRow(sql.ErrNoRows))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.ChangeUserStatus(context.Background(), testUID(1), suspension)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidTransition)
+		tx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+func TestReactivateDueUsers(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mp := new(MockPool)
+	db := &db{pool: mp}
+	mp.On("Exec", mock.MatchedBy(tenant.AllOrgs), mock.Anything, []any{now}).Return(pgconn.NewCommandTag("INSERT 0 2"), nil)
+
+	count, err := db.ReactivateDueUsers(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/email"
+	"go-users/internal/ownErrors"
+)
+
+// CreateEmailVerification stores a sent verification mail. Returns ErrNotFound if the user does not exist.
+func (db *db) CreateEmailVerification(ctx context.Context, v *api.EmailVerification) error {
+	s, err := db.sealer(v.ID)
+	if err != nil {
+		return err
+	}
+	address := s.seal("email_verifications.email", v.Email)
+	if s.err != nil {
+		return s.err
+	}
+
+	query := `INSERT INTO email_verifications (id, user_id, email, pii_key_id, expires_at)
+		SELECT $1, id, $3, $4, $5 FROM users WHERE uid = $2`
+
+	tag, err := db.pool.Exec(ctx, query, v.ID, v.UserID, address, s.keyID(), v.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ownErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// LastEmailVerificationSentAt returns when the last verification mail was sent to a user, or nil if none was sent.
+// Returns ErrNotFound if the user does not exist.
+func (db *db) LastEmailVerificationSentAt(ctx context.Context, id openapi_types.UUID) (*time.Time, error) {
+	query := `SELECT max(v.created_at)
+	FROM users u
+	LEFT JOIN email_verifications v ON v.user_id = u.id
+	WHERE u.uid = $1
+	GROUP BY u.id`
+
+	var sentAt *time.Time
+	if err := db.pool.QueryRow(ctx, query, id).Scan(&sentAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get email verification: %w", err)
+	}
+
+	return sentAt, nil
+}
+
+// ConfirmEmail uses up the verification with the given ID and marks its address as verified. A pending address
+// replaces the current one. Returns ErrInvalidToken if the verification is unknown, used or expired or its address
+// is neither the current nor the pending one anymore, and ErrUserAlreadyExists if another user took the address.
+func (db *db) ConfirmEmail(ctx context.Context, id openapi_types.UUID, address string) (*api.User, error) {
+	canonical, err := email.Canonicalize(address, db.emailOptions)
+	if err != nil {
+		return nil, ownErrors.ErrInvalidToken
+	}
+	lookup, err := db.emailLookup(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The address of the verification may be encrypted, so it is compared here rather than in SQL. A mismatch rolls
+	// the use back.
+	query := `UPDATE email_verifications SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id, email, pii_key_id IS NOT NULL`
+
+	var (
+		userID    int64
+		sent      string
+		encrypted bool
+	)
+	if err = tx.QueryRow(ctx, query, id).Scan(&userID, &sent, &encrypted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to confirm email: %w", err)
+	}
+	if sent, err = db.openAddress(ctx, "email_verifications.email", id, encrypted, sent); err != nil {
+		return nil, fmt.Errorf("failed to decrypt email verification: %w", err)
+	}
+	if sent != address {
+		return nil, ownErrors.ErrInvalidToken
+	}
+
+	// The addresses of the user may be encrypted, so they are compared here rather than in SQL.
+	var r piiRow
+	query = "SELECT uid, pii_key_id IS NOT NULL, email, pending_email FROM users WHERE id = $1 FOR UPDATE"
+	if err = tx.QueryRow(ctx, query, userID).Scan(&r.uid, &r.sealed, &r.email, &r.pendingEmail); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ownErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to confirm email: %w", err)
+	}
+	// The names stay as they are, so the address follows how the row is stored.
+	s, err := db.rowSealer(r.uid, r.sealed)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.openPIIRow(ctx, &r); err != nil {
+		return nil, fmt.Errorf("failed to decrypt user: %w", err)
+	}
+	pending := r.pendingEmail != nil && *r.pendingEmail == address
+	if r.email != address && !pending {
+		return nil, ownErrors.ErrInvalidToken
+	}
+	sealed := s.seal("email", address)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	query = `UPDATE users SET email = $2, email_canonical = $3, email_verified_at = CURRENT_TIMESTAMP,
+		pending_email = CASE WHEN $4 THEN NULL ELSE pending_email END
+	WHERE id = $1
+	RETURNING ` + userColumns
+
+	var user storedUser
+	if err = tx.QueryRow(ctx, query, userID, sealed, lookup, pending).Scan(userFields(&user)...); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, ownErrors.ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to confirm email: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err = db.openUser(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
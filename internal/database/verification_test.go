@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+func TestCreateEmailVerification(t *testing.T) {
+	expiresAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	v := &api.EmailVerification{ID: testUID(9), UserID: testUID(1), Email: "john@example.com", ExpiresAt: expiresAt}
+
+	t.Run("Created", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything, []any{testUID(9), testUID(1), "john@example.com", noPIIKey, expiresAt}).
+			Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+		assert.NoError(t, db.CreateEmailVerification(context.Background(), v))
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mp.On("Exec", context.Background(), mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil)
+
+		assert.ErrorIs(t, db.CreateEmailVerification(context.Background(), v), ownErrors.ErrNotFound)
+	})
+}
+
+func TestLastEmailVerificationSentAt(t *testing.T) {
+	sentAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Sent before", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(**time.Time) = &sentAt
+		}).Return(nil)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(mr)
+
+		result, err := db.LastEmailVerificationSentAt(context.Background(), testUID(1))
+		require.NoError(t, err)
+		assert.Equal(t, &sentAt, result)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mp := new(MockPool)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything).Return(sql.ErrNoRows)
+		mp.On("QueryRow", context.Background(), mock.Anything, []any{testUID(1)}).Return(mr)
+
+		result, err := db.LastEmailVerificationSentAt(context.Background(), testUID(1))
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ownErrors.ErrNotFound)
+	})
+}
+
+// verificationUsedRow returns the row of a used up verification sent to the address of the user with the given
+// internal ID.
+func verificationUsedRow(userID int64, address string) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*int64) = userID
+		*args.Get(1).(*string) = address
+	}).Return(nil)
+	return mr
+}
+
+// addressesRow returns the row of the current and pending address of a user.
+func addressesRow(current string, pending *string) *MockRow {
+	mr := new(MockRow)
+	mr.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*string) = current
+		*args.Get(3).(**string) = pending
+	}).Return(nil)
+	return mr
+}
+
+func TestConfirmEmail(t *testing.T) {
+	userScan := userScanArgs()
+	pending := "John.New@example.com"
+
+	t.Run("Confirmed", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		verifiedAt := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+		mr := new(MockRow)
+		mr.On("Scan", userScan...).Run(func(args mock.Arguments) {
+			*args.Get(0).(*openapi_types.UUID) = testUID(1)
+			*args.Get(3).(*openapi_types.Email) = "John.New@example.com"
+			*args.Get(6).(**time.Time) = &verifiedAt
+		}).Return(nil)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(9)}).
+			Return(verificationUsedRow(7, "John.New@example.com"))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7)}).
+			Return(addressesRow("john@example.com", &pending))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7), "John.New@example.com", "john.new@example.com", true}).
+			Return(mr)
+		tx.On("Commit", context.Background()).Return(nil)
+		tx.On("Rollback", context.Background()).Return(pgx.ErrTxClosed)
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "John.New@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, testUID(1), user.Id)
+		assert.Equal(t, openapi_types.Email("John.New@example.com"), user.Email)
+		assert.Equal(t, &verifiedAt, user.EmailVerifiedAt)
+		assert.Nil(t, user.PendingEmail)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Used or expired", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, mock.Anything).Return(mr)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "john@example.com")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+	})
+
+	t.Run("Address replaced since", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(9)}).
+			Return(verificationUsedRow(7, "John.New@example.com"))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7)}).
+			Return(addressesRow("john@example.com", nil))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "John.New@example.com")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Sent to another address", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(9)}).
+			Return(verificationUsedRow(7, "jane@example.com"))
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "John.New@example.com")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		tx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Address taken", func(t *testing.T) {
+		mp := new(MockPool)
+		tx := new(MockTx)
+		db := &db{pool: mp}
+		mr := new(MockRow)
+		mr.On("Scan", userScan...).Return(&pgconn.PgError{Code: "23505"})
+		mp.On("Begin", context.Background()).Return(tx, nil)
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{testUID(9)}).
+			Return(verificationUsedRow(7, "jane@example.com"))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7)}).
+			Return(addressesRow("jane@example.com", nil))
+		tx.On("QueryRow", context.Background(), mock.Anything, []any{int64(7), "jane@example.com", "jane@example.com", false}).
+			Return(mr)
+		tx.On("Rollback", context.Background()).Return(nil)
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "jane@example.com")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrUserAlreadyExists)
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		db := &db{pool: new(MockPool)}
+
+		user, err := db.ConfirmEmail(context.Background(), testUID(9), "not-an-email")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+	})
+}
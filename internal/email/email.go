@@ -0,0 +1,59 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+
+	"go-users/internal/ownErrors"
+)
+
+// gmailDomains lists the domains served by Gmail. Both are folded to the first one.
+var gmailDomains = []string{"gmail.com", "googlemail.com"}
+
+// Options configures the provider-specific canonicalization rules. The zero value applies none of them.
+type Options struct {
+	// FoldGmail removes dots and "+tag" suffixes from the local part of Gmail addresses and maps googlemail.com to
+	// gmail.com, as Gmail delivers all these variants to the same mailbox.
+	FoldGmail bool
+}
+
+// Canonicalize returns the canonical form of address, which identifies a mailbox regardless of how the address was
+// typed: the address is NFC-normalized and lower-cased and the domain is IDNA-encoded, e.g. "José@Bücher.DE" becomes
+// "josé@xn--bcher-kva.de". Returns ErrInvalidEmail if the address has no local part or an invalid domain.
+func Canonicalize(address string, opts Options) (string, error) {
+	address = strings.ToLower(norm.NFC.String(strings.TrimSpace(address)))
+
+	at := strings.LastIndexByte(address, '@')
+	if at <= 0 || at == len(address)-1 {
+		return "", fmt.Errorf("%w: %q", ownErrors.ErrInvalidEmail, address)
+	}
+	local, domain := address[:at], address[at+1:]
+
+	domain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %v", ownErrors.ErrInvalidEmail, address, err)
+	}
+
+	if opts.FoldGmail && isGmail(domain) {
+		local, _, _ = strings.Cut(local, "+")
+		local = strings.ReplaceAll(local, ".", "")
+		if local == "" {
+			return "", fmt.Errorf("%w: %q", ownErrors.ErrInvalidEmail, address)
+		}
+		domain = gmailDomains[0]
+	}
+
+	return local + "@" + domain, nil
+}
+
+func isGmail(domain string) bool {
+	for _, d := range gmailDomains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
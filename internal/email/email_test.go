@@ -0,0 +1,44 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		opts      Options
+		address   string
+		expected  string
+		expectErr bool
+	}{
+		{name: "Lower-cased", address: "John.Doe@Example.COM", expected: "john.doe@example.com"},
+		{name: "Surrounding space", address: " john@example.com ", expected: "john@example.com"},
+		{name: "NFC local part", address: "josé@example.com", expected: "josé@example.com"},
+		{name: "IDNA domain", address: "info@Bücher.de", expected: "info@xn--bcher-kva.de"},
+		{name: "Gmail kept without folding", address: "John.Doe+news@GoogleMail.com", expected: "john.doe+news@googlemail.com"},
+		{name: "Gmail folded", opts: Options{FoldGmail: true}, address: "John.Doe+news@GoogleMail.com", expected: "johndoe@gmail.com"},
+		{name: "Other provider not folded", opts: Options{FoldGmail: true}, address: "john.doe+news@example.com", expected: "john.doe+news@example.com"},
+		{name: "Missing domain", address: "john@", expectErr: true},
+		{name: "Missing at sign", address: "john.example.com", expectErr: true},
+		{name: "Invalid domain", address: "john@exa mple.com", expectErr: true},
+		{name: "Gmail without mailbox", opts: Options{FoldGmail: true}, address: "+tag@gmail.com", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			canonical, err := Canonicalize(tc.address, tc.opts)
+
+			if tc.expectErr {
+				assert.ErrorIs(t, err, ownErrors.ErrInvalidEmail)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, canonical)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/config"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// finalizeTimeout bounds the time spent persisting the outcome of a job once its handler has returned.
+const finalizeTimeout = 5 * time.Second
+
+// Job represents a unit of work claimed by a worker.
+type Job struct {
+	ID uint
+	// Org is the organization the job was created in; its handler runs scoped to it.
+	Org      uuid.UUID
+	Type     string
+	Payload  json.RawMessage
+	Attempts int
+}
+
+// Artifact represents a downloadable file produced by a job.
+type Artifact struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Result represents the outcome of a successfully finished job: a summary exposed through the API and an optional artifact.
+type Result struct {
+	Summary  map[string]interface{}
+	Artifact *Artifact
+}
+
+// Progress reports how many items of a job have been processed. It returns an error once the job should stop, e.g.
+// because its cancellation was requested or the pool is shutting down.
+type Progress func(done, total int) error
+
+// Handler executes a job of a particular type.
+type Handler func(ctx context.Context, job *Job, progress Progress) (*Result, error)
+
+// Store defines the persistence operations the worker pool needs to claim jobs and record their outcome.
+type Store interface {
+	ClaimJob(ctx context.Context, staleAfter time.Duration) (*Job, error)
+	UpdateJobProgress(ctx context.Context, id uint, done, total int) (bool, error)
+	HeartbeatJob(ctx context.Context, id uint) (bool, error)
+	CompleteJob(ctx context.Context, id uint, result *Result) error
+	FailJob(ctx context.Context, id uint, reason string) error
+	MarkJobCancelled(ctx context.Context, id uint) error
+	RequeueJob(ctx context.Context, id uint) error
+	// PurgeJobArtifacts deletes the artifacts of the jobs of every organization that finished before the cutoff.
+	PurgeJobArtifacts(ctx context.Context, before time.Time) (int, error)
+}
+
+// Pool represents a fixed-size set of workers polling the Store for queued jobs and executing registered handlers.
+type Pool struct {
+	store    Store
+	logger   *slog.Logger
+	cfg      config.Jobs
+	handlers map[string]Handler
+
+	stop   chan struct{}
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewPool creates a new worker pool backed by the given store. Handlers must be registered before Start is called.
+func NewPool(store Store, logger *slog.Logger, cfg config.Jobs) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Pool{
+		store:    store,
+		logger:   logger,
+		cfg:      cfg,
+		handlers: make(map[string]Handler),
+		stop:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Register associates a handler with a job type, replacing any handler registered previously for the same type.
+func (p *Pool) Register(jobType string, h Handler) {
+	p.handlers[jobType] = h
+}
+
+// Start launches the configured number of workers and the purge of expired artifacts. It returns immediately.
+func (p *Pool) Start() {
+	workers := p.cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.work(i)
+	}
+	p.wg.Add(1)
+	go p.purgeArtifacts()
+
+	p.logger.Info("Job workers started", "workers", workers)
+}
+
+// Shutdown stops claiming new jobs and waits for running jobs to finish. If ctx expires first, running jobs are
+// interrupted and put back into the queue so another instance can pick them up.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.logger.Warn("Job workers did not drain in time, interrupting running jobs")
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// work polls the store for jobs until the pool is stopped.
+func (p *Pool) work(worker int) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		for p.runNext(worker) {
+			select {
+			case <-p.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeArtifacts deletes the artifacts not downloaded within the artifact TTL until the pool is stopped, as exports
+// hold the data of every user in plain text.
+func (p *Pool) purgeArtifacts() {
+	defer p.wg.Done()
+
+	ttl := p.artifactTTL()
+	ticker := time.NewTicker(ttl / 4)
+	defer ticker.Stop()
+
+	for {
+		n, err := p.store.PurgeJobArtifacts(p.ctx, time.Now().Add(-ttl))
+		if err != nil {
+			if p.ctx.Err() == nil {
+				p.logger.Error("Failed to purge job artifacts", "error", err)
+			}
+		} else if n > 0 {
+			p.logger.Info("Job artifacts purged", "artifacts", n)
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and executes a single job. It reports whether a job was found so the worker can keep draining the queue.
+func (p *Pool) runNext(worker int) bool {
+	job, err := p.store.ClaimJob(p.ctx, p.staleTimeout())
+	if err != nil {
+		if !errors.Is(err, ownErrors.ErrNotFound) && p.ctx.Err() == nil {
+			p.logger.Error("Failed to claim job", "worker", worker, "error", err)
+		}
+		return false
+	}
+
+	p.logger.Info("Job started", "worker", worker, "job_id", job.ID, "type", job.Type, "attempt", job.Attempts)
+	start := time.Now()
+
+	err = p.run(job)
+
+	p.logger.Info("Job finished", "worker", worker, "job_id", job.ID, "duration", time.Since(start), "error", err)
+	return true
+}
+
+// run executes the handler of a claimed job and persists its outcome, both scoped to the organization of the job.
+func (p *Pool) run(job *Job) error {
+	ctx, cancel := context.WithCancel(tenant.WithOrg(p.ctx, job.Org))
+	defer cancel()
+
+	var cancelled bool
+	var mu sync.Mutex
+	requestCancel := func() {
+		mu.Lock()
+		cancelled = true
+		mu.Unlock()
+		cancel()
+	}
+
+	progress := func(done, total int) error {
+		cancelRequested, err := p.store.UpdateJobProgress(ctx, job.ID, done, total)
+		if err != nil {
+			return fmt.Errorf("failed to report progress: %w", err)
+		}
+		if cancelRequested {
+			requestCancel()
+			return ownErrors.ErrJobCancelled
+		}
+		return ctx.Err()
+	}
+
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		p.heartbeat(ctx, job.ID, requestCancel)
+	}()
+
+	result, err := p.execute(ctx, job, progress)
+	cancel()
+	<-heartbeatDone
+
+	finalizeCtx, finalizeCancel := context.WithTimeout(tenant.WithOrg(context.Background(), job.Org), finalizeTimeout)
+	defer finalizeCancel()
+
+	mu.Lock()
+	wasCancelled := cancelled
+	mu.Unlock()
+
+	var storeErr error
+	switch {
+	case err == nil:
+		storeErr = p.store.CompleteJob(finalizeCtx, job.ID, result)
+	case wasCancelled || errors.Is(err, ownErrors.ErrJobCancelled):
+		storeErr = p.store.MarkJobCancelled(finalizeCtx, job.ID)
+	case p.ctx.Err() != nil:
+		storeErr = p.store.RequeueJob(finalizeCtx, job.ID)
+	default:
+		storeErr = p.store.FailJob(finalizeCtx, job.ID, err.Error())
+	}
+
+	if storeErr != nil {
+		p.logger.Error("Failed to store job outcome", "job_id", job.ID, "error", storeErr)
+	}
+
+	return err
+}
+
+// execute looks up the handler for the job type and runs it, converting panics into errors.
+func (p *Pool) execute(ctx context.Context, job *Job, progress Progress) (result *Result, err error) {
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job, progress)
+}
+
+// heartbeat periodically marks the job as alive so it is not reclaimed as stale, and watches for cancellation requests.
+func (p *Pool) heartbeat(ctx context.Context, id uint, requestCancel func()) {
+	ticker := time.NewTicker(p.staleTimeout() / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cancelRequested, err := p.store.HeartbeatJob(ctx, id)
+			if err != nil {
+				if ctx.Err() == nil {
+					p.logger.Error("Failed to send job heartbeat", "job_id", id, "error", err)
+				}
+				continue
+			}
+			if cancelRequested {
+				requestCancel()
+				return
+			}
+		}
+	}
+}
+
+func (p *Pool) pollInterval() time.Duration {
+	if p.cfg.PollInterval < 1 {
+		return time.Second
+	}
+	return time.Duration(p.cfg.PollInterval) * time.Second
+}
+
+func (p *Pool) artifactTTL() time.Duration {
+	if p.cfg.ArtifactTTL < 4 {
+		return 4 * time.Second
+	}
+	return time.Duration(p.cfg.ArtifactTTL) * time.Second
+}
+
+func (p *Pool) staleTimeout() time.Duration {
+	if p.cfg.StaleTimeout < 3 {
+		return 3 * time.Second
+	}
+	return time.Duration(p.cfg.StaleTimeout) * time.Second
+}
@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/config"
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// fakeStore is an in-memory Store recording the final state of every job.
+type fakeStore struct {
+	mu              sync.Mutex
+	queue           []*Job
+	outcomes        map[uint]string
+	results         map[uint]*Result
+	reasons         map[uint]string
+	cancelRequested map[uint]bool
+	finished        chan uint
+	purged          chan time.Time
+}
+
+func newFakeStore(jobs ...*Job) *fakeStore {
+	return &fakeStore{
+		queue:           jobs,
+		outcomes:        make(map[uint]string),
+		results:         make(map[uint]*Result),
+		reasons:         make(map[uint]string),
+		cancelRequested: make(map[uint]bool),
+		finished:        make(chan uint, len(jobs)),
+		purged:          make(chan time.Time, 1),
+	}
+}
+
+func (s *fakeStore) ClaimJob(_ context.Context, _ time.Duration) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return nil, ownErrors.ErrNotFound
+	}
+	job := s.queue[0]
+	s.queue = s.queue[1:]
+	job.Attempts++
+	return job, nil
+}
+
+func (s *fakeStore) UpdateJobProgress(_ context.Context, id uint, _, _ int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cancelRequested[id], nil
+}
+
+func (s *fakeStore) HeartbeatJob(ctx context.Context, id uint) (bool, error) {
+	return s.UpdateJobProgress(ctx, id, 0, 0)
+}
+
+func (s *fakeStore) finish(id uint, outcome string) {
+	s.mu.Lock()
+	s.outcomes[id] = outcome
+	s.mu.Unlock()
+	s.finished <- id
+}
+
+func (s *fakeStore) CompleteJob(_ context.Context, id uint, result *Result) error {
+	s.mu.Lock()
+	s.results[id] = result
+	s.mu.Unlock()
+	s.finish(id, "succeeded")
+	return nil
+}
+
+func (s *fakeStore) FailJob(_ context.Context, id uint, reason string) error {
+	s.mu.Lock()
+	s.reasons[id] = reason
+	s.mu.Unlock()
+	s.finish(id, "failed")
+	return nil
+}
+
+func (s *fakeStore) MarkJobCancelled(_ context.Context, id uint) error {
+	s.finish(id, "cancelled")
+	return nil
+}
+
+func (s *fakeStore) RequeueJob(_ context.Context, id uint) error {
+	s.finish(id, "queued")
+	return nil
+}
+
+func (s *fakeStore) PurgeJobArtifacts(_ context.Context, before time.Time) (int, error) {
+	select {
+	case s.purged <- before:
+	default:
+	}
+	return 0, nil
+}
+
+func (s *fakeStore) outcome(id uint) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.outcomes[id]
+}
+
+func waitFinished(t *testing.T, s *fakeStore, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.finished:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %d finished jobs", n)
+		}
+	}
+}
+
+func newTestPool(store Store) *Pool {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewPool(store, logger, config.Jobs{Workers: 2, PollInterval: 1, StaleTimeout: 30})
+}
+
+func TestPool_Outcomes(t *testing.T) {
+	store := newFakeStore(
+		&Job{ID: 1, Type: "ok"},
+		&Job{ID: 2, Type: "boom"},
+		&Job{ID: 3, Type: "panic"},
+		&Job{ID: 4, Type: "unknown"},
+	)
+
+	pool := newTestPool(store)
+	pool.Register("ok", func(_ context.Context, _ *Job, progress Progress) (*Result, error) {
+		if err := progress(1, 1); err != nil {
+			return nil, err
+		}
+		return &Result{Summary: map[string]interface{}{"done": 1}}, nil
+	})
+	pool.Register("boom", func(context.Context, *Job, Progress) (*Result, error) {
+		return nil, errors.New("boom")
+	})
+	pool.Register("panic", func(context.Context, *Job, Progress) (*Result, error) {
+		panic("unexpected")
+	})
+
+	pool.Start()
+	waitFinished(t, store, 4)
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	assert.Equal(t, "succeeded", store.outcome(1))
+	assert.Equal(t, map[string]interface{}{"done": 1}, store.results[1].Summary)
+	assert.Equal(t, "failed", store.outcome(2))
+	assert.Equal(t, "boom", store.reasons[2])
+	assert.Equal(t, "failed", store.outcome(3))
+	assert.Contains(t, store.reasons[3], "panicked")
+	assert.Equal(t, "failed", store.outcome(4))
+	assert.Contains(t, store.reasons[4], `unknown job type "unknown"`)
+}
+
+func TestPool_CancelRequested(t *testing.T) {
+	store := newFakeStore(&Job{ID: 1, Type: "slow"})
+	store.cancelRequested[1] = true
+
+	pool := newTestPool(store)
+	pool.Register("slow", func(ctx context.Context, _ *Job, progress Progress) (*Result, error) {
+		for i := 0; ; i++ {
+			if err := progress(i, 0); err != nil {
+				return nil, err
+			}
+		}
+	})
+
+	pool.Start()
+	waitFinished(t, store, 1)
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	assert.Equal(t, "cancelled", store.outcome(1))
+}
+
+func TestPool_ShutdownRequeuesInterruptedJobs(t *testing.T) {
+	store := newFakeStore(&Job{ID: 1, Type: "block"})
+	started := make(chan struct{})
+
+	pool := newTestPool(store)
+	pool.Register("block", func(ctx context.Context, _ *Job, _ Progress) (*Result, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	pool.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := pool.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	waitFinished(t, store, 1)
+	assert.Equal(t, "queued", store.outcome(1))
+}
+
+func TestPool_PurgesExpiredArtifacts(t *testing.T) {
+	store := newFakeStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	pool := NewPool(store, logger, config.Jobs{Workers: 1, PollInterval: 1, StaleTimeout: 30, ArtifactTTL: 600})
+
+	pool.Start()
+	var before time.Time
+	select {
+	case before = <-store.purged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the artifacts to be purged")
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	assert.WithinDuration(t, time.Now().Add(-600*time.Second), before, 5*time.Second)
+}
+
+func TestPool_RunsJobsInTheirOrganization(t *testing.T) {
+	org := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-00000000a001")
+	store := newFakeStore(&Job{ID: 1, Org: org, Type: "scoped"})
+
+	var seen uuid.UUID
+	pool := newTestPool(store)
+	pool.Register("scoped", func(ctx context.Context, _ *Job, _ Progress) (*Result, error) {
+		seen, _ = tenant.FromContext(ctx)
+		return nil, nil
+	})
+
+	pool.Start()
+	waitFinished(t, store, 1)
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	assert.Equal(t, "succeeded", store.outcome(1))
+	assert.Equal(t, org, seen)
+}
@@ -0,0 +1,217 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// Job types for bulk user operations.
+const (
+	TypeUsersExport  = string(api.JobRequestTypeUsersExport)
+	TypeUsersImport  = string(api.JobRequestTypeUsersImport)
+	TypeUsersReindex = string(api.JobRequestTypeUsersReindex)
+)
+
+const (
+	// exportBatchSize is the number of users read from the store per query during an export.
+	exportBatchSize = 500
+	// reindexBatchSize is the number of users reindexed per transaction.
+	reindexBatchSize = 500
+	// importProgressEvery is the number of imported users between two progress reports.
+	importProgressEvery = 100
+	// maxReportedErrors caps the number of per-item errors kept in an import summary.
+	maxReportedErrors = 100
+)
+
+// UserStore defines the subset of the repository used by the bulk user jobs.
+type UserStore interface {
+	CreateUser(ctx context.Context, user *api.UserRequest) (*api.User, error)
+	ListUsers(ctx context.Context, after openapi_types.UUID, limit int, filter *api.UserFilter) ([]api.User, error)
+	CountUsers(ctx context.Context) (int, error)
+	ReindexUsers(ctx context.Context, after openapi_types.UUID, limit int) (*Reindex, error)
+}
+
+// Reindex is the outcome of reindexing a batch of users.
+type Reindex struct {
+	// Taken is the number of users taken for the batch, Updated how many of them got a new canonical address.
+	Taken   int
+	Updated int
+	// Last is the public ID of the last user taken; the next batch starts after it.
+	Last openapi_types.UUID
+	// Conflicts lists the users whose new canonical address is taken by another user. They keep the old one.
+	Conflicts []openapi_types.UUID
+}
+
+// importPayload represents the payload of a users.import job.
+type importPayload struct {
+	Users []api.UserRequest `json:"users"`
+}
+
+// importError describes a single user that could not be imported.
+type importError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// RegisterUserHandlers registers the handlers for bulk user import, export and reindex jobs in the pool.
+func RegisterUserHandlers(p *Pool, store UserStore) {
+	p.Register(TypeUsersExport, exportUsers(store))
+	p.Register(TypeUsersImport, importUsers(store))
+	p.Register(TypeUsersReindex, reindexUsers(store))
+}
+
+// exportUsers returns a handler writing all users as newline-delimited JSON into the job artifact.
+func exportUsers(store UserStore) Handler {
+	return func(ctx context.Context, _ *Job, progress Progress) (*Result, error) {
+		total, err := store.CountUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+
+		var after openapi_types.UUID
+		done := 0
+		for {
+			users, err := store.ListUsers(ctx, after, exportBatchSize, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range users {
+				if err = enc.Encode(users[i]); err != nil {
+					return nil, fmt.Errorf("failed to encode user: %w", err)
+				}
+			}
+			done += len(users)
+
+			// Users created during the export may push the count over the initial total.
+			if done > total {
+				total = done
+			}
+			if err = progress(done, total); err != nil {
+				return nil, err
+			}
+
+			if len(users) < exportBatchSize {
+				break
+			}
+			after = users[len(users)-1].Id
+		}
+
+		return &Result{
+			Summary: map[string]interface{}{
+				"exported": done,
+			},
+			Artifact: &Artifact{
+				Name:        "users.ndjson",
+				ContentType: "application/x-ndjson",
+				Data:        buf.Bytes(),
+			},
+		}, nil
+	}
+}
+
+// importUsers returns a handler creating the users listed in the job payload. Users that already exist are skipped.
+func importUsers(store UserStore) Handler {
+	return func(ctx context.Context, job *Job, progress Progress) (*Result, error) {
+		var payload importPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("invalid users.import payload: %w", err)
+		}
+
+		total := len(payload.Users)
+		created, skipped := 0, 0
+		failures := make([]importError, 0)
+		failed := 0
+
+		for i := range payload.Users {
+			_, err := store.CreateUser(ctx, &payload.Users[i])
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, ownErrors.ErrUserAlreadyExists):
+				skipped++
+			case ctx.Err() != nil:
+				return nil, ctx.Err()
+			default:
+				failed++
+				if len(failures) < maxReportedErrors {
+					failures = append(failures, importError{Index: i, Error: err.Error()})
+				}
+			}
+
+			if (i+1)%importProgressEvery == 0 || i+1 == total {
+				if err = progress(i+1, total); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return &Result{
+			Summary: map[string]interface{}{
+				"created": created,
+				"skipped": skipped,
+				"failed":  failed,
+				"errors":  failures,
+			},
+		}, nil
+	}
+}
+
+// reindexUsers returns a handler recomputing the canonical addresses of all users, e.g. after the canonicalization
+// options changed. Users whose new address conflicts with another user are reported and left as they are.
+func reindexUsers(store UserStore) Handler {
+	return func(ctx context.Context, _ *Job, progress Progress) (*Result, error) {
+		total, err := store.CountUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var after openapi_types.UUID
+		done, updated := 0, 0
+		conflicts := make([]openapi_types.UUID, 0)
+		for {
+			batch, err := store.ReindexUsers(ctx, after, reindexBatchSize)
+			if err != nil {
+				return nil, err
+			}
+			done += batch.Taken
+			updated += batch.Updated
+			for _, id := range batch.Conflicts {
+				if len(conflicts) < maxReportedErrors {
+					conflicts = append(conflicts, id)
+				}
+			}
+
+			if done > total {
+				total = done
+			}
+			if err = progress(done, total); err != nil {
+				return nil, err
+			}
+
+			if batch.Taken < reindexBatchSize {
+				break
+			}
+			after = batch.Last
+		}
+
+		return &Result{
+			Summary: map[string]interface{}{
+				"reindexed": done,
+				"updated":   updated,
+				"conflicts": conflicts,
+			},
+		}, nil
+	}
+}
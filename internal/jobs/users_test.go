@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+	"go-users/internal/ownErrors"
+)
+
+// fakeUserStore is an in-memory UserStore. Reindexing reports users with an address starting with "dup" as conflicts
+// and updates those starting with "new".
+type fakeUserStore struct {
+	users   []api.User
+	created []api.UserRequest
+}
+
+func (s *fakeUserStore) CreateUser(_ context.Context, u *api.UserRequest) (*api.User, error) {
+	switch {
+	case strings.HasPrefix(string(u.Email), "dup"):
+		return nil, ownErrors.ErrUserAlreadyExists
+	case strings.HasPrefix(string(u.Email), "bad"):
+		return nil, errors.New("db error")
+	}
+	s.created = append(s.created, *u)
+	return &api.User{Email: u.Email}, nil
+}
+
+func (s *fakeUserStore) ListUsers(_ context.Context, after openapi_types.UUID, limit int, _ *api.UserFilter) ([]api.User, error) {
+	result := make([]api.User, 0, limit)
+	for _, u := range s.users {
+		if bytes.Compare(u.Id[:], after[:]) > 0 && len(result) < limit {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeUserStore) CountUsers(context.Context) (int, error) {
+	return len(s.users), nil
+}
+
+func (s *fakeUserStore) ReindexUsers(ctx context.Context, after openapi_types.UUID, limit int) (*Reindex, error) {
+	users, _ := s.ListUsers(ctx, after, limit, nil)
+	r := &Reindex{Taken: len(users)}
+	for _, u := range users {
+		r.Last = u.Id
+		switch {
+		case strings.HasPrefix(string(u.Email), "dup"):
+			r.Conflicts = append(r.Conflicts, u.Id)
+		case strings.HasPrefix(string(u.Email), "new"):
+			r.Updated++
+		}
+	}
+	return r, nil
+}
+
+func TestExportUsers(t *testing.T) {
+	store := &fakeUserStore{}
+	for i := 1; i <= exportBatchSize+3; i++ {
+		store.users = append(store.users, api.User{Id: uuid.MustParse(fmt.Sprintf("00000000-0000-7000-8000-%012d", i)), FirstName: "John", Email: "john@example.com"})
+	}
+
+	var reported [][2]int
+	progress := func(done, total int) error {
+		reported = append(reported, [2]int{done, total})
+		return nil
+	}
+
+	result, err := exportUsers(store)(context.Background(), &Job{ID: 1}, progress)
+	require.NoError(t, err)
+
+	assert.Equal(t, exportBatchSize+3, result.Summary["exported"])
+	assert.Equal(t, [][2]int{{exportBatchSize, exportBatchSize + 3}, {exportBatchSize + 3, exportBatchSize + 3}}, reported)
+	require.NotNil(t, result.Artifact)
+	assert.Equal(t, "application/x-ndjson", result.Artifact.ContentType)
+
+	lines := strings.Split(strings.TrimSpace(string(result.Artifact.Data)), "\n")
+	assert.Len(t, lines, exportBatchSize+3)
+
+	var last api.User
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &last))
+	assert.Equal(t, store.users[len(store.users)-1].Id, last.Id)
+}
+
+func TestImportUsers(t *testing.T) {
+	store := &fakeUserStore{}
+	payload, err := json.Marshal(importPayload{Users: []api.UserRequest{
+		{Email: "john@example.com", FirstName: "John", LastName: "Doe"},
+		{Email: "dup@example.com", FirstName: "Jane", LastName: "Doe"},
+		{Email: "bad@example.com", FirstName: "Jim", LastName: "Doe"},
+	}})
+	require.NoError(t, err)
+
+	var lastDone, lastTotal int
+	progress := func(done, total int) error {
+		lastDone, lastTotal = done, total
+		return nil
+	}
+
+	result, err := importUsers(store)(context.Background(), &Job{ID: 1, Payload: payload}, progress)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Summary["created"])
+	assert.Equal(t, 1, result.Summary["skipped"])
+	assert.Equal(t, 1, result.Summary["failed"])
+	assert.Equal(t, []importError{{Index: 2, Error: "db error"}}, result.Summary["errors"])
+	assert.Equal(t, 3, lastDone)
+	assert.Equal(t, 3, lastTotal)
+	assert.Len(t, store.created, 1)
+}
+
+func TestImportUsers_StopsWhenCancelled(t *testing.T) {
+	store := &fakeUserStore{}
+	users := make([]api.UserRequest, importProgressEvery*2)
+	for i := range users {
+		users[i] = api.UserRequest{Email: "john@example.com"}
+	}
+	payload, err := json.Marshal(importPayload{Users: users})
+	require.NoError(t, err)
+
+	progress := func(int, int) error { return ownErrors.ErrJobCancelled }
+
+	_, err = importUsers(store)(context.Background(), &Job{ID: 1, Payload: payload}, progress)
+
+	assert.ErrorIs(t, err, ownErrors.ErrJobCancelled)
+	assert.Len(t, store.created, importProgressEvery)
+}
+
+func TestReindexUsers(t *testing.T) {
+	store := &fakeUserStore{}
+	for i := 1; i <= reindexBatchSize+2; i++ {
+		address := "john@example.com"
+		switch i {
+		case 3:
+			address = "new@example.com"
+		case reindexBatchSize + 1:
+			address = "dup@example.com"
+		}
+		store.users = append(store.users, api.User{Id: uuid.MustParse(fmt.Sprintf("00000000-0000-7000-8000-%012d", i)), Email: openapi_types.Email(address)})
+	}
+
+	var reported [][2]int
+	progress := func(done, total int) error {
+		reported = append(reported, [2]int{done, total})
+		return nil
+	}
+
+	result, err := reindexUsers(store)(context.Background(), &Job{ID: 1}, progress)
+	require.NoError(t, err)
+
+	assert.Equal(t, reindexBatchSize+2, result.Summary["reindexed"])
+	assert.Equal(t, 1, result.Summary["updated"])
+	assert.Equal(t, []openapi_types.UUID{store.users[reindexBatchSize].Id}, result.Summary["conflicts"])
+	assert.Equal(t, [][2]int{{reindexBatchSize, reindexBatchSize + 2}, {reindexBatchSize + 2, reindexBatchSize + 2}}, reported)
+	assert.Nil(t, result.Artifact)
+}
@@ -0,0 +1,41 @@
+package lifecycle
+
+import (
+	"fmt"
+	"slices"
+
+	"go-users/internal/ownErrors"
+)
+
+// Status represents the lifecycle status of a user.
+type Status string
+
+// Statuses of users. Only active users can log in and use their tokens.
+const (
+	Pending     Status = "pending"
+	Active      Status = "active"
+	Suspended   Status = "suspended"
+	Deactivated Status = "deactivated"
+)
+
+// transitions lists the statuses each status may change to. Pending users are activated by accepting their
+// invitation and may be deactivated before they do.
+var transitions = map[Status][]Status{
+	Pending:     {Active, Deactivated},
+	Active:      {Suspended, Deactivated},
+	Suspended:   {Active, Deactivated},
+	Deactivated: {Active},
+}
+
+// Transition checks whether a user may change from one status to another. Returns ErrInvalidTransition otherwise.
+func Transition(from, to Status) error {
+	if !slices.Contains(transitions[from], to) {
+		return fmt.Errorf("%w: %s to %s", ownErrors.ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// CanLogIn reports whether users with the status may log in and use their tokens.
+func CanLogIn(s Status) bool {
+	return s == Active
+}
@@ -0,0 +1,99 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		from    Status
+		to      Status
+		allowed bool
+	}{
+		{from: Pending, to: Active, allowed: true},
+		{from: Pending, to: Suspended},
+		{from: Pending, to: Deactivated, allowed: true},
+		{from: Active, to: Suspended, allowed: true},
+		{from: Active, to: Deactivated, allowed: true},
+		{from: Active, to: Active},
+		{from: Suspended, to: Active, allowed: true},
+		{from: Suspended, to: Deactivated, allowed: true},
+		{from: Suspended, to: Suspended},
+		{from: Deactivated, to: Active, allowed: true},
+		{from: Deactivated, to: Suspended},
+		{from: Deactivated, to: Pending},
+		{from: "unknown", to: Active},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.from)+" to "+string(tc.to), func(t *testing.T) {
+			err := Transition(tc.from, tc.to)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ownErrors.ErrInvalidTransition)
+			}
+		})
+	}
+}
+
+func TestCanLogIn(t *testing.T) {
+	assert.True(t, CanLogIn(Active))
+	for _, s := range []Status{Pending, Suspended, Deactivated} {
+		assert.False(t, CanLogIn(s), s)
+	}
+}
+
+type fakeStore struct {
+	calls []time.Time
+	err   error
+}
+
+func (s *fakeStore) ReactivateDueUsers(_ context.Context, now time.Time) (int, error) {
+	s.calls = append(s.calls, now)
+	return len(s.calls), s.err
+}
+
+func TestReactivator_Sweep(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Due users reactivated", func(t *testing.T) {
+		store := &fakeStore{}
+		r := NewReactivator(store, logger, time.Minute)
+		r.now = func() time.Time { return now }
+
+		r.Sweep(context.Background())
+
+		assert.Equal(t, []time.Time{now}, store.calls)
+	})
+
+	t.Run("Failures are retried by the next sweep", func(t *testing.T) {
+		store := &fakeStore{err: errors.New("db down")}
+		r := NewReactivator(store, logger, time.Minute)
+
+		r.Sweep(context.Background())
+		r.Sweep(context.Background())
+
+		assert.Len(t, store.calls, 2)
+	})
+
+	t.Run("Start sweeps immediately", func(t *testing.T) {
+		store := &fakeStore{}
+		r := NewReactivator(store, logger, time.Hour)
+
+		r.Start(context.Background())
+		r.Shutdown()
+
+		assert.Len(t, store.calls, 1)
+	})
+}
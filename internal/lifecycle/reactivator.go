@@ -0,0 +1,81 @@
+package lifecycle
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Store defines the persistence operation the reactivator needs.
+type Store interface {
+	// ReactivateDueUsers reactivates the suspended users whose suspension ended at the given time and returns how many
+	// were reactivated.
+	ReactivateDueUsers(ctx context.Context, now time.Time) (int, error)
+}
+
+// Reactivator reactivates users at the end of their scheduled suspension.
+type Reactivator struct {
+	store    Store
+	logger   *slog.Logger
+	interval time.Duration
+
+	now  func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReactivator creates a reactivator that checks for due suspensions at the given interval.
+func NewReactivator(store Store, logger *slog.Logger, interval time.Duration) *Reactivator {
+	return &Reactivator{
+		store:    store,
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start reactivates the users that are already due and keeps checking in the background until Shutdown.
+func (r *Reactivator) Start(ctx context.Context) {
+	r.Sweep(ctx)
+
+	go r.run()
+}
+
+// Shutdown stops the background checks.
+func (r *Reactivator) Shutdown() {
+	close(r.stop)
+	<-r.done
+}
+
+// run sweeps at the check interval until the reactivator is stopped.
+func (r *Reactivator) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+			r.Sweep(ctx)
+			cancel()
+		}
+	}
+}
+
+// Sweep reactivates the users whose suspension has ended. Failures are logged and retried by the next sweep.
+func (r *Reactivator) Sweep(ctx context.Context) {
+	count, err := r.store.ReactivateDueUsers(ctx, r.now())
+	if err != nil {
+		r.logger.Error("Failed to reactivate users", "error", err)
+		return
+	}
+	if count > 0 {
+		r.logger.Info("Reactivated users after their suspension", "count", count)
+	}
+}
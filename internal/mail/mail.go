@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go-users/internal/config"
+)
+
+// Drivers selectable with MAIL_DRIVER.
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+)
+
+// Message represents a plain text mail to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers mails.
+type Sender interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// New creates the sender selected by the configuration.
+func New(cfg config.Mail) (Sender, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTP(cfg, from), nil
+	case DriverFile:
+		return NewMailbox(cfg.Dir, from), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// compose renders a message in RFC 5322 format and returns it together with the parsed recipient.
+func compose(from *mail.Address, m *Message, now time.Time) (*mail.Address, []byte, error) {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+	// Header values are encoded below; line breaks would still allow injecting headers.
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return nil, nil, fmt.Errorf("invalid subject %q", m.Subject)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return to, b.Bytes(), nil
+}
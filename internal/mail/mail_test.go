@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/config"
+)
+
+func TestMailbox_RoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	b := NewMailbox(dir, &mail.Address{Name: "go-users", Address: "no-reply@example.com"})
+
+	first := &Message{To: "John Doe <john@example.com>", Subject: "Bestätigen Sie Ihre Adresse", Body: "Hello,\nplease confirm.\n"}
+	second := &Message{To: "jane@example.com", Subject: "Second", Body: "Hi"}
+	require.NoError(t, b.Send(context.Background(), first))
+	require.NoError(t, b.Send(context.Background(), second))
+
+	messages, err := b.Messages()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, Message{To: "john@example.com", Subject: first.Subject, Body: first.Body}, messages[0])
+	assert.Equal(t, *second, messages[1])
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	raw, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "From: \"go-users\" <no-reply@example.com>\r\n")
+}
+
+func TestMailbox_Empty(t *testing.T) {
+	messages, err := NewMailbox(t.TempDir(), &mail.Address{Address: "no-reply@example.com"}).Messages()
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestCompose_Rejected(t *testing.T) {
+	from := &mail.Address{Address: "no-reply@example.com"}
+
+	_, _, err := compose(from, &Message{To: "john@example.com", Subject: "Hi\r\nBcc: jane@example.com"}, time.Now())
+	assert.Error(t, err)
+
+	_, _, err = compose(from, &Message{To: "john@example.com\r\nBcc: jane@example.com", Subject: "Hi"}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	sender, err := New(config.Mail{Driver: DriverFile, From: "go-users <no-reply@example.com>", Dir: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &Mailbox{}, sender)
+
+	sender, err = New(config.Mail{Driver: DriverSMTP, From: "no-reply@example.com", SMTPHost: "localhost", SMTPPort: 587})
+	require.NoError(t, err)
+	assert.IsType(t, &SMTP{}, sender)
+
+	_, err = New(config.Mail{Driver: "pigeon", From: "no-reply@example.com"})
+	assert.Error(t, err)
+
+	_, err = New(config.Mail{Driver: DriverFile, From: "not an address"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mailbox writes every mail as .eml file into a directory instead of delivering it. It is meant for development
+// and tests, which read the mails back with Messages.
+type Mailbox struct {
+	dir  string
+	from *mail.Address
+	now  func() time.Time
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewMailbox creates a sender writing to dir, which is created on the first mail.
+func NewMailbox(dir string, from *mail.Address) *Mailbox {
+	return &Mailbox{dir: dir, from: from, now: time.Now}
+}
+
+// Send writes a message to the mailbox.
+func (b *Mailbox) Send(_ context.Context, m *Message) error {
+	now := b.now()
+	_, msg, err := compose(b.from, m, now)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(b.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create mailbox: %w", err)
+	}
+
+	// The sequence number keeps names unique and ordered within one process.
+	b.mu.Lock()
+	b.seq++
+	name := fmt.Sprintf("%d-%06d.eml", now.UnixNano(), b.seq)
+	b.mu.Unlock()
+
+	if err = os.WriteFile(filepath.Join(b.dir, name), msg, 0o640); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	return nil
+}
+
+// Messages reads the mails of the mailbox in the order they were sent.
+func (b *Mailbox) Messages() ([]Message, error) {
+	paths, err := filepath.Glob(filepath.Join(b.dir, "*.eml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mails: %w", err)
+	}
+	sort.Strings(paths)
+
+	messages := make([]Message, 0, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mail: %w", err)
+		}
+		m, err := parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mail %s: %w", filepath.Base(path), err)
+		}
+		messages = append(messages, *m)
+	}
+
+	return messages, nil
+}
+
+// parse reads a mail written by compose.
+func parse(raw []byte) (*Message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := mail.ParseAddress(msg.Header.Get("To"))
+	if err != nil {
+		return nil, err
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      to.Address,
+		Subject: subject,
+		Body:    strings.ReplaceAll(string(body), "\r\n", "\n"),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"go-users/internal/config"
+)
+
+// SMTP delivers mails through an SMTP server. Servers offering STARTTLS are used encrypted; credentials are only
+// sent over encrypted connections, as enforced by net/smtp.
+type SMTP struct {
+	addr string
+	from *mail.Address
+	auth smtp.Auth
+}
+
+// NewSMTP creates a sender for the SMTP server of the configuration. Without a username no authentication is used.
+func NewSMTP(cfg config.Mail, from *mail.Address) *SMTP {
+	s := &SMTP{
+		addr: net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		from: from,
+	}
+	if cfg.SMTPUsername != "" {
+		s.auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return s
+}
+
+// Send delivers a message. net/smtp does not support contexts, so the context is not honored.
+func (s *SMTP) Send(_ context.Context, m *Message) error {
+	to, msg, err := compose(s.from, m, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = smtp.SendMail(s.addr, s.auth, s.from.Address, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
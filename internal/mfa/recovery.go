@@ -0,0 +1,57 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+const (
+	// recoveryCodeGroups and recoveryCodeGroupLength define the format of recovery codes, e.g. 7kq2-m9xd-4hpt.
+	recoveryCodeGroups      = 3
+	recoveryCodeGroupLength = 4
+	// recoveryAlphabet leaves out characters that are easily confused.
+	recoveryAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+)
+
+// GenerateRecoveryCodes returns n new one-time recovery codes together with the hashes under which they are stored.
+func GenerateRecoveryCodes(n int) ([]string, [][]byte, error) {
+	codes := make([]string, 0, n)
+	hashes := make([][]byte, 0, n)
+
+	for range n {
+		raw := make([]byte, recoveryCodeGroups*recoveryCodeGroupLength)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		var b strings.Builder
+		for i, r := range raw {
+			if i > 0 && i%recoveryCodeGroupLength == 0 {
+				b.WriteByte('-')
+			}
+			// The slight modulo bias is irrelevant at this code length.
+			b.WriteByte(recoveryAlphabet[int(r)%len(recoveryAlphabet)])
+		}
+
+		code := b.String()
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the stored hash of a recovery code. Case, spaces and dashes are ignored.
+func HashRecoveryCode(code string) []byte {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	hash := sha256.Sum256([]byte(normalized))
+	return hash[:]
+}
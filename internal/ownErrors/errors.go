@@ -8,3 +8,57 @@ import (
 var ErrNotFound = fmt.Errorf("record not found")
 
 var ErrUserAlreadyExists = fmt.Errorf("user already exists")
+
+// ErrInvalidEmail is used to indicate that an email address cannot be brought into its canonical form.
+var ErrInvalidEmail = fmt.Errorf("invalid email address")
+
+// ErrJobFinished is used to indicate that a job can no longer be changed because it has already finished.
+var ErrJobFinished = fmt.Errorf("job already finished")
+
+// ErrJobCancelled is used to indicate that a running job stopped because its cancellation was requested.
+var ErrJobCancelled = fmt.Errorf("job cancelled")
+
+// ErrInvalidToken is used to indicate that a token is unknown, expired or revoked.
+var ErrInvalidToken = fmt.Errorf("invalid or expired token")
+
+// ErrMFAEnabled is used to indicate that MFA cannot be enrolled because it is already enabled.
+var ErrMFAEnabled = fmt.Errorf("MFA already enabled")
+
+// ErrInvitationClosed is used to indicate that an invitation can no longer be changed because it was accepted or revoked.
+var ErrInvitationClosed = fmt.Errorf("invitation already accepted or revoked")
+
+// ErrInvalidTransition is used to indicate that a user cannot change from their current status to the requested one.
+var ErrInvalidTransition = fmt.Errorf("status transition not allowed")
+
+// ErrGroupAlreadyExists is used to indicate that another group already has the requested name.
+var ErrGroupAlreadyExists = fmt.Errorf("group already exists")
+
+// ErrGroupCycle is used to indicate that nesting a group would make it a member of itself.
+var ErrGroupCycle = fmt.Errorf("group nesting would create a cycle")
+
+// ErrOrganizationAlreadyExists is used to indicate that another organization already has the requested slug.
+var ErrOrganizationAlreadyExists = fmt.Errorf("organization already exists")
+
+// ErrInvalidAttributes is used to indicate that the custom attributes of a user do not satisfy the attribute schema.
+var ErrInvalidAttributes = fmt.Errorf("invalid attributes")
+
+// ErrInvalidAttributeSchema is used to indicate that an attribute schema is malformed.
+var ErrInvalidAttributeSchema = fmt.Errorf("invalid attribute schema")
+
+// ErrInvalidProfile is used to indicate that profile fields of a user, such as the phone number or time zone, are invalid.
+var ErrInvalidProfile = fmt.Errorf("invalid profile")
+
+// ErrUnsupportedImage is used to indicate that an upload is not a JPEG, PNG or WebP image or cannot be decoded.
+var ErrUnsupportedImage = fmt.Errorf("unsupported image format")
+
+// ErrImageTooLarge is used to indicate that an image exceeds the upload size or pixel limit.
+var ErrImageTooLarge = fmt.Errorf("image too large")
+
+// ErrUserErased is used to indicate that the personal data of a user has already been erased.
+var ErrUserErased = fmt.Errorf("user already erased")
+
+// ErrLegalHold is used to indicate that a user cannot be deleted, erased or purged because they are under legal hold.
+var ErrLegalHold = fmt.Errorf("user is under legal hold")
+
+// ErrLegalHoldReleased is used to indicate that a legal hold can no longer be changed because it has been released.
+var ErrLegalHoldReleased = fmt.Errorf("legal hold already released")
@@ -0,0 +1,29 @@
+package password
+
+import "time"
+
+// Lockout represents the account lockout rules applied after failed logins. Once Threshold consecutive failures are
+// reached the account is locked for BaseCooldown, which doubles with every further failure up to MaxCooldown.
+type Lockout struct {
+	Threshold    int
+	BaseCooldown time.Duration
+	MaxCooldown  time.Duration
+}
+
+// Cooldown returns how long an account stays locked after the given number of consecutive failures, or zero if it
+// stays unlocked.
+func (l Lockout) Cooldown(failures int) time.Duration {
+	if l.Threshold <= 0 || failures < l.Threshold {
+		return 0
+	}
+
+	cooldown := l.BaseCooldown
+	for i := l.Threshold; i < failures; i++ {
+		cooldown *= 2
+		if cooldown >= l.MaxCooldown {
+			return l.MaxCooldown
+		}
+	}
+
+	return min(cooldown, l.MaxCooldown)
+}
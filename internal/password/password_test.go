@@ -0,0 +1,83 @@
+package password
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParams keeps the hashing cost low so that tests run fast.
+var testParams = Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHasher(t *testing.T) {
+	h, err := NewHasher(testParams)
+	require.NoError(t, err)
+
+	encoded, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=64,t=1,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`, encoded)
+
+	t.Run("Match", func(t *testing.T) {
+		match, needsRehash, err := h.Verify("correct horse battery staple", encoded)
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.False(t, needsRehash)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		match, _, err := h.Verify("wrong", encoded)
+		assert.NoError(t, err)
+		assert.False(t, match)
+	})
+
+	t.Run("Rehash after parameter change", func(t *testing.T) {
+		stronger, err := NewHasher(Params{Memory: 128, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+		require.NoError(t, err)
+
+		match, needsRehash, err := stronger.Verify("correct horse battery staple", encoded)
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("No stored hash", func(t *testing.T) {
+		match, _, err := h.Verify("anything", "")
+		assert.NoError(t, err)
+		assert.False(t, match)
+	})
+
+	t.Run("Invalid hash", func(t *testing.T) {
+		_, _, err := h.Verify("anything", "$2a$10$abcdefghijklmnopqrstuv")
+		assert.ErrorIs(t, err, ErrInvalidHash)
+	})
+}
+
+func TestNewHasher_InvalidParams(t *testing.T) {
+	_, err := NewHasher(Params{})
+	assert.Error(t, err)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := Policy{MinLength: 12, MaxLength: 64, MinClasses: 3}
+
+	assert.Empty(t, policy.Validate("Tr0ub4dor&3x!", "john@example.com"))
+	assert.Equal(t, []string{
+		"must be at least 12 characters long",
+		"must mix at least 3 of lower case letters, upper case letters, digits and other characters",
+	}, policy.Validate("short", "john@example.com"))
+	assert.Equal(t, []string{"must not contain the email address"}, policy.Validate("John-Secret-2024", "john@example.com"))
+}
+
+func TestLockout_Cooldown(t *testing.T) {
+	lockout := Lockout{Threshold: 3, BaseCooldown: 30 * time.Second, MaxCooldown: 5 * time.Minute}
+
+	assert.Zero(t, lockout.Cooldown(2))
+	assert.Equal(t, 30*time.Second, lockout.Cooldown(3))
+	assert.Equal(t, time.Minute, lockout.Cooldown(4))
+	assert.Equal(t, 4*time.Minute, lockout.Cooldown(6))
+	assert.Equal(t, 5*time.Minute, lockout.Cooldown(7))
+	assert.Equal(t, 5*time.Minute, lockout.Cooldown(100))
+	assert.Zero(t, Lockout{}.Cooldown(100))
+}
@@ -0,0 +1,60 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Policy represents the rules a new password must satisfy.
+type Policy struct {
+	MinLength int
+	MaxLength int
+	// MinClasses is the number of character classes (lower case, upper case, digits, other) a password must mix.
+	MinClasses int
+}
+
+// Validate checks password against the policy and returns a description of every violated rule. The email address
+// of the user is used to reject passwords containing its local part.
+func (p Policy) Validate(password, email string) []string {
+	violations := make([]string, 0)
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", p.MaxLength))
+	}
+
+	if classes := characterClasses(password); classes < p.MinClasses {
+		violations = append(violations, fmt.Sprintf(
+			"must mix at least %d of lower case letters, upper case letters, digits and other characters", p.MinClasses))
+	}
+
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if len(local) >= 3 && strings.Contains(strings.ToLower(password), local) {
+		violations = append(violations, "must not contain the email address")
+	}
+
+	return violations
+}
+
+// characterClasses counts the character classes used in s.
+func characterClasses(s string) int {
+	var lower, upper, digit, other int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
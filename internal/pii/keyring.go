@@ -0,0 +1,447 @@
+package pii
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/config"
+)
+
+const (
+	// sealedPrefix marks ciphertexts.
+	sealedPrefix = "pii:"
+	// indexPrefix marks blind indexes.
+	indexPrefix = "hmac:"
+)
+
+var (
+	// ErrInvalidCiphertext is returned for ciphertexts that are malformed or fail authentication.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+	// errNoKeys is returned when the keys have not been loaded yet.
+	errNoKeys = errors.New("personal data keys not loaded")
+)
+
+// Store defines the persistence operations the keyring needs. Keys are shared by all instances through the store.
+type Store interface {
+	// ListPIIKeys returns all keys.
+	ListPIIKeys(ctx context.Context) ([]Key, error)
+	// CreatePIIKey stores a new key. An index key is only stored if none exists, so that instances starting
+	// concurrently agree on a single one.
+	CreatePIIKey(ctx context.Context, key *Key) error
+	// RewrapPIIKey replaces the wrapped form of a key.
+	RewrapPIIKey(ctx context.Context, id int32, wrapped []byte, kekID string) error
+	// ReencryptUsers encrypts up to limit users ordered by public ID after the given one with the current data key.
+	// Only unencrypted users are taken if unencrypted is set, otherwise also those encrypted with an older data key.
+	ReencryptUsers(ctx context.Context, after uuid.UUID, limit int, unencrypted bool) (*Reencryption, error)
+}
+
+// Reencryption is the outcome of re-encrypting a batch of users.
+type Reencryption struct {
+	// Taken is the number of users taken for the batch, Encrypted how many of them were encrypted.
+	Taken     int
+	Encrypted int
+	// Last is the public ID of the last user taken; the next batch starts after it.
+	Last uuid.UUID
+	// Failed lists the users whose personal data could not be decrypted. They are skipped and left as they are.
+	Failed []uuid.UUID
+}
+
+// DataKey is a data key that seals values.
+type DataKey struct {
+	ID   int32
+	aead cipher.AEAD
+}
+
+// Keyring encrypts personal data with rotating data keys and computes blind indexes for looking up encrypted values.
+// The data keys and the index key are stored wrapped with the key-encryption key, which never leaves the
+// configuration.
+type Keyring struct {
+	store  Store
+	logger *slog.Logger
+
+	kek      *kek
+	previous map[string]*kek
+	interval time.Duration
+	check    time.Duration
+	batch    int
+
+	mu      sync.RWMutex
+	data    map[int32]*DataKey
+	current *DataKey
+	index   []byte
+
+	now  func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewKeyring creates a keyring backed by the given store. The keys are loaded by Start. Returns nil if no
+// key-encryption key is configured, which leaves personal data unencrypted.
+func NewKeyring(store Store, logger *slog.Logger, cfg config.PII) (*Keyring, error) {
+	if cfg.KEK == "" && cfg.KEKFile == "" {
+		return nil, nil
+	}
+
+	current, err := readKEK(cfg.KEK, cfg.KEKFile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]*kek, len(cfg.PreviousKEKs))
+	for _, encoded := range cfg.PreviousKEKs {
+		if strings.TrimSpace(encoded) == "" {
+			continue
+		}
+		k, err := parseKEK(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("previous %w", err)
+		}
+		previous[k.id] = k
+	}
+
+	if cfg.RotationInterval <= 0 || cfg.CheckInterval <= 0 || cfg.ReencryptBatch <= 0 {
+		return nil, fmt.Errorf("personal data key rotation interval, check interval and re-encryption batch must be positive")
+	}
+
+	return &Keyring{
+		store:    store,
+		logger:   logger,
+		kek:      current,
+		previous: previous,
+		interval: time.Duration(cfg.RotationInterval) * time.Second,
+		check:    time.Duration(cfg.CheckInterval) * time.Second,
+		batch:    cfg.ReencryptBatch,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start loads the keys, creating the first ones if necessary, and encrypts the users stored without encryption, so
+// that lookups by blind index find every user. Afterwards it keeps rotating the data key and re-encrypting users in
+// the background until Shutdown is called.
+func (k *Keyring) Start(ctx context.Context) error {
+	if err := k.Refresh(ctx); err != nil {
+		return err
+	}
+
+	count, err := k.reencrypt(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt users: %w", err)
+	}
+	if count > 0 {
+		k.logger.Info("Encrypted personal data of users", "count", count)
+	}
+
+	go k.run()
+
+	return nil
+}
+
+// Shutdown stops the background rotation and re-encryption.
+func (k *Keyring) Shutdown() {
+	close(k.stop)
+	<-k.done
+}
+
+// run refreshes the keys and re-encrypts users at the check interval until the keyring is stopped.
+func (k *Keyring) run() {
+	defer close(k.done)
+
+	ticker := time.NewTicker(k.check)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), k.check)
+			if err := k.Refresh(ctx); err != nil {
+				k.logger.Error("Failed to refresh personal data keys", "error", err)
+			} else if count, err := k.reencrypt(ctx, false); err != nil {
+				k.logger.Error("Failed to re-encrypt users", "error", err, "count", count)
+			} else if count > 0 {
+				k.logger.Info("Re-encrypted personal data of users", "count", count)
+			}
+			cancel()
+		}
+	}
+}
+
+// reencrypt encrypts users with the current data key batch by batch until none is left or the keyring is stopped.
+// Users whose personal data cannot be decrypted are logged and skipped. Returns how many users were encrypted.
+func (k *Keyring) reencrypt(ctx context.Context, unencrypted bool) (int, error) {
+	total := 0
+	var after uuid.UUID
+	for {
+		r, err := k.store.ReencryptUsers(ctx, after, k.batch, unencrypted)
+		if err != nil {
+			return total, err
+		}
+		total += r.Encrypted
+		if len(r.Failed) > 0 {
+			k.logger.Error("Failed to decrypt personal data of users", "users", r.Failed)
+		}
+		if r.Taken < k.batch {
+			return total, nil
+		}
+		after = r.Last
+
+		select {
+		case <-k.stop:
+			return total, nil
+		default:
+		}
+	}
+}
+
+// Refresh reloads the keys from the store. It creates the index key and a data key if there are none, a new data key
+// once the current one has been in use for the rotation interval, and rewraps keys wrapped with a previous
+// key-encryption key.
+func (k *Keyring) Refresh(ctx context.Context) error {
+	stored, err := k.store.ListPIIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list personal data keys: %w", err)
+	}
+
+	var created bool
+	if !hasPurpose(stored, PurposeIndex) {
+		if err = k.create(ctx, PurposeIndex); err != nil {
+			return err
+		}
+		created = true
+	}
+	if newest := newestData(stored); newest == nil || !k.now().Before(newest.CreatedAt.Add(k.interval)) {
+		if err = k.create(ctx, PurposeData); err != nil {
+			return err
+		}
+		created = true
+	}
+
+	if created {
+		if stored, err = k.store.ListPIIKeys(ctx); err != nil {
+			return fmt.Errorf("failed to list personal data keys: %w", err)
+		}
+	}
+
+	return k.load(ctx, stored)
+}
+
+// create stores a new key for the given purpose.
+func (k *Keyring) create(ctx context.Context, purpose string) error {
+	key, err := k.kek.generateKey(purpose)
+	if err != nil {
+		return err
+	}
+	if err = k.store.CreatePIIKey(ctx, key); err != nil {
+		return fmt.Errorf("failed to store personal data key: %w", err)
+	}
+	k.logger.Info("Personal data key created", "purpose", purpose)
+	return nil
+}
+
+// load unwraps the stored keys, rewrapping those wrapped with a previous key-encryption key, and makes the newest
+// data key the current one.
+func (k *Keyring) load(ctx context.Context, stored []Key) error {
+	data := make(map[int32]*DataKey, len(stored))
+	var (
+		current *DataKey
+		index   []byte
+	)
+
+	for _, s := range stored {
+		raw, err := k.unwrap(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		switch s.Purpose {
+		case PurposeIndex:
+			index = raw
+		case PurposeData:
+			aead, err := newAEAD(raw)
+			if err != nil {
+				return err
+			}
+			key := &DataKey{ID: s.ID, aead: aead}
+			data[s.ID] = key
+			if current == nil || s.ID > current.ID {
+				current = key
+			}
+		}
+	}
+
+	if current == nil || index == nil {
+		return errNoKeys
+	}
+
+	k.mu.Lock()
+	k.data = data
+	k.current = current
+	k.index = index
+	k.mu.Unlock()
+
+	return nil
+}
+
+// unwrap returns the raw form of a stored key. Keys wrapped with a previous key-encryption key are rewrapped with the
+// current one.
+func (k *Keyring) unwrap(ctx context.Context, stored Key) ([]byte, error) {
+	if stored.KEKID == k.kek.id {
+		return k.kek.unwrap(stored)
+	}
+
+	previous, ok := k.previous[stored.KEKID]
+	if !ok {
+		return nil, fmt.Errorf("key %d is wrapped with unknown key-encryption key %s", stored.ID, stored.KEKID)
+	}
+
+	raw, err := previous.unwrap(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := k.kek.wrap(stored.Purpose, raw)
+	if err != nil {
+		return nil, err
+	}
+	if err = k.store.RewrapPIIKey(ctx, stored.ID, wrapped, k.kek.id); err != nil {
+		return nil, fmt.Errorf("failed to rewrap key %d: %w", stored.ID, err)
+	}
+	k.logger.Info("Personal data key rewrapped", "id", stored.ID, "kek", k.kek.id)
+
+	return raw, nil
+}
+
+// hasPurpose reports whether a key with the given purpose is stored.
+func hasPurpose(keys []Key, purpose string) bool {
+	for _, key := range keys {
+		if key.Purpose == purpose {
+			return true
+		}
+	}
+	return false
+}
+
+// newestData returns the most recent data key, or nil if there is none.
+func newestData(keys []Key) *Key {
+	var newest *Key
+	for i := range keys {
+		if keys[i].Purpose == PurposeData && (newest == nil || keys[i].ID > newest.ID) {
+			newest = &keys[i]
+		}
+	}
+	return newest
+}
+
+// Current returns the data key that seals new values.
+func (k *Keyring) Current() (*DataKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.current == nil {
+		return nil, errNoKeys
+	}
+	return k.current, nil
+}
+
+// Seal encrypts the value of a column of the row with the given public ID. The column and the row are authenticated,
+// so a ciphertext cannot be moved to another column or row.
+func (d *DataKey) Seal(column string, row uuid.UUID, value string) (string, error) {
+	sealed, err := seal(d.aead, []byte(value), additionalData(column, row))
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 4, 4+len(sealed))
+	binary.BigEndian.PutUint32(buf, uint32(d.ID))
+	return sealedPrefix + base64.RawURLEncoding.EncodeToString(append(buf, sealed...)), nil
+}
+
+// Open decrypts the value of a column of the row with the given public ID sealed by any data key. A data key created
+// by another instance since the last refresh is loaded from the store.
+func (k *Keyring) Open(ctx context.Context, column string, row uuid.UUID, value string) (string, error) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return "", ErrInvalidCiphertext
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value[len(sealedPrefix):])
+	if err != nil || len(raw) < 4 {
+		return "", ErrInvalidCiphertext
+	}
+	id := int32(binary.BigEndian.Uint32(raw))
+
+	key, err := k.dataKey(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(key.aead, raw[4:], additionalData(column, row))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// additionalData returns the data authenticated with a sealed value of a column of a row.
+func additionalData(column string, row uuid.UUID) []byte {
+	return append([]byte(column+":"), row[:]...)
+}
+
+// dataKey returns the data key with the given ID, reloading the keys once if it is unknown.
+func (k *Keyring) dataKey(ctx context.Context, id int32) (*DataKey, error) {
+	k.mu.RLock()
+	key, ok := k.data[id]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	stored, err := k.store.ListPIIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal data keys: %w", err)
+	}
+	if err = k.load(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	key, ok = k.data[id]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown data key %d", ErrInvalidCiphertext, id)
+	}
+	return key, nil
+}
+
+// Index returns the blind index of a value: a keyed hash that finds equal values without revealing them.
+func (k *Keyring) Index(value string) (string, error) {
+	k.mu.RLock()
+	index := k.index
+	k.mu.RUnlock()
+	if index == nil {
+		return "", errNoKeys
+	}
+
+	mac := hmac.New(sha256.New, index)
+	mac.Write([]byte(value))
+	return indexPrefix + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// IsIndex reports whether a value is a blind index rather than plaintext.
+func IsIndex(value string) bool {
+	return strings.HasPrefix(value, indexPrefix)
+}
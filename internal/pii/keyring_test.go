@@ -0,0 +1,257 @@
+package pii
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/config"
+)
+
+// memoryStore is an in-memory Store. Keys are created at *now. Re-encryption returns the queued batches.
+type memoryStore struct {
+	now        *time.Time
+	keys       []Key
+	reencrypts []bool
+	batches    []Reencryption
+	after      []uuid.UUID
+}
+
+func (s *memoryStore) ListPIIKeys(context.Context) ([]Key, error) {
+	return append([]Key(nil), s.keys...), nil
+}
+
+func (s *memoryStore) CreatePIIKey(_ context.Context, key *Key) error {
+	if key.Purpose == PurposeIndex && hasPurpose(s.keys, PurposeIndex) {
+		return nil
+	}
+	stored := *key
+	stored.ID = int32(len(s.keys) + 1)
+	stored.CreatedAt = *s.now
+	s.keys = append(s.keys, stored)
+	return nil
+}
+
+func (s *memoryStore) RewrapPIIKey(_ context.Context, id int32, wrapped []byte, kekID string) error {
+	for i := range s.keys {
+		if s.keys[i].ID == id {
+			s.keys[i].Wrapped, s.keys[i].KEKID = wrapped, kekID
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) ReencryptUsers(_ context.Context, after uuid.UUID, _ int, unencrypted bool) (*Reencryption, error) {
+	s.reencrypts = append(s.reencrypts, unencrypted)
+	s.after = append(s.after, after)
+	if len(s.batches) == 0 {
+		return &Reencryption{}, nil
+	}
+	r := s.batches[0]
+	s.batches = s.batches[1:]
+	return &r, nil
+}
+
+// testRow is the row the values of the tests belong to.
+var testRow = uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-5a6b7c8d9e0f")
+
+func randomKEK(t *testing.T) string {
+	raw := make([]byte, keySize)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func newTestKeyring(t *testing.T, store *memoryStore, kek string, previous ...string) *Keyring {
+	k, err := NewKeyring(store, slog.New(slog.NewTextHandler(io.Discard, nil)), config.PII{
+		KEK:              kek,
+		PreviousKEKs:     previous,
+		RotationInterval: 90 * 86400,
+		CheckInterval:    300,
+		ReencryptBatch:   500,
+	})
+	require.NoError(t, err)
+	k.now = func() time.Time { return *store.now }
+	return k
+}
+
+func TestNewKeyring(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.PII{RotationInterval: 3600, CheckInterval: 60, ReencryptBatch: 100}
+
+	k, err := NewKeyring(&memoryStore{}, logger, cfg)
+	require.NoError(t, err)
+	assert.Nil(t, k, "encryption is disabled without a key-encryption key")
+
+	cfg.KEK = base64.StdEncoding.EncodeToString([]byte("too short"))
+	_, err = NewKeyring(&memoryStore{}, logger, cfg)
+	assert.ErrorContains(t, err, "must be 32 bytes")
+
+	cfg.KEK = randomKEK(t)
+	cfg.PreviousKEKs = []string{"not base64!"}
+	_, err = NewKeyring(&memoryStore{}, logger, cfg)
+	assert.ErrorContains(t, err, "previous key-encryption key is not base64")
+}
+
+func TestKeyring_SealAndOpen(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	store := &memoryStore{now: &now}
+	k := newTestKeyring(t, store, randomKEK(t))
+	require.NoError(t, k.Start(ctx))
+	defer k.Shutdown()
+	assert.Equal(t, []bool{true}, store.reencrypts, "unencrypted users are encrypted on start")
+
+	john, jane := uuid.New(), uuid.New()
+	key, err := k.Current()
+	require.NoError(t, err)
+	sealed, err := key.Seal("email", john, "john@example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, sealedPrefix))
+	assert.NotContains(t, sealed, "john")
+
+	opened, err := k.Open(ctx, "email", john, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", opened)
+
+	_, err = k.Open(ctx, "first_name", john, sealed)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext, "a ciphertext cannot be moved to another column")
+
+	_, err = k.Open(ctx, "email", jane, sealed)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext, "a ciphertext cannot be moved to another row")
+
+	_, err = k.Open(ctx, "email", john, sealed[:len(sealed)-2])
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, err = k.Open(ctx, "email", john, "jane@example.com")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext, "plaintext is no ciphertext")
+}
+
+func TestKeyring_Reencrypt(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	last, bad := uuid.New(), uuid.New()
+	store := &memoryStore{now: &now, batches: []Reencryption{
+		{Taken: 500, Encrypted: 499, Last: last, Failed: []uuid.UUID{bad}},
+		{Taken: 20, Encrypted: 20},
+	}}
+	k := newTestKeyring(t, store, randomKEK(t))
+	require.NoError(t, k.Refresh(context.Background()))
+
+	count, err := k.reencrypt(context.Background(), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 519, count, "users that cannot be decrypted do not stop the re-encryption")
+	assert.Equal(t, []uuid.UUID{uuid.Nil, last}, store.after, "the next batch starts after the last user taken")
+}
+
+func TestKeyring_Index(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	store := &memoryStore{now: &now}
+	kek := randomKEK(t)
+	k := newTestKeyring(t, store, kek)
+
+	_, err := k.Index("john@example.com")
+	assert.ErrorIs(t, err, errNoKeys)
+
+	require.NoError(t, k.Refresh(context.Background()))
+	index, err := k.Index("john@example.com")
+	require.NoError(t, err)
+	assert.True(t, IsIndex(index))
+	assert.False(t, IsIndex("john@example.com"))
+
+	// Another instance sharing the store computes the same index.
+	other := newTestKeyring(t, store, kek)
+	require.NoError(t, other.Refresh(context.Background()))
+	same, err := other.Index("john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, index, same)
+
+	different, err := k.Index("jane@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, index, different)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	now := start
+	store := &memoryStore{now: &now}
+	kek := randomKEK(t)
+	k := newTestKeyring(t, store, kek)
+	other := newTestKeyring(t, store, kek)
+
+	require.NoError(t, k.Refresh(ctx))
+	require.Len(t, store.keys, 2)
+	first, err := k.Current()
+	require.NoError(t, err)
+	sealed, err := first.Seal("last_name", testRow, "Doe")
+	require.NoError(t, err)
+
+	now = start.Add(89 * 24 * time.Hour)
+	require.NoError(t, k.Refresh(ctx))
+	assert.Len(t, store.keys, 2)
+
+	// After the rotation interval a new data key seals; values sealed with the previous one still open, also on an
+	// instance that has not refreshed since.
+	now = start.Add(90 * 24 * time.Hour)
+	require.NoError(t, other.Refresh(ctx))
+	require.Len(t, store.keys, 3)
+	second, err := other.Current()
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	opened, err := other.Open(ctx, "last_name", testRow, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "Doe", opened)
+
+	resealed, err := second.Seal("last_name", testRow, "Doe")
+	require.NoError(t, err)
+	opened, err = k.Open(ctx, "last_name", testRow, resealed)
+	require.NoError(t, err)
+	assert.Equal(t, "Doe", opened)
+}
+
+func TestKeyring_KEKRotation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	store := &memoryStore{now: &now}
+	oldKEK, newKEK := randomKEK(t), randomKEK(t)
+
+	k := newTestKeyring(t, store, oldKEK)
+	require.NoError(t, k.Refresh(ctx))
+	key, err := k.Current()
+	require.NoError(t, err)
+	sealed, err := key.Seal("first_name", testRow, "John")
+	require.NoError(t, err)
+	index, err := k.Index("john@example.com")
+	require.NoError(t, err)
+
+	// Without the previous key-encryption key the stored keys cannot be used.
+	err = newTestKeyring(t, store, newKEK).load(ctx, store.keys)
+	assert.ErrorContains(t, err, "unknown key-encryption key")
+
+	rotated := newTestKeyring(t, store, newKEK, oldKEK)
+	require.NoError(t, rotated.Refresh(ctx))
+	for _, stored := range store.keys {
+		assert.Equal(t, rotated.kek.id, stored.KEKID, "key %d is rewrapped", stored.ID)
+	}
+
+	opened, err := rotated.Open(ctx, "first_name", testRow, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "John", opened)
+	same, err := rotated.Index("john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, index, same)
+
+	// Once rewrapped, the previous key-encryption key is no longer needed.
+	require.NoError(t, newTestKeyring(t, store, newKEK).Refresh(ctx))
+}
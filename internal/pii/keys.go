@@ -0,0 +1,133 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// keySize is the size of key-encryption keys, data keys and the index key: AES-256 and HMAC-SHA256 keys.
+const keySize = 32
+
+// Purposes of persisted keys.
+const (
+	// PurposeData keys encrypt personal data; the most recent one is used for new ciphertexts.
+	PurposeData = "data"
+	// PurposeIndex is the single key of the blind indexes. It is never rotated, as that would change every index.
+	PurposeIndex = "index"
+)
+
+// Key represents a persisted key. Wrapped holds the key encrypted with the key-encryption key identified by KEKID.
+type Key struct {
+	ID        int32
+	Purpose   string
+	Wrapped   []byte
+	KEKID     string
+	CreatedAt time.Time
+}
+
+// kek is a key-encryption key, identified by a fingerprint so that wrapped keys name the key they need.
+type kek struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// parseKEK decodes a base64-encoded key-encryption key.
+func parseKEK(encoded string) (*kek, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key-encryption key is not base64: %w", err)
+	}
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("key-encryption key must be %d bytes, got %d", keySize, len(raw))
+	}
+
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(raw)
+	return &kek{id: hex.EncodeToString(sum[:8]), aead: aead}, nil
+}
+
+// readKEK returns the key-encryption key given inline or, if file is set, stored in file.
+func readKEK(inline, file string) (*kek, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key-encryption key: %w", err)
+		}
+		inline = string(data)
+	}
+	return parseKEK(inline)
+}
+
+// wrap encrypts a key for storage. The purpose is authenticated, so a data key cannot be passed off as the index key.
+func (k *kek) wrap(purpose string, key []byte) ([]byte, error) {
+	return seal(k.aead, key, []byte(purpose))
+}
+
+// unwrap decrypts a stored key.
+func (k *kek) unwrap(stored Key) ([]byte, error) {
+	key, err := open(k.aead, stored.Wrapped, []byte(stored.Purpose))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key %d: %w", stored.ID, err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key %d has %d bytes", stored.ID, len(key))
+	}
+	return key, nil
+}
+
+// generateKey returns a new random key wrapped with k.
+func (k *kek) generateKey(purpose string) (*Key, error) {
+	raw := make([]byte, keySize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	wrapped, err := k.wrap(purpose, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{Purpose: purpose, Wrapped: wrapped, KEKID: k.id}, nil
+}
+
+// newAEAD returns AES-GCM with the given key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which is prepended to the result.
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// open decrypts the result of seal.
+func open(aead cipher.AEAD, ciphertext, additional []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additional)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
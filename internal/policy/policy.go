@@ -0,0 +1,213 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Permissions known to the policy engine.
+const (
+	UsersRead         = "users:read"
+	UsersWrite        = "users:write"
+	UsersDelete       = "users:delete"
+	UsersPassword     = "users:password"
+	UsersStatus       = "users:status"
+	RolesRead         = "roles:read"
+	RolesAssign       = "roles:assign"
+	JobsManage        = "jobs:manage"
+	APIKeysManage     = "apikeys:manage"
+	MFAReset          = "mfa:reset"
+	InvitationsManage = "invitations:manage"
+	GroupsRead        = "groups:read"
+	GroupsManage      = "groups:manage"
+	OrgsManage        = "organizations:manage"
+	AttributesManage  = "attributes:manage"
+	UsersErase        = "users:erase"
+	UsersLegalHold    = "users:legal_hold"
+	MetricsRead       = "metrics:read"
+)
+
+// Rule represents the access rule of an operation.
+type Rule struct {
+	// Public operations need no principal at all.
+	Public bool
+	// Permissions are all required, unless Self applies.
+	Permissions []string
+	// Self allows users to perform the operation on themselves without the permissions. A self rule without
+	// permissions allows the operation only on the own user.
+	Self bool
+}
+
+// Input represents what is known about a request when its operation is authorized.
+type Input struct {
+	Operation string
+	// Authenticated reports whether the request has a principal.
+	Authenticated bool
+	// Permissions granted to the principal.
+	Permissions []string
+	// Self reports whether the principal is a user acting on their own user resource.
+	Self bool
+	// Scopes restrict the operations of a principal authenticated with a scoped API key to those whose permissions
+	// are all in scope, including operations on their own user. Nil means unrestricted.
+	Scopes []string
+	// Inactive reports whether the principal is a user who is not active, e.g. suspended; they may perform no
+	// operation until they are reactivated.
+	Inactive bool
+}
+
+// Decision represents the outcome of an authorization together with a reason for the decision log.
+type Decision struct {
+	Allowed bool
+	Reason  string
+}
+
+// Engine decides whether operations may be performed. Operations without a rule are denied.
+type Engine struct {
+	rules map[string]Rule
+}
+
+// New creates an engine with the given rules keyed by OpenAPI operationId.
+func New(rules map[string]Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Rule returns the rule of an operation.
+func (e *Engine) Rule(operation string) (Rule, bool) {
+	rule, ok := e.rules[operation]
+	return rule, ok
+}
+
+// Evaluate decides on a request.
+func (e *Engine) Evaluate(in Input) Decision {
+	rule, ok := e.rules[in.Operation]
+	switch {
+	case !ok:
+		return Decision{Reason: "no rule for operation"}
+	case rule.Public:
+		return Decision{Allowed: true, Reason: "public operation"}
+	case !in.Authenticated:
+		return Decision{Reason: "not authenticated"}
+	case in.Inactive:
+		return Decision{Reason: "user not active"}
+	}
+
+	if in.Scopes != nil {
+		if missing := missing(rule.Permissions, in.Scopes); len(missing) > 0 {
+			return Decision{Reason: fmt.Sprintf("missing scope %s", strings.Join(missing, ", "))}
+		}
+	}
+	if rule.Self && in.Self {
+		return Decision{Allowed: true, Reason: "own user"}
+	}
+	if len(rule.Permissions) == 0 {
+		return Decision{Reason: "only allowed on own user"}
+	}
+	if missing := missing(rule.Permissions, in.Permissions); len(missing) > 0 {
+		return Decision{Reason: fmt.Sprintf("missing permission %s", strings.Join(missing, ", "))}
+	}
+
+	return Decision{Allowed: true, Reason: fmt.Sprintf("granted %s", strings.Join(rule.Permissions, ", "))}
+}
+
+// missing returns the required permissions that are not granted.
+func missing(required, granted []string) []string {
+	result := make([]string, 0)
+	for _, p := range required {
+		if !slices.Contains(granted, p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// DefaultRules returns the rules of the user API keyed by operationId.
+func DefaultRules() map[string]Rule {
+	return map[string]Rule{
+		"health":               {Public: true},
+		"login":                {Public: true},
+		"refreshToken":         {Public: true},
+		"revokeToken":          {Public: true},
+		"verifyMfa":            {Public: true},
+		"verifyEmail":          {Public: true},
+		"requestPasswordReset": {Public: true},
+		"confirmPasswordReset": {Public: true},
+		"acceptInvitation":     {Public: true},
+
+		"listUsers":            {Permissions: []string{UsersRead}},
+		"postUser":             {Permissions: []string{UsersWrite}},
+		"getUser":              {Permissions: []string{UsersRead}, Self: true},
+		"putUser":              {Permissions: []string{UsersWrite}, Self: true},
+		"batchUsers":           {Permissions: []string{UsersWrite, UsersDelete}},
+		"batchGetUsers":        {Permissions: []string{UsersRead}},
+		"batchGetUsersPost":    {Permissions: []string{UsersRead}},
+		"getUserByEmail":       {Permissions: []string{UsersRead}},
+		"searchUsers":          {Permissions: []string{UsersRead}},
+		"setUserPassword":      {Permissions: []string{UsersPassword}, Self: true},
+		"suspendUser":          {Permissions: []string{UsersStatus}},
+		"reactivateUser":       {Permissions: []string{UsersStatus}},
+		"deactivateUser":       {Permissions: []string{UsersStatus}},
+		"listUserStatusEvents": {Permissions: []string{UsersStatus}},
+		"listRoles":            {Permissions: []string{RolesRead}},
+		"getUserRoles":         {Permissions: []string{RolesRead}, Self: true},
+		"assignUserRole":       {Permissions: []string{RolesAssign}},
+		"revokeUserRole":       {Permissions: []string{RolesAssign}},
+		"postJob":              {Permissions: []string{JobsManage}},
+		"getJob":               {Permissions: []string{JobsManage}},
+		"cancelJob":            {Permissions: []string{JobsManage}},
+		"getJobArtifact":       {Permissions: []string{JobsManage}},
+		"listUserAPIKeys":      {Permissions: []string{APIKeysManage}, Self: true},
+		"createUserAPIKey":     {Permissions: []string{APIKeysManage}, Self: true},
+		"revokeUserAPIKey":     {Permissions: []string{APIKeysManage}, Self: true},
+		"rotateUserAPIKey":     {Permissions: []string{APIKeysManage}, Self: true},
+		"listAPIKeys":          {Permissions: []string{APIKeysManage}},
+		"createAPIKey":         {Permissions: []string{APIKeysManage}},
+		"revokeAPIKey":         {Permissions: []string{APIKeysManage}},
+		"rotateAPIKey":         {Permissions: []string{APIKeysManage}},
+		"getMfaStatus":         {Permissions: []string{UsersRead}, Self: true},
+		"enrollTotp":           {Self: true},
+		"confirmTotp":          {Self: true},
+		"resetMfa":             {Permissions: []string{MFAReset}},
+		"listInvitations":      {Permissions: []string{InvitationsManage}},
+		"createInvitation":     {Permissions: []string{InvitationsManage}},
+		"getInvitation":        {Permissions: []string{InvitationsManage}},
+		"revokeInvitation":     {Permissions: []string{InvitationsManage}},
+		"resendInvitation":     {Permissions: []string{InvitationsManage}},
+		"listGroups":           {Permissions: []string{GroupsRead}},
+		"createGroup":          {Permissions: []string{GroupsManage}},
+		"getGroup":             {Permissions: []string{GroupsRead}},
+		"updateGroup":          {Permissions: []string{GroupsManage}},
+		"deleteGroup":          {Permissions: []string{GroupsManage}},
+		"listGroupMembers":     {Permissions: []string{GroupsRead}},
+		"checkGroupMembership": {Permissions: []string{GroupsRead}},
+		"addGroupUser":         {Permissions: []string{GroupsManage}},
+		"removeGroupUser":      {Permissions: []string{GroupsManage}},
+		"addSubgroup":          {Permissions: []string{GroupsManage}},
+		"removeSubgroup":       {Permissions: []string{GroupsManage}},
+		"listUserGroups":       {Permissions: []string{GroupsRead}, Self: true},
+		"listOrganizations":    {Permissions: []string{OrgsManage}},
+		"createOrganization":   {Permissions: []string{OrgsManage}},
+		"getOrganization":      {Permissions: []string{OrgsManage}},
+
+		"sendEmailVerification": {Permissions: []string{UsersWrite}, Self: true},
+		"getAttributeSchema":    {Permissions: []string{UsersRead}},
+		"putAttributeSchema":    {Permissions: []string{AttributesManage}},
+		"deleteAttributeSchema": {Permissions: []string{AttributesManage}},
+		"putUserAvatar":         {Permissions: []string{UsersWrite}, Self: true},
+		"deleteUserAvatar":      {Permissions: []string{UsersWrite}, Self: true},
+		"getAvatar":             {Public: true},
+		"exportUserData":        {Permissions: []string{UsersRead}, Self: true},
+		"eraseUser":             {Permissions: []string{UsersErase}},
+		"listLegalHolds":        {Permissions: []string{UsersLegalHold}},
+		"placeLegalHold":        {Permissions: []string{UsersLegalHold}},
+		"releaseLegalHold":      {Permissions: []string{UsersLegalHold}},
+		"getMetrics":            {Permissions: []string{MetricsRead}},
+	}
+}
+
+// AllPermissions returns every permission known to the engine.
+func AllPermissions() []string {
+	return []string{UsersRead, UsersWrite, UsersDelete, UsersPassword, UsersStatus, RolesRead, RolesAssign, JobsManage,
+		APIKeysManage, MFAReset, InvitationsManage, GroupsRead, GroupsManage, OrgsManage, AttributesManage,
+		UsersErase, UsersLegalHold, MetricsRead}
+}
@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_Evaluate(t *testing.T) {
+	engine := New(DefaultRules())
+
+	tests := []struct {
+		name    string
+		input   Input
+		allowed bool
+		reason  string
+	}{
+		{
+			name:    "Public operation without principal",
+			input:   Input{Operation: "health"},
+			allowed: true,
+			reason:  "public operation",
+		},
+		{
+			name:   "Unknown operation is denied",
+			input:  Input{Operation: "dropUsers", Authenticated: true, Permissions: AllPermissions()},
+			reason: "no rule for operation",
+		},
+		{
+			name:   "Protected operation without principal",
+			input:  Input{Operation: "getUser"},
+			reason: "not authenticated",
+		},
+		{
+			name:    "Permission granted",
+			input:   Input{Operation: "getUser", Authenticated: true, Permissions: []string{UsersRead}},
+			allowed: true,
+			reason:  "granted users:read",
+		},
+		{
+			name:   "Permission missing",
+			input:  Input{Operation: "putUser", Authenticated: true, Permissions: []string{UsersRead}},
+			reason: "missing permission users:write",
+		},
+		{
+			name:   "Inactive user is denied",
+			input:  Input{Operation: "getUser", Authenticated: true, Inactive: true, Permissions: AllPermissions()},
+			reason: "user not active",
+		},
+		{
+			name:    "User updates themselves",
+			input:   Input{Operation: "putUser", Authenticated: true, Self: true},
+			allowed: true,
+			reason:  "own user",
+		},
+		{
+			name:   "Ownership does not apply to role assignment",
+			input:  Input{Operation: "assignUserRole", Authenticated: true, Self: true},
+			reason: "missing permission roles:assign",
+		},
+		{
+			name: "Scoped key within its scopes",
+			input: Input{Operation: "getUser", Authenticated: true, Permissions: []string{UsersRead, UsersWrite},
+				Scopes: []string{UsersRead}},
+			allowed: true,
+			reason:  "granted users:read",
+		},
+		{
+			name: "Scoped key outside its scopes",
+			input: Input{Operation: "putUser", Authenticated: true, Permissions: []string{UsersRead, UsersWrite},
+				Scopes: []string{UsersRead}},
+			reason: "missing scope users:write",
+		},
+		{
+			name:   "Scopes also limit operations on the own user",
+			input:  Input{Operation: "putUser", Authenticated: true, Self: true, Scopes: []string{}},
+			reason: "missing scope users:write",
+		},
+		{
+			name:   "All permissions of the rule are required",
+			input:  Input{Operation: "batchUsers", Authenticated: true, Permissions: []string{UsersWrite}},
+			reason: "missing permission users:delete",
+		},
+		{
+			name:    "User enrolls themselves in MFA",
+			input:   Input{Operation: "enrollTotp", Authenticated: true, Self: true},
+			allowed: true,
+			reason:  "own user",
+		},
+		{
+			name:   "MFA enrollment of other users is denied to everyone",
+			input:  Input{Operation: "enrollTotp", Authenticated: true, Permissions: AllPermissions()},
+			reason: "only allowed on own user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := engine.Evaluate(tc.input)
+
+			assert.Equal(t, tc.allowed, decision.Allowed)
+			assert.Equal(t, tc.reason, decision.Reason)
+		})
+	}
+}
+
+func TestDefaultRules_PermissionsAreKnown(t *testing.T) {
+	for operation, rule := range DefaultRules() {
+		for _, p := range rule.Permissions {
+			assert.Contains(t, AllPermissions(), p, "operation %s", operation)
+		}
+	}
+}
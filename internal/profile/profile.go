@@ -0,0 +1,162 @@
+package profile
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	// The time zone database is embedded so that time zones validate the same on every host, also in minimal
+	// container images without /usr/share/zoneinfo.
+	_ "time/tzdata"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+
+	"go-users/internal/ownErrors"
+)
+
+const (
+	// maxDisplayNameLength is the maximum length of a display name in characters.
+	maxDisplayNameLength = 100
+	// maxAvatarURLLength is the maximum length of an avatar URL in bytes.
+	maxAvatarURLLength = 2048
+)
+
+// Profile holds the optional profile fields of a user. Nil fields are not set; empty strings clear a field.
+type Profile struct {
+	Phone       *string
+	Locale      *string
+	TimeZone    *string
+	DisplayName *string
+	AvatarURL   *string
+}
+
+// InvalidError reports the profile fields failing validation, one problem per field.
+type InvalidError struct {
+	Problems []string
+}
+
+func (e *InvalidError) Error() string {
+	return fmt.Sprintf("%v: %s", ownErrors.ErrInvalidProfile, strings.Join(e.Problems, "; "))
+}
+
+func (e *InvalidError) Unwrap() error {
+	return ownErrors.ErrInvalidProfile
+}
+
+// Normalize validates the fields of p and returns them in canonical form. Returns an InvalidError listing every
+// invalid field.
+func Normalize(p Profile) (Profile, error) {
+	var problems []string
+	field := func(name string, value *string, normalize func(string) (string, error)) *string {
+		if value == nil {
+			return nil
+		}
+		v := strings.TrimSpace(*value)
+		if v == "" {
+			return &v
+		}
+		v, err := normalize(v)
+		if err != nil {
+			problems = append(problems, name+": "+err.Error())
+			return nil
+		}
+		return &v
+	}
+
+	normalized := Profile{
+		Phone:       field("phone", p.Phone, NormalizePhone),
+		Locale:      field("locale", p.Locale, NormalizeLocale),
+		TimeZone:    field("time_zone", p.TimeZone, NormalizeTimeZone),
+		DisplayName: field("display_name", p.DisplayName, NormalizeDisplayName),
+		AvatarURL:   field("avatar_url", p.AvatarURL, NormalizeAvatarURL),
+	}
+	if len(problems) > 0 {
+		return Profile{}, &InvalidError{Problems: problems}
+	}
+
+	return normalized, nil
+}
+
+// NormalizePhone returns a phone number in E.164 format, e.g. "+14155552671". Spaces, dashes, dots and parentheses
+// are ignored, and the international prefix 00 is accepted in place of the plus sign. Numbers without country code
+// are rejected, as there is no region to take it from.
+func NormalizePhone(s string) (string, error) {
+	var digits strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("must be a phone number in E.164 format, e.g. +14155552671")
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(s, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		return "", fmt.Errorf("must start with + and the country code")
+	}
+
+	// E.164 numbers have at most 15 digits and country codes never start with 0.
+	if len(number) < 7 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("must be a phone number in E.164 format, e.g. +14155552671")
+	}
+
+	return "+" + number, nil
+}
+
+// NormalizeLocale returns a BCP 47 language tag in canonical form, e.g. "en-US" for "en_us".
+func NormalizeLocale(s string) (string, error) {
+	tag, err := language.Parse(s)
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("must be a BCP 47 language tag, e.g. en-US")
+	}
+	return tag.String(), nil
+}
+
+// NormalizeTimeZone returns the name of an IANA time zone, e.g. "Europe/Berlin". Zones are matched case-sensitively.
+func NormalizeTimeZone(s string) (string, error) {
+	// LoadLocation also accepts "Local" and file paths, which are no zone names.
+	if s == "Local" || strings.Contains(s, "..") || strings.HasPrefix(s, "/") {
+		return "", fmt.Errorf("must be an IANA time zone, e.g. Europe/Berlin")
+	}
+	if _, err := time.LoadLocation(s); err != nil {
+		return "", fmt.Errorf("must be an IANA time zone, e.g. Europe/Berlin")
+	}
+	return s, nil
+}
+
+// NormalizeDisplayName returns a display name in Unicode NFC. Control characters are rejected.
+func NormalizeDisplayName(s string) (string, error) {
+	s = norm.NFC.String(s)
+	if utf8.RuneCountInString(s) > maxDisplayNameLength {
+		return "", fmt.Errorf("must be at most %d characters long", maxDisplayNameLength)
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return "", fmt.Errorf("must not contain control characters")
+		}
+	}
+	return s, nil
+}
+
+// NormalizeAvatarURL returns an absolute http or https URL.
+func NormalizeAvatarURL(s string) (string, error) {
+	if len(s) > maxAvatarURLLength {
+		return "", fmt.Errorf("must be at most %d bytes long", maxAvatarURLLength)
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return "", fmt.Errorf("must be an absolute http or https URL")
+	}
+	return u.String(), nil
+}
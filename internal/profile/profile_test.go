@@ -0,0 +1,116 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	testCases := []struct {
+		name      string
+		phone     string
+		expected  string
+		expectErr bool
+	}{
+		{name: "E.164", phone: "+14155552671", expected: "+14155552671"},
+		{name: "Separators", phone: "+49 (30) 123-456.78", expected: "+493012345678"},
+		{name: "International prefix", phone: "0049 30 1234567", expected: "+49301234567"},
+		{name: "National number", phone: "030 1234567", expectErr: true},
+		{name: "Too long", phone: "+1234567890123456", expectErr: true},
+		{name: "Too short", phone: "+12345", expectErr: true},
+		{name: "Leading zero", phone: "+0301234567", expectErr: true},
+		{name: "Letters", phone: "+1 415 CALL NOW", expectErr: true},
+		{name: "Plus inside", phone: "+49+301234567", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			phone, err := NormalizePhone(tc.phone)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, phone)
+		})
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	for input, expected := range map[string]string{"en": "en", "en_us": "en-US", "de-CH": "de-CH", "zh-hant-tw": "zh-Hant-TW"} {
+		locale, err := NormalizeLocale(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, locale)
+	}
+
+	for _, input := range []string{"und", "english", "en-", "12"} {
+		_, err := NormalizeLocale(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNormalizeTimeZone(t *testing.T) {
+	for _, input := range []string{"Europe/Berlin", "America/Argentina/Buenos_Aires", "UTC"} {
+		tz, err := NormalizeTimeZone(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, input, tz)
+	}
+
+	for _, input := range []string{"Local", "Europe/Atlantis", "europe/berlin", "../../etc/passwd", "/etc/localtime"} {
+		_, err := NormalizeTimeZone(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNormalizeAvatarURL(t *testing.T) {
+	avatar, err := NormalizeAvatarURL("https://cdn.example.com/avatars/1.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/avatars/1.png", avatar)
+
+	for _, input := range []string{"/avatars/1.png", "javascript:alert(1)", "ftp://example.com/1.png", "https://user:pw@example.com/"} {
+		_, err := NormalizeAvatarURL(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	t.Run("Fields normalized", func(t *testing.T) {
+		p, err := Normalize(Profile{
+			Phone:       ptr(" +1 415 555 2671 "),
+			Locale:      ptr("en_gb"),
+			DisplayName: ptr("José"),
+			AvatarURL:   ptr(""),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "+14155552671", *p.Phone)
+		assert.Equal(t, "en-GB", *p.Locale)
+		assert.Nil(t, p.TimeZone)
+		assert.Equal(t, "José", *p.DisplayName)
+		assert.Equal(t, "", *p.AvatarURL, "empty strings clear a field")
+	})
+
+	t.Run("Every invalid field reported", func(t *testing.T) {
+		_, err := Normalize(Profile{
+			Phone:       ptr("12345"),
+			TimeZone:    ptr("Mars/Olympus_Mons"),
+			DisplayName: ptr("John\x00"),
+		})
+
+		var invalid *InvalidError
+		require.ErrorAs(t, err, &invalid)
+		assert.ErrorIs(t, err, ownErrors.ErrInvalidProfile)
+		assert.Equal(t, []string{
+			"phone: must start with + and the country code",
+			"time_zone: must be an IANA time zone, e.g. Europe/Berlin",
+			"display_name: must not contain control characters",
+		}, invalid.Problems)
+	})
+}
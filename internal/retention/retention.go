@@ -0,0 +1,209 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/avatar"
+	"go-users/internal/blob"
+	"go-users/internal/config"
+)
+
+// Rules of the retention policy.
+const (
+	// DeactivatedUsers purges users deactivated before the cutoff, with their dependent rows.
+	DeactivatedUsers = "deactivated_users"
+	// PendingUsers purges pending users created before the cutoff who never verified their address and have no
+	// pending invitation.
+	PendingUsers = "pending_users"
+	// AuditLogs trims the entries of the audit logs written before the cutoff.
+	AuditLogs = "audit_logs"
+)
+
+// ErrLocked is returned by the store when another instance is applying the same rule.
+var ErrLocked = errors.New("retention rule is applied by another instance")
+
+// metrics counts per rule how often it ran, was skipped because another instance applied it or failed, and how many
+// rows it purged or, in a dry run, would have purged. They are published by expvar under "retention".
+var metrics = expvar.NewMap("retention")
+
+// Store defines the persistence operation the scheduler needs.
+type Store interface {
+	// ApplyRetention deletes the rows of a rule older than the cutoff and returns how many were deleted per table and
+	// the avatars of the deleted users, whose blobs the scheduler deletes. In a dry run nothing is deleted and the rows
+	// that would be are counted. Returns ErrLocked if another instance is applying the rule.
+	ApplyRetention(ctx context.Context, rule string, cutoff time.Time, dryRun bool) (map[string]int, []Avatar, error)
+}
+
+// Avatar identifies the uploaded avatar of a purged user.
+type Avatar struct {
+	User    uuid.UUID
+	Version string
+}
+
+// Report describes one application of a rule.
+type Report struct {
+	Rule   string
+	Cutoff time.Time
+	DryRun bool
+	// Rows holds the rows deleted per table, or in a dry run the rows that would have been deleted.
+	Rows map[string]int
+}
+
+// Scheduler applies the retention rules at a fixed interval. Instances coordinate through the store, so every rule
+// is applied by one instance at a time.
+type Scheduler struct {
+	store    Store
+	blobs    blob.Store
+	logger   *slog.Logger
+	rules    map[string]time.Duration
+	interval time.Duration
+	dryRun   bool
+
+	now  func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewScheduler creates a scheduler for the rules with a positive age in cfg. The avatars of purged users are deleted
+// from blobs.
+func NewScheduler(store Store, blobs blob.Store, logger *slog.Logger, cfg config.Retention) (*Scheduler, error) {
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("retention interval must be positive")
+	}
+
+	days := map[string]int{
+		DeactivatedUsers: cfg.DeactivatedUserDays,
+		PendingUsers:     cfg.PendingUserDays,
+		AuditLogs:        cfg.AuditLogDays,
+	}
+	rules := make(map[string]time.Duration, len(days))
+	for rule, d := range days {
+		if d < 0 {
+			return nil, fmt.Errorf("retention of %s must not be negative", rule)
+		}
+		if d > 0 {
+			rules[rule] = time.Duration(d) * 24 * time.Hour
+		}
+	}
+
+	return &Scheduler{
+		store:    store,
+		blobs:    blobs,
+		logger:   logger,
+		rules:    rules,
+		interval: time.Duration(cfg.Interval) * time.Second,
+		dryRun:   cfg.DryRun,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start applies the rules in the background, once right away and then at the interval, until Shutdown.
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Shutdown stops the background runs.
+func (s *Scheduler) Shutdown() {
+	close(s.stop)
+	<-s.done
+}
+
+// run sweeps right away and then at the interval until the scheduler is stopped.
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	s.sweep()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// sweep applies the rules with a timeout of one interval, so that a hanging store neither piles up sweeps nor delays
+// Shutdown for longer.
+func (s *Scheduler) sweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	s.Sweep(ctx)
+}
+
+// Sweep applies every rule and returns the reports of those applied by this instance. Failures are logged and retried
+// by the next sweep.
+func (s *Scheduler) Sweep(ctx context.Context) []Report {
+	var reports []Report
+	for _, rule := range []string{DeactivatedUsers, PendingUsers, AuditLogs} {
+		age, ok := s.rules[rule]
+		if !ok {
+			continue
+		}
+
+		cutoff := s.now().Add(-age)
+		rows, avatars, err := s.store.ApplyRetention(ctx, rule, cutoff, s.dryRun)
+		switch {
+		case errors.Is(err, ErrLocked):
+			metrics.Add(rule+".skipped", 1)
+			continue
+		case err != nil:
+			metrics.Add(rule+".failures", 1)
+			s.logger.Error("Failed to apply retention rule", "rule", rule, "error", err)
+			continue
+		}
+
+		s.deleteAvatars(ctx, avatars)
+
+		report := Report{Rule: rule, Cutoff: cutoff, DryRun: s.dryRun, Rows: rows}
+		s.record(report)
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// deleteAvatars deletes the variants of the avatars of purged users. Failures are only logged: the avatars are no
+// longer referenced, so a leftover blob just takes up space.
+func (s *Scheduler) deleteAvatars(ctx context.Context, avatars []Avatar) {
+	for _, a := range avatars {
+		for _, size := range avatar.Sizes {
+			if err := s.blobs.Delete(ctx, avatar.Key(a.User.String(), a.Version, size)); err != nil {
+				s.logger.Error("Failed to delete avatar", "error", err, "user", a.User, "version", a.Version)
+			}
+		}
+	}
+}
+
+// record counts and logs an applied rule.
+func (s *Scheduler) record(r Report) {
+	total := 0
+	for _, n := range r.Rows {
+		total += n
+	}
+
+	metrics.Add(r.Rule+".runs", 1)
+	if r.DryRun {
+		metrics.Add(r.Rule+".would_purge", int64(total))
+		s.logger.Info("Retention dry run", "rule", r.Rule, "cutoff", r.Cutoff, "rows", r.Rows)
+		return
+	}
+
+	metrics.Add(r.Rule+".purged", int64(total))
+	if total > 0 {
+		s.logger.Info("Purged expired data", "rule", r.Rule, "cutoff", r.Cutoff, "rows", r.Rows)
+	}
+}
@@ -0,0 +1,179 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/blob"
+	"go-users/internal/config"
+)
+
+type applied struct {
+	rule   string
+	cutoff time.Time
+	dryRun bool
+}
+
+type fakeStore struct {
+	calls   []applied
+	rows    map[string]int
+	avatars []Avatar
+	errs    map[string]error
+}
+
+func (s *fakeStore) ApplyRetention(_ context.Context, rule string, cutoff time.Time, dryRun bool) (map[string]int, []Avatar, error) {
+	s.calls = append(s.calls, applied{rule: rule, cutoff: cutoff, dryRun: dryRun})
+	if err := s.errs[rule]; err != nil {
+		return nil, nil, err
+	}
+	return s.rows, s.avatars, nil
+}
+
+// deletedBlobs records the keys of deleted blobs.
+type deletedBlobs struct {
+	blob.Store
+	keys []string
+}
+
+func (b *deletedBlobs) Delete(_ context.Context, key string) error {
+	b.keys = append(b.keys, key)
+	return nil
+}
+
+// metric returns the current value of a retention counter.
+func metric(name string) int64 {
+	if v, ok := metrics.Get(name).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func newTestScheduler(t *testing.T, store Store, cfg config.Retention, now time.Time) *Scheduler {
+	s, err := NewScheduler(store, &deletedBlobs{}, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg)
+	require.NoError(t, err)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestNewScheduler_InvalidConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	_, err := NewScheduler(&fakeStore{}, &deletedBlobs{}, logger, config.Retention{})
+	assert.ErrorContains(t, err, "interval must be positive")
+
+	_, err = NewScheduler(&fakeStore{}, &deletedBlobs{}, logger, config.Retention{Interval: 60, AuditLogDays: -1})
+	assert.ErrorContains(t, err, "audit_logs must not be negative")
+}
+
+func TestScheduler_Sweep(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Enabled rules applied with their cutoff", func(t *testing.T) {
+		store := &fakeStore{rows: map[string]int{"users": 2}}
+		s := newTestScheduler(t, store, config.Retention{Interval: 60, DeactivatedUserDays: 30, AuditLogDays: 365}, now)
+		purged := metric(DeactivatedUsers + ".purged")
+
+		reports := s.Sweep(context.Background())
+
+		assert.Equal(t, []applied{
+			{rule: DeactivatedUsers, cutoff: now.AddDate(0, 0, -30)},
+			{rule: AuditLogs, cutoff: now.AddDate(0, 0, -365)},
+		}, store.calls)
+		require.Len(t, reports, 2)
+		assert.Equal(t, Report{Rule: DeactivatedUsers, Cutoff: now.AddDate(0, 0, -30), Rows: map[string]int{"users": 2}}, reports[0])
+		assert.Equal(t, purged+2, metric(DeactivatedUsers+".purged"))
+	})
+
+	t.Run("Avatars of purged users deleted", func(t *testing.T) {
+		user := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-000000000001")
+		store := &fakeStore{rows: map[string]int{"users": 1}, avatars: []Avatar{{User: user, Version: "0123456789abcdef"}}}
+		s := newTestScheduler(t, store, config.Retention{Interval: 60, DeactivatedUserDays: 30}, now)
+		blobs := &deletedBlobs{}
+		s.blobs = blobs
+
+		s.Sweep(context.Background())
+
+		assert.Equal(t, []string{
+			"avatars/" + user.String() + "/0123456789abcdef/64",
+			"avatars/" + user.String() + "/0123456789abcdef/128",
+			"avatars/" + user.String() + "/0123456789abcdef/512",
+		}, blobs.keys)
+	})
+
+	t.Run("Dry run only reports", func(t *testing.T) {
+		store := &fakeStore{rows: map[string]int{"users": 3}}
+		s := newTestScheduler(t, store, config.Retention{Interval: 60, PendingUserDays: 7, DryRun: true}, now)
+		purged, wouldPurge := metric(PendingUsers+".purged"), metric(PendingUsers+".would_purge")
+
+		reports := s.Sweep(context.Background())
+
+		require.Len(t, reports, 1)
+		assert.True(t, reports[0].DryRun)
+		assert.True(t, store.calls[0].dryRun)
+		assert.Equal(t, purged, metric(PendingUsers+".purged"))
+		assert.Equal(t, wouldPurge+3, metric(PendingUsers+".would_purge"))
+	})
+
+	t.Run("Rules locked or failing are skipped", func(t *testing.T) {
+		store := &fakeStore{
+			rows: map[string]int{"policy_decisions": 1},
+			errs: map[string]error{DeactivatedUsers: ErrLocked, PendingUsers: errors.New("db down")},
+		}
+		s := newTestScheduler(t, store, config.Retention{Interval: 60, DeactivatedUserDays: 1, PendingUserDays: 1, AuditLogDays: 1}, now)
+		skipped, failures := metric(DeactivatedUsers+".skipped"), metric(PendingUsers+".failures")
+
+		reports := s.Sweep(context.Background())
+
+		assert.Len(t, store.calls, 3)
+		require.Len(t, reports, 1)
+		assert.Equal(t, AuditLogs, reports[0].Rule)
+		assert.Equal(t, skipped+1, metric(DeactivatedUsers+".skipped"))
+		assert.Equal(t, failures+1, metric(PendingUsers+".failures"))
+	})
+
+	t.Run("Start sweeps immediately", func(t *testing.T) {
+		store := &fakeStore{}
+		s := newTestScheduler(t, store, config.Retention{Interval: 3600, AuditLogDays: 90}, now)
+
+		s.Start()
+		s.Shutdown()
+
+		assert.Len(t, store.calls, 1)
+	})
+
+	t.Run("Start does not wait for the first sweep", func(t *testing.T) {
+		store := &blockingStore{deadlines: make(chan bool, 1)}
+		s := newTestScheduler(t, store, config.Retention{Interval: 1, AuditLogDays: 90}, now)
+
+		started := time.Now()
+		s.Start()
+		assert.Less(t, time.Since(started), 500*time.Millisecond)
+
+		assert.True(t, <-store.deadlines, "the sweep is bounded")
+		s.Shutdown()
+	})
+}
+
+// blockingStore hangs until the context of a call ends and reports whether it had a deadline.
+type blockingStore struct {
+	deadlines chan bool
+}
+
+func (s *blockingStore) ApplyRetention(ctx context.Context, _ string, _ time.Time, _ bool) (map[string]int, []Avatar, error) {
+	_, ok := ctx.Deadline()
+	select {
+	case s.deadlines <- ok:
+	default:
+	}
+	<-ctx.Done()
+	return nil, nil, ctx.Err()
+}
@@ -0,0 +1,190 @@
+package router
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go-users/internal/token"
+)
+
+// APIKeyHeader is the request header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// minAPIKeyLength is the minimum length of a static API key secret.
+const minAPIKeyLength = 32
+
+// ErrInvalidCredentials is returned by verifiers for credentials that are unknown, expired or otherwise not valid.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// PrincipalKind distinguishes the kinds of authenticated callers.
+type PrincipalKind string
+
+const (
+	// PrincipalUser is a user authenticated with a bearer access token.
+	PrincipalUser PrincipalKind = "user"
+	// PrincipalService is a service authenticated with an API key.
+	PrincipalService PrincipalKind = "service"
+)
+
+// Principal represents the authenticated caller of a request.
+type Principal struct {
+	Kind PrincipalKind
+	// ID is the user ID for users and the name or ID of the API key for services.
+	ID string
+	// KeyID identifies the API key the request was authenticated with; it is empty for bearer tokens.
+	KeyID string
+	// Scopes restrict the permissions of a principal authenticated with a scoped API key. Nil means unrestricted.
+	Scopes []string
+	// Org is the organization of a user principal. Services act on the organization named by the request.
+	Org string
+}
+
+// principalKey is the context key of the authenticated principal.
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the given principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal authenticated for the request of ctx, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// TokenVerifier verifies bearer access tokens.
+type TokenVerifier interface {
+	Verify(accessToken string) (*token.Claims, error)
+}
+
+// APIKeyVerifier resolves API keys to the principal they belong to. It returns ErrInvalidCredentials for keys that
+// are not valid.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// StaticAPIKeys is an APIKeyVerifier for a fixed set of named keys. Only the SHA-256 hashes of the keys are kept.
+type StaticAPIKeys struct {
+	keys []staticAPIKey
+}
+
+// staticAPIKey represents a single named static key.
+type staticAPIKey struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+// NewStaticAPIKeys creates a verifier from entries of the form "name:secret".
+func NewStaticAPIKeys(entries []string) (*StaticAPIKeys, error) {
+	s := &StaticAPIKeys{keys: make([]staticAPIKey, 0, len(entries))}
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, secret, ok := strings.Cut(entry, ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("API key entries must have the form name:secret")
+		}
+		if len(secret) < minAPIKeyLength {
+			return nil, fmt.Errorf("API key %q must be at least %d characters long", name, minAPIKeyLength)
+		}
+
+		s.keys = append(s.keys, staticAPIKey{name: name, hash: sha256.Sum256([]byte(secret))})
+	}
+
+	return s, nil
+}
+
+// VerifyAPIKey compares the key against every configured key in constant time.
+func (s *StaticAPIKeys) VerifyAPIKey(_ context.Context, key string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+
+	var principal *Principal
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			principal = &Principal{Kind: PrincipalService, ID: k.name, KeyID: k.name}
+		}
+	}
+	if principal == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return principal, nil
+}
+
+// authMiddleware authenticates the caller from a bearer access token or an API key and stores the principal in the
+// request context. Requests without credentials pass through anonymously; whether an operation requires a principal
+// is decided by the handler. Invalid credentials are rejected with 401.
+func authMiddleware(tokens TokenVerifier, apiKeys APIKeyVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get("Authorization")
+			apiKey := r.Header.Get(APIKeyHeader)
+
+			var principal *Principal
+			switch {
+			case authorization != "" && apiKey != "":
+				writeAuthError(w, "Provide either a bearer token or an API key")
+				return
+			case authorization != "":
+				scheme, accessToken, _ := strings.Cut(authorization, " ")
+				if !strings.EqualFold(scheme, "Bearer") || tokens == nil {
+					writeAuthError(w, "Unsupported authorization scheme")
+					return
+				}
+				claims, err := tokens.Verify(strings.TrimSpace(accessToken))
+				if err != nil {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					writeAuthError(w, "Invalid access token")
+					return
+				}
+				principal = &Principal{Kind: PrincipalUser, ID: claims.Subject, Org: claims.Org}
+			case apiKey != "":
+				if apiKeys == nil {
+					writeAuthError(w, "Invalid API key")
+					return
+				}
+				p, err := apiKeys.VerifyAPIKey(r.Context(), apiKey)
+				if errors.Is(err, ErrInvalidCredentials) {
+					writeAuthError(w, "Invalid API key")
+					return
+				}
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+				principal = p
+			}
+
+			if principal != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), principal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeAuthError responds with 401 and the given message.
+func writeAuthError(w http.ResponseWriter, message string) {
+	if w.Header().Get("WWW-Authenticate") == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	writeError(w, http.StatusUnauthorized, message)
+}
+
+// writeError responds with a JSON error body in the format of the API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,133 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/token"
+)
+
+// fakeVerifier accepts the access token "valid".
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(accessToken string) (*token.Claims, error) {
+	if accessToken != "valid" {
+		return nil, token.ErrInvalidToken
+	}
+	return &token.Claims{Subject: "01927a3e-8f2c-7b3d-9e4f-000000000001"}, nil
+}
+
+// failingAPIKeys fails every lookup.
+type failingAPIKeys struct{}
+
+func (failingAPIKeys) VerifyAPIKey(context.Context, string) (*Principal, error) {
+	return nil, errors.New("db error")
+}
+
+func TestNewStaticAPIKeys(t *testing.T) {
+	keys, err := NewStaticAPIKeys([]string{"ci:0123456789abcdef0123456789abcdef", " "})
+	require.NoError(t, err)
+
+	principal, err := keys.VerifyAPIKey(context.Background(), "0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Kind: PrincipalService, ID: "ci", KeyID: "ci"}, principal)
+
+	_, err = keys.VerifyAPIKey(context.Background(), "0123456789abcdef0123456789abcdeX")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = NewStaticAPIKeys([]string{"ci:short"})
+	assert.ErrorContains(t, err, "at least 32 characters")
+
+	_, err = NewStaticAPIKeys([]string{"0123456789abcdef0123456789abcdef"})
+	assert.ErrorContains(t, err, "name:secret")
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	apiKeys, err := NewStaticAPIKeys([]string{"ci:0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		apiKeys           APIKeyVerifier
+		headers           map[string]string
+		expectedStatus    int
+		expectedPrincipal *Principal
+	}{
+		{name: "Anonymous", apiKeys: apiKeys, expectedStatus: http.StatusOK},
+		{
+			name:              "Bearer token",
+			apiKeys:           apiKeys,
+			headers:           map[string]string{"Authorization": "Bearer valid"},
+			expectedStatus:    http.StatusOK,
+			expectedPrincipal: &Principal{Kind: PrincipalUser, ID: "01927a3e-8f2c-7b3d-9e4f-000000000001"},
+		},
+		{
+			name:           "Invalid bearer token",
+			apiKeys:        apiKeys,
+			headers:        map[string]string{"Authorization": "Bearer forged"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unsupported scheme",
+			apiKeys:        apiKeys,
+			headers:        map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:              "API key",
+			apiKeys:           apiKeys,
+			headers:           map[string]string{APIKeyHeader: "0123456789abcdef0123456789abcdef"},
+			expectedStatus:    http.StatusOK,
+			expectedPrincipal: &Principal{Kind: PrincipalService, ID: "ci", KeyID: "ci"},
+		},
+		{
+			name:           "Invalid API key",
+			apiKeys:        apiKeys,
+			headers:        map[string]string{APIKeyHeader: "wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Both credentials",
+			apiKeys:        apiKeys,
+			headers:        map[string]string{"Authorization": "Bearer valid", APIKeyHeader: "0123456789abcdef0123456789abcdef"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "API key lookup fails",
+			apiKeys:        failingAPIKeys{},
+			headers:        map[string]string{APIKeyHeader: "0123456789abcdef0123456789abcdef"},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(Options{Tokens: fakeVerifier{}, APIKeys: tc.apiKeys})
+
+			var principal *Principal
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedPrincipal, principal)
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
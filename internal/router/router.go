@@ -1,17 +1,31 @@
 package router
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-// Options represents the configuration for the router.
+// Options represents the configuration for the router. Callers are authenticated if Tokens or APIKeys is set, and
+// requests are scoped to an organization if Orgs is set.
 type Options struct {
-	Logger *slog.Logger
+	Logger  *slog.Logger
+	Tokens  TokenVerifier
+	APIKeys APIKeyVerifier
+	Orgs    OrgResolver
+	// BaseDomain is the domain below which subdomains name organizations, e.g. users.example.com.
+	BaseDomain string
+	// TrustedProxies are the proxies whose X-Forwarded-For and X-Real-IP headers are honoured. Requests from other
+	// addresses are attributed to their remote address.
+	TrustedProxies []netip.Prefix
 }
 
 // New creates and configures a new HTTP router with standard middleware.
@@ -19,16 +33,119 @@ func New(opts Options) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(clientIPMiddleware(opts.TrustedProxies))
 	r.Use(middleware.Recoverer)
 
 	if opts.Logger != nil {
 		r.Use(loggingMiddleware(opts.Logger))
 	}
 
+	if opts.Tokens != nil || opts.APIKeys != nil {
+		r.Use(authMiddleware(opts.Tokens, opts.APIKeys))
+	}
+
+	if opts.Orgs != nil {
+		r.Use(tenantMiddleware(opts.Orgs, opts.BaseDomain))
+	}
+
 	return r
 }
 
+type clientIPKey struct{}
+
+// WithClientIP returns a copy of ctx carrying the given client IP address.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the IP address of the client of the request of ctx, or an empty string if unknown.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// ParseTrustedProxies parses proxy addresses given as IP addresses or CIDR prefixes.
+func ParseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			addr, err := netip.ParseAddr(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// clientIPMiddleware stores the client IP address in the request context. It is the remote address, unless that is a
+// trusted proxy: then it is the last address in X-Forwarded-For that is not a trusted proxy, or X-Real-IP. Addresses
+// a client adds to X-Forwarded-For itself come before those and are ignored.
+func clientIPMiddleware(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), clientIP(r, trusted))))
+		})
+	}
+}
+
+// clientIP returns the client IP address of a request.
+func clientIP(r *http.Request, trusted []netip.Prefix) string {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !isTrusted(remote, trusted) {
+		return host
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = addr.Unmap()
+		if !isTrusted(client, trusted) {
+			break
+		}
+	}
+	if len(hops) == 0 {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+			client = addr.Unmap()
+		}
+	}
+
+	return client.String()
+}
+
+// isTrusted reports whether addr is one of the trusted proxies.
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // loggingMiddleware adds structured logging for each request.
 func loggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
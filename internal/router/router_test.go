@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRouterMiddleware(t *testing.T) {
@@ -47,3 +48,79 @@ func TestRouterLogging(t *testing.T) {
 	assert.Contains(t, logs.String(), `"path":"/"`)
 	assert.Contains(t, logs.String(), `"status":200`)
 }
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.10"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		expected   string
+	}{
+		{name: "Remote address", remoteAddr: "192.0.2.1:54321", expected: "192.0.2.1"},
+		{name: "IPv6 remote address", remoteAddr: "[2001:db8::1]:54321", expected: "2001:db8::1"},
+		{name: "Forwarded by trusted proxy", remoteAddr: "10.0.0.1:54321", forwarded: "198.51.100.7", expected: "198.51.100.7"},
+		{name: "Forwarded through trusted proxies", remoteAddr: "10.0.0.1:54321", forwarded: "198.51.100.7, 192.0.2.10",
+			expected: "198.51.100.7"},
+		{name: "Addresses added by the client ignored", remoteAddr: "10.0.0.1:54321", forwarded: "203.0.113.9, 198.51.100.7",
+			expected: "198.51.100.7"},
+		{name: "Real IP from trusted proxy", remoteAddr: "10.0.0.1:54321", realIP: "198.51.100.7", expected: "198.51.100.7"},
+		{name: "Forwarded header of untrusted client ignored", remoteAddr: "192.0.2.1:54321", forwarded: "198.51.100.7",
+			expected: "192.0.2.1"},
+		{name: "Real IP of untrusted client ignored", remoteAddr: "192.0.2.1:54321", realIP: "198.51.100.7", expected: "192.0.2.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var ip string
+			r := New(Options{TrustedProxies: trusted})
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				ip = ClientIPFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			if tc.realIP != "" {
+				req.Header.Set("X-Real-IP", tc.realIP)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.expected, ip)
+		})
+	}
+}
+
+// TestClientIP_Spoofed checks that a client cannot escape per-IP limits, such as the one on password resets, by
+// sending a different forwarding header with every request.
+func TestClientIP_Spoofed(t *testing.T) {
+	var ips []string
+	r := New(Options{})
+	r.Post("/auth/password-reset", func(w http.ResponseWriter, r *http.Request) {
+		ips = append(ips, ClientIPFromContext(r.Context()))
+	})
+
+	for _, spoofed := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/password-reset", nil)
+		req.RemoteAddr = "192.0.2.1:54321"
+		req.Header.Set("X-Forwarded-For", spoofed)
+		req.Header.Set("X-Real-IP", spoofed)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.1", "192.0.2.1"}, ips)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	prefixes, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.0.2.10 ", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.10/32"}, []string{prefixes[0].String(), prefixes[1].String()})
+
+	_, err = ParseTrustedProxies([]string{"proxy.local"})
+	assert.Error(t, err)
+}
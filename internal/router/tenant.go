@@ -0,0 +1,65 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+)
+
+// OrgHeader is the request header naming the organization of a request by ID or slug.
+const OrgHeader = "X-Organization"
+
+// OrgResolver resolves the ID or slug of an organization to its ID. It returns ErrNotFound for unknown
+// organizations.
+type OrgResolver interface {
+	ResolveOrg(ctx context.Context, ref string) (uuid.UUID, error)
+}
+
+// tenantMiddleware stores the organization of the request in its context. The organization of the principal, taken
+// from their token or managed API key, wins; otherwise it is named by the X-Organization header or, below baseDomain,
+// by the subdomain. Requests naming another organization than their principal's are rejected with 403, unknown
+// organizations with 400. Requests without an organization pass through and see no tenant data.
+func tenantMiddleware(orgs OrgResolver, baseDomain string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ref := strings.TrimSpace(r.Header.Get(OrgHeader))
+			if ref == "" {
+				ref, _ = tenant.FromHost(r.Host, baseDomain)
+			}
+
+			var org uuid.UUID
+			if ref != "" {
+				var err error
+				org, err = orgs.ResolveOrg(r.Context(), ref)
+				if errors.Is(err, ownErrors.ErrNotFound) {
+					writeError(w, http.StatusBadRequest, "Unknown organization")
+					return
+				}
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+			}
+
+			if principal, ok := PrincipalFromContext(r.Context()); ok && principal.Org != "" {
+				own, err := uuid.Parse(principal.Org)
+				if err != nil || (ref != "" && org != own) {
+					writeError(w, http.StatusForbidden, "Organization does not match the credentials")
+					return
+				}
+				org = own
+			}
+
+			if org != uuid.Nil {
+				r = r.WithContext(tenant.WithOrg(r.Context(), org))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
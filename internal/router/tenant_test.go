@@ -0,0 +1,142 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"go-users/internal/ownErrors"
+	"go-users/internal/tenant"
+	"go-users/internal/token"
+)
+
+var (
+	acmeID   = uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-00000000a001")
+	globexID = uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-00000000a002")
+)
+
+// fakeOrgs knows the organizations acme and globex; resolving "broken" fails.
+type fakeOrgs struct{}
+
+func (fakeOrgs) ResolveOrg(_ context.Context, ref string) (uuid.UUID, error) {
+	switch ref {
+	case "acme", acmeID.String():
+		return acmeID, nil
+	case "globex":
+		return globexID, nil
+	case "broken":
+		return uuid.Nil, errors.New("db error")
+	}
+	return uuid.Nil, ownErrors.ErrNotFound
+}
+
+// orgVerifier accepts the access token "acme" of a user of the acme organization.
+type orgVerifier struct{}
+
+func (orgVerifier) Verify(accessToken string) (*token.Claims, error) {
+	if accessToken != "acme" {
+		return nil, token.ErrInvalidToken
+	}
+	return &token.Claims{Subject: "01927a3e-8f2c-7b3d-9e4f-000000000001", Org: acmeID.String()}, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		host           string
+		headers        map[string]string
+		expectedStatus int
+		expectedOrg    uuid.UUID
+	}{
+		{name: "No organization", expectedStatus: http.StatusOK},
+		{
+			name:           "Header with slug",
+			headers:        map[string]string{OrgHeader: "acme"},
+			expectedStatus: http.StatusOK,
+			expectedOrg:    acmeID,
+		},
+		{
+			name:           "Header with ID",
+			headers:        map[string]string{OrgHeader: acmeID.String()},
+			expectedStatus: http.StatusOK,
+			expectedOrg:    acmeID,
+		},
+		{
+			name:           "Subdomain",
+			host:           "globex.users.example.com",
+			expectedStatus: http.StatusOK,
+			expectedOrg:    globexID,
+		},
+		{
+			name:           "Header wins over subdomain",
+			host:           "globex.users.example.com",
+			headers:        map[string]string{OrgHeader: "acme"},
+			expectedStatus: http.StatusOK,
+			expectedOrg:    acmeID,
+		},
+		{
+			name:           "Token claim",
+			headers:        map[string]string{"Authorization": "Bearer acme"},
+			expectedStatus: http.StatusOK,
+			expectedOrg:    acmeID,
+		},
+		{
+			name:           "Token claim matching the subdomain",
+			host:           "acme.users.example.com",
+			headers:        map[string]string{"Authorization": "Bearer acme"},
+			expectedStatus: http.StatusOK,
+			expectedOrg:    acmeID,
+		},
+		{
+			name:           "Token claim conflicting with the header",
+			headers:        map[string]string{"Authorization": "Bearer acme", OrgHeader: "globex"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Token claim conflicting with the subdomain",
+			host:           "globex.users.example.com",
+			headers:        map[string]string{"Authorization": "Bearer acme"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Unknown organization",
+			headers:        map[string]string{OrgHeader: "initech"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Lookup fails",
+			headers:        map[string]string{OrgHeader: "broken"},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(Options{Tokens: orgVerifier{}, Orgs: fakeOrgs{}, BaseDomain: "users.example.com"})
+
+			var org uuid.UUID
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				org, _ = tenant.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.host != "" {
+				req.Host = tc.host
+			}
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedOrg, org)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package search
+
+import (
+	"bytes"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go-users/internal/api"
+)
+
+const (
+	// SimilarityThreshold mirrors the default pg_trgm.similarity_threshold used by the % operator.
+	SimilarityThreshold = 0.3
+
+	// MarkStart and MarkEnd delimit highlighted words in snippets.
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// Terms splits a query into lower-cased alphanumeric words. Every term is matched as a word prefix.
+func Terms(q string) []string {
+	return words(strings.ToLower(q))
+}
+
+// TSQuery builds a to_tsquery expression requiring every term as a prefix, e.g. "jo:* & do:*".
+// Terms only contain letters and digits, so the expression needs no further escaping.
+func TSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Similarity returns the trigram similarity of two strings with the same semantics as pg_trgm's similarity():
+// the number of shared trigrams divided by the number of distinct trigrams of both strings.
+func Similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// Highlight wraps every word of text starting with one of the terms in MarkStart and MarkEnd. The rest of the text is
+// HTML-escaped, so the marks are the only markup of the snippet. It reports whether any word was highlighted.
+func Highlight(text string, terms []string) (string, bool) {
+	var b strings.Builder
+	highlighted := false
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+
+		word := string(runes[i:j])
+		if startsWithAny(strings.ToLower(word), terms) {
+			b.WriteString(MarkStart + word + MarkEnd)
+			highlighted = true
+		} else {
+			b.WriteString(word)
+		}
+		i = j
+	}
+
+	return b.String(), highlighted
+}
+
+// Rank searches users in memory. It is the reference implementation for repository backends without Postgres
+// full-text and trigram support and follows the same rules: a user matches if every term is a prefix of one of its
+// words or if its name or email is similar enough to the query. The score adds the share of matched terms to the
+// name and email similarities, so exact prefix matches rank above fuzzy ones.
+func Rank(users []api.User, q string, limit int) []api.SearchResult {
+	terms := Terms(q)
+	if len(terms) == 0 || limit < 1 {
+		return []api.SearchResult{}
+	}
+	normalized := strings.Join(terms, " ")
+
+	results := make([]api.SearchResult, 0)
+	for _, user := range users {
+		name := user.FirstName + " " + user.LastName
+		email := string(user.Email)
+
+		userWords := append(words(strings.ToLower(name)), words(strings.ToLower(email))...)
+		matched := 0
+		for _, term := range terms {
+			for _, word := range userWords {
+				if strings.HasPrefix(word, term) {
+					matched++
+					break
+				}
+			}
+		}
+
+		nameSimilarity := Similarity(name, normalized)
+		emailSimilarity := Similarity(email, normalized)
+		if matched < len(terms) && nameSimilarity < SimilarityThreshold && emailSimilarity < SimilarityThreshold {
+			continue
+		}
+
+		result := api.SearchResult{
+			User:  user,
+			Score: float64(matched)/float64(len(terms)) + nameSimilarity + emailSimilarity,
+		}
+		if snippet, ok := Highlight(name, terms); ok {
+			result.Highlights.Name = &snippet
+		}
+		if snippet, ok := Highlight(email, terms); ok {
+			result.Highlights.Email = &snippet
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return bytes.Compare(results[i].User.Id[:], results[j].User.Id[:]) < 0
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// startsWithAny reports whether s starts with one of the prefixes.
+func startsWithAny(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// trigrams returns the set of trigrams of s the way pg_trgm builds them: every lower-cased alphanumeric word is padded
+// with two spaces in front and one behind before being cut into overlapping three-character sequences.
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range words(strings.ToLower(s)) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// words splits s into runs of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
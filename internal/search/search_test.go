@@ -0,0 +1,82 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/api"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"john", "o", "brien", "example", "com"}, Terms("  John O'Brien, @example.com "))
+	assert.Empty(t, Terms(" @@ -- "))
+}
+
+func TestTSQuery(t *testing.T) {
+	assert.Equal(t, "jo:* & do:*", TSQuery([]string{"jo", "do"}))
+}
+
+func TestSimilarity(t *testing.T) {
+	// Values match SELECT similarity(a, b) with pg_trgm.
+	assert.Equal(t, 1.0, Similarity("John", "john"))
+	assert.InDelta(t, 0.3636, Similarity("word", "two words"), 0.0001)
+	assert.InDelta(t, 0.6190, Similarity("jhon@example.com", "john@example.com"), 0.0001)
+	assert.Equal(t, 0.0, Similarity("", "john"))
+}
+
+func TestHighlight(t *testing.T) {
+	snippet, ok := Highlight("John Johnson-Doe", []string{"joh"})
+	assert.True(t, ok)
+	assert.Equal(t, "<mark>John</mark> <mark>Johnson</mark>-Doe", snippet)
+
+	snippet, ok = Highlight(`<b onclick="x()">John</b> & Co`, []string{"joh"})
+	assert.True(t, ok)
+	assert.Equal(t, "&lt;b onclick=&#34;x()&#34;&gt;<mark>John</mark>&lt;/b&gt; &amp; Co", snippet)
+
+	snippet, ok = Highlight("jane@example.com", []string{"joh"})
+	assert.False(t, ok)
+	assert.Equal(t, "jane@example.com", snippet)
+}
+
+func TestRank(t *testing.T) {
+	ids := []openapi_types.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	users := []api.User{
+		{Id: ids[0], FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		{Id: ids[1], FirstName: "Johanna", LastName: "Smith", Email: "jsmith@example.com"},
+		{Id: ids[2], FirstName: "Jane", LastName: "Roe", Email: "jane@example.org"},
+		{Id: ids[3], FirstName: "Jon", LastName: "Doe", Email: "jon@example.net"},
+	}
+
+	t.Run("Prefix match on every term", func(t *testing.T) {
+		results := Rank(users, "jo do", 10)
+
+		require.Len(t, results, 2)
+		assert.Equal(t, ids[0], results[0].User.Id)
+		assert.Equal(t, ids[3], results[1].User.Id)
+		require.NotNil(t, results[0].Highlights.Name)
+		assert.Equal(t, "<mark>John</mark> <mark>Doe</mark>", *results[0].Highlights.Name)
+		assert.Equal(t, "<mark>john</mark>.<mark>doe</mark>@example.com", *results[0].Highlights.Email)
+	})
+
+	t.Run("Misspelled name matches by similarity", func(t *testing.T) {
+		results := Rank(users, "Jhon Doe", 10)
+
+		found := make([]openapi_types.UUID, len(results))
+		for i, r := range results {
+			found[i] = r.User.Id
+		}
+		assert.ElementsMatch(t, []openapi_types.UUID{ids[0], ids[3]}, found)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		assert.Len(t, Rank(users, "jo", 2), 2)
+	})
+
+	t.Run("No terms", func(t *testing.T) {
+		assert.Empty(t, Rank(users, "!!", 10))
+	})
+}
@@ -0,0 +1,55 @@
+package tenant
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// orgKey is the context key of the organization a request acts on.
+type orgKey struct{}
+
+// allOrgsKey is the context key marking system tasks that act on every organization.
+type allOrgsKey struct{}
+
+// WithOrg returns a copy of ctx acting on the given organization.
+func WithOrg(ctx context.Context, org uuid.UUID) context.Context {
+	return context.WithValue(ctx, orgKey{}, org)
+}
+
+// FromContext returns the organization ctx acts on, if any.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	org, ok := ctx.Value(orgKey{}).(uuid.UUID)
+	return org, ok && org != uuid.Nil
+}
+
+// WithAllOrgs returns a copy of ctx for system tasks that act on every organization, such as claiming jobs. It must
+// never be derived from anything a client sent.
+func WithAllOrgs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allOrgsKey{}, true)
+}
+
+// AllOrgs reports whether ctx acts on every organization.
+func AllOrgs(ctx context.Context) bool {
+	all, _ := ctx.Value(allOrgsKey{}).(bool)
+	return all
+}
+
+// FromHost returns the subdomain of host directly below baseDomain, e.g. acme for acme.users.example.com with the base
+// domain users.example.com. Ports are ignored.
+func FromHost(host, baseDomain string) (string, bool) {
+	if baseDomain == "" {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	sub, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(strings.Trim(baseDomain, ".")))
+	if !ok || sub == "" || strings.Contains(sub, ".") {
+		return "", false
+	}
+	return sub, true
+}
@@ -0,0 +1,49 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	org := uuid.MustParse("01927a3e-8f2c-7b3d-9e4f-000000000001")
+
+	got, ok := FromContext(WithOrg(context.Background(), org))
+	assert.True(t, ok)
+	assert.Equal(t, org, got)
+
+	_, ok = FromContext(context.Background())
+	assert.False(t, ok)
+	_, ok = FromContext(WithOrg(context.Background(), uuid.Nil))
+	assert.False(t, ok)
+
+	assert.True(t, AllOrgs(WithAllOrgs(context.Background())))
+	assert.False(t, AllOrgs(context.Background()))
+}
+
+func TestFromHost(t *testing.T) {
+	tests := []struct {
+		host       string
+		baseDomain string
+		expected   string
+	}{
+		{host: "acme.users.example.com", baseDomain: "users.example.com", expected: "acme"},
+		{host: "ACME.Users.Example.com:8080", baseDomain: "users.example.com", expected: "acme"},
+		{host: "users.example.com", baseDomain: "users.example.com"},
+		{host: "a.b.users.example.com", baseDomain: "users.example.com"},
+		{host: "acme.evil.com", baseDomain: "users.example.com"},
+		{host: "acmeusers.example.com", baseDomain: "users.example.com"},
+		{host: "acme.users.example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			sub, ok := FromHost(tc.host, tc.baseDomain)
+			assert.Equal(t, tc.expected, sub)
+			assert.Equal(t, tc.expected != "", ok)
+		})
+	}
+}
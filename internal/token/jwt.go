@@ -0,0 +1,131 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Supported signing algorithms.
+const (
+	EdDSA = "EdDSA"
+	RS256 = "RS256"
+)
+
+// ErrInvalidToken is returned for access tokens that are malformed, carry an unknown key ID, have an invalid signature
+// or claims that are not valid at the time of verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims represents the JWT claims of an access token. Times are seconds since the Unix epoch.
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+	// Org is the organization of the subject, if any.
+	Org string `json:"org,omitempty"`
+}
+
+// header represents the JOSE header of a signed token.
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// sign encodes claims as a compact JWS signed with the given key.
+func sign(key *signingKey, claims *Claims) (string, error) {
+	h, err := json.Marshal(header{Algorithm: key.Algorithm, Type: "JWT", KeyID: key.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode header: %w", err)
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	var signature []byte
+	switch key.Algorithm {
+	case EdDSA:
+		signature, err = key.signer.Sign(nil, []byte(input), crypto.Hash(0))
+	case RS256:
+		digest := sha256.Sum256([]byte(input))
+		signature, err = key.signer.Sign(nil, digest[:], crypto.SHA256)
+	default:
+		err = fmt.Errorf("unsupported algorithm %q", key.Algorithm)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parse splits a compact JWS and decodes its header. The returned function verifies the signature with a key and, on
+// success, decodes the claims.
+func parse(token string) (*header, func(key *signingKey) (*Claims, error), error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+	var h header
+	if err = json.Unmarshal(raw, &h); err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	verify := func(key *signingKey) (*Claims, error) {
+		// The algorithm is bound to the key, never taken from the token alone.
+		if h.Algorithm != key.Algorithm {
+			return nil, ErrInvalidToken
+		}
+
+		input := []byte(parts[0] + "." + parts[1])
+		switch pub := key.signer.Public().(type) {
+		case ed25519.PublicKey:
+			if !ed25519.Verify(pub, input, signature) {
+				return nil, ErrInvalidToken
+			}
+		case *rsa.PublicKey:
+			digest := sha256.Sum256(input)
+			if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) != nil {
+				return nil, ErrInvalidToken
+			}
+		default:
+			return nil, ErrInvalidToken
+		}
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		var claims Claims
+		if err = json.Unmarshal(payload, &claims); err != nil {
+			return nil, ErrInvalidToken
+		}
+
+		return &claims, nil
+	}
+
+	return &h, verify, nil
+}
@@ -0,0 +1,284 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/config"
+)
+
+// clockSkew is the tolerance applied to the time based claims of verified tokens.
+const clockSkew = 30 * time.Second
+
+// errNoSigningKey is returned when no key is valid for signing, e.g. before the keys were loaded.
+var errNoSigningKey = errors.New("no signing key available")
+
+// Store defines the persistence operations the keyring needs. Keys are shared by all instances through the store.
+type Store interface {
+	// ListSigningKeys returns the keys that have not expired at the given time.
+	ListSigningKeys(ctx context.Context, now time.Time) ([]Key, error)
+	// CreateSigningKey stores a key unless a key with the same NotBefore exists, so that instances rotating
+	// concurrently agree on a single successor.
+	CreateSigningKey(ctx context.Context, key *Key) error
+}
+
+// Keyring issues and verifies access tokens with a set of rotating signing keys.
+type Keyring struct {
+	store  Store
+	logger *slog.Logger
+
+	algorithm string
+	issuer    string
+	audience  string
+	accessTTL time.Duration
+	interval  time.Duration
+	overlap   time.Duration
+	check     time.Duration
+
+	mu   sync.RWMutex
+	keys []*signingKey // sorted by NotBefore
+
+	now  func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewKeyring creates a keyring backed by the given store. The keys are loaded by Start.
+func NewKeyring(store Store, logger *slog.Logger, jwtCfg config.JWT, keysCfg config.SigningKeys) (*Keyring, error) {
+	if jwtCfg.Algorithm != EdDSA && jwtCfg.Algorithm != RS256 {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", jwtCfg.Algorithm)
+	}
+
+	k := &Keyring{
+		store:     store,
+		logger:    logger,
+		algorithm: jwtCfg.Algorithm,
+		issuer:    jwtCfg.Issuer,
+		audience:  jwtCfg.Audience,
+		accessTTL: time.Duration(jwtCfg.AccessTTL) * time.Second,
+		interval:  time.Duration(keysCfg.RotationInterval) * time.Second,
+		overlap:   time.Duration(keysCfg.Overlap) * time.Second,
+		check:     time.Duration(keysCfg.CheckInterval) * time.Second,
+		now:       time.Now,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if k.accessTTL <= 0 || k.check <= 0 {
+		return nil, fmt.Errorf("access token TTL and key check interval must be positive")
+	}
+	if k.overlap < k.accessTTL || k.overlap >= k.interval {
+		return nil, fmt.Errorf("signing key overlap must be at least the access token TTL and shorter than the rotation interval")
+	}
+
+	return k, nil
+}
+
+// Start loads the signing keys, creating the first one if necessary, and keeps rotating them in the background until
+// Shutdown is called.
+func (k *Keyring) Start(ctx context.Context) error {
+	if err := k.Refresh(ctx); err != nil {
+		return err
+	}
+
+	go k.run()
+
+	return nil
+}
+
+// Shutdown stops the background rotation.
+func (k *Keyring) Shutdown() {
+	close(k.stop)
+	<-k.done
+}
+
+// run refreshes the keys at the check interval until the keyring is stopped.
+func (k *Keyring) run() {
+	defer close(k.done)
+
+	ticker := time.NewTicker(k.check)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), k.check)
+			if err := k.Refresh(ctx); err != nil {
+				k.logger.Error("Failed to refresh signing keys", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// Refresh reloads the keys from the store and creates the successor of the current key once it is due to be
+// published, i.e. Overlap before the current key has been in use for the rotation interval.
+func (k *Keyring) Refresh(ctx context.Context) error {
+	now := k.now()
+
+	keys, err := k.load(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var next *Key
+	current := active(keys, now)
+	switch {
+	case current == nil:
+		next, err = generateKey(k.algorithm, now, now.Add(k.interval+k.overlap))
+	case keys[len(keys)-1] == current && !now.Before(current.NotBefore.Add(k.interval-k.overlap)):
+		notBefore := current.NotBefore.Add(k.interval)
+		next, err = generateKey(k.algorithm, notBefore, notBefore.Add(k.interval+k.overlap))
+	}
+	if err != nil {
+		return err
+	}
+
+	if next != nil {
+		if err = k.store.CreateSigningKey(ctx, next); err != nil {
+			return fmt.Errorf("failed to store signing key: %w", err)
+		}
+		k.logger.Info("Signing key created", "kid", next.ID, "not_before", next.NotBefore)
+
+		if keys, err = k.load(ctx, now); err != nil {
+			return err
+		}
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+
+	return nil
+}
+
+// load reads and decodes the unexpired keys from the store.
+func (k *Keyring) load(ctx context.Context, now time.Time) ([]*signingKey, error) {
+	stored, err := k.store.ListSigningKeys(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]*signingKey, 0, len(stored))
+	for _, s := range stored {
+		key, err := decodeKey(s)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].NotBefore.Before(keys[j].NotBefore) })
+
+	return keys, nil
+}
+
+// active returns the most recent key that is valid for signing at the given time.
+func active(keys []*signingKey, now time.Time) *signingKey {
+	for i := len(keys) - 1; i >= 0; i-- {
+		if !keys[i].NotBefore.After(now) && keys[i].ExpiresAt.After(now) {
+			return keys[i]
+		}
+	}
+	return nil
+}
+
+// Issue returns a signed access token for the given subject together with its expiry. A non-empty org binds the
+// token to the organization of the subject.
+func (k *Keyring) Issue(subject, org string) (string, time.Time, error) {
+	now := k.now()
+
+	k.mu.RLock()
+	key := active(k.keys, now)
+	k.mu.RUnlock()
+	if key == nil {
+		return "", time.Time{}, errNoSigningKey
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	expiresAt := now.Add(k.accessTTL)
+	token, err := sign(key, &Claims{
+		Issuer:    k.issuer,
+		Subject:   subject,
+		Org:       org,
+		Audience:  k.audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		ID:        id.String(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// Verify checks the signature of an access token against the published keys and validates its issuer, audience and
+// validity period. Returns ErrInvalidToken if the token is not valid.
+func (k *Keyring) Verify(token string) (*Claims, error) {
+	h, verify, err := parse(token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := k.now()
+
+	k.mu.RLock()
+	var key *signingKey
+	for _, candidate := range k.keys {
+		if candidate.ID == h.KeyID && candidate.ExpiresAt.After(now) {
+			key = candidate
+			break
+		}
+	}
+	k.mu.RUnlock()
+	if key == nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, err := verify(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.Issuer != k.issuer, claims.Audience != k.audience, claims.Subject == "":
+		return nil, ErrInvalidToken
+	case now.Add(-clockSkew).Unix() >= claims.ExpiresAt:
+		return nil, ErrInvalidToken
+	case now.Add(clockSkew).Unix() < claims.NotBefore:
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys of all published signing keys, including the successor of the current key.
+func (k *Keyring) JWKS() JWKSet {
+	now := k.now()
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(k.keys))}
+	for _, key := range k.keys {
+		if key.ExpiresAt.After(now) {
+			set.Keys = append(set.Keys, key.jwk())
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,171 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/config"
+)
+
+// memoryStore is an in-memory Store.
+type memoryStore struct {
+	keys []Key
+}
+
+func (s *memoryStore) ListSigningKeys(_ context.Context, now time.Time) ([]Key, error) {
+	result := make([]Key, 0, len(s.keys))
+	for _, k := range s.keys {
+		if k.ExpiresAt.After(now) {
+			result = append(result, k)
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) CreateSigningKey(_ context.Context, key *Key) error {
+	for _, k := range s.keys {
+		if k.NotBefore.Equal(key.NotBefore) {
+			return nil
+		}
+	}
+	s.keys = append(s.keys, *key)
+	return nil
+}
+
+func newTestKeyring(t *testing.T, store Store, algorithm string, now *time.Time) *Keyring {
+	k, err := NewKeyring(store, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		config.JWT{Algorithm: algorithm, Issuer: "go-users", Audience: "go-users", AccessTTL: 900},
+		config.SigningKeys{RotationInterval: 7 * 86400, Overlap: 86400, CheckInterval: 60},
+	)
+	require.NoError(t, err)
+	k.now = func() time.Time { return *now }
+	return k
+}
+
+func TestNewKeyring_InvalidConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	keysCfg := config.SigningKeys{RotationInterval: 3600, Overlap: 900, CheckInterval: 60}
+
+	_, err := NewKeyring(&memoryStore{}, logger, config.JWT{Algorithm: "HS256", AccessTTL: 900}, keysCfg)
+	assert.ErrorContains(t, err, "unsupported JWT algorithm")
+
+	_, err = NewKeyring(&memoryStore{}, logger, config.JWT{Algorithm: EdDSA, AccessTTL: 1800}, keysCfg)
+	assert.ErrorContains(t, err, "overlap")
+}
+
+func TestKeyring_IssueAndVerify(t *testing.T) {
+	for _, algorithm := range []string{EdDSA, RS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+			k := newTestKeyring(t, &memoryStore{}, algorithm, &now)
+			require.NoError(t, k.Refresh(context.Background()))
+
+			token, expiresAt, err := k.Issue("01927a3e-8f2c-7b3d-9e4f-000000000001", "01927a3e-8f2c-7b3d-9e4f-0000000000aa")
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(15*time.Minute), expiresAt)
+
+			claims, err := k.Verify(token)
+			require.NoError(t, err)
+			assert.Equal(t, "01927a3e-8f2c-7b3d-9e4f-000000000001", claims.Subject)
+			assert.Equal(t, "go-users", claims.Audience)
+			assert.Equal(t, "01927a3e-8f2c-7b3d-9e4f-0000000000aa", claims.Org)
+			assert.NotEmpty(t, claims.ID)
+
+			jwks := k.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, algorithm, jwks.Keys[0].Algorithm)
+
+			now = now.Add(16 * time.Minute)
+			_, err = k.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestKeyring_VerifyRejectsTampering(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	k := newTestKeyring(t, &memoryStore{}, EdDSA, &now)
+	require.NoError(t, k.Refresh(context.Background()))
+
+	token, _, err := k.Issue("alice", "")
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	claims, _ := json.Marshal(Claims{Issuer: "go-users", Audience: "go-users", Subject: "mallory", ExpiresAt: now.Add(time.Hour).Unix()})
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString(claims) + "." + parts[2]
+	_, err = k.Verify(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	header, _ := json.Marshal(map[string]string{"alg": "none", "kid": k.JWKS().Keys[0].KeyID})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + parts[1] + "."
+	_, err = k.Verify(unsigned)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = k.Verify("not a token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	start := time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC)
+	now := start
+	store := &memoryStore{}
+	k := newTestKeyring(t, store, EdDSA, &now)
+	other := newTestKeyring(t, store, EdDSA, &now)
+
+	require.NoError(t, k.Refresh(context.Background()))
+	require.Len(t, store.keys, 1)
+	first := store.keys[0].ID
+
+	// The successor is published one overlap before it takes over; both instances agree on it.
+	now = start.Add(6 * 24 * time.Hour)
+	require.NoError(t, k.Refresh(context.Background()))
+	require.NoError(t, other.Refresh(context.Background()))
+	require.Len(t, store.keys, 2)
+	assert.Len(t, k.JWKS().Keys, 2)
+	second := store.keys[1].ID
+
+	now = start.Add(7*24*time.Hour - 5*time.Minute)
+	oldToken, _, err := k.Issue("alice", "")
+	require.NoError(t, err)
+	assert.Equal(t, first, keyID(t, oldToken))
+
+	// After the rotation the successor signs, tokens of the previous key still verify.
+	now = start.Add(7*24*time.Hour + 5*time.Minute)
+	token, _, err := other.Issue("alice", "")
+	require.NoError(t, err)
+	assert.Equal(t, second, keyID(t, token))
+	_, err = k.Verify(oldToken)
+	assert.NoError(t, err)
+
+	// The previous key is withdrawn once the overlap has passed.
+	now = start.Add(8 * 24 * time.Hour)
+	require.NoError(t, k.Refresh(context.Background()))
+	jwks := k.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, second, jwks.Keys[0].KeyID)
+}
+
+func TestKeyring_IssueWithoutKeys(t *testing.T) {
+	now := time.Now()
+	k := newTestKeyring(t, &memoryStore{}, EdDSA, &now)
+
+	_, _, err := k.Issue("alice", "")
+
+	assert.ErrorIs(t, err, errNoSigningKey)
+}
+
+// keyID returns the kid header of a token.
+func keyID(t *testing.T, token string) string {
+	h, _, err := parse(token)
+	require.NoError(t, err)
+	return h.KeyID
+}
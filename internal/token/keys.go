@@ -0,0 +1,137 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// rsaKeyBits is the modulus size of generated RS256 keys.
+const rsaKeyBits = 2048
+
+// Key represents a persisted signing key. PrivateKey holds the PKCS #8 DER encoding of the private key.
+//
+// A key is used for signing from NotBefore until the next key becomes valid and is published in the JWKS until
+// ExpiresAt, so that it is known to verifiers before its first token and after its last token expired.
+type Key struct {
+	ID         string
+	Algorithm  string
+	PrivateKey []byte
+	NotBefore  time.Time
+	ExpiresAt  time.Time
+}
+
+// signingKey is a Key together with its decoded private key.
+type signingKey struct {
+	Key
+	signer crypto.Signer
+}
+
+// generateKey creates a new key for the given algorithm.
+func generateKey(algorithm string, notBefore, expiresAt time.Time) (*Key, error) {
+	var private any
+	switch algorithm {
+	case EdDSA:
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		private = k
+	case RS256:
+		k, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		private = k
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	return &Key{
+		ID:         id.String(),
+		Algorithm:  algorithm,
+		PrivateKey: der,
+		NotBefore:  notBefore,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// decodeKey parses the private key of a persisted key and checks that it matches the key's algorithm.
+func decodeKey(k Key) (*signingKey, error) {
+	private, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key %s: %w", k.ID, err)
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key %s is not a signing key", k.ID)
+	}
+
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		ok = k.Algorithm == EdDSA
+	case *rsa.PrivateKey:
+		ok = k.Algorithm == RS256
+	default:
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("key %s does not match algorithm %q", k.ID, k.Algorithm)
+	}
+
+	return &signingKey{Key: k, signer: signer}, nil
+}
+
+// JWK represents the public part of a signing key as a JSON Web Key (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKSet represents a JSON Web Key Set.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwk returns the public JWK of the key.
+func (k *signingKey) jwk() JWK {
+	jwk := JWK{KeyID: k.ID, Algorithm: k.Algorithm, Use: "sig"}
+
+	switch pub := k.signer.Public().(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	}
+
+	return jwk
+}
@@ -0,0 +1,93 @@
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-users/internal/ownErrors"
+)
+
+// minSecretLength is the minimum length of a configured signing secret in bytes.
+const minSecretLength = 32
+
+// Claims represents what a verification token asserts. The ID identifies the stored verification, which makes the
+// token single use.
+type Claims struct {
+	ID        uuid.UUID `json:"jti"`
+	UserID    uuid.UUID `json:"sub"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// Signer signs and verifies tokens of the form <payload>.<signature>, both base64url encoded, with HMAC-SHA256.
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewSigner creates a signer with the given secret. An empty secret is replaced by a random one, so tokens are only
+// accepted by this process.
+func NewSigner(secret string) (*Signer, error) {
+	key := []byte(secret)
+	switch {
+	case secret == "":
+		key = make([]byte, minSecretLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate verification secret: %w", err)
+		}
+	case len(key) < minSecretLength:
+		return nil, fmt.Errorf("verification secret must be at least %d bytes long", minSecretLength)
+	}
+
+	return &Signer{secret: key, now: time.Now}, nil
+}
+
+// Sign returns the token of the claims.
+func (s *Signer) Sign(c *Claims) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode verification token: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Verify returns the claims of a token. Returns ErrInvalidToken if the token is malformed, its signature does not
+// match or it expired.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ownErrors.ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return nil, ownErrors.ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ownErrors.ErrInvalidToken
+	}
+	var c Claims
+	if err = json.Unmarshal(payload, &c); err != nil || !c.ExpiresAt.After(s.now()) {
+		return nil, ownErrors.ErrInvalidToken
+	}
+
+	return &c, nil
+}
+
+// sign returns the signature of an encoded payload.
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
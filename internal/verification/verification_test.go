@@ -0,0 +1,81 @@
+package verification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-users/internal/ownErrors"
+)
+
+func testClaims(expiresAt time.Time) *Claims {
+	return &Claims{
+		ID:        uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"),
+		UserID:    uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8058"),
+		Email:     "john@example.com",
+		ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+	}
+}
+
+func TestSigner_RoundTrip(t *testing.T) {
+	s, err := NewSigner(strings.Repeat("s", 32))
+	require.NoError(t, err)
+	claims := testClaims(time.Now().Add(time.Hour))
+
+	token, err := s.Sign(claims)
+	require.NoError(t, err)
+
+	verified, err := s.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, claims, verified)
+}
+
+func TestSigner_Rejected(t *testing.T) {
+	s, err := NewSigner(strings.Repeat("s", 32))
+	require.NoError(t, err)
+	other, err := NewSigner(strings.Repeat("o", 32))
+	require.NoError(t, err)
+
+	valid, err := s.Sign(testClaims(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	expired, err := s.Sign(testClaims(time.Now().Add(-time.Second)))
+	require.NoError(t, err)
+	foreign, err := other.Sign(testClaims(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	tests := map[string]string{
+		"Expired":           expired,
+		"Other secret":      foreign,
+		"Missing signature": payload,
+		"Tampered payload":  "x" + payload + "." + signature,
+		"Invalid encoding":  payload + ".!!!",
+		"Empty":             "",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims, err := s.Verify(token)
+			assert.Nil(t, claims)
+			assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	_, err := NewSigner("too-short")
+	assert.Error(t, err)
+
+	// Random secrets differ, so tokens of one process are not accepted by another.
+	a, err := NewSigner("")
+	require.NoError(t, err)
+	b, err := NewSigner("")
+	require.NoError(t, err)
+	token, err := a.Sign(testClaims(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	_, err = b.Verify(token)
+	assert.ErrorIs(t, err, ownErrors.ErrInvalidToken)
+}
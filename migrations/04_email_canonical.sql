@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Canonical email used for uniqueness and lookups; the email column keeps the address as entered.
+-- The application writes it on every insert and update, see internal/email.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_canonical VARCHAR(255);
+
+-- Users whose canonical email could not be backfilled. They keep a NULL email_canonical until an operator resolves the
+-- conflict, e.g. by merging the accounts or updating the email through the API, and then deletes the report row.
+CREATE TABLE IF NOT EXISTS email_canonical_conflicts (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    email_canonical VARCHAR(255) NOT NULL,
+    reason VARCHAR(32) NOT NULL CHECK (reason IN ('duplicate', 'non_ascii_domain')),
+    conflicts_with INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- IDNA encoding is not available in SQL, so addresses with a non-ASCII domain are reported instead of being stored
+-- with a domain the application would never produce.
+INSERT INTO email_canonical_conflicts (user_id, email, email_canonical, reason)
+SELECT id, email, lower(normalize(email, NFC)), 'non_ascii_domain'
+FROM users
+WHERE split_part(email, '@', 2) !~ '^[\x01-\x7F]*$';
+
+-- The oldest user of each canonical address keeps it; the others are reported as duplicates.
+INSERT INTO email_canonical_conflicts (user_id, email, email_canonical, reason, conflicts_with)
+SELECT id, email, canonical, 'duplicate', kept_id
+FROM (
+    SELECT id, email, lower(normalize(email, NFC)) AS canonical,
+        min(id) OVER (PARTITION BY lower(normalize(email, NFC))) AS kept_id
+    FROM users
+    WHERE id NOT IN (SELECT user_id FROM email_canonical_conflicts)
+) AS candidates
+WHERE id <> kept_id;
+
+-- Backfill without touching updated_at. Gmail addresses are not folded here even with EMAIL_FOLD_GMAIL; a users.reindex
+-- job recomputes the canonical emails with the options of the application.
+ALTER TABLE users DISABLE TRIGGER update_users_updated_at;
+
+UPDATE users SET email_canonical = lower(normalize(email, NFC))
+WHERE id NOT IN (SELECT user_id FROM email_canonical_conflicts);
+
+ALTER TABLE users ENABLE TRIGGER update_users_updated_at;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_canonical ON users(email_canonical);
+
+DO $$
+DECLARE
+    conflicts INTEGER;
+BEGIN
+    SELECT count(*) INTO conflicts FROM email_canonical_conflicts;
+    IF conflicts > 0 THEN
+        RAISE WARNING '% user(s) could not get a canonical email, see table email_canonical_conflicts', conflicts;
+    END IF;
+END
+$$;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_email_canonical;
+DROP TABLE IF EXISTS email_canonical_conflicts;
+ALTER TABLE users DROP COLUMN IF EXISTS email_canonical;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Generates a time-ordered UUID version 7: a 48-bit Unix timestamp in milliseconds followed by random bits.
+-- It starts from a random version 4 UUID, overlays the timestamp and sets the version bits from 0100 to 0111.
+CREATE OR REPLACE FUNCTION uuid_generate_v7()
+RETURNS UUID AS $$
+BEGIN
+    RETURN encode(
+        set_bit(
+            set_bit(
+                overlay(uuid_send(gen_random_uuid())
+                    PLACING substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::BIGINT) FROM 3)
+                    FROM 1 FOR 6),
+                52, 1),
+            53, 1),
+        'hex')::UUID;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- Public user identifier. The volatile default gives every existing row its own value when the column is added.
+-- The SERIAL id stays the internal key used by foreign keys.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS uid UUID NOT NULL DEFAULT uuid_generate_v7();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_uid ON users(uid);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_uid;
+ALTER TABLE users DROP COLUMN IF EXISTS uid;
+DROP FUNCTION IF EXISTS uuid_generate_v7();
+
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Access token signing keys shared by all instances. Keys are published from not_before minus the rotation overlap
+-- until expires_at; the unique not_before lets concurrently rotating instances agree on a single successor.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid TEXT PRIMARY KEY,
+    algorithm TEXT NOT NULL CHECK (algorithm IN ('EdDSA', 'RS256')),
+    private_key BYTEA NOT NULL,
+    not_before TIMESTAMP WITH TIME ZONE NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_expires_at ON signing_keys(expires_at);
+
+-- Single-use refresh tokens; only the SHA-256 hash of a token is stored. All tokens issued for one login share a
+-- family, which is revoked as a whole on logout or when a used token is presented again.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+-- Refresh tokens replace the opaque session tokens; open sessions keep working as refresh tokens.
+INSERT INTO refresh_tokens (user_id, token_hash, created_at, expires_at)
+SELECT user_id, token_hash, created_at, expires_at
+FROM sessions
+WHERE expires_at > CURRENT_TIMESTAMP;
+
+DROP TABLE IF EXISTS sessions;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at);
+
+INSERT INTO sessions (user_id, token_hash, created_at, expires_at)
+SELECT user_id, token_hash, created_at, expires_at
+FROM refresh_tokens
+WHERE used_at IS NULL AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP;
+
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS signing_keys;
+
+-- +goose StatementEnd
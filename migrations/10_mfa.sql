@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- TOTP secret of a user; enabled_at is NULL while the enrollment awaits its first code. last_used_step is the TOTP
+-- time step of the last accepted code, which must not be accepted again.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret BYTEA NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One-time recovery codes; only their SHA-256 hash is stored. Removed together with the TOTP secret.
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES user_mfa(user_id) ON DELETE CASCADE,
+    code_hash BYTEA NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, code_hash)
+);
+
+-- Logins awaiting their second factor; only the SHA-256 hash of the challenge token is stored.
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES user_mfa(user_id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
+
+-- Audit log of MFA changes. The user is kept by public ID so that entries outlive the user.
+CREATE TABLE IF NOT EXISTS mfa_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_uid UUID NOT NULL,
+    event TEXT NOT NULL CHECK (event IN ('enrolled', 'reset', 'recovery_code_used')),
+    actor_kind TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_events_user_uid ON mfa_events(user_uid);
+
+-- Permissions of roles requiring MFA are withheld from users who have not enabled it.
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE roles SET mfa_required = TRUE WHERE name = 'admin';
+
+INSERT INTO permissions (name, description) VALUES
+    ('mfa:reset', 'Reset the MFA of any user')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'mfa:reset' FROM roles WHERE name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'mfa:reset';
+ALTER TABLE roles DROP COLUMN IF EXISTS mfa_required;
+DROP TABLE IF EXISTS mfa_events;
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
+
+-- +goose StatementEnd
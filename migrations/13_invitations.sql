@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Invited users are pending until they accept their invitation.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active'
+    CONSTRAINT users_status_check CHECK (status IN ('pending', 'active'));
+
+-- Invitations by email; only the SHA-256 hash of the current token is stored. A revocation deletes the pending user,
+-- so user_id becomes NULL.
+CREATE TABLE IF NOT EXISTS invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    email TEXT NOT NULL,
+    token_hash BYTEA NOT NULL UNIQUE,
+    invited_by_kind TEXT NOT NULL,
+    invited_by_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_user_id ON invitations(user_id);
+
+-- Transitions of invitations with the acting principal.
+CREATE TABLE IF NOT EXISTS invitation_events (
+    id BIGSERIAL PRIMARY KEY,
+    invitation_id UUID NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+    event TEXT NOT NULL CHECK (event IN ('created', 'resent', 'revoked', 'accepted')),
+    actor_kind TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitation_events_invitation_id ON invitation_events(invitation_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('invitations:manage', 'Invite users and manage invitations')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'invitations:manage' FROM roles WHERE name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'invitations:manage';
+DROP TABLE IF EXISTS invitation_events;
+DROP TABLE IF EXISTS invitations;
+DELETE FROM users WHERE status = 'pending';
+ALTER TABLE users DROP COLUMN IF EXISTS status;
+
+-- +goose StatementEnd
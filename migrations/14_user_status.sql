@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Active users can be suspended, optionally until a scheduled reactivation, or deactivated.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending', 'active', 'suspended', 'deactivated'));
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
+CREATE INDEX IF NOT EXISTS idx_users_suspended_until ON users(suspended_until) WHERE status = 'suspended';
+
+-- Status changes of users with the acting principal; kept when the user is deleted.
+CREATE TABLE IF NOT EXISTS user_status_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_uid UUID NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    reason TEXT,
+    until TIMESTAMP WITH TIME ZONE,
+    actor_kind TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_events_user_uid ON user_status_events(user_uid);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:status', 'Suspend, reactivate and deactivate users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'users:status' FROM roles WHERE name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'users:status';
+DROP TABLE IF EXISTS user_status_events;
+DROP INDEX IF EXISTS idx_users_suspended_until;
+DROP INDEX IF EXISTS idx_users_status;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
+UPDATE users SET status = 'active' WHERE status IN ('suspended', 'deactivated');
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('pending', 'active'));
+
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Groups of users. Names are unique regardless of case.
+CREATE TABLE IF NOT EXISTS groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_name ON groups(lower(name));
+
+CREATE TRIGGER update_groups_updated_at
+    BEFORE UPDATE ON groups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Direct members of groups.
+CREATE TABLE IF NOT EXISTS group_users (
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_users_user_id ON group_users(user_id);
+
+-- Nesting of groups: the members of a child are transitively members of its parents. The graph is kept acyclic by
+-- the application, which checks new edges while holding a lock on this table.
+CREATE TABLE IF NOT EXISTS group_subgroups (
+    parent_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    child_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (parent_id, child_id),
+    CHECK (parent_id <> child_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_subgroups_child_id ON group_subgroups(child_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('groups:read', 'Read groups and their members'),
+    ('groups:manage', 'Create, change and delete groups and their members')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.name FROM roles r JOIN permissions p ON p.name IN ('groups:read', 'groups:manage')
+WHERE r.name = 'admin'
+UNION ALL
+SELECT r.id, 'groups:read' FROM roles r WHERE r.name = 'support'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name IN ('groups:read', 'groups:manage');
+DROP TABLE IF EXISTS group_subgroups;
+DROP TABLE IF EXISTS group_users;
+DROP TABLE IF EXISTS groups;
+
+-- +goose StatementEnd
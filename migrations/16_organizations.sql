@@ -0,0 +1,244 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Customer organizations. The slug names an organization in the X-Organization header and as subdomain, so it must
+-- be a DNS label.
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    slug TEXT NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$'),
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing data belongs to the default organization.
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default') ON CONFLICT (slug) DO NOTHING;
+
+-- Tenant-owned rows carry their organization. New rows take it from the app.current_org setting of the transaction,
+-- see internal/database/tenant.go, so inserts cannot pick another organization by accident.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE invitations ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE user_status_events ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE mfa_events ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+-- API keys belong to the organization they were created in, which for personal access tokens is their owner's.
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+
+-- Backfill without touching updated_at
+ALTER TABLE users DISABLE TRIGGER update_users_updated_at;
+ALTER TABLE groups DISABLE TRIGGER update_groups_updated_at;
+ALTER TABLE jobs DISABLE TRIGGER update_jobs_updated_at;
+
+UPDATE users SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE groups SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE invitations SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE jobs SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE user_status_events SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE mfa_events SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+UPDATE api_keys k SET org_id = COALESCE((SELECT u.org_id FROM users u WHERE u.id = k.user_id),
+    (SELECT id FROM organizations WHERE slug = 'default')) WHERE org_id IS NULL;
+
+ALTER TABLE users ENABLE TRIGGER update_users_updated_at;
+ALTER TABLE groups ENABLE TRIGGER update_groups_updated_at;
+ALTER TABLE jobs ENABLE TRIGGER update_jobs_updated_at;
+
+ALTER TABLE users ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE groups ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE invitations ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE jobs ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE user_status_events ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE mfa_events ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+ALTER TABLE api_keys ALTER COLUMN org_id SET NOT NULL,
+    ALTER COLUMN org_id SET DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid;
+
+CREATE INDEX IF NOT EXISTS idx_groups_org_id ON groups(org_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_org_id ON invitations(org_id);
+CREATE INDEX IF NOT EXISTS idx_jobs_org_id ON jobs(org_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys(org_id);
+
+-- Email addresses and group names are unique per organization.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email_canonical;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_org_email_canonical ON users(org_id, email_canonical);
+DROP INDEX IF EXISTS idx_groups_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_org_name ON groups(org_id, lower(name));
+
+-- Rows of an organization are visible to transactions that set app.current_org to it. System tasks spanning all
+-- organizations, such as the job workers, set app.all_orgs instead. Without either setting no rows are visible.
+CREATE OR REPLACE FUNCTION app_org_visible(org UUID)
+RETURNS BOOLEAN AS $$
+    SELECT current_setting('app.all_orgs', true) = 'on'
+        OR org = NULLIF(current_setting('app.current_org', true), '')::uuid;
+$$ LANGUAGE sql STABLE;
+
+-- FORCE applies the policies to the owner of the tables too, which the application usually connects as. Superusers
+-- and roles with BYPASSRLS still see every row.
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users USING (app_org_visible(org_id));
+
+ALTER TABLE groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE groups FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON groups USING (app_org_visible(org_id));
+
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitations USING (app_org_visible(org_id));
+
+ALTER TABLE jobs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE jobs FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON jobs USING (app_org_visible(org_id));
+
+ALTER TABLE user_status_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_status_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_status_events USING (app_org_visible(org_id));
+
+ALTER TABLE mfa_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON mfa_events USING (app_org_visible(org_id));
+
+-- Rows owned by a user or group are visible with their owner; the subqueries are subject to the policies above.
+ALTER TABLE user_credentials ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_credentials FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_credentials
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_credentials.user_id));
+
+ALTER TABLE refresh_tokens ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refresh_tokens FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON refresh_tokens
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = refresh_tokens.user_id));
+
+ALTER TABLE user_roles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_roles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_roles
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_roles.user_id));
+
+ALTER TABLE user_mfa ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_mfa FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_mfa
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_mfa.user_id));
+
+ALTER TABLE mfa_recovery_codes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_recovery_codes FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON mfa_recovery_codes
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = mfa_recovery_codes.user_id));
+
+ALTER TABLE mfa_challenges ENABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_challenges FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON mfa_challenges
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = mfa_challenges.user_id));
+
+ALTER TABLE email_verifications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_verifications FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON email_verifications
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = email_verifications.user_id));
+
+ALTER TABLE password_resets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE password_resets FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON password_resets
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = password_resets.user_id));
+
+ALTER TABLE email_canonical_conflicts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_canonical_conflicts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON email_canonical_conflicts
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.id = email_canonical_conflicts.user_id));
+
+ALTER TABLE api_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE api_keys FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON api_keys USING (app_org_visible(org_id));
+
+ALTER TABLE group_users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE group_users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON group_users
+    USING (EXISTS (SELECT 1 FROM groups g WHERE g.id = group_users.group_id)
+        AND EXISTS (SELECT 1 FROM users u WHERE u.id = group_users.user_id));
+
+ALTER TABLE group_subgroups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE group_subgroups FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON group_subgroups
+    USING (EXISTS (SELECT 1 FROM groups g WHERE g.id = group_subgroups.parent_id)
+        AND EXISTS (SELECT 1 FROM groups g WHERE g.id = group_subgroups.child_id));
+
+ALTER TABLE invitation_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitation_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitation_events
+    USING (EXISTS (SELECT 1 FROM invitations i WHERE i.id = invitation_events.invitation_id));
+
+INSERT INTO permissions (name, description) VALUES
+    ('organizations:manage', 'List and create organizations')
+ON CONFLICT (name) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'organizations:manage';
+
+DROP POLICY IF EXISTS tenant_isolation ON invitation_events;
+DROP POLICY IF EXISTS tenant_isolation ON group_subgroups;
+DROP POLICY IF EXISTS tenant_isolation ON group_users;
+DROP POLICY IF EXISTS tenant_isolation ON api_keys;
+DROP POLICY IF EXISTS tenant_isolation ON email_canonical_conflicts;
+DROP POLICY IF EXISTS tenant_isolation ON password_resets;
+DROP POLICY IF EXISTS tenant_isolation ON email_verifications;
+DROP POLICY IF EXISTS tenant_isolation ON mfa_challenges;
+DROP POLICY IF EXISTS tenant_isolation ON mfa_recovery_codes;
+DROP POLICY IF EXISTS tenant_isolation ON user_mfa;
+DROP POLICY IF EXISTS tenant_isolation ON user_roles;
+DROP POLICY IF EXISTS tenant_isolation ON refresh_tokens;
+DROP POLICY IF EXISTS tenant_isolation ON user_credentials;
+DROP POLICY IF EXISTS tenant_isolation ON mfa_events;
+DROP POLICY IF EXISTS tenant_isolation ON user_status_events;
+DROP POLICY IF EXISTS tenant_isolation ON jobs;
+DROP POLICY IF EXISTS tenant_isolation ON invitations;
+DROP POLICY IF EXISTS tenant_isolation ON groups;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+
+ALTER TABLE invitation_events NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE group_subgroups NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE group_users NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE api_keys NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE email_canonical_conflicts NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE password_resets NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE email_verifications NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_challenges NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_recovery_codes NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_mfa NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_roles NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE refresh_tokens NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_credentials NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_events NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_status_events NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE jobs NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE groups NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS app_org_visible(UUID);
+
+-- Restoring the global unique indexes fails if organizations share addresses or group names.
+DROP INDEX IF EXISTS idx_groups_org_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_name ON groups(lower(name));
+DROP INDEX IF EXISTS idx_users_org_email_canonical;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_canonical ON users(email_canonical);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DROP INDEX IF EXISTS idx_api_keys_org_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS org_id;
+ALTER TABLE mfa_events DROP COLUMN IF EXISTS org_id;
+ALTER TABLE user_status_events DROP COLUMN IF EXISTS org_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS org_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS org_id;
+ALTER TABLE groups DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS organizations;
+
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Custom attributes of users, validated by the application against the schema of their organization.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+-- jsonb_path_ops supports the containment queries used to filter users by attribute.
+CREATE INDEX IF NOT EXISTS idx_users_attributes ON users USING gin (attributes jsonb_path_ops);
+
+-- At most one attribute schema per organization.
+CREATE TABLE IF NOT EXISTS attribute_schemas (
+    org_id UUID PRIMARY KEY DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid
+        REFERENCES organizations(id) ON DELETE CASCADE,
+    schema JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_attribute_schemas_updated_at
+    BEFORE UPDATE ON attribute_schemas
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE attribute_schemas ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attribute_schemas FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON attribute_schemas USING (app_org_visible(org_id));
+
+INSERT INTO permissions (name, description) VALUES
+    ('attributes:manage', 'Register and remove the attribute schema of users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'attributes:manage' FROM roles r WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'attributes:manage';
+DROP TABLE IF EXISTS attribute_schemas;
+DROP INDEX IF EXISTS idx_users_attributes;
+ALTER TABLE users DROP COLUMN IF EXISTS attributes;
+
+-- +goose StatementEnd
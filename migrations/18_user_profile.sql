@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Standard profile fields, normalized by the application (internal/profile). Existing users start without them; the
+-- checks only back up the normalization.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone TEXT CHECK (phone ~ '^\+[1-9][0-9]{6,14}$'),
+    ADD COLUMN IF NOT EXISTS locale TEXT CHECK (locale <> ''),
+    ADD COLUMN IF NOT EXISTS time_zone TEXT CHECK (time_zone <> ''),
+    ADD COLUMN IF NOT EXISTS display_name TEXT CHECK (display_name <> '' AND char_length(display_name) <= 100),
+    ADD COLUMN IF NOT EXISTS avatar_url TEXT CHECK (avatar_url ~ '^https?://' AND length(avatar_url) <= 2048);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS time_zone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS phone;
+
+-- +goose StatementEnd
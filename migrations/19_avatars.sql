@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Version of the uploaded avatar, whose resized variants are stored as blobs under avatars/<uid>/<version>/<size>.
+-- NULL if the user has not uploaded one; avatar_url may still point to an external image.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS avatar_version TEXT CHECK (avatar_version ~ '^[0-9a-f]{16}$');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar_version;
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Erased users keep their row with all personal data removed, so that foreign keys and the audit entries referring to
+-- their public ID stay intact.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP WITH TIME ZONE;
+
+-- Proof of erasure: who erased a user when and why, and how many rows of each table were anonymized or deleted. It
+-- holds no personal data of the user and is kept for good.
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id BIGSERIAL PRIMARY KEY,
+    org_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid
+        REFERENCES organizations(id) ON DELETE RESTRICT,
+    user_uid UUID NOT NULL UNIQUE,
+    reason TEXT,
+    actor_kind TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    affected JSONB NOT NULL,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE user_erasures ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_erasures FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_erasures USING (app_org_visible(org_id));
+
+-- Data exports include the policy decisions on a user.
+CREATE INDEX IF NOT EXISTS idx_policy_decisions_resource ON policy_decisions(resource);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:erase', 'Irreversibly erase the personal data of any user')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'users:erase' FROM roles r WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'users:erase';
+DROP INDEX IF EXISTS idx_policy_decisions_resource;
+DROP TABLE IF EXISTS user_erasures;
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
+
+-- +goose StatementEnd
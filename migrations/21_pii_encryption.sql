@@ -0,0 +1,89 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keys encrypting personal data, wrapped with the key-encryption key named by kek_id, which only the application
+-- knows. Data keys are kept after their rotation, as rows encrypted with them are only re-encrypted over time; there is
+-- a single index key.
+CREATE TABLE IF NOT EXISTS pii_keys (
+    id SERIAL PRIMARY KEY,
+    purpose TEXT NOT NULL CHECK (purpose IN ('data', 'index')),
+    wrapped_key BYTEA NOT NULL,
+    kek_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pii_keys_index ON pii_keys(purpose) WHERE purpose = 'index';
+
+-- Data key of the encrypted columns of a user; NULL while the user is stored unencrypted. With encryption enabled,
+-- email_canonical holds the blind index of the canonical address.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pii_key_id INTEGER REFERENCES pii_keys(id);
+
+CREATE INDEX IF NOT EXISTS idx_users_pii_key_id ON users(pii_key_id);
+
+-- Ciphertexts are longer than the plaintext limits. The search document depends on the columns, so it is recreated.
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE users
+    ALTER COLUMN first_name TYPE TEXT,
+    ALTER COLUMN last_name TYPE TEXT,
+    ALTER COLUMN email TYPE TEXT,
+    ALTER COLUMN email_canonical TYPE TEXT;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            first_name || ' ' || last_name || ' ' || email || ' ' || regexp_replace(email, '[@.+_-]', ' ', 'g'))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING gin (search_vector);
+
+-- Re-encrypting a user is no change of the user, so transactions setting app.keep_updated_at keep updated_at.
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('app.keep_updated_at', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Encrypted users must be decrypted first: ciphertexts do not fit the former limits and the keys are dropped.
+
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE users
+    ALTER COLUMN first_name TYPE VARCHAR(255),
+    ALTER COLUMN last_name TYPE VARCHAR(255),
+    ALTER COLUMN email TYPE VARCHAR(255),
+    ALTER COLUMN email_canonical TYPE VARCHAR(255);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            first_name || ' ' || last_name || ' ' || email || ' ' || regexp_replace(email, '[@.+_-]', ' ', 'g'))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING gin (search_vector);
+
+DROP INDEX IF EXISTS idx_users_pii_key_id;
+ALTER TABLE users DROP COLUMN IF EXISTS pii_key_id;
+DROP TABLE IF EXISTS pii_keys;
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Retention purges select by age. policy_decisions is already indexed by created_at.
+CREATE INDEX IF NOT EXISTS idx_user_status_events_created_at ON user_status_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_mfa_events_created_at ON mfa_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_invitation_events_created_at ON invitation_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_users_pending_created_at ON users(created_at) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_pending_created_at;
+DROP INDEX IF EXISTS idx_invitation_events_created_at;
+DROP INDEX IF EXISTS idx_mfa_events_created_at;
+DROP INDEX IF EXISTS idx_user_status_events_created_at;
+
+-- +goose StatementEnd
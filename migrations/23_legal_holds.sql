@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Set while a user has an active legal hold. Deletions, erasure and retention purges check it in the same statement
+-- that changes the user, so a hold placed concurrently is never missed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_users_legal_hold ON users(uid) WHERE legal_hold;
+
+-- Legal holds with who placed and released them and why. The user is kept by public ID, and holds are never deleted,
+-- so the record outlives the user.
+CREATE TABLE IF NOT EXISTS legal_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    org_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.current_org', true), '')::uuid
+        REFERENCES organizations(id) ON DELETE RESTRICT,
+    user_uid UUID NOT NULL,
+    reason TEXT NOT NULL,
+    case_reference TEXT NOT NULL,
+    placed_by_kind TEXT NOT NULL,
+    placed_by_id TEXT NOT NULL,
+    placed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    released_by_kind TEXT,
+    released_by_id TEXT,
+    release_reason TEXT,
+    released_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_legal_holds_user_uid ON legal_holds(user_uid);
+
+ALTER TABLE legal_holds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE legal_holds FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON legal_holds USING (app_org_visible(org_id));
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:legal_hold', 'Place and release legal holds on users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'users:legal_hold' FROM roles r WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'users:legal_hold';
+DROP TABLE IF EXISTS legal_holds;
+DROP INDEX IF EXISTS idx_users_legal_hold;
+ALTER TABLE users DROP COLUMN IF EXISTS legal_hold;
+
+-- +goose StatementEnd
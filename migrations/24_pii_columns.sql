@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The phone number and display name of encrypted users are sealed as well, so their checks only apply in plaintext.
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_phone_check,
+    DROP CONSTRAINT IF EXISTS users_display_name_check;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_phone_check CHECK (pii_key_id IS NOT NULL OR phone ~ '^\+[1-9][0-9]{6,14}$'),
+    ADD CONSTRAINT users_display_name_check
+        CHECK (display_name <> '' AND (pii_key_id IS NOT NULL OR char_length(display_name) <= 100));
+
+-- Addresses that invitations and verification mails were sent to; pii_key_id is NULL while the address is plaintext.
+ALTER TABLE invitations ADD COLUMN IF NOT EXISTS pii_key_id INTEGER REFERENCES pii_keys(id);
+ALTER TABLE email_verifications ADD COLUMN IF NOT EXISTS pii_key_id INTEGER REFERENCES pii_keys(id);
+
+-- Ciphertexts must not reach the search document or the trigram indexes, so they only cover unencrypted users.
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        CASE WHEN pii_key_id IS NULL THEN to_tsvector('simple',
+            first_name || ' ' || last_name || ' ' || email || ' ' || regexp_replace(email, '[@.+_-]', ' ', 'g'))
+        END
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin ((first_name || ' ' || last_name) gin_trgm_ops)
+    WHERE pii_key_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops) WHERE pii_key_id IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Encrypted users, invitations and verifications must be decrypted first: their checks and indexes expect plaintext.
+
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            first_name || ' ' || last_name || ' ' || email || ' ' || regexp_replace(email, '[@.+_-]', ' ', 'g'))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin ((first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops);
+
+ALTER TABLE email_verifications DROP COLUMN IF EXISTS pii_key_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS pii_key_id;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_display_name_check,
+    DROP CONSTRAINT IF EXISTS users_phone_check;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_phone_check CHECK (phone ~ '^\+[1-9][0-9]{6,14}$'),
+    ADD CONSTRAINT users_display_name_check CHECK (display_name <> '' AND char_length(display_name) <= 100);
+
+-- +goose StatementEnd
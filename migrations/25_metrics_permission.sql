@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Reading the metrics at /debug/vars, which is limited to API keys.
+INSERT INTO permissions (name, description) VALUES
+    ('metrics:read', 'Read the service metrics')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'metrics:read' FROM roles r WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM permissions WHERE name = 'metrics:read';
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Finished jobs no longer keep their payload, which may hold the users of an import. The rows of every organization
+-- are visible to this transaction only.
+SELECT set_config('app.all_orgs', 'on', true);
+
+ALTER TABLE jobs DISABLE TRIGGER update_jobs_updated_at;
+UPDATE jobs SET payload = '{}' WHERE status IN ('succeeded', 'failed', 'cancelled') AND payload <> '{}';
+ALTER TABLE jobs ENABLE TRIGGER update_jobs_updated_at;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- The cleared payloads cannot be restored.
+SELECT 1;
+
+-- +goose StatementEnd
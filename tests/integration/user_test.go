@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"os"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 const (
 	defaultBaseURL = "http://localhost:8080/api/v1"
+	defaultOrg     = "default"
 )
 
 type TestClient struct {
@@ -21,9 +23,30 @@ type TestClient struct {
 	baseURL string
 }
 
+// apiKeyTransport authenticates every request with the API key from INTEGRATION_API_KEY and scopes it to the
+// organization from INTEGRATION_ORG, the default organization if unset.
+type apiKeyTransport struct {
+	key string
+	org string
+}
+
+func (t apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.key != "" {
+		req.Header.Set("X-API-Key", t.key)
+	}
+	req.Header.Set("X-Organization", t.org)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
 func NewTestClient() *TestClient {
+	org := os.Getenv("INTEGRATION_ORG")
+	if org == "" {
+		org = defaultOrg
+	}
+
 	return &TestClient{
-		client:  &http.Client{},
+		client:  &http.Client{Transport: apiKeyTransport{key: os.Getenv("INTEGRATION_API_KEY"), org: org}},
 		baseURL: defaultBaseURL,
 	}
 }
@@ -162,7 +185,41 @@ func TestUpdateUser(t *testing.T) {
 
 	assert.Equal(t, updatedUser["first_name"], result["first_name"])
 	assert.Equal(t, updatedUser["last_name"], result["last_name"])
-	assert.Equal(t, updatedUser["email"], result["email"])
+	// A changed address only takes effect once confirmed.
+	assert.Equal(t, updatedUser["email"], result["pending_email"])
+}
+
+func TestCreateUser_SameEmailInTwoOrganizations(t *testing.T) {
+	client := NewTestClient()
+
+	slug := fmt.Sprintf("it-%d", rand.Intn(1000000))
+	body, err := json.Marshal(map[string]interface{}{"slug": slug, "name": "Integration " + slug})
+	assert.NoError(t, err)
+
+	resp, err := client.client.Post(client.baseURL+"/organizations", "application/json", bytes.NewBuffer(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	other := &TestClient{
+		client:  &http.Client{Transport: apiKeyTransport{key: os.Getenv("INTEGRATION_API_KEY"), org: slug}},
+		baseURL: client.baseURL,
+	}
+
+	user := map[string]interface{}{
+		"first_name": "John",
+		"last_name":  "Doe",
+		"email":      fmt.Sprintf("john.doe%d@example.com", rand.Intn(1000000)),
+	}
+	body, err = json.Marshal(user)
+	assert.NoError(t, err)
+
+	for _, c := range []*TestClient{client, other} {
+		resp, err := c.client.Post(c.baseURL+"/users", "application/json", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
 }
 
 func init() {